@@ -28,7 +28,8 @@ TTL can be set globally or per resource type. Resource types include:
   - instances : GCP instance location cache
   - zones     : Zone listings per project
   - projects  : Project entries for autocomplete
-  - subnets   : Subnet information for IP lookup`,
+  - subnets   : Subnet information for IP lookup
+  - ip_history: Retention of IP ownership history (ip history), from the last sighting`,
 }
 
 var cacheTTLGetCmd = &cobra.Command{
@@ -7,6 +7,7 @@ import (
 	"strings"
 	"sync"
 
+	"github.com/kedare/compass/internal/cache"
 	"github.com/kedare/compass/internal/gcp"
 	"github.com/kedare/compass/internal/logger"
 	"github.com/pterm/pterm"
@@ -14,6 +15,9 @@ import (
 )
 
 var regexFilter string
+var importOrganization string
+var importFolders []string
+var importLabels []string
 
 var gcpProjectsCmd = &cobra.Command{
 	Use:   "projects",
@@ -26,6 +30,13 @@ var gcpProjectsImportCmd = &cobra.Command{
 	Short: "Import GCP projects and scan their resources into cache",
 	Long: `Discover all GCP projects you have access to and select which ones to cache.
 
+Use --org, --folder and --label to only discover part of the resource hierarchy. --org walks
+every folder of an organization, --folder takes a folder ID or a path of folder display names
+(e.g. /prod/web, resolved under --org or every accessible organization) and can be repeated,
+and --label key=value keeps projects carrying that label (repeat to require several).
+The folder path, display name, number and labels of each project are stored in the cache,
+and the folder path is shown in the interactive selection.
+
 After selecting projects, this command will scan and cache:
   - Zones available in each project
   - All compute instances (for faster SSH lookups)
@@ -40,14 +51,29 @@ Examples:
 
   # Import all projects matching a regex pattern
   compass gcp projects import --regex "^prod-.*"
-  compass gcp projects import -r "dev|staging"`,
+  compass gcp projects import -r "dev|staging"
+
+  # Import the projects of a folder owned by a team
+  compass gcp projects import --folder /prod --label team=payments
+
+  # Import every project of an organization
+  compass gcp projects import --org 123456789012 --regex ".*"`,
 	Run: func(cmd *cobra.Command, args []string) {
 		ctx := cmd.Context()
 		if ctx == nil {
 			ctx = context.Background()
 		}
 
-		runProjectsImport(ctx, regexFilter)
+		labels, err := gcp.ParseProjectLabels(importLabels)
+		if err != nil {
+			logger.Log.Fatalf("%v", err)
+		}
+
+		runProjectsImport(ctx, regexFilter, gcp.ProjectSelector{
+			Organization: importOrganization,
+			Folders:      importFolders,
+			Labels:       labels,
+		})
 	},
 }
 
@@ -115,6 +141,11 @@ func init() {
 	gcpProjectsCmd.AddCommand(gcpProjectsRemoveCmd)
 	gcpProjectsCmd.AddCommand(gcpProjectsRefreshCmd)
 	gcpProjectsImportCmd.Flags().StringVarP(&regexFilter, "regex", "r", "", "Regex pattern to filter projects (bypasses interactive selection)")
+	gcpProjectsImportCmd.Flags().StringVar(&importOrganization, "org", "", "Only discover projects of this organization ID")
+	gcpProjectsImportCmd.Flags().StringArrayVar(&importFolders, "folder", nil,
+		"Only discover projects under this folder ID or path such as /prod (can be specified multiple times)")
+	gcpProjectsImportCmd.Flags().StringArrayVar(&importLabels, "label", nil,
+		"Only discover projects with this key=value label (can be specified multiple times)")
 }
 
 // projectNameCompletion provides shell completion for cached project names.
@@ -188,23 +219,34 @@ func runProjectsRefreshAll(ctx context.Context) {
 	pterm.Success.Printfln("All %d project(s) cache refreshed!", len(projects))
 }
 
-// runProjectsImport discovers all accessible GCP projects and prompts the user to select
-// which projects to cache for future use. If regexPattern is provided, projects matching
-// the pattern are automatically selected without interactive prompting.
-func runProjectsImport(ctx context.Context, regexPattern string) {
-	// Discover all projects
-	logger.Log.Info("Discovering all accessible GCP projects...")
-	projects, err := gcp.ListAllProjects(ctx)
+// runProjectsImport discovers the accessible GCP projects matching selector and prompts the
+// user to select which projects to cache for future use. If regexPattern is provided, projects
+// matching the pattern are automatically selected without interactive prompting.
+func runProjectsImport(ctx context.Context, regexPattern string, selector gcp.ProjectSelector) {
+	// Discover projects
+	if selector.IsZero() {
+		logger.Log.Info("Discovering all accessible GCP projects...")
+	} else {
+		logger.Log.Info("Discovering GCP projects matching the selection...")
+	}
+	discovered, err := gcp.DiscoverProjects(ctx, selector)
 	if err != nil {
 		logger.Log.Fatalf("Failed to discover projects: %v", err)
 	}
 
-	if len(projects) == 0 {
+	if len(discovered) == 0 {
 		logger.Log.Info("No projects found. Make sure you have the necessary permissions.")
 		return
 	}
 
-	logger.Log.Infof("Found %d projects", len(projects))
+	logger.Log.Infof("Found %d projects", len(discovered))
+
+	projects := make([]string, 0, len(discovered))
+	infos := make(map[string]gcp.ProjectInfo, len(discovered))
+	for _, info := range discovered {
+		projects = append(projects, info.ID)
+		infos[info.ID] = info
+	}
 
 	var selectedProjects []string
 
@@ -223,9 +265,10 @@ func runProjectsImport(ctx context.Context, regexPattern string) {
 
 		logger.Log.Infof("Found %d projects matching pattern '%s'", len(selectedProjects), regexPattern)
 	} else {
-		// Use pterm interactive multiselect
-		selectedProjects, err = pterm.DefaultInteractiveMultiselect.
-			WithOptions(projects).
+		// Use pterm interactive multiselect, showing where each project lives
+		options, optionProjects := projectSelectOptions(discovered)
+		selectedOptions, err := pterm.DefaultInteractiveMultiselect.
+			WithOptions(options).
 			WithDefaultText("Select projects to cache (use arrow keys, space to select, enter to confirm):").
 			WithMaxHeight(15).
 			Show()
@@ -234,6 +277,10 @@ func runProjectsImport(ctx context.Context, regexPattern string) {
 			logger.Log.Fatalf("Failed to show project selector: %v", err)
 		}
 
+		for _, option := range selectedOptions {
+			selectedProjects = append(selectedProjects, optionProjects[option])
+		}
+
 		if len(selectedProjects) == 0 {
 			logger.Log.Info("No projects selected. Cache unchanged.")
 			return
@@ -243,19 +290,22 @@ func runProjectsImport(ctx context.Context, regexPattern string) {
 	}
 
 	// Load or create cache
-	cache, err := gcp.LoadCache()
+	cacheStore, err := gcp.LoadCache()
 	if err != nil {
 		logger.Log.Fatalf("Failed to load cache: %v", err)
 	}
 
-	// Add selected projects to cache
+	// Add selected projects to cache along with their place in the hierarchy
 	successCount := 0
 	for _, project := range selectedProjects {
-		if err := cache.AddProject(project); err != nil {
+		if err := cacheStore.AddProject(project); err != nil {
 			logger.Log.Warnf("Failed to add project %s: %v", project, err)
-		} else {
-			successCount++
+			continue
 		}
+		if err := cacheStore.SetProjectMetadata(projectMetadata(infos[project])); err != nil {
+			logger.Log.Warnf("Failed to store metadata of project %s: %v", project, err)
+		}
+		successCount++
 	}
 
 	logger.Log.Infof("Successfully cached %d projects", successCount)
@@ -267,10 +317,43 @@ func runProjectsImport(ctx context.Context, regexPattern string) {
 	pterm.Success.Printfln("Projects imported and resources cached! You can now use 'compass gcp ssh' without --project flag.")
 }
 
-// scanProjectResources scans zones, instances, MIGs, and subnets for each project and caches them.
+// projectSelectOptions returns the interactive selection entries, the project ID followed by
+// its folder path, and the project ID of each entry.
+func projectSelectOptions(projects []gcp.ProjectInfo) ([]string, map[string]string) {
+	width := 0
+	for _, p := range projects {
+		width = max(width, len(p.ID))
+	}
+
+	options := make([]string, 0, len(projects))
+	optionProjects := make(map[string]string, len(projects))
+	for _, p := range projects {
+		option := p.ID
+		if p.FolderPath != "" {
+			option = fmt.Sprintf("%-*s  %s", width, p.ID, p.FolderPath)
+		}
+		options = append(options, option)
+		optionProjects[option] = p.ID
+	}
+
+	return options, optionProjects
+}
+
+// projectMetadata converts discovered project details to their cached form.
+func projectMetadata(info gcp.ProjectInfo) cache.ProjectMetadata {
+	return cache.ProjectMetadata{
+		Name:        info.ID,
+		DisplayName: info.DisplayName,
+		Number:      info.Number,
+		FolderPath:  info.FolderPath,
+		Labels:      info.Labels,
+	}
+}
+
+// scanProjectResources scans zones, instances, MIGs, subnets and IP addresses for each project and caches them.
 func scanProjectResources(ctx context.Context, projects []string) {
-	// 4 API operations per project: zones, instances, MIGs, subnets
-	totalAPICalls := len(projects) * 4
+	// 5 scan operations per project: zones, instances, MIGs, subnets, IP addresses
+	totalAPICalls := len(projects) * 5
 	var completedAPICalls int
 	var apiCallsMu sync.Mutex
 
@@ -302,10 +385,11 @@ func scanProjectResources(ctx context.Context, projects []string) {
 		instances := totalStats.Instances
 		migs := totalStats.MIGs
 		subnets := totalStats.Subnets
+		addresses := totalStats.IPAddresses
 		statsMu.Unlock()
 
-		title := fmt.Sprintf("%s (%s) | %d zones, %d instances, %d MIGs, %d subnets",
-			currentProject, currentOp, zones, instances, migs, subnets)
+		title := fmt.Sprintf("%s (%s) | %d zones, %d instances, %d MIGs, %d subnets, %d IPs",
+			currentProject, currentOp, zones, instances, migs, subnets, addresses)
 
 		// Pad with spaces to clear any remaining characters from previous longer titles
 		maxTitleMu.Lock()
@@ -338,10 +422,10 @@ func scanProjectResources(ctx context.Context, projects []string) {
 				errors = append(errors, fmt.Sprintf("%s: %v", proj, err))
 				errorsMu.Unlock()
 
-				// Count all 4 operations as done (failed)
+				// Count all 5 operations as done (failed)
 				apiCallsMu.Lock()
-				completedAPICalls += 4
-				progressBar.Add(4)
+				completedAPICalls += 5
+				progressBar.Add(5)
 				apiCallsMu.Unlock()
 				return
 			}
@@ -358,6 +442,8 @@ func scanProjectResources(ctx context.Context, projects []string) {
 					totalStats.MIGs += count
 				case "subnets":
 					totalStats.Subnets += count
+				case "ip_addresses":
+					totalStats.IPAddresses += count
 				}
 				statsMu.Unlock()
 
@@ -394,8 +480,8 @@ func scanProjectResources(ctx context.Context, projects []string) {
 
 	// Print summary
 	statsMu.Lock()
-	pterm.Success.Printfln("Cached: %d zones, %d instances, %d MIGs, %d subnets across %d projects",
-		totalStats.Zones, totalStats.Instances, totalStats.MIGs, totalStats.Subnets, len(projects))
+	pterm.Success.Printfln("Cached: %d zones, %d instances, %d MIGs, %d subnets, %d IP addresses across %d projects",
+		totalStats.Zones, totalStats.Instances, totalStats.MIGs, totalStats.Subnets, totalStats.IPAddresses, len(projects))
 	statsMu.Unlock()
 
 	// Print errors if any
@@ -3,6 +3,7 @@ package cmd
 import (
 	"testing"
 
+	"github.com/kedare/compass/internal/gcp"
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/require"
 )
@@ -361,3 +362,36 @@ func TestProjectNameCompletion(t *testing.T) {
 	require.Nil(t, suggestions)
 	require.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
 }
+
+func TestGcpProjectsImportSelectorFlags(t *testing.T) {
+	for _, name := range []string{"org", "folder", "label"} {
+		flag := gcpProjectsImportCmd.Flags().Lookup(name)
+		require.NotNil(t, flag, "%s flag should exist", name)
+		require.NotEmpty(t, flag.Usage)
+	}
+}
+
+func TestProjectSelectOptions(t *testing.T) {
+	options, optionProjects := projectSelectOptions([]gcp.ProjectInfo{
+		{ID: "admin", FolderPath: "/"},
+		{ID: "web-prod", FolderPath: "/prod/web"},
+		{ID: "personal"},
+	})
+
+	require.Equal(t, []string{"admin     /", "web-prod  /prod/web", "personal"}, options)
+	require.Equal(t, "web-prod", optionProjects["web-prod  /prod/web"])
+	require.Equal(t, "personal", optionProjects["personal"])
+}
+
+func TestProjectMetadata(t *testing.T) {
+	meta := projectMetadata(gcp.ProjectInfo{
+		ID: "web-prod", DisplayName: "Web", Number: "101", FolderPath: "/prod/web",
+		Labels: map[string]string{"team": "web"},
+	})
+
+	require.Equal(t, "web-prod", meta.Name)
+	require.Equal(t, "Web", meta.DisplayName)
+	require.Equal(t, "101", meta.Number)
+	require.Equal(t, "/prod/web", meta.FolderPath)
+	require.Equal(t, map[string]string{"team": "web"}, meta.Labels)
+}
@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/kedare/compass/internal/gcp"
+	"github.com/kedare/compass/internal/gcp/search"
+	"github.com/kedare/compass/internal/logger"
+	"github.com/spf13/cobra"
+)
+
+// relatedInventoryLoader loads the resources of a project used to build the relationship graph.
+var relatedInventoryLoader = func(ctx context.Context, projectID string) (*search.Inventory, error) {
+	client, err := gcp.NewClient(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GCP client: %w", err)
+	}
+
+	return search.LoadInventory(ctx, client)
+}
+
+var relatedDepth int
+
+var gcpRelatedCmd = &cobra.Command{
+	Use:   "related <kind> <name>",
+	Short: "Show the resources related to a resource",
+	Long: `Walk from a resource to its neighbours and print them as a tree, for example
+instance → boot disk → snapshot, instance → MIG → template, forwarding rule → backend
+service → health check, or subnet → firewall rules applied through instance network tags.
+
+Relations are derived from the references resources hold to each other, in both
+directions, so a disk also lists the instances it is attached to. Resources referenced but
+not found in the project (e.g. Shared VPC subnets) are marked as such.
+
+Supported kinds: compute.instance, compute.mig, compute.instanceTemplate, compute.disk,
+compute.snapshot, compute.forwardingRule, compute.backendService, compute.targetPool,
+compute.healthCheck, compute.urlMap, compute.network, compute.subnet and compute.firewall.
+
+Examples:
+  compass gcp related compute.instance web-1 --project my-project
+  compass gcp related compute.subnet default --depth 3`,
+	Args:              cobra.ExactArgs(2),
+	ValidArgsFunction: completeRelatedKinds,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		if ctx == nil {
+			ctx = context.Background()
+		}
+
+		kind := search.ResourceKind(strings.TrimSpace(args[0]))
+		if !search.IsRelatedKind(kind) {
+			return fmt.Errorf("unsupported kind %q for related resources", args[0])
+		}
+		name := strings.TrimSpace(args[1])
+
+		inventory, err := relatedInventoryLoader(ctx, project)
+		if inventory == nil {
+			return err
+		}
+		if err != nil {
+			logger.Log.Warnf("Some resources could not be listed: %v", err)
+		}
+
+		graph := search.BuildGraph(inventory)
+		roots := graph.Find(kind, name)
+		if len(roots) == 0 {
+			return fmt.Errorf("no %s named %q found", kind, name)
+		}
+
+		for i, root := range roots {
+			if i > 0 {
+				fmt.Println()
+			}
+			fmt.Print(formatRelatedTree(graph.Tree(root, relatedDepth)))
+		}
+
+		return nil
+	},
+}
+
+// formatRelatedTree renders a neighbourhood tree with box drawing characters.
+func formatRelatedTree(root *search.RelatedNode) string {
+	var b strings.Builder
+	b.WriteString(root.Resource.String())
+	b.WriteString("\n")
+	writeRelatedChildren(&b, root.Children, "")
+
+	return b.String()
+}
+
+func writeRelatedChildren(b *strings.Builder, children []*search.RelatedNode, prefix string) {
+	for i, child := range children {
+		branch, indent := "├── ", "│   "
+		if i == len(children)-1 {
+			branch, indent = "└── ", "    "
+		}
+
+		fmt.Fprintf(b, "%s%s%s: %s", prefix, branch, child.Relation, child.Resource)
+		if child.Missing {
+			b.WriteString(" [not found in project]")
+		}
+		b.WriteString("\n")
+
+		writeRelatedChildren(b, child.Children, prefix+indent)
+	}
+}
+
+// completeRelatedKinds completes the kind argument with the kinds covered by the relationship graph.
+func completeRelatedKinds(_ *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	kinds := search.RelatedKinds()
+	completions := make([]string, 0, len(kinds))
+	for _, kind := range kinds {
+		if d, ok := search.Lookup(kind); ok {
+			completions = append(completions, cobra.CompletionWithDesc(string(kind), d.DisplayName))
+			continue
+		}
+		completions = append(completions, string(kind))
+	}
+
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
+
+func init() {
+	gcpRelatedCmd.Flags().IntVar(&relatedDepth, "depth", search.DefaultRelatedDepth,
+		"Number of hops to follow from the resource")
+
+	gcpCmd.AddCommand(gcpRelatedCmd)
+}
@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/kedare/compass/internal/gcp"
+	"github.com/kedare/compass/internal/gcp/search"
+)
+
+func TestFormatRelatedTree(t *testing.T) {
+	tree := &search.RelatedNode{
+		Resource: search.ResourceRef{Kind: search.KindComputeInstance, Name: "web-1", Location: "us-central1-a"},
+		Children: []*search.RelatedNode{
+			{
+				Resource: search.ResourceRef{Kind: search.KindDisk, Name: "web-1", Location: "us-central1-a"},
+				Relation: "boot disk",
+				Children: []*search.RelatedNode{{
+					Resource: search.ResourceRef{Kind: search.KindSnapshot, Name: "daily", Location: "global"},
+					Relation: "snapshot",
+				}},
+			},
+			{
+				Resource: search.ResourceRef{Kind: search.KindSubnet, Name: "shared", Location: "us-central1"},
+				Relation: "subnet",
+				Missing:  true,
+			},
+		},
+	}
+
+	expected := strings.Join([]string{
+		"compute.instance web-1 (us-central1-a)",
+		"├── boot disk: compute.disk web-1 (us-central1-a)",
+		"│   └── snapshot: compute.snapshot daily (global)",
+		"└── subnet: compute.subnet shared (us-central1) [not found in project]",
+		"",
+	}, "\n")
+
+	if got := formatRelatedTree(tree); got != expected {
+		t.Fatalf("unexpected tree:\n%s\nwant:\n%s", got, expected)
+	}
+}
+
+func TestGCPRelatedCommandValidatesKindAndName(t *testing.T) {
+	prevLoader := relatedInventoryLoader
+	relatedInventoryLoader = func(context.Context, string) (*search.Inventory, error) {
+		return &search.Inventory{Instances: []*gcp.Instance{{Name: "web-1", Zone: "us-central1-a"}}}, nil
+	}
+	t.Cleanup(func() { relatedInventoryLoader = prevLoader })
+
+	if err := gcpRelatedCmd.RunE(gcpRelatedCmd, []string{"storage.bucket", "b"}); err == nil {
+		t.Fatal("expected error for unsupported kind")
+	}
+	if err := gcpRelatedCmd.RunE(gcpRelatedCmd, []string{"compute.instance", "missing"}); err == nil {
+		t.Fatal("expected error for unknown resource")
+	}
+	if err := gcpRelatedCmd.RunE(gcpRelatedCmd, []string{"compute.instance", "web-1"}); err != nil {
+		t.Fatalf("expected related lookup to succeed, got %v", err)
+	}
+}
+
+func TestCompleteRelatedKinds(t *testing.T) {
+	completions, _ := completeRelatedKinds(gcpRelatedCmd, nil, "")
+	if len(completions) != len(search.RelatedKinds()) {
+		t.Fatalf("expected one completion per kind, got %v", completions)
+	}
+	if completions[0] != "compute.instance\tCompute Engine instances" {
+		t.Fatalf("unexpected first completion %q", completions[0])
+	}
+
+	if completions, _ := completeRelatedKinds(gcpRelatedCmd, []string{"compute.instance"}, ""); len(completions) != 0 {
+		t.Fatalf("expected no completions for the name, got %v", completions)
+	}
+}
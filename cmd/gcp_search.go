@@ -6,7 +6,9 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"time"
 
+	"github.com/kedare/compass/internal/cache"
 	"github.com/kedare/compass/internal/gcp"
 	"github.com/kedare/compass/internal/gcp/search"
 	"github.com/kedare/compass/internal/logger"
@@ -35,243 +37,169 @@ var (
 		// Use search affinity to prioritize projects likely to have results
 		return cacheStore.GetProjectsForSearch(searchTerm, resourceTypes), true, nil
 	}
-	instanceProviderFactory = func() search.Provider {
-		return &search.InstanceProvider{
-			NewClient: func(ctx context.Context, project string) (search.InstanceClient, error) {
-				return gcp.NewClient(ctx, project)
-			},
-		}
-	}
-	migProviderFactory = func() search.Provider {
-		return &search.MIGProvider{
-			NewClient: func(ctx context.Context, project string) (search.MIGClient, error) {
-				return gcp.NewClient(ctx, project)
-			},
-		}
-	}
-	instanceTemplateProviderFactory = func() search.Provider {
-		return &search.InstanceTemplateProvider{
-			NewClient: func(ctx context.Context, project string) (search.InstanceTemplateClient, error) {
-				return gcp.NewClient(ctx, project)
-			},
-		}
-	}
-	addressProviderFactory = func() search.Provider {
-		return &search.AddressProvider{
-			NewClient: func(ctx context.Context, project string) (search.AddressClient, error) {
-				return gcp.NewClient(ctx, project)
-			},
-		}
-	}
-	diskProviderFactory = func() search.Provider {
-		return &search.DiskProvider{
-			NewClient: func(ctx context.Context, project string) (search.DiskClient, error) {
-				return gcp.NewClient(ctx, project)
-			},
-		}
-	}
-	snapshotProviderFactory = func() search.Provider {
-		return &search.SnapshotProvider{
-			NewClient: func(ctx context.Context, project string) (search.SnapshotClient, error) {
-				return gcp.NewClient(ctx, project)
-			},
-		}
-	}
-	bucketProviderFactory = func() search.Provider {
-		return &search.BucketProvider{
-			NewClient: func(ctx context.Context, project string) (search.BucketClient, error) {
-				return gcp.NewClient(ctx, project)
-			},
-		}
-	}
-	forwardingRuleProviderFactory = func() search.Provider {
-		return &search.ForwardingRuleProvider{
-			NewClient: func(ctx context.Context, project string) (search.ForwardingRuleClient, error) {
-				return gcp.NewClient(ctx, project)
-			},
-		}
-	}
-	backendServiceProviderFactory = func() search.Provider {
-		return &search.BackendServiceProvider{
-			NewClient: func(ctx context.Context, project string) (search.BackendServiceClient, error) {
-				return gcp.NewClient(ctx, project)
-			},
-		}
-	}
-	targetPoolProviderFactory = func() search.Provider {
-		return &search.TargetPoolProvider{
-			NewClient: func(ctx context.Context, project string) (search.TargetPoolClient, error) {
-				return gcp.NewClient(ctx, project)
-			},
-		}
-	}
-	healthCheckProviderFactory = func() search.Provider {
-		return &search.HealthCheckProvider{
-			NewClient: func(ctx context.Context, project string) (search.HealthCheckClient, error) {
-				return gcp.NewClient(ctx, project)
-			},
-		}
-	}
-	urlMapProviderFactory = func() search.Provider {
-		return &search.URLMapProvider{
-			NewClient: func(ctx context.Context, project string) (search.URLMapClient, error) {
-				return gcp.NewClient(ctx, project)
-			},
-		}
-	}
-	cloudSQLProviderFactory = func() search.Provider {
-		return &search.CloudSQLProvider{
-			NewClient: func(ctx context.Context, project string) (search.CloudSQLClient, error) {
-				return gcp.NewClient(ctx, project)
-			},
-		}
-	}
-	gkeClusterProviderFactory = func() search.Provider {
-		return &search.GKEClusterProvider{
-			NewClient: func(ctx context.Context, project string) (search.GKEClusterClient, error) {
-				return gcp.NewClient(ctx, project)
-			},
-		}
-	}
-	gkeNodePoolProviderFactory = func() search.Provider {
-		return &search.GKENodePoolProvider{
-			NewClient: func(ctx context.Context, project string) (search.GKENodePoolClient, error) {
-				return gcp.NewClient(ctx, project)
-			},
-		}
-	}
-	vpcNetworkProviderFactory = func() search.Provider {
-		return &search.VPCNetworkProvider{
-			NewClient: func(ctx context.Context, project string) (search.VPCNetworkClient, error) {
-				return gcp.NewClient(ctx, project)
-			},
-		}
-	}
-	subnetProviderFactory = func() search.Provider {
-		return &search.SubnetProvider{
-			NewClient: func(ctx context.Context, project string) (search.SubnetClient, error) {
-				return gcp.NewClient(ctx, project)
-			},
-		}
-	}
-	cloudRunProviderFactory = func() search.Provider {
-		return &search.CloudRunProvider{
-			NewClient: func(ctx context.Context, project string) (search.CloudRunClient, error) {
-				return gcp.NewClient(ctx, project)
-			},
-		}
-	}
-	firewallRuleProviderFactory = func() search.Provider {
-		return &search.FirewallRuleProvider{
-			NewClient: func(ctx context.Context, project string) (search.FirewallRuleClient, error) {
-				return gcp.NewClient(ctx, project)
-			},
-		}
-	}
-	secretProviderFactory = func() search.Provider {
-		return &search.SecretProvider{
-			NewClient: func(ctx context.Context, project string) (search.SecretClient, error) {
-				return gcp.NewClient(ctx, project)
-			},
-		}
-	}
-	vpnGatewayProviderFactory = func() search.Provider {
-		return &search.VPNGatewayProvider{
-			NewClient: func(ctx context.Context, project string) (search.VPNGatewayClient, error) {
-				return gcp.NewClient(ctx, project)
-			},
-		}
-	}
-	vpnTunnelProviderFactory = func() search.Provider {
-		return &search.VPNTunnelProvider{
-			NewClient: func(ctx context.Context, project string) (search.VPNTunnelClient, error) {
-				return gcp.NewClient(ctx, project)
-			},
+	// cachedAffinityProvider returns the per-project affinity scores used to boost result relevance
+	cachedAffinityProvider = func(searchTerm string, resourceTypes []string) map[string]float64 {
+		cacheStore, err := gcp.LoadCache()
+		if err != nil || cacheStore == nil {
+			return nil
 		}
+
+		return cacheStore.SearchAffinityScores(searchTerm, resourceTypes)
 	}
 	searchEngineFactory = func(parallelism int, providers ...search.Provider) resourceSearchEngine {
 		engine := search.NewEngine(providers...)
 		engine.MaxConcurrentProjects = parallelism
+		engine.ProviderTimeout = searchProviderTimeout
+		// Remember disabled APIs so they are skipped until the next project refresh
+		if cacheStore, err := gcp.LoadCache(); err == nil && cacheStore != nil {
+			engine.ServiceState = cacheStore
+		}
 		return engine
 	}
 	// useSpinner controls whether to show a spinner during search (disabled in tests to avoid races)
 	useSpinner = true
+	// registerSearchPlugins adds the exec-plugin providers found on PATH to the resource kinds
+	registerSearchPlugins = func(ctx context.Context) {
+		if _, err := search.RegisterPlugins(ctx); err != nil {
+			logger.Log.Warnf("Some search plugins were not loaded: %v", err)
+		}
+	}
 )
 
 var searchTypes []string
 var searchNoTypes []string
 var searchParallelism int
+var searchShowLabels bool
+var searchSort string
+var searchProviderTimeout time.Duration
+var searchSave string
+var searchSaved string
+var searchListSaved bool
+var searchDeleteSaved string
+var searchDiff bool
+var searchRegex bool
+var searchExact bool
 
 var gcpSearchCmd = &cobra.Command{
-	Use:   "search <name-fragment>",
+	Use:   "search [name-fragment]",
 	Short: "Search cached GCP projects for resources by name",
 	Long: `Search across the cached GCP projects (or a --project override) for resources that
 contain the provided text. The search covers Compute Engine instances, managed instance
 groups, instance templates, IP address reservations, disks, snapshots, Cloud Storage
 buckets, load balancer resources (forwarding rules, backend services, target pools,
 health checks, URL maps), Cloud SQL instances, GKE clusters and node pools, VPC networks
-and subnets, Cloud Run services, firewall rules, Secret Manager secrets, and Cloud VPN
-resources (HA VPN gateways and tunnels). Returns every match along with the project and
-location.
+and subnets, Cloud Run services and jobs, Cloud Functions (1st and 2nd gen), App Engine
+services, firewall rules, Secret Manager secrets, hybrid connectivity (HA VPN gateways
+and tunnels, Cloud Routers, Cloud NAT gateways and Interconnect attachments, matched on
+ASNs and NAT IPs), Pub/Sub topics and subscriptions, Cloud DNS zones and record sets
+(matched on record names and data, so searching an IP finds the records pointing at it),
+IAM service accounts, and data services (Memorystore Redis and Memcached, Filestore,
+Spanner and BigQuery datasets, with private IPs and endpoints searchable). Returns every
+match along with the project and location.
 
 Use --type to filter results to specific resource types. Multiple types can be specified
 by using the flag multiple times (e.g., --type compute.instance --type compute.disk).
 
+Use --regex to match the term as a case-sensitive regular expression (RE2 syntax, add (?i)
+to ignore case), e.g. '^api-v[0-9]+-', or --exact to only match values equal to the term.
+Both apply to resource names and the other searched fields.
+
 Use --no-type to exclude specific resource types from the results. Multiple types can be
 specified by using the flag multiple times (e.g., --no-type storage.bucket --no-type compute.disk).
-When both --type and --no-type are used, --no-type is applied to the --type filter.`,
-	Args: cobra.ExactArgs(1),
+When both --type and --no-type are used, --no-type is applied to the --type filter.
+
+Terms of the form key=value match resource labels instead of names: "env=prod" finds
+resources labelled env=prod, "env=prod*" accepts any value starting with prod and "team=*"
+finds every resource carrying a team label. Use --labels to add a LABELS column to the output.
+
+Results are ranked by relevance: exact name matches first, then name prefixes, name
+substrings, matches in other fields and finally fuzzy matches, with projects where the
+term was found before ranked higher. Use --sort project (or name, type) to order results
+alphabetically instead.
+
+Each resource type is given --timeout to answer for a project, and requests are rate limited
+per API host (see --api-rate-limit). APIs that are disabled in a project are remembered and skipped on later searches
+until the project is refreshed with 'compass gcp projects refresh'.
+
+Use --save <name> to store a search (term, types and --project) and re-run it later with
+--saved <name>. Each run of a saved search stores its results; add --diff to print the
+resources added, removed or changed (by details) since the previous run instead of the
+full list. --diff exits with code 2 when something changed, so cron jobs can alert on drift.
+Resource types that fail or are skipped in a project keep their previous results and are
+reported separately, so a transient error is not reported as a change.
+Use --list-saved and --delete-saved <name> to manage saved searches.
+
+Executables named compass-provider-<name> on PATH, or listed in the COMPASS_PROVIDERS
+environment variable (separated like PATH), are used as additional providers. Each one
+receives the project and query as JSON on stdin and prints its results as a JSON array,
+see DEVELOPMENT.md for the protocol. Failing plugins are reported as warnings.`,
+	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		ctx := cmd.Context()
 		if ctx == nil {
 			ctx = context.Background()
 		}
 
-		searchTerm := args[0]
+		if searchListSaved {
+			return runListSavedSearches()
+		}
+		if searchDeleteSaved != "" {
+			return runDeleteSavedSearch(searchDeleteSaved)
+		}
+
+		registerSearchPlugins(ctx)
+
+		spec, err := resolveSearchSpec(args)
+		if err != nil {
+			return err
+		}
 
-		// Parse and validate type filters
-		typeFilters, err := parseSearchTypes(searchTypes, searchNoTypes)
+		sortOrder, err := search.ParseSortOrder(searchSort)
 		if err != nil {
 			return err
 		}
 
 		// Convert type filters to strings for affinity lookup
 		var typeStrings []string
-		for _, t := range typeFilters {
+		for _, t := range spec.types {
 			typeStrings = append(typeStrings, string(t))
 		}
 
-		projects, err := resolveSearchProjects(searchTerm, typeStrings)
-		if err != nil {
-			return err
+		query := spec.query()
+		affinityTerm := query.AffinityTerm()
+
+		var projects []string
+		if spec.project != "" && project == "" {
+			projects = []string{spec.project}
+		} else {
+			projects, err = resolveSearchProjects(affinityTerm, typeStrings)
+			if err != nil {
+				return err
+			}
+		}
+
+		var store savedSearchCache
+		if spec.name != "" {
+			if store, err = savedSearchStore(); err != nil {
+				return err
+			}
+		}
+		if searchSave != "" {
+			saved := cache.SavedSearch{
+				Name:    searchSave,
+				Term:    spec.term,
+				Types:   typeStrings,
+				Project: strings.TrimSpace(project),
+				Mode:    savedSearchMode(spec.mode),
+			}
+			if err := store.SaveSearch(saved); err != nil {
+				return err
+			}
+			pterm.Success.Printfln("Search saved as '%s'", searchSave)
 		}
 
-		engine := searchEngineFactory(
-			searchParallelism,
-			instanceProviderFactory(),
-			migProviderFactory(),
-			instanceTemplateProviderFactory(),
-			addressProviderFactory(),
-			diskProviderFactory(),
-			snapshotProviderFactory(),
-			bucketProviderFactory(),
-			forwardingRuleProviderFactory(),
-			backendServiceProviderFactory(),
-			targetPoolProviderFactory(),
-			healthCheckProviderFactory(),
-			urlMapProviderFactory(),
-			cloudSQLProviderFactory(),
-			gkeClusterProviderFactory(),
-			gkeNodePoolProviderFactory(),
-			vpcNetworkProviderFactory(),
-			subnetProviderFactory(),
-			cloudRunProviderFactory(),
-			firewallRuleProviderFactory(),
-			secretProviderFactory(),
-			vpnGatewayProviderFactory(),
-			vpnTunnelProviderFactory(),
-		)
-		query := search.Query{Term: searchTerm, Types: typeFilters}
+		engine := searchEngineFactory(searchParallelism, search.NewProviders()...)
+		query.Affinity = cachedAffinityProvider(affinityTerm, typeStrings)
 
 		var spinner *pterm.SpinnerPrinter
 		if useSpinner {
@@ -291,15 +219,153 @@ When both --type and --no-type are used, --no-type is applied to the --type filt
 
 		// Record search affinity for future searches
 		if len(searchOutput.Results) > 0 {
-			go recordSearchAffinity(searchTerm, searchOutput.Results)
+			go recordSearchAffinity(affinityTerm, searchOutput.Results)
+		}
+
+		// Keep the previous run for --diff before storing this one. Resource types that
+		// failed or were skipped in a project keep their previous results, so a partial
+		// run neither reports them as removed nor drops them from the baseline.
+		var previous []search.Result
+		var previousRun time.Time
+		var hasPrevious bool
+		incomplete := searchOutput.IncompletePairs()
+		current := searchOutput.Results
+		if spec.name != "" {
+			var saved []cache.SavedSearchResult
+			saved, previousRun, hasPrevious = store.GetSavedSearchResults(spec.name)
+			previous = fromSavedResults(saved)
+			if carried := search.CarryOverResults(previous, incomplete); len(carried) > 0 {
+				current = append(append(make([]search.Result, 0, len(current)+len(carried)), current...), carried...)
+			}
+			if err := store.SetSavedSearchResults(spec.name, toSavedResults(current)); err != nil {
+				return err
+			}
 		}
 
-		displaySearchResults(searchOutput.Results)
+		if searchDiff && hasPrevious {
+			diff := search.DiffResults(previous, current)
+			displaySearchDiff(diff, previousRun)
+			displayIncompletePairs(incomplete)
+			displaySkippedProviders(searchOutput.Skipped)
+			if diff.HasChanges() {
+				searchExit(searchDiffExitCode)
+			}
+			return nil
+		}
+		if searchDiff {
+			pterm.Info.Printfln("No previous run of '%s', results stored as the baseline", spec.name)
+		}
+
+		search.SortResults(searchOutput.Results, sortOrder)
+		displaySearchResults(searchOutput.Results, searchShowLabels)
+		displaySkippedProviders(searchOutput.Skipped)
 
 		return nil
 	},
 }
 
+// searchSpec is the search to run, from the command line or a saved search.
+type searchSpec struct {
+	name    string // saved search name, empty for one-off searches
+	term    string
+	types   []search.ResourceKind
+	mode    search.MatchMode
+	project string // project stored with a saved search
+}
+
+// query returns the search query described by the spec.
+func (s *searchSpec) query() search.Query {
+	return search.Query{Term: s.term, Types: s.types}.WithMode(s.mode)
+}
+
+// resolveSearchSpec builds the search to run from the arguments and the saved search flags.
+func resolveSearchSpec(args []string) (*searchSpec, error) {
+	if searchSave != "" && searchSaved != "" {
+		return nil, errors.New("--save and --saved cannot be used together")
+	}
+
+	if searchRegex && searchExact {
+		return nil, errors.New("--regex and --exact cannot be used together")
+	}
+
+	if searchSaved != "" {
+		if len(args) > 0 || len(searchTypes) > 0 || len(searchNoTypes) > 0 || searchRegex || searchExact {
+			return nil, errors.New("--saved runs the stored term, types and matching, do not pass a term, --type/--no-type or --regex/--exact")
+		}
+
+		store, err := savedSearchStore()
+		if err != nil {
+			return nil, err
+		}
+		saved, ok := store.GetSavedSearch(searchSaved)
+		if !ok {
+			return nil, fmt.Errorf("no saved search named %q (use --list-saved to see them)", searchSaved)
+		}
+
+		types, err := parseSearchTypes(saved.Types, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		mode := search.MatchSubstring
+		if saved.Mode != "" {
+			mode = search.MatchMode(saved.Mode)
+		}
+
+		return &searchSpec{name: saved.Name, term: saved.Term, types: types, mode: mode, project: saved.Project}, nil
+	}
+
+	if len(args) == 0 {
+		return nil, errors.New("a search term is required (or --saved <name>)")
+	}
+	if searchDiff && searchSave == "" {
+		return nil, errors.New("--diff requires --save <name> or --saved <name>")
+	}
+
+	types, err := parseSearchTypes(searchTypes, searchNoTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	mode := search.MatchSubstring
+	switch {
+	case searchRegex:
+		mode = search.MatchRegex
+	case searchExact:
+		mode = search.MatchExact
+	}
+
+	spec := &searchSpec{name: strings.TrimSpace(searchSave), term: args[0], types: types, mode: mode}
+	if err := spec.query().Validate(); err != nil {
+		return nil, err
+	}
+
+	return spec, nil
+}
+
+// savedSearchMode returns the match mode stored with a saved search, empty for substring matching.
+func savedSearchMode(mode search.MatchMode) string {
+	if mode == search.MatchSubstring {
+		return ""
+	}
+	return string(mode)
+}
+
+// displaySkippedProviders reports the resource types that were not searched because
+// their API is disabled in the project.
+func displaySkippedProviders(skipped []search.SkippedProvider) {
+	if len(skipped) == 0 {
+		return
+	}
+
+	for _, s := range skipped {
+		logger.Log.Debugf("Skipped %s in %s: %s is disabled", s.Provider, s.Project, s.Service)
+	}
+
+	pterm.Info.Printfln("Skipped %d resource type/project pair(s) whose API is disabled "+
+		"(run 'compass gcp projects refresh' to check again)", len(skipped))
+}
+
 // recordSearchAffinity records which projects had results for a search term.
 func recordSearchAffinity(searchTerm string, results []search.Result) {
 	cacheStore, err := gcp.LoadCache()
@@ -366,24 +432,32 @@ func resolveSearchProjects(searchTerm string, resourceTypes []string) ([]string,
 
 // displaySearchResults renders the search matches to stdout.
 // It uses a full table when the terminal is wide enough, otherwise falls back to a compact card layout.
-func displaySearchResults(results []search.Result) {
+// When showLabels is set, resource labels are rendered in an extra LABELS column.
+func displaySearchResults(results []search.Result, showLabels bool) {
 	if len(results) == 0 {
 		pterm.Info.Println("No resources matched your query.")
 		return
 	}
 
 	headers := []string{"TYPE", "PROJECT", "LOCATION", "NAME", "DETAILS"}
+	if showLabels {
+		headers = append(headers, "LABELS")
+	}
 	rows := make([][]string, 0, len(results)+1)
 	rows = append(rows, headers)
 
 	for _, result := range results {
-		rows = append(rows, []string{
+		row := []string{
 			string(result.Type),
 			result.Project,
 			result.Location,
 			result.Name,
 			formatResultDetails(result.Details),
-		})
+		}
+		if showLabels {
+			row = append(row, formatResultLabels(result.Labels))
+		}
+		rows = append(rows, row)
 	}
 
 	// Try rendering as a table first, then check if it fits
@@ -394,11 +468,11 @@ func displaySearchResults(results []search.Result) {
 	}
 
 	// Fall back to compact card layout for narrow terminals
-	displaySearchResultsCompact(results)
+	displaySearchResultsCompact(results, showLabels)
 }
 
 // displaySearchResultsCompact renders search results in a compact card layout suitable for narrow terminals.
-func displaySearchResultsCompact(results []search.Result) {
+func displaySearchResultsCompact(results []search.Result, showLabels bool) {
 	for i, result := range results {
 		if i > 0 {
 			pterm.Println()
@@ -409,6 +483,11 @@ func displaySearchResultsCompact(results []search.Result) {
 		if details := formatResultDetails(result.Details); details != "" {
 			pterm.Printf("  %-10s %s\n", "Details:", details)
 		}
+		if showLabels {
+			if labels := formatResultLabels(result.Labels); labels != "" {
+				pterm.Printf("  %-10s %s\n", "Labels:", labels)
+			}
+		}
 	}
 }
 
@@ -434,15 +513,36 @@ func formatResultDetails(details map[string]string) string {
 	return strings.Join(parts, ", ")
 }
 
+// formatResultLabels renders resource labels as sorted key=value pairs.
+func formatResultLabels(labels map[string]string) string {
+	if len(labels) == 0 {
+		return ""
+	}
+
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, key := range keys {
+		parts = append(parts, fmt.Sprintf("%s=%s", key, labels[key]))
+	}
+
+	return strings.Join(parts, ", ")
+}
+
 // parseSearchTypes validates and converts string type filters to ResourceKind values.
 // It handles both inclusion (types) and exclusion (noTypes) filters.
 // If types is empty but noTypes is specified, it returns all types except the excluded ones.
 // If both are specified, it returns the intersection (types minus noTypes).
+// Default plugin kinds offered by completion are resolved to the kinds the plugins described.
 func parseSearchTypes(types []string, noTypes []string) ([]search.ResourceKind, error) {
 	// Validate and parse excluded types first
 	excludedTypes := make(map[search.ResourceKind]struct{})
 	for _, t := range noTypes {
-		t = strings.TrimSpace(t)
+		t = search.ResolvePluginKind(strings.TrimSpace(t))
 		if t == "" {
 			continue
 		}
@@ -481,7 +581,7 @@ func parseSearchTypes(types []string, noTypes []string) ([]search.ResourceKind,
 	// Parse and filter inclusion types
 	result := make([]search.ResourceKind, 0, len(types))
 	for _, t := range types {
-		t = strings.TrimSpace(t)
+		t = search.ResolvePluginKind(strings.TrimSpace(t))
 		if t == "" {
 			continue
 		}
@@ -505,19 +605,45 @@ func parseSearchTypes(types []string, noTypes []string) ([]search.ResourceKind,
 	return result, nil
 }
 
-// completeSearchTypes provides shell completion for the --type flag.
+// completeSearchTypes provides shell completion for the --type flag, described with each kind's display name.
+// Plugins are completed with their default plugin.<name> kind: running them on every key press would be too slow.
 func completeSearchTypes(_ *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
-	allKinds := search.AllResourceKinds()
-	completions := make([]string, 0, len(allKinds))
+	descriptors := search.Descriptors()
+	plugins := search.InstalledPlugins()
+	completions := make([]string, 0, len(descriptors)+len(plugins))
+	seen := make(map[search.ResourceKind]struct{}, cap(completions))
 
 	toComplete = strings.ToLower(toComplete)
-	for _, kind := range allKinds {
+	add := func(kind search.ResourceKind, displayName string) {
+		if _, ok := seen[kind]; ok {
+			return
+		}
+		seen[kind] = struct{}{}
+
 		kindStr := string(kind)
 		if toComplete == "" || strings.Contains(strings.ToLower(kindStr), toComplete) {
-			completions = append(completions, kindStr)
+			completions = append(completions, cobra.CompletionWithDesc(kindStr, displayName))
 		}
 	}
 
+	for _, d := range descriptors {
+		add(d.Kind, d.DisplayName)
+	}
+	for _, plugin := range plugins {
+		add(plugin.Kind, plugin.DisplayName)
+	}
+
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeSearchSort provides shell completion for the --sort flag.
+func completeSearchSort(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+	orders := search.AllSortOrders()
+	completions := make([]string, 0, len(orders))
+	for _, order := range orders {
+		completions = append(completions, string(order))
+	}
+
 	return completions, cobra.ShellCompDirectiveNoFileComp
 }
 
@@ -533,5 +659,34 @@ func init() {
 	gcpSearchCmd.Flags().IntVar(&searchParallelism, "parallelism", 8,
 		"Number of projects to search in parallel (default 8)")
 
+	gcpSearchCmd.Flags().BoolVar(&searchShowLabels, "labels", false,
+		"Show resource labels in an additional LABELS column")
+
+	gcpSearchCmd.Flags().StringVar(&searchSort, "sort", string(search.SortRelevance),
+		"Result ordering: relevance, project, name or type")
+	_ = gcpSearchCmd.RegisterFlagCompletionFunc("sort", completeSearchSort)
+
+	gcpSearchCmd.Flags().DurationVar(&searchProviderTimeout, "timeout", 30*time.Second,
+		"Maximum time to wait for each resource type in a project")
+
+	gcpSearchCmd.Flags().StringVar(&searchSave, "save", "",
+		"Save this search under a name to re-run it later with --saved")
+	gcpSearchCmd.Flags().StringVar(&searchSaved, "saved", "",
+		"Run the saved search with this name")
+	_ = gcpSearchCmd.RegisterFlagCompletionFunc("saved", completeSavedSearches)
+	gcpSearchCmd.Flags().BoolVar(&searchListSaved, "list-saved", false,
+		"List the saved searches")
+	gcpSearchCmd.Flags().StringVar(&searchDeleteSaved, "delete-saved", "",
+		"Delete the saved search with this name")
+	_ = gcpSearchCmd.RegisterFlagCompletionFunc("delete-saved", completeSavedSearches)
+	gcpSearchCmd.Flags().BoolVar(&searchDiff, "diff", false,
+		"Compare the results of a saved search with its last run (exit code 2 when something changed)")
+
+	gcpSearchCmd.Flags().BoolVar(&searchRegex, "regex", false,
+		"Match the term as a case-sensitive regular expression (e.g. '^api-v[0-9]+-')")
+
+	gcpSearchCmd.Flags().BoolVar(&searchExact, "exact", false,
+		"Only match values equal to the term (case-sensitive)")
+
 	gcpCmd.AddCommand(gcpSearchCmd)
 }
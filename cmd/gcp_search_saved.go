@@ -0,0 +1,215 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/kedare/compass/internal/cache"
+	"github.com/kedare/compass/internal/gcp"
+	"github.com/kedare/compass/internal/gcp/search"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+)
+
+// searchDiffExitCode is returned by --diff when the results changed since the last run,
+// so cron jobs can tell changes (2) apart from failures (1).
+const searchDiffExitCode = 2
+
+// savedSearchCache captures the cache operations used by saved searches.
+type savedSearchCache interface {
+	SaveSearch(search cache.SavedSearch) error
+	GetSavedSearch(name string) (*cache.SavedSearch, bool)
+	ListSavedSearches() ([]cache.SavedSearch, error)
+	DeleteSavedSearch(name string) error
+	SetSavedSearchResults(name string, results []cache.SavedSearchResult) error
+	GetSavedSearchResults(name string) ([]cache.SavedSearchResult, time.Time, bool)
+}
+
+var (
+	// savedSearchStore returns the cache holding saved searches.
+	savedSearchStore = func() (savedSearchCache, error) {
+		cacheStore, err := gcp.LoadCache()
+		if err != nil {
+			return nil, fmt.Errorf("failed to load cache: %w", err)
+		}
+		if cacheStore == nil {
+			return nil, errors.New("saved searches require the cache, remove --cache=false")
+		}
+
+		return cacheStore, nil
+	}
+	// searchExit terminates the process with the given code (replaced in tests).
+	searchExit = os.Exit
+)
+
+// completeSavedSearches completes saved search names.
+func completeSavedSearches(_ *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	store, err := savedSearchStore()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	searches, err := store.ListSavedSearches()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	var names []string
+	for _, s := range searches {
+		if strings.HasPrefix(s.Name, toComplete) {
+			names = append(names, cobra.CompletionWithDesc(s.Name, s.Term))
+		}
+	}
+
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
+
+// runListSavedSearches prints the saved searches.
+func runListSavedSearches() error {
+	store, err := savedSearchStore()
+	if err != nil {
+		return err
+	}
+
+	searches, err := store.ListSavedSearches()
+	if err != nil {
+		return fmt.Errorf("failed to list saved searches: %w", err)
+	}
+	if len(searches) == 0 {
+		pterm.Info.Println("No saved searches. Use --save <name> to save one.")
+		return nil
+	}
+
+	rows := [][]string{{"NAME", "TERM", "TYPES", "PROJECT", "LAST RUN"}}
+	for _, s := range searches {
+		lastRun := "never"
+		if _, runAt, ok := store.GetSavedSearchResults(s.Name); ok {
+			lastRun = runAt.Format(time.RFC3339)
+		}
+		projectName := s.Project
+		if projectName == "" {
+			projectName = "(cached projects)"
+		}
+		types := strings.Join(s.Types, ", ")
+		if types == "" {
+			types = "(all)"
+		}
+		term := s.Term
+		if s.Mode != "" {
+			term = fmt.Sprintf("%s (%s)", s.Term, s.Mode)
+		}
+		rows = append(rows, []string{s.Name, term, types, projectName, lastRun})
+	}
+
+	return pterm.DefaultTable.WithHasHeader().WithData(rows).Render()
+}
+
+// runDeleteSavedSearch removes a saved search.
+func runDeleteSavedSearch(name string) error {
+	store, err := savedSearchStore()
+	if err != nil {
+		return err
+	}
+
+	if _, ok := store.GetSavedSearch(name); !ok {
+		return fmt.Errorf("no saved search named %q", name)
+	}
+	if err := store.DeleteSavedSearch(name); err != nil {
+		return err
+	}
+
+	pterm.Success.Printfln("Saved search '%s' deleted", name)
+
+	return nil
+}
+
+// toSavedResults converts search results to their stored form.
+func toSavedResults(results []search.Result) []cache.SavedSearchResult {
+	saved := make([]cache.SavedSearchResult, 0, len(results))
+	for _, r := range results {
+		saved = append(saved, cache.SavedSearchResult{
+			Type:     string(r.Type),
+			Name:     r.Name,
+			Project:  r.Project,
+			Location: r.Location,
+			Details:  r.Details,
+		})
+	}
+
+	return saved
+}
+
+// fromSavedResults converts stored results back to search results.
+func fromSavedResults(saved []cache.SavedSearchResult) []search.Result {
+	results := make([]search.Result, 0, len(saved))
+	for _, r := range saved {
+		results = append(results, search.Result{
+			Type:     search.ResourceKind(r.Type),
+			Name:     r.Name,
+			Project:  r.Project,
+			Location: r.Location,
+			Details:  r.Details,
+		})
+	}
+
+	return results
+}
+
+// displaySearchDiff prints the resources added, removed and changed since the previous run.
+func displaySearchDiff(diff search.ResultDiff, previousRun time.Time) {
+	if !diff.HasChanges() {
+		pterm.Success.Printfln("No changes since the last run (%s)", previousRun.Format(time.RFC3339))
+		return
+	}
+
+	pterm.Info.Printfln("Changes since the last run (%s): %d added, %d removed, %d changed",
+		previousRun.Format(time.RFC3339), len(diff.Added), len(diff.Removed), len(diff.Changed))
+
+	for _, r := range diff.Added {
+		pterm.Println(pterm.Green("+ " + formatDiffResult(r)))
+	}
+	for _, r := range diff.Removed {
+		pterm.Println(pterm.Red("- " + formatDiffResult(r)))
+	}
+	for _, change := range diff.Changed {
+		pterm.Println(pterm.Yellow("~ " + formatDiffResult(change.After)))
+		for _, field := range change.Fields {
+			pterm.Printfln("    %s: %s → %s", field,
+				formatDiffValue(change.Before.Details, field), formatDiffValue(change.After.Details, field))
+		}
+	}
+}
+
+// displayIncompletePairs reports the resource type/project pairs whose results are unknown in
+// this run, and whose previous results were kept for the diff and the stored baseline.
+func displayIncompletePairs(incomplete []search.ProviderProject) {
+	if len(incomplete) == 0 {
+		return
+	}
+
+	pterm.Warning.Printfln("%d resource type/project pair(s) failed or were skipped, their previous results were kept:", len(incomplete))
+	for _, pair := range incomplete {
+		pterm.Printfln("  ? %s in %s", pair.Provider, pair.Project)
+	}
+}
+
+// formatDiffResult renders a result on a single line for diff output.
+func formatDiffResult(r search.Result) string {
+	return fmt.Sprintf("%s %s (project %s, %s)", r.Type, r.Name, r.Project, r.Location)
+}
+
+// formatDiffValue returns a detail value, or a placeholder when the key is absent.
+func formatDiffValue(details map[string]string, key string) string {
+	value, ok := details[key]
+	if !ok {
+		return "(none)"
+	}
+	if value == "" {
+		return `""`
+	}
+
+	return value
+}
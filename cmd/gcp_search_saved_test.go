@@ -0,0 +1,295 @@
+package cmd
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kedare/compass/internal/cache"
+	"github.com/kedare/compass/internal/gcp/search"
+)
+
+type stubSavedSearchCache struct {
+	searches map[string]cache.SavedSearch
+	results  map[string][]cache.SavedSearchResult
+}
+
+func newStubSavedSearchCache() *stubSavedSearchCache {
+	return &stubSavedSearchCache{
+		searches: make(map[string]cache.SavedSearch),
+		results:  make(map[string][]cache.SavedSearchResult),
+	}
+}
+
+func (s *stubSavedSearchCache) SaveSearch(saved cache.SavedSearch) error {
+	// Like the cache, a changed definition drops the results of the last run
+	if previous, ok := s.searches[saved.Name]; ok && (previous.Term != saved.Term || previous.Mode != saved.Mode ||
+		previous.Project != saved.Project || strings.Join(previous.Types, ",") != strings.Join(saved.Types, ",")) {
+		delete(s.results, saved.Name)
+	}
+	s.searches[saved.Name] = saved
+	return nil
+}
+
+func (s *stubSavedSearchCache) GetSavedSearch(name string) (*cache.SavedSearch, bool) {
+	saved, ok := s.searches[name]
+	return &saved, ok
+}
+
+func (s *stubSavedSearchCache) ListSavedSearches() ([]cache.SavedSearch, error) {
+	var searches []cache.SavedSearch
+	for _, saved := range s.searches {
+		searches = append(searches, saved)
+	}
+	return searches, nil
+}
+
+func (s *stubSavedSearchCache) DeleteSavedSearch(name string) error {
+	delete(s.searches, name)
+	delete(s.results, name)
+	return nil
+}
+
+func (s *stubSavedSearchCache) SetSavedSearchResults(name string, results []cache.SavedSearchResult) error {
+	s.results[name] = results
+	return nil
+}
+
+func (s *stubSavedSearchCache) GetSavedSearchResults(name string) ([]cache.SavedSearchResult, time.Time, bool) {
+	results, ok := s.results[name]
+	return results, time.Unix(0, 0), ok
+}
+
+// setupSavedSearchTest stubs the cache, engine and exit code handling used by saved searches.
+// The engine returns whatever results currently holds.
+func setupSavedSearchTest(t *testing.T, results *[]search.Result) (*stubSavedSearchCache, *int, *[]search.Query) {
+	t.Helper()
+
+	store := newStubSavedSearchCache()
+	exitCode := -1
+	var queries []search.Query
+
+	prevStore, prevExit, prevFactory := savedSearchStore, searchExit, searchEngineFactory
+	prevProject, prevProvider, prevAffinity, prevSpinner := project, cachedProjectsProvider, cachedAffinityProvider, useSpinner
+	prevSave, prevSaved, prevDiff, prevTypes := searchSave, searchSaved, searchDiff, searchTypes
+	prevRegex, prevExact := searchRegex, searchExact
+
+	savedSearchStore = func() (savedSearchCache, error) { return store, nil }
+	searchExit = func(code int) { exitCode = code }
+	searchEngineFactory = func(_ int, _ ...search.Provider) resourceSearchEngine {
+		return searchEngineFunc(func(_ context.Context, _ []string, query search.Query) ([]search.Result, error) {
+			queries = append(queries, query)
+			return append([]search.Result(nil), *results...), nil
+		})
+	}
+	project = ""
+	cachedProjectsProvider = func(string, []string) ([]string, bool, error) { return []string{"proj-a"}, true, nil }
+	cachedAffinityProvider = func(string, []string) map[string]float64 { return nil }
+	useSpinner = false
+
+	t.Cleanup(func() {
+		savedSearchStore, searchExit, searchEngineFactory = prevStore, prevExit, prevFactory
+		project, cachedProjectsProvider, cachedAffinityProvider, useSpinner = prevProject, prevProvider, prevAffinity, prevSpinner
+		searchSave, searchSaved, searchDiff, searchTypes = prevSave, prevSaved, prevDiff, prevTypes
+		searchRegex, searchExact = prevRegex, prevExact
+	})
+
+	return store, &exitCode, &queries
+}
+
+func TestSavedSearchDiffSignalsChanges(t *testing.T) {
+	results := []search.Result{
+		{Type: search.KindAddress, Name: "ip-1", Project: "proj-a", Location: "us-east1", Details: map[string]string{"status": "RESERVED"}},
+	}
+	store, exitCode, queries := setupSavedSearchTest(t, &results)
+
+	// Save the search; the first run becomes the baseline.
+	searchSave = "unused-ips"
+	searchTypes = []string{"compute.address"}
+	if err := gcpSearchCmd.RunE(gcpSearchCmd, []string{"RESERVED"}); err != nil {
+		t.Fatalf("saving search failed: %v", err)
+	}
+	saved, ok := store.searches["unused-ips"]
+	if !ok || saved.Term != "RESERVED" || len(saved.Types) != 1 || saved.Types[0] != "compute.address" {
+		t.Fatalf("unexpected saved search: %+v", saved)
+	}
+	if len(store.results["unused-ips"]) != 1 {
+		t.Fatalf("expected baseline results to be stored, got %+v", store.results)
+	}
+
+	// Re-running by name without changes does not signal anything.
+	searchSave, searchTypes = "", nil
+	searchSaved, searchDiff = "unused-ips", true
+	if err := gcpSearchCmd.RunE(gcpSearchCmd, nil); err != nil {
+		t.Fatalf("running saved search failed: %v", err)
+	}
+	if *exitCode != -1 {
+		t.Fatalf("expected no exit without changes, got %d", *exitCode)
+	}
+	if last := (*queries)[len(*queries)-1]; last.Term != "RESERVED" || len(last.Types) != 1 || last.Types[0] != search.KindAddress {
+		t.Fatalf("expected stored term and types to be used, got %+v", last)
+	}
+
+	// A changed resource makes --diff exit with the changes code.
+	results[0].Details = map[string]string{"status": "IN_USE"}
+	if err := gcpSearchCmd.RunE(gcpSearchCmd, nil); err != nil {
+		t.Fatalf("running saved search failed: %v", err)
+	}
+	if *exitCode != searchDiffExitCode {
+		t.Fatalf("expected exit code %d, got %d", searchDiffExitCode, *exitCode)
+	}
+	if store.results["unused-ips"][0].Details["status"] != "IN_USE" {
+		t.Fatal("expected the latest run to be stored")
+	}
+}
+
+func TestSavedSearchDiffKeepsIncompletePairs(t *testing.T) {
+	results := []search.Result{
+		{Type: search.KindAddress, Name: "ip-1", Project: "proj-a", Location: "us-east1"},
+		{Type: search.KindDisk, Name: "disk-1", Project: "proj-a", Location: "us-east1-b"},
+	}
+	store, exitCode, _ := setupSavedSearchTest(t, &results)
+
+	searchSave = "inventory"
+	if err := gcpSearchCmd.RunE(gcpSearchCmd, []string{"1"}); err != nil {
+		t.Fatalf("saving search failed: %v", err)
+	}
+
+	// The disk search times out: its previous result is neither removed nor dropped.
+	searchEngineFactory = func(_ int, _ ...search.Provider) resourceSearchEngine {
+		return searchOutputFunc(func() *search.SearchOutput {
+			return &search.SearchOutput{
+				Results:  []search.Result{results[0]},
+				Warnings: []search.SearchWarning{{Project: "proj-a", Provider: search.KindDisk, Err: context.DeadlineExceeded}},
+			}
+		})
+	}
+	searchSave = ""
+	searchSaved, searchDiff = "inventory", true
+	if err := gcpSearchCmd.RunE(gcpSearchCmd, nil); err != nil {
+		t.Fatalf("running saved search failed: %v", err)
+	}
+	if *exitCode != -1 {
+		t.Fatalf("expected no exit for a failed resource type, got %d", *exitCode)
+	}
+	if len(store.results["inventory"]) != 2 {
+		t.Fatalf("expected the disk to stay in the baseline, got %+v", store.results["inventory"])
+	}
+}
+
+// searchOutputFunc is a search engine returning a full output, with warnings and skipped providers.
+type searchOutputFunc func() *search.SearchOutput
+
+func (f searchOutputFunc) Search(context.Context, []string, search.Query) ([]search.Result, error) {
+	return f().Results, nil
+}
+
+func (f searchOutputFunc) SearchWithWarnings(context.Context, []string, search.Query) (*search.SearchOutput, error) {
+	return f(), nil
+}
+
+func TestResavedSearchDiffStartsNewBaseline(t *testing.T) {
+	results := []search.Result{
+		{Type: search.KindAddress, Name: "ip-1", Project: "proj-a", Location: "us-east1"},
+	}
+	store, exitCode, _ := setupSavedSearchTest(t, &results)
+
+	searchSave = "ips"
+	if err := gcpSearchCmd.RunE(gcpSearchCmd, []string{"ip"}); err != nil {
+		t.Fatalf("saving search failed: %v", err)
+	}
+
+	// Re-saving with another term does not diff against the old query's results.
+	results = []search.Result{{Type: search.KindAddress, Name: "nat-1", Project: "proj-a", Location: "us-east1"}}
+	searchDiff = true
+	if err := gcpSearchCmd.RunE(gcpSearchCmd, []string{"nat"}); err != nil {
+		t.Fatalf("re-saving search failed: %v", err)
+	}
+	if *exitCode != -1 {
+		t.Fatalf("expected no exit for a new baseline, got %d", *exitCode)
+	}
+	if stored := store.results["ips"]; len(stored) != 1 || stored[0].Name != "nat-1" {
+		t.Fatalf("expected the new run to be the baseline, got %+v", stored)
+	}
+}
+
+func TestResolveSearchSpecValidation(t *testing.T) {
+	results := []search.Result{}
+	setupSavedSearchTest(t, &results)
+
+	searchSave, searchSaved = "a", "b"
+	if _, err := resolveSearchSpec(nil); err == nil {
+		t.Fatal("expected error when combining --save and --saved")
+	}
+
+	searchSave, searchSaved = "", "missing"
+	if _, err := resolveSearchSpec(nil); err == nil {
+		t.Fatal("expected error for unknown saved search")
+	}
+
+	searchSaved = ""
+	if _, err := resolveSearchSpec(nil); err == nil {
+		t.Fatal("expected error without a search term")
+	}
+
+	searchDiff = true
+	if _, err := resolveSearchSpec([]string{"web"}); err == nil {
+		t.Fatal("expected error for --diff without a saved search")
+	}
+
+	searchDiff, searchRegex, searchExact = false, true, true
+	if _, err := resolveSearchSpec([]string{"web"}); err == nil {
+		t.Fatal("expected error when combining --regex and --exact")
+	}
+
+	searchExact = false
+	if _, err := resolveSearchSpec([]string{"api-["}); err == nil {
+		t.Fatal("expected error for an invalid regular expression")
+	}
+}
+
+func TestSavedSearchKeepsMatchMode(t *testing.T) {
+	results := []search.Result{}
+	store, _, queries := setupSavedSearchTest(t, &results)
+
+	var affinityTerms []string
+	cachedAffinityProvider = func(term string, _ []string) map[string]float64 {
+		affinityTerms = append(affinityTerms, term)
+		return nil
+	}
+
+	searchSave, searchRegex = "api", true
+	if err := gcpSearchCmd.RunE(gcpSearchCmd, []string{"^api-v[0-9]+-"}); err != nil {
+		t.Fatalf("saving search failed: %v", err)
+	}
+	if mode := store.searches["api"].Mode; mode != "regex" {
+		t.Fatalf("expected the regex mode to be saved, got %q", mode)
+	}
+
+	searchSave, searchRegex, searchSaved = "", false, "api"
+	if err := gcpSearchCmd.RunE(gcpSearchCmd, nil); err != nil {
+		t.Fatalf("running saved search failed: %v", err)
+	}
+	if last := (*queries)[len(*queries)-1]; !last.Regex || last.Term != "^api-v[0-9]+-" {
+		t.Fatalf("expected the saved search to run as a regex, got %+v", last)
+	}
+	for _, term := range affinityTerms {
+		if term != "api-v" {
+			t.Fatalf("expected affinity to use the literal prefix of the pattern, got %q", term)
+		}
+	}
+}
+
+func TestFormatDiffValue(t *testing.T) {
+	details := map[string]string{"status": "RESERVED", "users": ""}
+	if got := formatDiffValue(details, "status"); got != "RESERVED" {
+		t.Fatalf("unexpected value %q", got)
+	}
+	if got := formatDiffValue(details, "users"); got != `""` {
+		t.Fatalf("unexpected empty value %q", got)
+	}
+	if got := formatDiffValue(details, "missing"); got != "(none)" {
+		t.Fatalf("unexpected missing value %q", got)
+	}
+}
@@ -2,9 +2,13 @@ package cmd
 
 import (
 	"context"
+	"os"
+	"path/filepath"
+	"runtime"
 	"testing"
 
 	"github.com/kedare/compass/internal/gcp/search"
+	"github.com/spf13/cobra"
 )
 
 func TestResolveSearchProjectsPrefersFlag(t *testing.T) {
@@ -68,11 +72,13 @@ func TestGCPSearchCommandInvokesEngine(t *testing.T) {
 	t.Cleanup(func() { useSpinner = prevUseSpinner })
 
 	prevSearchFactory := searchEngineFactory
-	prevProviderFactory := instanceProviderFactory
 	prevProject := project
 	prevCacheProvider := cachedProjectsProvider
+	prevAffinityProvider := cachedAffinityProvider
 	project = ""
-	instanceProviderFactory = func() search.Provider { return nil }
+	cachedAffinityProvider = func(string, []string) map[string]float64 {
+		return map[string]float64{"proj-a": 1.5}
+	}
 	cachedProjectsProvider = func(searchTerm string, resourceTypes []string) ([]string, bool, error) {
 		return []string{"proj-a"}, true, nil
 	}
@@ -83,6 +89,9 @@ func TestGCPSearchCommandInvokesEngine(t *testing.T) {
 			if query.Term != "piou" {
 				t.Fatalf("unexpected query: %s", query.Term)
 			}
+			if query.Affinity["proj-a"] != 1.5 {
+				t.Fatalf("expected affinity scores on the query, got %v", query.Affinity)
+			}
 			if len(projects) != 1 || projects[0] != "proj-a" {
 				t.Fatalf("unexpected projects: %#v", projects)
 			}
@@ -93,9 +102,9 @@ func TestGCPSearchCommandInvokesEngine(t *testing.T) {
 
 	t.Cleanup(func() {
 		searchEngineFactory = prevSearchFactory
-		instanceProviderFactory = prevProviderFactory
 		project = prevProject
 		cachedProjectsProvider = prevCacheProvider
+		cachedAffinityProvider = prevAffinityProvider
 	})
 
 	if err := gcpSearchCmd.RunE(gcpSearchCmd, []string{"piou"}); err != nil {
@@ -119,6 +128,18 @@ func TestFormatResultDetails(t *testing.T) {
 	}
 }
 
+func TestFormatResultLabels(t *testing.T) {
+	labels := map[string]string{"team": "sre", "env": "prod", "cost-center": ""}
+	formatted := formatResultLabels(labels)
+	if formatted != "cost-center=, env=prod, team=sre" {
+		t.Fatalf("unexpected formatted value: %s", formatted)
+	}
+
+	if formatResultLabels(nil) != "" {
+		t.Fatal("expected empty string for nil labels")
+	}
+}
+
 type searchEngineFunc func(ctx context.Context, projects []string, query search.Query) ([]search.Result, error)
 
 func (f searchEngineFunc) Search(ctx context.Context, projects []string, query search.Query) ([]search.Result, error) {
@@ -230,3 +251,47 @@ func TestParseSearchTypes(t *testing.T) {
 		}
 	})
 }
+
+func TestCompleteSearchTypes(t *testing.T) {
+	completions, directive := completeSearchTypes(nil, nil, "")
+	if directive != cobra.ShellCompDirectiveNoFileComp {
+		t.Fatalf("unexpected directive %v", directive)
+	}
+	if len(completions) != len(search.AllResourceKinds()) {
+		t.Fatalf("expected %d completions, got %d", len(search.AllResourceKinds()), len(completions))
+	}
+
+	completions, _ = completeSearchTypes(nil, nil, "FUNCTIONS")
+	if len(completions) != 1 || completions[0] != string(search.KindCloudFunction)+"\tCloud Functions" {
+		t.Fatalf("unexpected completions for functions: %v", completions)
+	}
+
+	completions, _ = completeSearchTypes(nil, nil, "run.")
+	if len(completions) != 2 {
+		t.Fatalf("expected run.service and run.job, got %v", completions)
+	}
+}
+
+func TestCompleteSearchTypesListsPluginsWithoutRunningThem(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell script plugins are not supported on windows")
+	}
+
+	dir := t.TempDir()
+	marker := filepath.Join(dir, "ran")
+	script := "#!/bin/sh\ntouch " + marker + "\necho '[]'\n"
+	if err := os.WriteFile(filepath.Join(dir, search.PluginPrefix+"cmdb"), []byte(script), 0o755); err != nil {
+		t.Fatalf("failed to write plugin: %v", err)
+	}
+	t.Setenv("PATH", dir)
+	t.Setenv(search.PluginPathsEnv, "")
+
+	completions, _ := completeSearchTypes(nil, nil, "plugin.")
+	if len(completions) != 1 || completions[0] != "plugin.cmdb\tcmdb (plugin)" {
+		t.Fatalf("expected the plugin to be completed, got %v", completions)
+	}
+
+	if _, err := os.Stat(marker); err == nil {
+		t.Fatal("completion must not run plugins")
+	}
+}
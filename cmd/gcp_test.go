@@ -143,11 +143,12 @@ func TestResourceTypeConstants(t *testing.T) {
 }
 
 func TestIPLookupCommand(t *testing.T) {
-	require.Equal(t, "lookup <ip-address>", ipLookupCmd.Use)
+	require.Equal(t, "lookup <ip-address|cidr|hostname>...", ipLookupCmd.Use)
 	require.NotEmpty(t, ipLookupCmd.Short)
 	require.Error(t, ipLookupCmd.Args(ipLookupCmd, []string{}))
-	require.Error(t, ipLookupCmd.Args(ipLookupCmd, []string{"1.2.3.4", "extra"}))
+	require.NoError(t, ipLookupCmd.Args(ipLookupCmd, []string{"1.2.3.4", "10.0.0.1"}))
 	require.NoError(t, ipLookupCmd.Args(ipLookupCmd, []string{"1.2.3.4"}))
+	require.NotNil(t, ipLookupCmd.Flags().Lookup("file"))
 }
 
 func TestEnumerateProjects(t *testing.T) {
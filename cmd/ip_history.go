@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"net"
+	"strings"
+
+	"github.com/kedare/compass/internal/logger"
+	"github.com/kedare/compass/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var ipHistoryOutputFormat string
+
+var ipHistoryCmd = &cobra.Command{
+	Use:   "history <ip-address>",
+	Short: "Show which resources owned an IP address over time",
+	Long: `Print the ownership timeline of an IP address from the local cache: every resource
+seen with the address, with the first and last time it was seen.
+
+Associations are recorded by "ip lookup" and by project scans ("projects import" and
+"projects refresh"), so the timeline only covers what was looked up or scanned. Entries are
+kept for the ip_history TTL after their last sighting (see "compass cache ttl").`,
+	Args: cobra.ExactArgs(1),
+	Run: func(_ *cobra.Command, args []string) {
+		runIPHistory(args[0])
+	},
+}
+
+func init() {
+	ipCmd.AddCommand(ipHistoryCmd)
+
+	ipHistoryCmd.Flags().StringVarP(&ipHistoryOutputFormat, "output", "o",
+		output.DefaultFormat("text", []string{"table", "text", "json"}),
+		"Output format: table, text, json")
+}
+
+// runIPHistory prints the recorded owners of the address.
+func runIPHistory(address string) {
+	output.SetFormat(ipHistoryOutputFormat)
+
+	ip := net.ParseIP(strings.TrimSpace(address))
+	if ip == nil {
+		logger.Log.Fatalf("Invalid IP address: %s", address)
+	}
+
+	cacheInst, err := loadCacheFunc()
+	if err != nil {
+		logger.Log.Fatalf("Failed to load cache: %v", err)
+	}
+
+	history, err := cacheInst.GetIPHistory(ip.String())
+	if err != nil {
+		logger.Log.Fatalf("Failed to load IP history: %v", err)
+	}
+
+	if err := output.DisplayIPHistory(ip.String(), history, ipHistoryOutputFormat); err != nil {
+		logger.Log.Fatalf("Failed to render IP history: %v", err)
+	}
+}
@@ -1,46 +1,84 @@
 package cmd
 
 import (
+	"bufio"
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"net"
+	"os"
 	"strings"
 	"sync"
+	"unicode"
 
+	"github.com/kedare/compass/internal/cache"
 	"github.com/kedare/compass/internal/gcp"
+	"github.com/kedare/compass/internal/ipranges"
 	"github.com/kedare/compass/internal/logger"
 	"github.com/kedare/compass/internal/output"
 	"github.com/spf13/cobra"
 	"golang.org/x/sync/errgroup"
 )
 
-var ipLookupOutputFormat string
+var (
+	ipLookupOutputFormat string
+	ipLookupFile         string
+	ipLookupPolicy       bool
+)
 
 // lookupResult represents the result of an IP lookup operation for a single GCP client.
 type lookupResult struct {
 	client       *gcp.Client
-	associations []gcp.IPAssociation
+	associations map[string][]gcp.IPAssociation
 	err          error
 }
 
 // lookupAttemptOutcome aggregates the results from multiple lookup attempts across projects.
 type lookupAttemptOutcome struct {
-	results        []gcp.IPAssociation
+	results        map[string][]gcp.IPAssociation
 	successClients []*gcp.Client
 	hadSuccess     bool
 	canceled       bool
 }
 
 var ipLookupCmd = &cobra.Command{
-	Use:   "lookup <ip-address>",
-	Short: "Find the GCP resources referencing an IP address",
+	Use:   "lookup <ip-address|cidr|hostname>...",
+	Short: "Find the GCP resources referencing IP addresses, ranges or hostnames",
 	Long: `Search Compute Engine instances, forwarding rules, and reserved addresses to identify
 which resources own or reference a given IP address. When no project is specified, all cached
-projects are scanned automatically.`,
-	Args: cobra.ExactArgs(1),
+projects are scanned automatically.
+
+Given a CIDR prefix such as 10.20.0.0/22, every instance interface, alias IP range, forwarding
+rule, reserved address and subnet range inside it is listed, sorted by address.
+
+Several addresses can be looked up at once, as arguments or one or more per line in a file
+given with --file ("-" reads from stdin, as does a "-" argument). Each project is then listed
+only once for all addresses, and the results are reported per address, including the ones
+without any association.
+
+Hostnames such as db.internal.example.com are resolved with the system resolver and in the
+private Cloud DNS zones of the cached projects (which a resolver outside of their VPC networks
+cannot see), following CNAME records. The zone and record behind each address are shown, then
+every resolved address is looked up.
+
+With --policy, the firewall rules whose source or destination ranges contain each address and
+the routes whose destination range contains it (longest prefix first) are listed as well, from
+the projects that were searched.
+
+Addresses nothing references are classified against the published Google IP ranges (scope,
+region and documented purpose such as IAP or health checks) and the special-purpose ranges
+(RFC 1918, shared address space, link-local...). Refresh the Google ranges with
+"compass gcp ip ranges update".`,
+	Args: func(_ *cobra.Command, args []string) error {
+		if len(args) == 0 && strings.TrimSpace(ipLookupFile) == "" {
+			return errors.New("requires at least one IP address, CIDR or hostname, or --file")
+		}
+
+		return nil
+	},
 	Run: func(cmd *cobra.Command, args []string) {
-		runIPLookup(cmd.Context(), args[0])
+		runIPLookup(cmd.Context(), args)
 	},
 }
 
@@ -51,26 +89,52 @@ func init() {
 	ipCmd.AddCommand(ipLookupCmd)
 
 	ipLookupCmd.Flags().StringVarP(&ipLookupOutputFormat, "output", "o",
-		output.DefaultFormat("text", []string{"table", "text", "json"}),
-		"Output format: table, text, json")
+		output.DefaultFormat("text", []string{"table", "text", "json", "csv"}),
+		"Output format: table, text, json, csv")
+	ipLookupCmd.Flags().StringVarP(&ipLookupFile, "file", "f", "",
+		"Read IP addresses, CIDRs or hostnames from a file, one or more per line (\"-\" for stdin)")
+	ipLookupCmd.Flags().BoolVar(&ipLookupPolicy, "policy", false,
+		"Also list the firewall rules and routes referencing each address")
 }
 
 // runIPLookup orchestrates the lookup by querying all candidate projects in parallel and gathering matches.
-func runIPLookup(ctx context.Context, rawIP string) {
+func runIPLookup(ctx context.Context, args []string) {
 	// Set the output format to ensure JSON mode detection works correctly for spinners
 	output.SetFormat(ipLookupOutputFormat)
 
-	ip := strings.TrimSpace(rawIP)
-	parsedIP := net.ParseIP(ip)
-	if parsedIP == nil {
-		logger.Log.Fatalf("Invalid IP address: %s", rawIP)
+	targets, err := collectLookupTargets(args, ipLookupFile, os.Stdin)
+	if err != nil {
+		logger.Log.Fatalf("%v", err)
+	}
+
+	if len(targets) == 0 {
+		logger.Log.Fatalf("No IP address to look up")
 	}
 
 	if ctx == nil {
 		ctx = context.Background()
 	}
 
-	preferredProjects := preferredProjectsForIP(parsedIP)
+	hostnames, targets := splitHostnameTargets(targets)
+	var resolutions []gcp.HostnameAddress
+	if len(hostnames) > 0 {
+		resolveSpin := output.NewSpinner("Resolving hostnames")
+		resolveSpin.Start()
+		resolutions = resolveLookupHostnames(ctx, hostnames, lookupClients(ctx, nil))
+		resolveSpin.Stop()
+
+		for _, hostname := range unresolvedHostnames(hostnames, resolutions) {
+			logger.Log.Warnf("No address found for hostname %s", hostname)
+		}
+
+		targets = resolvedTargets(targets, resolutions)
+		if len(targets) == 0 {
+			logger.Log.Fatalf("No address found for %s", strings.Join(hostnames, ", "))
+		}
+	}
+
+	preferredProjects := preferredProjectsForTargets(targets)
+
 	clients := lookupClients(ctx, preferredProjects)
 	if len(clients) == 0 && len(preferredProjects) > 0 {
 		logger.Log.Debug("Cached subnet matches did not yield any clients; falling back to full discovery")
@@ -85,31 +149,34 @@ func runIPLookup(ctx context.Context, rawIP string) {
 	spin := output.NewSpinner("Searching IP associations")
 	spin.Start()
 
-	combinedResults := make([]gcp.IPAssociation, 0)
+	combinedResults := make(map[string][]gcp.IPAssociation, len(targets))
 	successClientSet := make(map[*gcp.Client]struct{})
+	successClients := make([]*gcp.Client, 0, len(clients))
 	hadSuccess := false
 	canceled := false
 
-	outcome := executeLookupAcrossClients(ctx, ip, clients, spin)
-	combinedResults = append(combinedResults, outcome.results...)
-	for _, client := range outcome.successClients {
-		successClientSet[client] = struct{}{}
-	}
-	hadSuccess = hadSuccess || outcome.hadSuccess
-	canceled = canceled || outcome.canceled
-
-	if !canceled && len(outcome.results) == 0 && len(preferredProjects) > 0 {
-		spin.Update("No matches from cached subnets; scanning all configured projects…")
-		fallbackClients := lookupClients(ctx, nil)
-		outcome = executeLookupAcrossClients(ctx, ip, fallbackClients, spin)
-		combinedResults = append(combinedResults, outcome.results...)
+	merge := func(outcome lookupAttemptOutcome) {
+		for target, associations := range outcome.results {
+			combinedResults[target] = append(combinedResults[target], associations...)
+		}
 		for _, client := range outcome.successClients {
+			if _, seen := successClientSet[client]; !seen {
+				successClients = append(successClients, client)
+			}
 			successClientSet[client] = struct{}{}
 		}
 		hadSuccess = hadSuccess || outcome.hadSuccess
 		canceled = canceled || outcome.canceled
 	}
 
+	merge(executeLookupAcrossClients(ctx, targets, clients, spin))
+
+	if missing := unmatchedTargets(targets, combinedResults); !canceled && len(missing) > 0 && len(preferredProjects) > 0 {
+		spin.Update("No matches from cached subnets; scanning all configured projects…")
+		fallbackClients := lookupClients(ctx, nil)
+		merge(executeLookupAcrossClients(ctx, missing, fallbackClients, spin))
+	}
+
 	if canceled {
 		spin.Fail("Lookup canceled")
 
@@ -121,25 +188,246 @@ func runIPLookup(ctx context.Context, rawIP string) {
 		logger.Log.Fatalf("IP lookup failed for all checked projects")
 	}
 
-	deduped := dedupeAssociations(combinedResults)
+	for _, target := range targets {
+		deduped := dedupeAssociations(combinedResults[target])
+		if _, isPrefix := gcp.ParseIPPrefix(target); isPrefix {
+			gcp.SortAssociationsByAddress(deduped)
+		}
+		combinedResults[target] = deduped
+	}
 
 	spin.Success("Lookup complete")
 
-	if len(deduped) == 0 {
-		fmt.Printf("No resources found for IP %s\n", ip)
+	for _, client := range successClients {
+		client.RememberProject()
+	}
+
+	var policies map[string]gcp.IPPolicy
+	if ipLookupPolicy {
+		policySpin := output.NewSpinner("Listing firewall rules and routes")
+		policySpin.Start()
+		policies = collectLookupPolicies(ctx, successClients, targets)
+		policySpin.Stop()
+	}
+
+	if len(hostnames) > 0 || policies != nil {
+		switch strings.ToLower(ipLookupOutputFormat) {
+		case "json", "csv":
+			if err := output.DisplayIPLookupDocument(resolutions, targets, combinedResults, policies, ipLookupOutputFormat); err != nil {
+				logger.Log.Fatalf("Failed to render lookup results: %v", err)
+			}
+
+			return
+		}
+	}
+
+	if len(hostnames) > 0 {
+		if err := output.DisplayHostnameResolutions(resolutions, ipLookupOutputFormat); err != nil {
+			logger.Log.Fatalf("Failed to render hostname resolutions: %v", err)
+		}
+	}
+
+	displayLookupAssociations(targets, combinedResults)
+
+	if policies != nil {
+		if err := output.DisplayIPPolicies(targets, policies, ipLookupOutputFormat); err != nil {
+			logger.Log.Fatalf("Failed to render firewall rules and routes: %v", err)
+		}
+	}
+}
+
+// displayLookupAssociations renders the associations of the targets, batch style for several
+// targets or CSV, with the range classification of a single address nothing references.
+func displayLookupAssociations(targets []string, combinedResults map[string][]gcp.IPAssociation) {
+	if len(targets) > 1 || strings.EqualFold(ipLookupOutputFormat, "csv") {
+		if err := output.DisplayBatchIPLookupResults(targets, combinedResults, ipLookupOutputFormat); err != nil {
+			logger.Log.Fatalf("Failed to render lookup results: %v", err)
+		}
 
 		return
 	}
 
-	for client := range successClientSet {
-		client.RememberProject()
+	target := targets[0]
+	if len(combinedResults[target]) == 0 {
+		if _, isPrefix := gcp.ParseIPPrefix(target); isPrefix {
+			fmt.Printf("No resources found in %s\n", target)
+
+			return
+		}
+
+		class := ipranges.Default().Classify(net.ParseIP(target))
+		if !strings.EqualFold(ipLookupOutputFormat, "json") {
+			fmt.Printf("No resources found for IP %s\n", target)
+		}
+		if err := output.DisplayIPClassification(target, class, ipLookupOutputFormat); err != nil {
+			logger.Log.Fatalf("Failed to render classification: %v", err)
+		}
+
+		return
 	}
 
-	if err := output.DisplayIPLookupResults(deduped, ipLookupOutputFormat); err != nil {
+	if err := output.DisplayIPLookupResults(combinedResults[target], ipLookupOutputFormat); err != nil {
 		logger.Log.Fatalf("Failed to render lookup results: %v", err)
 	}
 }
 
+// collectLookupTargets gathers the addresses to look up from the arguments and the optional file,
+// canonicalized and deduplicated in order. A "-" argument or file reads from stdin.
+// Invalid arguments are an error, while invalid entries of a file are skipped with a warning.
+func collectLookupTargets(args []string, file string, stdin io.Reader) ([]string, error) {
+	seen := make(map[string]struct{})
+	targets := make([]string, 0, len(args))
+
+	add := func(target string) {
+		if _, exists := seen[target]; exists {
+			return
+		}
+		seen[target] = struct{}{}
+		targets = append(targets, target)
+	}
+
+	readStdin := false
+	for _, arg := range args {
+		if strings.TrimSpace(arg) == "-" {
+			readStdin = true
+
+			continue
+		}
+
+		for _, token := range splitLookupTokens(arg) {
+			target, err := normalizeLookupTarget(token)
+			if err != nil {
+				return nil, err
+			}
+			add(target)
+		}
+	}
+
+	var readers []io.Reader
+	var sources []string
+
+	file = strings.TrimSpace(file)
+	switch file {
+	case "":
+	case "-":
+		readStdin = true
+	default:
+		f, err := os.Open(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open %s: %w", file, err)
+		}
+		defer func() { _ = f.Close() }()
+
+		readers = append(readers, f)
+		sources = append(sources, file)
+	}
+
+	if readStdin {
+		readers = append(readers, stdin)
+		sources = append(sources, "stdin")
+	}
+
+	for i, reader := range readers {
+		scanner := bufio.NewScanner(reader)
+		line := 0
+		for scanner.Scan() {
+			line++
+
+			text := scanner.Text()
+			if idx := strings.Index(text, "#"); idx >= 0 {
+				text = text[:idx]
+			}
+
+			for _, token := range splitLookupTokens(text) {
+				target, err := normalizeLookupTarget(token)
+				if err != nil {
+					logger.Log.Warnf("Skipping %s line %d: %v", sources[i], line, err)
+
+					continue
+				}
+				add(target)
+			}
+		}
+
+		if err := scanner.Err(); err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", sources[i], err)
+		}
+	}
+
+	return targets, nil
+}
+
+// splitLookupTokens splits a line of addresses separated by whitespace or commas.
+func splitLookupTokens(value string) []string {
+	return strings.FieldsFunc(value, func(r rune) bool {
+		return r == ',' || unicode.IsSpace(r)
+	})
+}
+
+// normalizeLookupTarget validates an IP address, CIDR prefix or hostname and returns its canonical form.
+func normalizeLookupTarget(token string) (string, error) {
+	token = strings.TrimSpace(token)
+
+	if strings.Contains(token, "/") {
+		prefix, ok := gcp.ParseIPPrefix(token)
+		if !ok {
+			return "", fmt.Errorf("invalid CIDR prefix: %s", token)
+		}
+
+		return prefix.String(), nil
+	}
+
+	ip := net.ParseIP(token)
+	if ip == nil {
+		if isHostname(token) {
+			return strings.ToLower(strings.TrimSuffix(token, ".")), nil
+		}
+
+		return "", fmt.Errorf("invalid IP address or hostname: %s", token)
+	}
+
+	return ip.String(), nil
+}
+
+// preferredProjectsForTargets returns the union of the projects whose cached subnets
+// contain or overlap the targets, in order.
+func preferredProjectsForTargets(targets []string) []string {
+	seen := make(map[string]struct{})
+	projects := make([]string, 0)
+
+	for _, target := range targets {
+		var preferred []string
+		if prefix, ok := gcp.ParseIPPrefix(target); ok {
+			preferred = preferredProjectsForCIDR(prefix)
+		} else {
+			preferred = preferredProjectsForIP(net.ParseIP(target))
+		}
+
+		for _, projectID := range preferred {
+			key := strings.ToLower(projectID)
+			if _, exists := seen[key]; exists {
+				continue
+			}
+			seen[key] = struct{}{}
+			projects = append(projects, projectID)
+		}
+	}
+
+	return projects
+}
+
+// unmatchedTargets returns the targets without any association yet.
+func unmatchedTargets(targets []string, results map[string][]gcp.IPAssociation) []string {
+	missing := make([]string, 0)
+	for _, target := range targets {
+		if len(results[target]) == 0 {
+			missing = append(missing, target)
+		}
+	}
+
+	return missing
+}
+
 // preferredProjectsForIP returns projects whose cached subnets already contain the IP.
 func preferredProjectsForIP(ip net.IP) []string {
 	if ip == nil {
@@ -151,7 +439,25 @@ func preferredProjectsForIP(ip net.IP) []string {
 		return nil
 	}
 
-	entries := cacheInst.FindSubnetsForIP(ip)
+	return projectsFromSubnets(cacheInst.FindSubnetsForIP(ip))
+}
+
+// preferredProjectsForCIDR returns projects whose cached subnets overlap the prefix.
+func preferredProjectsForCIDR(prefix *net.IPNet) []string {
+	if prefix == nil {
+		return nil
+	}
+
+	cacheInst, err := loadCacheFunc()
+	if err != nil || cacheInst == nil {
+		return nil
+	}
+
+	return projectsFromSubnets(cacheInst.FindSubnetsOverlapping(prefix))
+}
+
+// projectsFromSubnets returns the unique projects of cached subnet entries, in order.
+func projectsFromSubnets(entries []*cache.SubnetEntry) []string {
 	if len(entries) == 0 {
 		return nil
 	}
@@ -183,10 +489,12 @@ func preferredProjectsForIP(ip net.IP) []string {
 	return ordered
 }
 
-// executeLookupAcrossClients scans each client and aggregates the results while updating the spinner.
+// executeLookupAcrossClients scans each client for all targets and aggregates the results per target
+// while updating the spinner. A single target uses the cache-aware LookupIPAddressFast, while several
+// targets share one listing of each project's resources through LookupIPAddresses.
 // The function uses the global concurrency setting to limit the number of concurrent operations
 // and the number of concurrent progress spinners displayed.
-func executeLookupAcrossClients(ctx context.Context, ip string, clients []*gcp.Client, spin *output.Spinner) lookupAttemptOutcome {
+func executeLookupAcrossClients(ctx context.Context, targets []string, clients []*gcp.Client, spin *output.Spinner) lookupAttemptOutcome {
 	outcome := lookupAttemptOutcome{}
 	if len(clients) == 0 {
 		return outcome
@@ -250,8 +558,7 @@ func executeLookupAcrossClients(ctx context.Context, ip string, clients []*gcp.C
 				return groupCtx.Err()
 			}
 
-			// Use optimized lookup that leverages cached subnet information
-			associations, err := client.LookupIPAddressFast(groupCtx, ip)
+			associations, err := lookupTargets(groupCtx, client, targets)
 
 			res := lookupResult{
 				client:       client,
@@ -284,7 +591,7 @@ func executeLookupAcrossClients(ctx context.Context, ip string, clients []*gcp.C
 
 	successSeen := make(map[*gcp.Client]struct{}, len(clients))
 	successClients := make([]*gcp.Client, 0, len(clients))
-	results := make([]gcp.IPAssociation, 0)
+	results := make(map[string][]gcp.IPAssociation, len(targets))
 	ctxDone := ctx.Done()
 	total := len(clients)
 	started := 0
@@ -365,8 +672,8 @@ loop:
 			}
 
 			outcome.hadSuccess = true
-			if len(res.associations) > 0 {
-				results = append(results, res.associations...)
+			for target, associations := range res.associations {
+				results[target] = append(results[target], associations...)
 			}
 
 			if _, seen := successSeen[res.client]; !seen {
@@ -393,6 +700,21 @@ loop:
 	return outcome
 }
 
+// lookupTargets runs the lookup of the targets in a single project.
+func lookupTargets(ctx context.Context, client *gcp.Client, targets []string) (map[string][]gcp.IPAssociation, error) {
+	if len(targets) == 1 {
+		// Use optimized lookup that leverages cached subnet information
+		associations, err := client.LookupIPAddressFast(ctx, targets[0])
+		if err != nil {
+			return nil, err
+		}
+
+		return map[string][]gcp.IPAssociation{targets[0]: associations}, nil
+	}
+
+	return client.LookupIPAddresses(ctx, targets)
+}
+
 // dedupeAssociations removes duplicate associations based on key fields.
 func dedupeAssociations(input []gcp.IPAssociation) []gcp.IPAssociation {
 	if len(input) <= 1 {
@@ -47,3 +47,31 @@ func TestPreferredProjectsForIP_UsesCachedSubnets(t *testing.T) {
 	require.NoError(t, err)
 	require.False(t, info.IsDir(), "expected cache file, found directory: %s", cacheFile)
 }
+
+func TestPreferredProjectsForCIDR_UsesOverlappingSubnets(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	cache.SetEnabled(true)
+
+	c, err := cache.New()
+	require.NoError(t, err)
+
+	origLoad := loadCacheFunc
+	defer func() { loadCacheFunc = origLoad }()
+	loadCacheFunc = func() (*cache.Cache, error) {
+		return c, nil
+	}
+
+	for _, entry := range []*cache.SubnetEntry{
+		{Project: "proj-a", Network: "vpc", Region: "us-central1", Name: "subnet-a", PrimaryCIDR: "10.20.1.0/24"},
+		{Project: "proj-a", Network: "vpc", Region: "europe-west1", Name: "subnet-b", PrimaryCIDR: "10.20.2.0/24"},
+		{Project: "proj-b", Network: "vpc", Region: "us-central1", Name: "subnet-c", PrimaryCIDR: "10.50.0.0/24"},
+	} {
+		require.NoError(t, c.RememberSubnet(entry))
+	}
+
+	_, prefix, err := net.ParseCIDR("10.20.0.0/22")
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"proj-a"}, preferredProjectsForCIDR(prefix))
+}
@@ -3,6 +3,9 @@ package cmd
 import (
 	"context"
 	"errors"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/kedare/compass/internal/gcp"
@@ -95,3 +98,128 @@ func TestIsContextError(t *testing.T) {
 		})
 	}
 }
+
+func TestCollectLookupTargets(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "ips.txt")
+	content := "# egress addresses\n10.0.0.1, 10.0.0.2\n\nnot-an-ip 10.0.0.1\n10.20.1.7/22 # office\n"
+	require.NoError(t, os.WriteFile(file, []byte(content), 0o600))
+
+	targets, err := collectLookupTargets([]string{"10.0.0.2", "2600:ABCD::1"}, file, strings.NewReader(""))
+	require.NoError(t, err)
+	require.Equal(t, []string{"10.0.0.2", "2600:abcd::1", "10.0.0.1", "10.20.0.0/22"}, targets)
+
+	targets, err = collectLookupTargets([]string{"-"}, "", strings.NewReader("192.0.2.1\n192.0.2.1 192.0.2.2\n"))
+	require.NoError(t, err)
+	require.Equal(t, []string{"192.0.2.1", "192.0.2.2"}, targets)
+
+	targets, err = collectLookupTargets(nil, "-", strings.NewReader("198.51.100.7"))
+	require.NoError(t, err)
+	require.Equal(t, []string{"198.51.100.7"}, targets)
+
+	_, err = collectLookupTargets([]string{"10.0.0.1", "nope"}, "", nil)
+	require.Error(t, err)
+
+	targets, err = collectLookupTargets([]string{"DB.Internal.example.com.", "10.0.0.1"}, "", nil)
+	require.NoError(t, err)
+	require.Equal(t, []string{"db.internal.example.com", "10.0.0.1"}, targets)
+
+	_, err = collectLookupTargets(nil, filepath.Join(t.TempDir(), "missing.txt"), nil)
+	require.Error(t, err)
+}
+
+func TestIsHostname(t *testing.T) {
+	require.True(t, isHostname("db.internal.example.com"))
+	require.True(t, isHostname("_sip.example.com."))
+	require.True(t, isHostname("10-0-0-1.nip.io"))
+	require.False(t, isHostname("nope"), "single labels are rejected")
+	require.False(t, isHostname("10.0.0.256"))
+	require.False(t, isHostname("-bad.example.com"))
+	require.False(t, isHostname("bad..example.com"))
+	require.False(t, isHostname("spaces in.example.com"))
+}
+
+func TestResolveLookupHostnames(t *testing.T) {
+	originalSystem, originalCloud := resolveSystemHostname, resolveCloudDNSHostnames
+	t.Cleanup(func() {
+		resolveSystemHostname, resolveCloudDNSHostnames = originalSystem, originalCloud
+	})
+
+	resolveSystemHostname = func(_ context.Context, hostname string) ([]string, error) {
+		if hostname == "www.example.com" {
+			return []string{"203.0.113.10"}, nil
+		}
+
+		return nil, errors.New("no such host")
+	}
+	resolveCloudDNSHostnames = func(_ context.Context, _ *gcp.Client, hostnames []string) ([]gcp.HostnameAddress, error) {
+		require.Equal(t, []string{"db.internal.example.com", "www.example.com", "missing.example.com"}, hostnames)
+
+		return []gcp.HostnameAddress{{
+			Hostname: "db.internal.example.com",
+			Address:  "10.0.0.5",
+			Source:   gcp.HostnameSourceCloudDNS,
+			Project:  "proj",
+			Zone:     "internal",
+			Record:   "db.internal.example.com. A",
+		}}, nil
+	}
+
+	hostnames := []string{"db.internal.example.com", "www.example.com", "missing.example.com"}
+	resolutions := resolveLookupHostnames(context.Background(), hostnames, []*gcp.Client{nil})
+	require.Len(t, resolutions, 2)
+	require.Equal(t, "10.0.0.5", resolutions[0].Address)
+	require.Equal(t, gcp.HostnameSourceSystem, resolutions[1].Source)
+
+	require.Equal(t, []string{"missing.example.com"}, unresolvedHostnames(hostnames, resolutions))
+	require.Equal(t, []string{"10.0.0.5", "203.0.113.10"}, resolvedTargets([]string{"10.0.0.5"}, resolutions))
+
+	names, addresses := splitHostnameTargets([]string{"10.0.0.5", "db.internal.example.com", "10.0.0.0/24"})
+	require.Equal(t, []string{"db.internal.example.com"}, names)
+	require.Equal(t, []string{"10.0.0.5", "10.0.0.0/24"}, addresses)
+}
+
+func TestCollectLookupPolicies(t *testing.T) {
+	original := lookupIPPolicy
+	t.Cleanup(func() { lookupIPPolicy = original })
+
+	first, second, failing := &gcp.Client{}, &gcp.Client{}, &gcp.Client{}
+	lookupIPPolicy = func(_ context.Context, client *gcp.Client, targets []string) (map[string]gcp.IPPolicy, error) {
+		require.Equal(t, []string{"10.0.0.5", "10.0.0.9"}, targets)
+
+		switch client {
+		case first:
+			return map[string]gcp.IPPolicy{"10.0.0.5": {
+				FirewallRules: []gcp.FirewallRuleMatch{{Project: "a", Name: "allow-internal", Priority: 1000}},
+				Routes:        []gcp.RouteMatch{{Project: "a", Name: "default", DestRange: "0.0.0.0/0", Priority: 1000}},
+			}}, nil
+		case second:
+			return map[string]gcp.IPPolicy{"10.0.0.5": {
+				FirewallRules: []gcp.FirewallRuleMatch{{Project: "b", Name: "deny-db", Priority: 900, Action: gcp.FirewallActionDeny}},
+				Routes:        []gcp.RouteMatch{{Project: "b", Name: "to-onprem", DestRange: "10.0.0.0/16", Priority: 100}},
+			}}, nil
+		default:
+			return nil, errors.New("permission denied")
+		}
+	}
+
+	policies := collectLookupPolicies(context.Background(), []*gcp.Client{first, failing, second}, []string{"10.0.0.5", "10.0.0.9"})
+	require.Len(t, policies, 2)
+
+	policy := policies["10.0.0.5"]
+	require.Len(t, policy.FirewallRules, 2)
+	require.Equal(t, "deny-db", policy.FirewallRules[0].Name, "rules are merged in evaluation order")
+	require.Len(t, policy.Routes, 2)
+	require.Equal(t, "to-onprem", policy.Routes[0].Name, "routes are merged longest prefix first")
+
+	require.NotNil(t, policies["10.0.0.9"].FirewallRules)
+	require.Empty(t, policies["10.0.0.9"].Routes)
+}
+
+func TestUnmatchedTargets(t *testing.T) {
+	results := map[string][]gcp.IPAssociation{
+		"10.0.0.1": {{Project: "proj", Resource: "vm"}},
+		"10.0.0.2": nil,
+	}
+
+	require.Equal(t, []string{"10.0.0.2", "10.0.0.3"}, unmatchedTargets([]string{"10.0.0.1", "10.0.0.2", "10.0.0.3"}, results))
+}
@@ -0,0 +1,186 @@
+package cmd
+
+import (
+	"context"
+	"net"
+	"strings"
+	"sync"
+
+	"github.com/kedare/compass/internal/gcp"
+	"github.com/kedare/compass/internal/logger"
+	"golang.org/x/sync/errgroup"
+)
+
+// resolveSystemHostname resolves a hostname with the system resolver, overridden in tests.
+var resolveSystemHostname = func(ctx context.Context, hostname string) ([]string, error) {
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, hostname)
+	if err != nil {
+		return nil, err
+	}
+
+	addresses := make([]string, 0, len(addrs))
+	for _, addr := range addrs {
+		addresses = append(addresses, addr.IP.String())
+	}
+
+	return addresses, nil
+}
+
+// resolveCloudDNSHostnames resolves hostnames in the private Cloud DNS zones of a project,
+// overridden in tests.
+var resolveCloudDNSHostnames = func(ctx context.Context, client *gcp.Client, hostnames []string) ([]gcp.HostnameAddress, error) {
+	return client.ResolveHostnames(ctx, hostnames)
+}
+
+// isHostname reports whether the token is a dotted hostname such as db.internal.example.com.
+// Single labels are rejected so that mistyped addresses are not taken for hostnames.
+func isHostname(token string) bool {
+	token = strings.TrimSuffix(token, ".")
+	if len(token) == 0 || len(token) > 253 || !strings.Contains(token, ".") {
+		return false
+	}
+
+	labels := strings.Split(token, ".")
+	for _, label := range labels {
+		if len(label) == 0 || len(label) > 63 || label[0] == '-' || label[len(label)-1] == '-' {
+			return false
+		}
+
+		for _, r := range label {
+			if !(r >= 'a' && r <= 'z') && !(r >= 'A' && r <= 'Z') && !(r >= '0' && r <= '9') && r != '-' && r != '_' {
+				return false
+			}
+		}
+	}
+
+	// A numeric top-level label is a malformed address rather than a hostname
+	return strings.Trim(labels[len(labels)-1], "0123456789") != ""
+}
+
+// splitHostnameTargets separates hostnames from the IP addresses and CIDR prefixes to look up.
+func splitHostnameTargets(targets []string) ([]string, []string) {
+	var hostnames, addresses []string
+	for _, target := range targets {
+		if _, ok := gcp.ParseIPPrefix(target); !ok && net.ParseIP(target) == nil {
+			hostnames = append(hostnames, target)
+
+			continue
+		}
+		addresses = append(addresses, target)
+	}
+
+	return hostnames, addresses
+}
+
+// resolveLookupHostnames resolves each hostname with the system resolver and in the private
+// Cloud DNS zones of the clients. Addresses are returned per hostname, system resolver first,
+// then Cloud DNS in the order of the clients.
+func resolveLookupHostnames(ctx context.Context, hostnames []string, clients []*gcp.Client) []gcp.HostnameAddress {
+	system := make([][]gcp.HostnameAddress, len(hostnames))
+	cloud := make([][]gcp.HostnameAddress, len(clients))
+
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(max(concurrency, 1))
+
+	var mu sync.Mutex
+	for i, hostname := range hostnames {
+		group.Go(func() error {
+			addresses, err := resolveSystemHostname(groupCtx, hostname)
+			if err != nil {
+				logger.Log.Debugf("System resolver could not resolve %s: %v", hostname, err)
+
+				return nil
+			}
+
+			resolved := make([]gcp.HostnameAddress, 0, len(addresses))
+			for _, address := range addresses {
+				resolved = append(resolved, gcp.HostnameAddress{
+					Hostname: hostname,
+					Address:  address,
+					Source:   gcp.HostnameSourceSystem,
+				})
+			}
+
+			mu.Lock()
+			system[i] = resolved
+			mu.Unlock()
+
+			return nil
+		})
+	}
+
+	for i, client := range clients {
+		group.Go(func() error {
+			resolved, err := resolveCloudDNSHostnames(groupCtx, client, hostnames)
+			if err != nil {
+				if isContextError(err) {
+					return err
+				}
+				logger.Log.Warnf("Skipping Cloud DNS of project %s: %v", client.ProjectID(), err)
+
+				return nil
+			}
+
+			mu.Lock()
+			cloud[i] = resolved
+			mu.Unlock()
+
+			return nil
+		})
+	}
+
+	if err := group.Wait(); err != nil {
+		logger.Log.Debugf("Hostname resolution interrupted: %v", err)
+	}
+
+	results := make([]gcp.HostnameAddress, 0)
+	for i, hostname := range hostnames {
+		results = append(results, system[i]...)
+		for _, resolved := range cloud {
+			for _, address := range resolved {
+				if address.Hostname == hostname {
+					results = append(results, address)
+				}
+			}
+		}
+	}
+
+	return results
+}
+
+// resolvedTargets appends the addresses of the resolutions to the targets, skipping duplicates.
+func resolvedTargets(targets []string, resolutions []gcp.HostnameAddress) []string {
+	seen := make(map[string]struct{}, len(targets)+len(resolutions))
+	merged := make([]string, 0, len(targets)+len(resolutions))
+	for _, target := range targets {
+		seen[target] = struct{}{}
+		merged = append(merged, target)
+	}
+
+	for _, resolution := range resolutions {
+		if _, ok := seen[resolution.Address]; ok {
+			continue
+		}
+		seen[resolution.Address] = struct{}{}
+		merged = append(merged, resolution.Address)
+	}
+
+	return merged
+}
+
+// unresolvedHostnames returns the hostnames no resolver returned an address for.
+func unresolvedHostnames(hostnames []string, resolutions []gcp.HostnameAddress) []string {
+	resolved := make(map[string]struct{}, len(resolutions))
+	for _, resolution := range resolutions {
+		resolved[resolution.Hostname] = struct{}{}
+	}
+
+	missing := make([]string, 0)
+	for _, hostname := range hostnames {
+		if _, ok := resolved[hostname]; !ok {
+			missing = append(missing, hostname)
+		}
+	}
+
+	return missing
+}
@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"context"
+	"sync"
+
+	"github.com/kedare/compass/internal/gcp"
+	"github.com/kedare/compass/internal/logger"
+	"golang.org/x/sync/errgroup"
+)
+
+// lookupIPPolicy lists the firewall rules and routes of a project referencing the targets,
+// overridden in tests.
+var lookupIPPolicy = func(ctx context.Context, client *gcp.Client, targets []string) (map[string]gcp.IPPolicy, error) {
+	return client.LookupIPPolicy(ctx, targets)
+}
+
+// collectLookupPolicies gathers the firewall rules and routes referencing each target across the
+// projects of the clients. Projects whose rules or routes cannot be listed are skipped with a warning.
+func collectLookupPolicies(ctx context.Context, clients []*gcp.Client, targets []string) map[string]gcp.IPPolicy {
+	perClient := make([]map[string]gcp.IPPolicy, len(clients))
+
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(max(concurrency, 1))
+
+	var mu sync.Mutex
+	for i, client := range clients {
+		group.Go(func() error {
+			policies, err := lookupIPPolicy(groupCtx, client, targets)
+			if err != nil {
+				if isContextError(err) {
+					return err
+				}
+				logger.Log.Warnf("Skipping firewall rules and routes of project %s: %v", client.ProjectID(), err)
+
+				return nil
+			}
+
+			mu.Lock()
+			perClient[i] = policies
+			mu.Unlock()
+
+			return nil
+		})
+	}
+
+	if err := group.Wait(); err != nil {
+		logger.Log.Debugf("Firewall rule and route lookup interrupted: %v", err)
+	}
+
+	merged := make(map[string]gcp.IPPolicy, len(targets))
+	for _, target := range targets {
+		policy := gcp.IPPolicy{
+			FirewallRules: []gcp.FirewallRuleMatch{},
+			Routes:        []gcp.RouteMatch{},
+		}
+		for _, policies := range perClient {
+			policy.FirewallRules = append(policy.FirewallRules, policies[target].FirewallRules...)
+			policy.Routes = append(policy.Routes, policies[target].Routes...)
+		}
+		gcp.SortIPPolicy(&policy)
+		merged[target] = policy
+	}
+
+	return merged
+}
@@ -0,0 +1,158 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/kedare/compass/internal/gcp"
+	"github.com/kedare/compass/internal/logger"
+	"github.com/kedare/compass/internal/output"
+	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
+)
+
+var (
+	ipOverlapsOutputFormat string
+	ipOverlapsCandidate    string
+	ipOverlapsBGP          bool
+)
+
+var ipOverlapsCmd = &cobra.Command{
+	Use:   "overlaps",
+	Short: "Detect overlapping subnet ranges and BGP-learned prefixes",
+	Long: `Report every pair of overlapping ranges among the cached subnets of all projects (primary,
+secondary and IPv6 ranges) and the prefixes learned by Cloud VPN BGP sessions, grouped by
+network and project.
+
+Use --candidate to check a range before allocating it: the ranges it would conflict with are
+listed and the command exits with status 1 when there is any.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, _ []string) {
+		runIPOverlaps(cmd.Context())
+	},
+}
+
+func init() {
+	ipCmd.AddCommand(ipOverlapsCmd)
+
+	ipOverlapsCmd.Flags().StringVarP(&ipOverlapsOutputFormat, "output", "o",
+		output.DefaultFormat("text", []string{"table", "text", "json"}),
+		"Output format: table, text, json")
+	ipOverlapsCmd.Flags().StringVar(&ipOverlapsCandidate, "candidate", "",
+		"Check a proposed CIDR (e.g. 10.40.0.0/20) for conflicts instead of listing all overlaps")
+	ipOverlapsCmd.Flags().BoolVar(&ipOverlapsBGP, "bgp", true,
+		"Include prefixes learned by Cloud VPN BGP sessions (queries every cached project)")
+}
+
+// runIPOverlaps collects the known prefixes and reports overlaps or candidate conflicts.
+func runIPOverlaps(ctx context.Context) {
+	output.SetFormat(ipOverlapsOutputFormat)
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	candidate := strings.TrimSpace(ipOverlapsCandidate)
+	if candidate != "" {
+		prefix, ok := gcp.ParseIPPrefix(candidate)
+		if !ok {
+			logger.Log.Fatalf("Invalid candidate CIDR: %s", ipOverlapsCandidate)
+		}
+		candidate = prefix.String()
+	}
+
+	cacheInst, err := loadCacheFunc()
+	if err != nil {
+		logger.Log.Debugf("Failed to load cache: %v", err)
+	}
+
+	var prefixes []gcp.NetworkPrefix
+	if cacheInst != nil {
+		prefixes = gcp.SubnetPrefixes(cacheInst.ListSubnets())
+	}
+
+	if len(prefixes) == 0 {
+		logger.Log.Warnf("No cached subnet. Run `compass gcp projects import` to cache the subnets of your projects.")
+	}
+
+	if ipOverlapsBGP {
+		prefixes = append(prefixes, collectLearnedPrefixes(ctx)...)
+	}
+
+	if candidate != "" {
+		prefix, _ := gcp.ParseIPPrefix(candidate)
+		conflicts := gcp.FindConflicts(prefix, prefixes)
+
+		if err := output.DisplayPrefixConflicts(candidate, conflicts, ipOverlapsOutputFormat); err != nil {
+			logger.Log.Fatalf("Failed to render conflicts: %v", err)
+		}
+
+		if len(conflicts) > 0 {
+			os.Exit(1)
+		}
+
+		return
+	}
+
+	if err := output.DisplayPrefixOverlaps(gcp.FindOverlaps(prefixes), ipOverlapsOutputFormat); err != nil {
+		logger.Log.Fatalf("Failed to render overlaps: %v", err)
+	}
+}
+
+// collectLearnedPrefixes gathers the BGP-learned prefixes of every selected or cached project.
+// Projects that cannot be queried are skipped with a warning.
+func collectLearnedPrefixes(ctx context.Context) []gcp.NetworkPrefix {
+	clients := lookupClients(ctx, nil)
+	if len(clients) == 0 {
+		return nil
+	}
+
+	spin := output.NewSpinner("Collecting BGP-learned prefixes")
+	spin.Start()
+
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(max(concurrency, 1))
+
+	var (
+		mu        sync.Mutex
+		prefixes  []gcp.NetworkPrefix
+		processed int
+	)
+
+	for _, client := range clients {
+		group.Go(func() error {
+			learned, err := client.LearnedPrefixes(groupCtx, nil)
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			processed++
+			spin.Update(fmt.Sprintf("Collecting BGP-learned prefixes (%d/%d projects)", processed, len(clients)))
+
+			if err != nil {
+				if isContextError(err) {
+					return err
+				}
+				logger.Log.Warnf("Skipping BGP prefixes of project %s: %v", client.ProjectID(), err)
+
+				return nil
+			}
+
+			prefixes = append(prefixes, learned...)
+
+			return nil
+		})
+	}
+
+	if err := group.Wait(); err != nil {
+		spin.Fail("Canceled")
+		logger.Log.Fatalf("Collection of BGP-learned prefixes canceled: %v", err)
+	}
+
+	spin.Success(fmt.Sprintf("Collected %d BGP-learned prefixes", len(prefixes)))
+
+	return prefixes
+}
@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	"github.com/kedare/compass/internal/ipranges"
+	"github.com/kedare/compass/internal/logger"
+	"github.com/kedare/compass/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var ipRangesOutputFormat string
+
+var ipRangesCmd = &cobra.Command{
+	Use:   "ranges",
+	Short: "Show the Google IP range lists used to classify addresses",
+	Long: `Show the Google IP range lists (goog.json and cloud.json, published at
+https://www.gstatic.com/ipranges/) used by "ip lookup" to classify addresses no resource
+references. A copy is embedded in compass; use "update" to download the current lists.`,
+	Args: cobra.NoArgs,
+	Run: func(_ *cobra.Command, _ []string) {
+		output.SetFormat(ipRangesOutputFormat)
+
+		if err := output.DisplayIPRanges(ipranges.Default(), ipRangesOutputFormat); err != nil {
+			logger.Log.Fatalf("Failed to render IP ranges: %v", err)
+		}
+	},
+}
+
+var ipRangesUpdateCmd = &cobra.Command{
+	Use:   "update",
+	Short: "Download the current Google IP range lists",
+	Long: `Download goog.json and cloud.json from https://www.gstatic.com/ipranges/ into
+~/` + ipranges.Dir + `. The downloaded lists take precedence over the embedded copy.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, _ []string) {
+		runIPRangesUpdate(cmd.Context())
+	},
+}
+
+func init() {
+	ipCmd.AddCommand(ipRangesCmd)
+	ipRangesCmd.AddCommand(ipRangesUpdateCmd)
+
+	ipRangesCmd.PersistentFlags().StringVarP(&ipRangesOutputFormat, "output", "o",
+		output.DefaultFormat("text", []string{"table", "text", "json"}),
+		"Output format: table, text, json")
+}
+
+// runIPRangesUpdate downloads the Google IP range lists into the compass cache directory.
+func runIPRangesUpdate(ctx context.Context) {
+	output.SetFormat(ipRangesOutputFormat)
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		logger.Log.Fatalf("Failed to locate home directory: %v", err)
+	}
+
+	spin := output.NewSpinner("Downloading Google IP ranges")
+	spin.Start()
+
+	set, err := ipranges.Update(ctx, nil, filepath.Join(home, ipranges.Dir))
+	if err != nil {
+		spin.Fail("Failed to download Google IP ranges")
+		logger.Log.Fatalf("Failed to update Google IP ranges: %v", err)
+	}
+
+	spin.Success("Google IP ranges updated")
+
+	if err := output.DisplayIPRanges(set, ipRangesOutputFormat); err != nil {
+		logger.Log.Fatalf("Failed to render IP ranges: %v", err)
+	}
+}
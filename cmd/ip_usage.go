@@ -0,0 +1,189 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/kedare/compass/internal/cache"
+	"github.com/kedare/compass/internal/gcp"
+	"github.com/kedare/compass/internal/logger"
+	"github.com/kedare/compass/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var (
+	ipUsageOutputFormat string
+	ipUsageRegion       string
+)
+
+var ipUsageCmd = &cobra.Command{
+	Use:   "usage <subnet|cidr>",
+	Short: "Report used and free addresses of subnet ranges",
+	Long: `Report how many addresses of a subnet's primary and secondary ranges (including GKE pod
+and service ranges) are reserved by Google Cloud, allocated to instances, alias IP ranges,
+forwarding rules and reserved addresses, or still free, along with the largest free blocks
+and the next free address. IPv6 ranges of dual-stack subnets are counted in /96 blocks.
+
+The argument is a subnet name, looked up in the cache and then in the selected projects, or a
+CIDR prefix, in which case the cached subnet ranges overlapping it are reported. Allocations are
+read from the subnet's project, so addresses used by Shared VPC service projects are not counted.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		runIPUsage(cmd.Context(), args[0])
+	},
+}
+
+func init() {
+	ipCmd.AddCommand(ipUsageCmd)
+
+	ipUsageCmd.Flags().StringVarP(&ipUsageOutputFormat, "output", "o",
+		output.DefaultFormat("text", []string{"table", "text", "json"}),
+		"Output format: table, text, json")
+	ipUsageCmd.Flags().StringVar(&ipUsageRegion, "region", "", "Only report subnets in this region")
+}
+
+// runIPUsage resolves the subnets matching target and reports the usage of their ranges.
+func runIPUsage(ctx context.Context, target string) {
+	output.SetFormat(ipUsageOutputFormat)
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	target = strings.TrimSpace(target)
+	if target == "" {
+		logger.Log.Fatalf("subnet name or CIDR must not be empty")
+	}
+
+	prefix, isPrefix := gcp.ParseIPPrefix(target)
+	if !isPrefix && strings.Contains(target, "/") {
+		logger.Log.Fatalf("Invalid CIDR prefix: %s", target)
+	}
+
+	cacheInst, err := loadCacheFunc()
+	if err != nil {
+		logger.Log.Debugf("Failed to load cache: %v", err)
+	}
+
+	entries := cachedUsageSubnets(cacheInst, target, prefix, project, ipUsageRegion)
+	if len(entries) == 0 && !isPrefix {
+		entries = discoverUsageSubnets(ctx, target, ipUsageRegion)
+	}
+
+	if len(entries) == 0 {
+		if isPrefix {
+			logger.Log.Fatalf("No cached subnet overlaps %s. Run `compass gcp ip lookup %s` to cache the subnets of your projects first.", target, target)
+		}
+		logger.Log.Fatalf("Subnet %s not found", target)
+	}
+
+	spin := output.NewSpinner("Computing IP usage")
+	spin.Start()
+
+	clients := make(map[string]*gcp.Client)
+	usages := make([]*gcp.SubnetUsage, 0, len(entries))
+	for _, entry := range entries {
+		client, ok := clients[entry.Project]
+		if !ok {
+			client, err = gcp.NewClient(ctx, entry.Project)
+			if err != nil {
+				logger.Log.Warnf("Failed to initialize GCP client for project %s: %v", entry.Project, err)
+			}
+			clients[entry.Project] = client
+		}
+		if client == nil {
+			continue
+		}
+
+		spin.Update(fmt.Sprintf("Collecting allocations of %s in project %s", entry.Name, entry.Project))
+
+		usage, err := client.SubnetUsage(ctx, entry, prefix)
+		if err != nil {
+			if isContextError(err) {
+				spin.Fail("Usage report canceled")
+
+				return
+			}
+			logger.Log.Warnf("Skipping subnet %s in project %s: %v", entry.Name, entry.Project, err)
+
+			continue
+		}
+
+		usages = append(usages, usage)
+	}
+
+	if len(usages) == 0 {
+		spin.Fail("Usage report failed")
+		logger.Log.Fatalf("Failed to compute the usage of any subnet")
+	}
+
+	spin.Success("Usage report complete")
+
+	if err := output.DisplaySubnetUsage(usages, ipUsageOutputFormat); err != nil {
+		logger.Log.Fatalf("Failed to render usage report: %v", err)
+	}
+}
+
+// cachedUsageSubnets returns the cached subnets named target, or overlapping prefix when set,
+// restricted to the project and region when given and sorted by project, region and name.
+func cachedUsageSubnets(c *cache.Cache, target string, prefix *net.IPNet, projectID, region string) []*cache.SubnetEntry {
+	if c == nil {
+		return nil
+	}
+
+	var entries []*cache.SubnetEntry
+	if prefix != nil {
+		entries = c.FindSubnetsOverlapping(prefix)
+	} else {
+		entries = c.FindSubnetsByName(target)
+	}
+
+	return filterUsageSubnets(entries, projectID, region)
+}
+
+// discoverUsageSubnets looks up subnets by name in the selected or cached projects.
+func discoverUsageSubnets(ctx context.Context, name, region string) []*cache.SubnetEntry {
+	var entries []*cache.SubnetEntry
+	for _, client := range lookupClients(ctx, nil) {
+		found, err := client.FindSubnets(ctx, name)
+		if err != nil {
+			logger.Log.Warnf("Skipping project %s: %v", client.ProjectID(), err)
+
+			continue
+		}
+		entries = append(entries, found...)
+	}
+
+	return filterUsageSubnets(entries, "", region)
+}
+
+func filterUsageSubnets(entries []*cache.SubnetEntry, projectID, region string) []*cache.SubnetEntry {
+	filtered := make([]*cache.SubnetEntry, 0, len(entries))
+	for _, entry := range entries {
+		if entry == nil {
+			continue
+		}
+		if projectID != "" && !strings.EqualFold(entry.Project, projectID) {
+			continue
+		}
+		if region != "" && !strings.EqualFold(entry.Region, region) {
+			continue
+		}
+		filtered = append(filtered, entry)
+	}
+
+	sort.Slice(filtered, func(i, j int) bool {
+		if filtered[i].Project != filtered[j].Project {
+			return filtered[i].Project < filtered[j].Project
+		}
+		if filtered[i].Region != filtered[j].Region {
+			return filtered[i].Region < filtered[j].Region
+		}
+		return filtered[i].Name < filtered[j].Name
+	})
+
+	return filtered
+}
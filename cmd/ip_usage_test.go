@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"net"
+	"testing"
+
+	"github.com/kedare/compass/internal/cache"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCachedUsageSubnets(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	cache.SetEnabled(true)
+
+	c, err := cache.New()
+	require.NoError(t, err)
+
+	for _, entry := range []*cache.SubnetEntry{
+		{Project: "proj-b", Network: "vpc", Region: "us-central1", Name: "default", PrimaryCIDR: "10.128.0.0/20"},
+		{Project: "proj-a", Network: "vpc", Region: "europe-west1", Name: "default", PrimaryCIDR: "10.132.0.0/20"},
+		{Project: "proj-a", Network: "vpc", Region: "us-central1", Name: "gke", PrimaryCIDR: "10.0.0.0/24",
+			SecondaryRanges: []cache.SubnetSecondaryRange{{Name: "pods", CIDR: "10.4.0.0/14"}}},
+	} {
+		require.NoError(t, c.RememberSubnet(entry))
+	}
+
+	names := func(entries []*cache.SubnetEntry) []string {
+		result := make([]string, 0, len(entries))
+		for _, entry := range entries {
+			result = append(result, entry.Project+"/"+entry.Region+"/"+entry.Name)
+		}
+		return result
+	}
+
+	require.Equal(t, []string{"proj-a/europe-west1/default", "proj-b/us-central1/default"},
+		names(cachedUsageSubnets(c, "default", nil, "", "")))
+	require.Equal(t, []string{"proj-b/us-central1/default"},
+		names(cachedUsageSubnets(c, "default", nil, "", "US-CENTRAL1")))
+	require.Equal(t, []string{"proj-a/europe-west1/default"},
+		names(cachedUsageSubnets(c, "default", nil, "proj-a", "")))
+
+	_, prefix, err := net.ParseCIDR("10.5.0.0/16")
+	require.NoError(t, err)
+	require.Equal(t, []string{"proj-a/us-central1/gke"}, names(cachedUsageSubnets(c, prefix.String(), prefix, "", "")))
+
+	require.Nil(t, cachedUsageSubnets(nil, "default", nil, "", ""))
+}
@@ -7,15 +7,17 @@ import (
 	"os"
 
 	"github.com/kedare/compass/internal/cache"
+	"github.com/kedare/compass/internal/gcp"
 	"github.com/kedare/compass/internal/logger"
 	"github.com/spf13/cobra"
 )
 
 var (
-	logLevel    string
-	logFile     string
-	useCache    bool
-	concurrency int
+	logLevel     string
+	logFile      string
+	useCache     bool
+	concurrency  int
+	apiRateLimit float64
 )
 
 var rootCmd = &cobra.Command{
@@ -51,6 +53,8 @@ diagnostics without leaving the CLI.`,
 			os.Exit(1)
 		}
 		logger.Log.Debugf("Concurrency set to: %d", concurrency)
+
+		gcp.SetAPIRateLimit(apiRateLimit)
 	},
 }
 
@@ -71,5 +75,6 @@ func init() {
 	rootCmd.PersistentFlags().StringVar(&logFile, "log-file", "", "Write logs to a file (in addition to stderr)")
 	rootCmd.PersistentFlags().BoolVar(&useCache, "cache", true, "Enable cache usage across commands")
 	rootCmd.PersistentFlags().IntVar(&concurrency, "concurrency", 10, "Maximum number of concurrent operations (worker pool size)")
+	rootCmd.PersistentFlags().Float64Var(&apiRateLimit, "api-rate-limit", gcp.DefaultAPIRateLimit, "Maximum number of requests per second sent to each Google Cloud API (0 disables the limit)")
 	rootCmd.AddCommand(gcpCmd)
 }
@@ -156,22 +156,8 @@ func (c *Cache) GetProjectsForSearch(searchTerm string, resourceTypes []string)
 		projectSet[p] = true
 	}
 
-	// Get affinity scores for this search term
-	scores := c.getAffinityScores(searchTerm, resourceTypes)
-
-	// Also check prefix matches
-	prefix := extractSearchPrefix(searchTerm)
-	if prefix != "" && prefix != searchTerm {
-		prefixScores := c.getAffinityScores(prefix, nil)
-		for project, score := range prefixScores {
-			// Prefix matches get 50% weight compared to exact matches
-			if existing, ok := scores[project]; ok {
-				scores[project] = existing + score*0.5
-			} else {
-				scores[project] = score * 0.5
-			}
-		}
-	}
+	// Get affinity scores for this search term, including prefix matches
+	scores := c.searchAffinityScores(searchTerm, resourceTypes)
 
 	// If no affinity data, return by usage
 	if len(scores) == 0 {
@@ -213,6 +199,39 @@ func (c *Cache) GetProjectsForSearch(searchTerm string, resourceTypes []string)
 	return result
 }
 
+// SearchAffinityScores returns the learned affinity score of each project for the given
+// search term (and its prefix), higher meaning more likely to contain results.
+// Projects without affinity data are omitted.
+func (c *Cache) SearchAffinityScores(searchTerm string, resourceTypes []string) map[string]float64 {
+	if c.isNoOp() {
+		return nil
+	}
+
+	searchTerm = normalizeSearchTerm(searchTerm)
+	if searchTerm == "" {
+		return nil
+	}
+
+	return c.searchAffinityScores(searchTerm, resourceTypes)
+}
+
+// searchAffinityScores combines the scores of a normalized search term with half the
+// weight of its prefix scores.
+func (c *Cache) searchAffinityScores(searchTerm string, resourceTypes []string) map[string]float64 {
+	scores := c.getAffinityScores(searchTerm, resourceTypes)
+
+	prefix := extractSearchPrefix(searchTerm)
+	if prefix != "" && prefix != searchTerm {
+		prefixScores := c.getAffinityScores(prefix, nil)
+		for project, score := range prefixScores {
+			// Prefix matches get 50% weight compared to exact matches
+			scores[project] += score * 0.5
+		}
+	}
+
+	return scores
+}
+
 // getAffinityScores retrieves and scores affinity entries for a search term.
 func (c *Cache) getAffinityScores(searchTerm string, resourceTypes []string) map[string]float64 {
 	scores := make(map[string]float64)
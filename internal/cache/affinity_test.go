@@ -114,6 +114,26 @@ func TestGetProjectsForSearchWithAffinity(t *testing.T) {
 	}
 }
 
+func TestSearchAffinityScores(t *testing.T) {
+	c := newTestCache(t)
+
+	if err := c.RecordSearchAffinity("web-server", map[string]int{"project-a": 5}, ""); err != nil {
+		t.Fatalf("RecordSearchAffinity failed: %v", err)
+	}
+
+	scores := c.SearchAffinityScores("Web-Server", nil)
+	if scores["project-a"] <= 0 {
+		t.Fatalf("expected positive score for project-a, got %v", scores)
+	}
+	if _, ok := scores["project-b"]; ok {
+		t.Errorf("expected no score for project-b, got %v", scores)
+	}
+
+	if scores := c.SearchAffinityScores("  ", nil); scores != nil {
+		t.Errorf("expected nil scores for empty term, got %v", scores)
+	}
+}
+
 func TestGetProjectsForSearchFallsBackToUsage(t *testing.T) {
 	c := newTestCache(t)
 
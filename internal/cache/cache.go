@@ -3,7 +3,6 @@ package cache
 
 import (
 	"database/sql"
-	"encoding/binary"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -69,16 +68,17 @@ type SubnetSecondaryRange struct {
 
 // SubnetEntry stores metadata about a VPC subnet for quick IP matching.
 type SubnetEntry struct {
-	Timestamp       time.Time              `json:"timestamp"`
-	Project         string                 `json:"project"`
-	Network         string                 `json:"network"`
-	Region          string                 `json:"region"`
-	Name            string                 `json:"name"`
-	SelfLink        string                 `json:"self_link,omitempty"`
-	PrimaryCIDR     string                 `json:"primary_cidr,omitempty"`
-	SecondaryRanges []SubnetSecondaryRange `json:"secondary_ranges,omitempty"`
-	IPv6CIDR        string                 `json:"ipv6_cidr,omitempty"`
-	Gateway         string                 `json:"gateway,omitempty"`
+	Timestamp        time.Time              `json:"timestamp"`
+	Project          string                 `json:"project"`
+	Network          string                 `json:"network"`
+	Region           string                 `json:"region"`
+	Name             string                 `json:"name"`
+	SelfLink         string                 `json:"self_link,omitempty"`
+	PrimaryCIDR      string                 `json:"primary_cidr,omitempty"`
+	SecondaryRanges  []SubnetSecondaryRange `json:"secondary_ranges,omitempty"`
+	IPv6CIDR         string                 `json:"ipv6_cidr,omitempty"`
+	ExternalIPv6CIDR string                 `json:"external_ipv6_cidr,omitempty"`
+	Gateway          string                 `json:"gateway,omitempty"`
 }
 
 // clone returns a deep copy of the subnet entry to avoid sharing mutable slices.
@@ -96,23 +96,15 @@ func (s *SubnetEntry) clone() *SubnetEntry {
 	return &clone
 }
 
-// containsIP reports whether the provided IP falls within any of the subnet's CIDRs.
-func (s *SubnetEntry) containsIP(ip net.IP) bool {
-	if s == nil || ip == nil {
-		return false
-	}
-
-	if cidrContainsIP(s.PrimaryCIDR, ip) {
-		return true
-	}
-
+// rangeCIDRs returns every range of the subnet: primary, secondary, internal and external IPv6.
+func (s *SubnetEntry) rangeCIDRs() []string {
+	cidrs := make([]string, 0, 3+len(s.SecondaryRanges))
+	cidrs = append(cidrs, s.PrimaryCIDR)
 	for _, sr := range s.SecondaryRanges {
-		if cidrContainsIP(sr.CIDR, ip) {
-			return true
-		}
+		cidrs = append(cidrs, sr.CIDR)
 	}
 
-	return cidrContainsIP(s.IPv6CIDR, ip)
+	return append(cidrs, s.IPv6CIDR, s.ExternalIPv6CIDR)
 }
 
 // ZoneListing holds cached zones for a project.
@@ -416,7 +408,8 @@ func (c *Cache) prepareStatements() error {
 	}
 
 	c.stmts.setProject, err = c.db.Prepare(
-		`INSERT OR REPLACE INTO projects (name, timestamp, last_used) VALUES (?, ?, ?)`)
+		`INSERT INTO projects (name, timestamp, last_used) VALUES (?, ?, ?)
+		ON CONFLICT(name) DO UPDATE SET timestamp = excluded.timestamp, last_used = excluded.last_used`)
 	if err != nil {
 		return fmt.Errorf("failed to prepare setProject: %w", err)
 	}
@@ -440,11 +433,10 @@ func (c *Cache) prepareStatements() error {
 	}
 
 	c.stmts.getSubnetsByIP, err = c.db.Prepare(
-		`SELECT id, timestamp, project, network, region, name, self_link, primary_cidr,
-		        secondary_ranges_json, ipv6_cidr, gateway, cidr_start_int, cidr_end_int
-		 FROM subnets
-		 WHERE timestamp > ? AND cidr_start_int IS NOT NULL
-		   AND cidr_start_int <= ? AND cidr_end_int >= ?`)
+		`SELECT DISTINCT ` + subnetColumnsFrom("s") + `
+		 FROM subnet_ranges r
+		 JOIN subnets s ON s.project = r.project AND s.network = r.network AND s.name = r.name
+		 WHERE s.timestamp > ? AND r.range_start <= ? AND r.range_end >= ?`)
 	if err != nil {
 		return fmt.Errorf("failed to prepare getSubnetsByIP: %w", err)
 	}
@@ -452,8 +444,8 @@ func (c *Cache) prepareStatements() error {
 	c.stmts.setSubnet, err = c.db.Prepare(
 		`INSERT OR REPLACE INTO subnets
 		 (timestamp, project, network, region, name, self_link, primary_cidr,
-		  secondary_ranges_json, ipv6_cidr, gateway, cidr_start_int, cidr_end_int, last_used)
-		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+		  secondary_ranges_json, ipv6_cidr, external_ipv6_cidr, gateway, last_used)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
 	if err != nil {
 		return fmt.Errorf("failed to prepare setSubnet: %w", err)
 	}
@@ -1291,6 +1283,10 @@ func (c *Cache) ClearProjectSubnets(projectName string) error {
 		return fmt.Errorf("failed to clear subnets for project %s: %w", projectName, err)
 	}
 
+	if _, err := c.exec("DELETE FROM subnet_ranges WHERE project = ?", projectName); err != nil {
+		return fmt.Errorf("failed to clear subnet ranges for project %s: %w", projectName, err)
+	}
+
 	count, _ := result.RowsAffected()
 	if count > 0 {
 		logger.Log.Debugf("Cleared %d subnets from cache for project %s", count, projectName)
@@ -1329,7 +1325,7 @@ func (c *Cache) ClearProjectZones(projectName string) error {
 }
 
 // DeleteProject removes a project and all its associated resources from the cache.
-// This includes entries from the projects, instances, zones, and subnets tables.
+// This includes entries from the projects, instances, zones, subnets and disabled services tables.
 func (c *Cache) DeleteProject(projectName string) error {
 	if c.isNoOp() {
 		return nil
@@ -1362,6 +1358,9 @@ func (c *Cache) DeleteProject(projectName string) error {
 		{"instances", "project"},
 		{"zones", "project"},
 		{"subnets", "project"},
+		{"subnet_ranges", "project"},
+		{"disabled_services", "project"},
+		{"ip_history", "project"},
 		{"projects", "name"},
 	}
 
@@ -1414,7 +1413,7 @@ func (c *Cache) Clear() error {
 		c.stats.recordOperation("Clear", time.Since(start))
 	}()
 
-	tables := []string{"instances", "zones", "projects", "subnets"}
+	tables := []string{"instances", "zones", "projects", "subnets", "subnet_ranges", "disabled_services", "ip_history"}
 
 	for _, table := range tables {
 		if _, err := c.exec(fmt.Sprintf("DELETE FROM %s", table)); err != nil {
@@ -1435,19 +1434,21 @@ func (c *Cache) cleanExpired() {
 
 	tables := []struct {
 		name    string
+		column  string
 		ttlType TTLType
 	}{
-		{"instances", TTLTypeInstances},
-		{"zones", TTLTypeZones},
-		{"projects", TTLTypeProjects},
-		{"subnets", TTLTypeSubnets},
+		{"instances", "timestamp", TTLTypeInstances},
+		{"zones", "timestamp", TTLTypeZones},
+		{"projects", "timestamp", TTLTypeProjects},
+		{"subnets", "timestamp", TTLTypeSubnets},
+		{"ip_history", "last_seen", TTLTypeIPHistory},
 	}
 
 	for _, table := range tables {
 		ttl := c.getEffectiveTTL(table.ttlType)
 		expiryTime := time.Now().Add(-ttl).Unix()
 
-		stmt := fmt.Sprintf("DELETE FROM %s WHERE timestamp <= ?", table.name)
+		stmt := fmt.Sprintf("DELETE FROM %s WHERE %s <= ?", table.name, table.column)
 
 		result, err := c.exec(stmt, expiryTime)
 		if err != nil {
@@ -1461,6 +1462,14 @@ func (c *Cache) cleanExpired() {
 			logger.Log.Debugf("Cleaned %d expired entries from %s", count, table.name)
 		}
 	}
+
+	// Drop the indexed ranges of subnets that expired
+	_, err := c.exec(`DELETE FROM subnet_ranges WHERE NOT EXISTS (
+		SELECT 1 FROM subnets s
+		WHERE s.project = subnet_ranges.project AND s.network = subnet_ranges.network AND s.name = subnet_ranges.name)`)
+	if err != nil {
+		logger.Log.Warnf("Failed to clean orphaned subnet ranges: %v", err)
+	}
 }
 
 // RememberSubnet records subnet metadata in the cache for faster future lookups.
@@ -1493,14 +1502,12 @@ func (c *Cache) RememberSubnet(info *SubnetEntry) error {
 		return fmt.Errorf("failed to marshal secondary ranges: %w", err)
 	}
 
-	// Calculate CIDR bounds for faster lookups
-	cidrStart, cidrEnd := cidrToIntRange(strings.TrimSpace(info.PrimaryCIDR))
 	now := time.Now().Unix()
 
 	logSQL("setSubnet", now, project, network, region, name,
 		strings.TrimSpace(info.SelfLink), strings.TrimSpace(info.PrimaryCIDR),
-		string(secondaryJSON), strings.TrimSpace(info.IPv6CIDR), strings.TrimSpace(info.Gateway),
-		cidrStart, cidrEnd, now)
+		string(secondaryJSON), strings.TrimSpace(info.IPv6CIDR), strings.TrimSpace(info.ExternalIPv6CIDR),
+		strings.TrimSpace(info.Gateway), now)
 
 	_, err = c.stmts.setSubnet.Exec(
 		now, project, network, region, name,
@@ -1508,13 +1515,18 @@ func (c *Cache) RememberSubnet(info *SubnetEntry) error {
 		strings.TrimSpace(info.PrimaryCIDR),
 		string(secondaryJSON),
 		strings.TrimSpace(info.IPv6CIDR),
+		strings.TrimSpace(info.ExternalIPv6CIDR),
 		strings.TrimSpace(info.Gateway),
-		cidrStart, cidrEnd, now,
+		now,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to cache subnet %s: %w", name, err)
 	}
 
+	if err := indexSubnetRanges(c.db, project, network, name, info.rangeCIDRs()); err != nil {
+		return fmt.Errorf("failed to index ranges of subnet %s: %w", name, err)
+	}
+
 	// Also remember the project
 	_ = c.addProjectInternal(project)
 
@@ -1572,16 +1584,17 @@ func (c *Cache) RememberSubnetBatch(entries []*SubnetEntry) error {
 			continue
 		}
 
-		cidrStart, cidrEnd := cidrToIntRange(strings.TrimSpace(info.PrimaryCIDR))
+		network := strings.TrimSpace(info.Network)
 
 		_, err = stmt.Exec(
-			now.Unix(), project, strings.TrimSpace(info.Network), strings.TrimSpace(info.Region), name,
+			now.Unix(), project, network, strings.TrimSpace(info.Region), name,
 			strings.TrimSpace(info.SelfLink),
 			strings.TrimSpace(info.PrimaryCIDR),
 			string(secondaryJSON),
 			strings.TrimSpace(info.IPv6CIDR),
+			strings.TrimSpace(info.ExternalIPv6CIDR),
 			strings.TrimSpace(info.Gateway),
-			cidrStart, cidrEnd, now.Unix(),
+			now.Unix(),
 		)
 		if err != nil {
 			logger.Log.Warnf("Failed to cache subnet %s: %v", name, err)
@@ -1589,6 +1602,12 @@ func (c *Cache) RememberSubnetBatch(entries []*SubnetEntry) error {
 			continue
 		}
 
+		if err = indexSubnetRanges(tx, project, network, name, info.rangeCIDRs()); err != nil {
+			logger.Log.Warnf("Failed to index ranges of subnet %s: %v", name, err)
+
+			continue
+		}
+
 		projects[project] = true
 	}
 
@@ -1626,26 +1645,14 @@ func (c *Cache) FindSubnetsForIP(ip net.IP) []*SubnetEntry {
 	ttl := c.getEffectiveTTL(TTLTypeSubnets)
 	expiryTime := time.Now().Add(-ttl).Unix()
 
-	// Convert IP to integer for indexed lookup
-	ipInt := ipToInt(ip)
-
-	var rows *sql.Rows
-	var err error
-
-	// Try indexed lookup first for IPv4
-	if ip.To4() != nil && ipInt > 0 {
-		logSQL("getSubnetsByIP (indexed)", expiryTime, ipInt, ipInt)
-		rows, err = c.stmts.getSubnetsByIP.Query(expiryTime, ipInt, ipInt)
-	} else {
-		// Fall back to full scan for IPv6 or when indexed lookup not available
-		rows, err = c.query(
-			`SELECT id, timestamp, project, network, region, name, self_link, primary_cidr,
-			        secondary_ranges_json, ipv6_cidr, gateway, cidr_start_int, cidr_end_int
-			 FROM subnets WHERE timestamp > ?`,
-			expiryTime,
-		)
+	key := ipKey(ip)
+	if key == nil {
+		return nil
 	}
 
+	logSQL("getSubnetsByIP", expiryTime, key, key)
+
+	rows, err := c.stmts.getSubnetsByIP.Query(expiryTime, key, key)
 	if err != nil {
 		logger.Log.Warnf("Failed to query subnets: %v", err)
 
@@ -1657,37 +1664,13 @@ func (c *Cache) FindSubnetsForIP(ip net.IP) []*SubnetEntry {
 	var matchedIDs []int64
 
 	for rows.Next() {
-		var entry SubnetEntry
-		var id, timestamp int64
-		var secondaryJSON string
-		var cidrStart, cidrEnd *int64
-
-		err := rows.Scan(
-			&id, &timestamp, &entry.Project, &entry.Network, &entry.Region, &entry.Name,
-			&entry.SelfLink, &entry.PrimaryCIDR, &secondaryJSON, &entry.IPv6CIDR, &entry.Gateway,
-			&cidrStart, &cidrEnd,
-		)
+		id, entry, err := scanSubnetRow(rows)
 		if err != nil {
 			logger.Log.Warnf("Failed to scan subnet row: %v", err)
 
 			continue
 		}
 
-		entry.Timestamp = time.Unix(timestamp, 0)
-
-		if secondaryJSON != "" {
-			if err := json.Unmarshal([]byte(secondaryJSON), &entry.SecondaryRanges); err != nil {
-				logger.Log.Warnf("Failed to unmarshal secondary ranges: %v", err)
-			}
-		}
-
-		// For indexed queries, we already filtered. For full scan, check containment.
-		if ip.To4() == nil || ipInt == 0 {
-			if !entry.containsIP(ip) {
-				continue
-			}
-		}
-
 		results = append(results, entry.clone())
 		matchedIDs = append(matchedIDs, id)
 	}
@@ -1715,66 +1698,195 @@ func (c *Cache) FindSubnetsForIP(ip net.IP) []*SubnetEntry {
 	return results
 }
 
-// cidrContainsIP reports whether the given IP is inside the provided CIDR.
-func cidrContainsIP(cidr string, ip net.IP) bool {
-	cidr = strings.TrimSpace(cidr)
-	if cidr == "" || ip == nil {
-		return false
+// FindSubnetsOverlapping returns cached subnets with a primary, secondary or IPv6 range
+// overlapping the specified prefix.
+func (c *Cache) FindSubnetsOverlapping(prefix *net.IPNet) []*SubnetEntry {
+	if !Enabled() || c.isNoOp() || prefix == nil {
+		return nil
 	}
 
-	_, network, err := net.ParseCIDR(cidr)
-	if err != nil || network == nil {
-		return false
+	start := time.Now()
+	defer func() {
+		c.stats.recordOperation("FindSubnetsOverlapping", time.Since(start))
+	}()
+
+	ttl := c.getEffectiveTTL(TTLTypeSubnets)
+	expiryTime := time.Now().Add(-ttl).Unix()
+
+	prefixStart, prefixEnd := rangeBounds(prefix)
+
+	rows, err := c.query(
+		`SELECT DISTINCT `+subnetColumnsFrom("s")+`
+		 FROM subnet_ranges r
+		 JOIN subnets s ON s.project = r.project AND s.network = r.network AND s.name = r.name
+		 WHERE s.timestamp > ? AND r.range_start <= ? AND r.range_end >= ?`,
+		expiryTime, prefixEnd, prefixStart,
+	)
+	if err != nil {
+		logger.Log.Warnf("Failed to query subnets: %v", err)
+
+		return nil
+	}
+	defer func() { _ = rows.Close() }()
+
+	var results []*SubnetEntry
+
+	for rows.Next() {
+		_, entry, err := scanSubnetRow(rows)
+		if err != nil {
+			logger.Log.Warnf("Failed to scan subnet row: %v", err)
+
+			continue
+		}
+
+		results = append(results, entry.clone())
+	}
+
+	if err := rows.Err(); err != nil {
+		logger.Log.Warnf("Error iterating subnet rows: %v", err)
+	}
+
+	if len(results) > 0 {
+		c.stats.recordHit()
+	} else {
+		c.stats.recordMiss()
+	}
+
+	return results
+}
+
+// ListSubnets returns every cached subnet that has not expired, sorted by project, network, region and name.
+func (c *Cache) ListSubnets() []*SubnetEntry {
+	if !Enabled() || c.isNoOp() {
+		return nil
 	}
 
-	return network.Contains(ip)
+	start := time.Now()
+	defer func() {
+		c.stats.recordOperation("ListSubnets", time.Since(start))
+	}()
+
+	ttl := c.getEffectiveTTL(TTLTypeSubnets)
+	expiryTime := time.Now().Add(-ttl).Unix()
+
+	rows, err := c.query(
+		`SELECT `+subnetColumnsFrom("subnets")+`
+		 FROM subnets WHERE timestamp > ?
+		 ORDER BY project, network, region, name`,
+		expiryTime,
+	)
+	if err != nil {
+		logger.Log.Warnf("Failed to query subnets: %v", err)
+
+		return nil
+	}
+	defer func() { _ = rows.Close() }()
+
+	var results []*SubnetEntry
+
+	for rows.Next() {
+		_, entry, err := scanSubnetRow(rows)
+		if err != nil {
+			logger.Log.Warnf("Failed to scan subnet row: %v", err)
+
+			continue
+		}
+
+		results = append(results, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		logger.Log.Warnf("Error iterating subnet rows: %v", err)
+	}
+
+	return results
 }
 
-// cidrToIntRange converts a CIDR string to start and end IP integers.
-// Returns 0, 0 for invalid CIDRs or IPv6.
-func cidrToIntRange(cidr string) (int64, int64) {
-	if cidr == "" {
-		return 0, 0
+// FindSubnetsByName returns the cached subnets with the given name, across projects and regions.
+func (c *Cache) FindSubnetsByName(name string) []*SubnetEntry {
+	name = strings.TrimSpace(name)
+	if !Enabled() || c.isNoOp() || name == "" {
+		return nil
 	}
 
-	_, network, err := net.ParseCIDR(cidr)
-	if err != nil || network == nil {
-		return 0, 0
+	start := time.Now()
+	defer func() {
+		c.stats.recordOperation("FindSubnetsByName", time.Since(start))
+	}()
+
+	ttl := c.getEffectiveTTL(TTLTypeSubnets)
+	expiryTime := time.Now().Add(-ttl).Unix()
+
+	rows, err := c.query(
+		`SELECT `+subnetColumnsFrom("subnets")+`
+		 FROM subnets WHERE timestamp > ? AND name = ?
+		 ORDER BY project, region`,
+		expiryTime, name,
+	)
+	if err != nil {
+		logger.Log.Warnf("Failed to query subnets: %v", err)
+
+		return nil
 	}
+	defer func() { _ = rows.Close() }()
 
-	// Only handle IPv4 for now
-	ip4 := network.IP.To4()
-	if ip4 == nil {
-		return 0, 0
+	var results []*SubnetEntry
+
+	for rows.Next() {
+		_, entry, err := scanSubnetRow(rows)
+		if err != nil {
+			logger.Log.Warnf("Failed to scan subnet row: %v", err)
+
+			continue
+		}
+
+		results = append(results, entry)
 	}
 
-	start := int64(binary.BigEndian.Uint32(ip4))
+	if err := rows.Err(); err != nil {
+		logger.Log.Warnf("Error iterating subnet rows: %v", err)
+	}
 
-	// Calculate end IP from mask
-	ones, bits := network.Mask.Size()
-	if bits != 32 {
-		return 0, 0
+	if len(results) > 0 {
+		c.stats.recordHit()
+	} else {
+		c.stats.recordMiss()
 	}
 
-	hostBits := uint32(bits - ones)
-	end := start | int64((1<<hostBits)-1)
+	return results
+}
 
-	return start, end
+// subnetColumnsFrom returns the subnet columns read by scanSubnetRow, qualified with the given table name.
+func subnetColumnsFrom(table string) string {
+	return fmt.Sprintf(`%[1]s.id, %[1]s.timestamp, %[1]s.project, %[1]s.network, %[1]s.region, %[1]s.name,
+		%[1]s.self_link, %[1]s.primary_cidr, %[1]s.secondary_ranges_json, %[1]s.ipv6_cidr,
+		COALESCE(%[1]s.external_ipv6_cidr, ''), %[1]s.gateway`, table)
 }
 
-// ipToInt converts an IP address to an integer.
-// Returns 0 for IPv6 or invalid IPs.
-func ipToInt(ip net.IP) int64 {
-	if ip == nil {
-		return 0
+// scanSubnetRow decodes a subnets row selected with the columns used by the subnet lookups.
+func scanSubnetRow(rows *sql.Rows) (int64, *SubnetEntry, error) {
+	var entry SubnetEntry
+	var id, timestamp int64
+	var secondaryJSON string
+
+	err := rows.Scan(
+		&id, &timestamp, &entry.Project, &entry.Network, &entry.Region, &entry.Name,
+		&entry.SelfLink, &entry.PrimaryCIDR, &secondaryJSON, &entry.IPv6CIDR, &entry.ExternalIPv6CIDR,
+		&entry.Gateway,
+	)
+	if err != nil {
+		return 0, nil, err
 	}
 
-	ip4 := ip.To4()
-	if ip4 == nil {
-		return 0
+	entry.Timestamp = time.Unix(timestamp, 0)
+
+	if secondaryJSON != "" {
+		if err := json.Unmarshal([]byte(secondaryJSON), &entry.SecondaryRanges); err != nil {
+			logger.Log.Warnf("Failed to unmarshal secondary ranges: %v", err)
+		}
 	}
 
-	return int64(binary.BigEndian.Uint32(ip4))
+	return id, &entry, nil
 }
 
 // getFileInfo returns file info for the given path.
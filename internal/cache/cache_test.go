@@ -1,6 +1,7 @@
 package cache
 
 import (
+	"net"
 	"path/filepath"
 	"testing"
 	"time"
@@ -1146,3 +1147,140 @@ func TestIAPPreservationWithCompositeKey(t *testing.T) {
 	require.True(t, *retrievedA.IAP)
 	require.Equal(t, "us-west1-a", retrievedA.Zone)
 }
+
+func TestFindSubnetsOverlapping(t *testing.T) {
+	cache := newTestCache(t)
+
+	require.NoError(t, cache.RememberSubnet(&SubnetEntry{
+		Project:     "proj-a",
+		Network:     "vpc",
+		Region:      "us-central1",
+		Name:        "primary-match",
+		PrimaryCIDR: "10.20.1.0/24",
+	}))
+	require.NoError(t, cache.RememberSubnet(&SubnetEntry{
+		Project:         "proj-b",
+		Network:         "vpc",
+		Region:          "us-central1",
+		Name:            "secondary-match",
+		PrimaryCIDR:     "10.30.0.0/24",
+		SecondaryRanges: []SubnetSecondaryRange{{Name: "pods", CIDR: "10.16.0.0/12"}},
+	}))
+	require.NoError(t, cache.RememberSubnet(&SubnetEntry{
+		Project:     "proj-c",
+		Network:     "vpc",
+		Region:      "us-central1",
+		Name:        "no-match",
+		PrimaryCIDR: "10.40.0.0/24",
+	}))
+
+	_, prefix, err := net.ParseCIDR("10.20.0.0/22")
+	require.NoError(t, err)
+
+	names := make([]string, 0)
+	for _, entry := range cache.FindSubnetsOverlapping(prefix) {
+		names = append(names, entry.Name)
+	}
+	require.ElementsMatch(t, []string{"primary-match", "secondary-match"}, names)
+
+	require.Nil(t, cache.FindSubnetsOverlapping(nil))
+
+	_, v6Prefix, err := net.ParseCIDR("2600:1900:4000::/44")
+	require.NoError(t, err)
+	require.NoError(t, cache.RememberSubnet(&SubnetEntry{
+		Project:          "proj-d",
+		Network:          "vpc",
+		Region:           "us-central1",
+		Name:             "dual-stack",
+		PrimaryCIDR:      "10.50.0.0/24",
+		ExternalIPv6CIDR: "2600:1900:4001:a2b::/64",
+	}))
+
+	overlapping := cache.FindSubnetsOverlapping(v6Prefix)
+	require.Len(t, overlapping, 1)
+	require.Equal(t, "dual-stack", overlapping[0].Name)
+}
+
+func TestFindSubnetsForIP(t *testing.T) {
+	cache := newTestCache(t)
+
+	require.NoError(t, cache.RememberSubnet(&SubnetEntry{
+		Project:         "proj-a",
+		Network:         "vpc",
+		Region:          "us-central1",
+		Name:            "gke-nodes",
+		PrimaryCIDR:     "10.20.1.0/24",
+		SecondaryRanges: []SubnetSecondaryRange{{Name: "pods", CIDR: "10.16.0.0/14"}},
+		IPv6CIDR:        "fd20:a:b:c::/64",
+	}))
+	require.NoError(t, cache.RememberSubnetBatch([]*SubnetEntry{{
+		Project:          "proj-b",
+		Network:          "vpc",
+		Region:           "europe-west1",
+		Name:             "dual-stack",
+		PrimaryCIDR:      "10.30.0.0/24",
+		ExternalIPv6CIDR: "2600:1900:4001:a2b::/64",
+	}}))
+
+	for ip, expected := range map[string]string{
+		"10.20.1.9":               "gke-nodes",
+		"10.17.3.4":               "gke-nodes",
+		"fd20:a:b:c::42":          "gke-nodes",
+		"2600:1900:4001:a2b:0::5": "dual-stack",
+		"10.30.0.255":             "dual-stack",
+	} {
+		matches := cache.FindSubnetsForIP(net.ParseIP(ip))
+		require.Len(t, matches, 1, ip)
+		require.Equal(t, expected, matches[0].Name, ip)
+	}
+
+	require.Empty(t, cache.FindSubnetsForIP(net.ParseIP("10.21.0.1")))
+	require.Empty(t, cache.FindSubnetsForIP(net.ParseIP("fd20:a:b:d::1")))
+	// An IPv4 address must not match an IPv6 range sharing the same low-order bits
+	require.Empty(t, cache.FindSubnetsForIP(net.ParseIP("::10.20.1.9")))
+
+	require.NoError(t, cache.ClearProjectSubnets("proj-a"))
+	require.Empty(t, cache.FindSubnetsForIP(net.ParseIP("10.20.1.9")))
+
+	var count int
+	require.NoError(t, cache.queryRow(`SELECT COUNT(*) FROM subnet_ranges WHERE project = ?`, "proj-a").Scan(&count))
+	require.Zero(t, count)
+}
+
+func TestFindSubnetsByName(t *testing.T) {
+	cache := newTestCache(t)
+
+	for _, entry := range []*SubnetEntry{
+		{Project: "proj-b", Network: "vpc", Region: "us-central1", Name: "default", PrimaryCIDR: "10.128.0.0/20"},
+		{Project: "proj-a", Network: "vpc", Region: "europe-west1", Name: "default", PrimaryCIDR: "10.132.0.0/20"},
+		{Project: "proj-a", Network: "vpc", Region: "europe-west1", Name: "other", PrimaryCIDR: "10.0.0.0/24"},
+	} {
+		require.NoError(t, cache.RememberSubnet(entry))
+	}
+
+	entries := cache.FindSubnetsByName("default")
+	require.Len(t, entries, 2)
+	require.Equal(t, "proj-a", entries[0].Project)
+	require.Equal(t, "proj-b", entries[1].Project)
+
+	require.Empty(t, cache.FindSubnetsByName("missing"))
+	require.Nil(t, cache.FindSubnetsByName(""))
+}
+
+func TestListSubnets(t *testing.T) {
+	cache := newTestCache(t)
+
+	require.Empty(t, cache.ListSubnets())
+
+	require.NoError(t, cache.RememberSubnet(&SubnetEntry{Project: "proj-b", Network: "vpc", Region: "us-central1", Name: "b", PrimaryCIDR: "10.1.0.0/24"}))
+	require.NoError(t, cache.RememberSubnet(&SubnetEntry{
+		Project: "proj-a", Network: "vpc", Region: "us-central1", Name: "a", PrimaryCIDR: "10.0.0.0/24",
+		SecondaryRanges: []SubnetSecondaryRange{{Name: "pods", CIDR: "10.4.0.0/14"}},
+	}))
+
+	entries := cache.ListSubnets()
+	require.Len(t, entries, 2)
+	require.Equal(t, "a", entries[0].Name)
+	require.Equal(t, []SubnetSecondaryRange{{Name: "pods", CIDR: "10.4.0.0/14"}}, entries[0].SecondaryRanges)
+	require.Equal(t, "b", entries[1].Name)
+}
@@ -0,0 +1,180 @@
+package cache
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/kedare/compass/internal/logger"
+)
+
+// IPSighting records that a resource referenced an IP address during a period, between
+// its first and last sightings.
+type IPSighting struct {
+	IP           string
+	Project      string
+	Kind         string
+	Resource     string
+	Location     string
+	Details      string
+	ResourceLink string
+	FirstSeen    time.Time
+	LastSeen     time.Time
+}
+
+// RecordIPSightings stores the IP associations seen at the given time as ownership periods.
+// A resource that was among the latest owners of an address in its project has its period
+// extended (last sighting, details and link updated). Otherwise a new period starts, so an
+// address owned by A, then B, then A again keeps three periods instead of one for A
+// overlapping B's. Periods are kept per project, as private addresses are reused across
+// the VPC networks of different projects.
+func (c *Cache) RecordIPSightings(sightings []IPSighting, seenAt time.Time) error {
+	if !Enabled() || c.isNoOp() || len(sightings) == 0 {
+		return nil
+	}
+
+	start := time.Now()
+	defer func() {
+		c.stats.recordOperation("RecordIPSightings", time.Since(start))
+	}()
+
+	tx, err := c.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	latestQuery := `SELECT COALESCE(MAX(last_seen), 0) FROM ip_history WHERE ip = ? AND project = ?`
+	periodQuery := `
+		SELECT first_seen, last_seen FROM ip_history
+		WHERE ip = ? AND project = ? AND kind = ? AND resource = ? AND location = ?
+		ORDER BY last_seen DESC LIMIT 1`
+	extendQuery := `
+		UPDATE ip_history SET details = ?, resource_link = ?, last_seen = MAX(last_seen, ?)
+		WHERE ip = ? AND project = ? AND kind = ? AND resource = ? AND location = ? AND first_seen = ?`
+	insertQuery := `
+		INSERT INTO ip_history (ip, project, kind, resource, location, details, resource_link, first_seen, last_seen)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(ip, project, kind, resource, location, first_seen) DO UPDATE SET
+			details = excluded.details,
+			resource_link = excluded.resource_link,
+			last_seen = MAX(ip_history.last_seen, excluded.last_seen)`
+
+	seen := seenAt.Unix()
+	recorded := 0
+
+	// Latest sighting of each address and project before this batch, which tells the
+	// current owners apart from earlier ones
+	latest := make(map[string]int64)
+
+	for _, sighting := range sightings {
+		ip := canonicalIP(sighting.IP)
+		if ip == "" || sighting.Project == "" || sighting.Resource == "" {
+			continue
+		}
+
+		key := ip + "\x00" + sighting.Project
+		previous, ok := latest[key]
+		if !ok {
+			logSQL(latestQuery, ip, sighting.Project)
+			if err = tx.QueryRow(latestQuery, ip, sighting.Project).Scan(&previous); err != nil {
+				return fmt.Errorf("failed to load history of %s: %w", ip, err)
+			}
+			latest[key] = previous
+		}
+
+		var firstSeen, lastSeen int64
+		logSQL(periodQuery, ip, sighting.Project, sighting.Kind, sighting.Resource, sighting.Location)
+		err = tx.QueryRow(periodQuery, ip, sighting.Project, sighting.Kind, sighting.Resource, sighting.Location).
+			Scan(&firstSeen, &lastSeen)
+
+		switch {
+		case err == nil && lastSeen >= previous:
+			logSQL(extendQuery, ip, sighting.Project, sighting.Kind, sighting.Resource, sighting.Location, firstSeen)
+			_, err = tx.Exec(extendQuery, sighting.Details, sighting.ResourceLink, seen,
+				ip, sighting.Project, sighting.Kind, sighting.Resource, sighting.Location, firstSeen)
+		case err == nil || errors.Is(err, sql.ErrNoRows):
+			logSQL(insertQuery, ip, sighting.Project, sighting.Kind, sighting.Resource, sighting.Location)
+			_, err = tx.Exec(insertQuery, ip, sighting.Project, sighting.Kind, sighting.Resource, sighting.Location,
+				sighting.Details, sighting.ResourceLink, seen, seen)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to record sighting of %s: %w", ip, err)
+		}
+		recorded++
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	logger.Log.Debugf("Recorded %d IP sighting(s)", recorded)
+
+	return nil
+}
+
+// GetIPHistory returns every recorded owner of the IP address within the IP history
+// retention, oldest first.
+func (c *Cache) GetIPHistory(ip string) ([]IPSighting, error) {
+	if c.isNoOp() {
+		return nil, nil
+	}
+
+	canonical := canonicalIP(ip)
+	if canonical == "" {
+		return nil, fmt.Errorf("invalid IP address %q", ip)
+	}
+
+	start := time.Now()
+	defer func() {
+		c.stats.recordOperation("GetIPHistory", time.Since(start))
+	}()
+
+	expiry := time.Now().Add(-c.getEffectiveTTL(TTLTypeIPHistory)).Unix()
+
+	rows, err := c.query(`
+		SELECT ip, project, kind, resource, location, COALESCE(details, ''), COALESCE(resource_link, ''), first_seen, last_seen
+		FROM ip_history
+		WHERE ip = ? AND last_seen > ?
+		ORDER BY first_seen, last_seen, project, resource`,
+		canonical, expiry)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load history of %s: %w", canonical, err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var history []IPSighting
+	for rows.Next() {
+		var sighting IPSighting
+		var firstSeen, lastSeen int64
+
+		if err := rows.Scan(&sighting.IP, &sighting.Project, &sighting.Kind, &sighting.Resource, &sighting.Location,
+			&sighting.Details, &sighting.ResourceLink, &firstSeen, &lastSeen); err != nil {
+			return nil, fmt.Errorf("failed to read history of %s: %w", canonical, err)
+		}
+
+		sighting.FirstSeen = time.Unix(firstSeen, 0)
+		sighting.LastSeen = time.Unix(lastSeen, 0)
+		history = append(history, sighting)
+	}
+
+	return history, rows.Err()
+}
+
+// canonicalIP returns the canonical form of an IP address, or "" when it is not one.
+func canonicalIP(value string) string {
+	ip := net.ParseIP(strings.TrimSpace(value))
+	if ip == nil {
+		return ""
+	}
+
+	return ip.String()
+}
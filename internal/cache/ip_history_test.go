@@ -0,0 +1,96 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIPHistory(t *testing.T) {
+	c := newTestCache(t)
+
+	monday := time.Now().Add(-7 * 24 * time.Hour).Truncate(time.Second)
+	tuesday := monday.Add(24 * time.Hour)
+	today := time.Now().Truncate(time.Second)
+
+	vm1 := IPSighting{IP: "10.1.2.3", Project: "proj", Kind: "instance_internal", Resource: "vm-1", Location: "europe-west1-b"}
+	vm2 := IPSighting{IP: "10.1.2.3", Project: "proj", Kind: "instance_internal", Resource: "vm-2", Location: "europe-west1-b"}
+
+	require.NoError(t, c.RecordIPSightings([]IPSighting{vm1}, monday))
+	require.NoError(t, c.RecordIPSightings([]IPSighting{vm1}, tuesday))
+	require.NoError(t, c.RecordIPSightings([]IPSighting{
+		vm2,
+		{IP: "not-an-ip", Project: "proj", Resource: "ignored"},
+	}, today))
+
+	history, err := c.GetIPHistory(" 10.1.2.3 ")
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	require.Equal(t, "vm-1", history[0].Resource)
+	require.Equal(t, monday.Unix(), history[0].FirstSeen.Unix())
+	require.Equal(t, tuesday.Unix(), history[0].LastSeen.Unix())
+	require.Equal(t, "vm-2", history[1].Resource)
+	require.Equal(t, today.Unix(), history[1].FirstSeen.Unix())
+
+	// Sightings older than the retention are hidden, then pruned
+	require.NoError(t, c.SetTTL(TTLTypeIPHistory, 72*time.Hour))
+	history, err = c.GetIPHistory("10.1.2.3")
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	require.Equal(t, "vm-2", history[0].Resource)
+
+	c.cleanExpired()
+	var count int
+	require.NoError(t, c.queryRow("SELECT COUNT(*) FROM ip_history").Scan(&count))
+	require.Equal(t, 1, count)
+
+	_, err = c.GetIPHistory("nope")
+	require.Error(t, err)
+
+	require.NoError(t, c.Clear())
+	history, err = c.GetIPHistory("10.1.2.3")
+	require.NoError(t, err)
+	require.Empty(t, history)
+}
+
+func TestIPHistoryReuse(t *testing.T) {
+	c := newTestCache(t)
+
+	day := func(n int) time.Time {
+		return time.Now().Add(time.Duration(n-10) * 24 * time.Hour).Truncate(time.Second)
+	}
+
+	a := IPSighting{IP: "10.1.2.3", Project: "proj", Kind: "instance_internal", Resource: "vm-a", Location: "europe-west1-b"}
+	b := IPSighting{IP: "10.1.2.3", Project: "proj", Kind: "instance_internal", Resource: "vm-b", Location: "europe-west1-b"}
+	lb := IPSighting{IP: "10.1.2.3", Project: "proj", Kind: "forwarding_rule", Resource: "ilb", Location: "europe-west1"}
+	other := IPSighting{IP: "10.1.2.3", Project: "other", Kind: "instance_internal", Resource: "vm-x", Location: "us-east1-b"}
+
+	// A (with a forwarding rule on the same address), then B, then A again
+	require.NoError(t, c.RecordIPSightings([]IPSighting{a, lb, other}, day(1)))
+	require.NoError(t, c.RecordIPSightings([]IPSighting{a, lb}, day(2)))
+	require.NoError(t, c.RecordIPSightings([]IPSighting{b}, day(3)))
+	require.NoError(t, c.RecordIPSightings([]IPSighting{b}, day(4)))
+	require.NoError(t, c.RecordIPSightings([]IPSighting{a}, day(5)))
+	require.NoError(t, c.RecordIPSightings([]IPSighting{a}, day(6)))
+
+	history, err := c.GetIPHistory("10.1.2.3")
+	require.NoError(t, err)
+
+	type period struct {
+		project, resource string
+		first, last       int64
+	}
+	periods := make([]period, 0, len(history))
+	for _, sighting := range history {
+		periods = append(periods, period{sighting.Project, sighting.Resource, sighting.FirstSeen.Unix(), sighting.LastSeen.Unix()})
+	}
+
+	require.Equal(t, []period{
+		{"other", "vm-x", day(1).Unix(), day(1).Unix()},
+		{"proj", "ilb", day(1).Unix(), day(2).Unix()},
+		{"proj", "vm-a", day(1).Unix(), day(2).Unix()},
+		{"proj", "vm-b", day(3).Unix(), day(4).Unix()},
+		{"proj", "vm-a", day(5).Unix(), day(6).Unix()},
+	}, periods, "A is not reported as owner while B held the address")
+}
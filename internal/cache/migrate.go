@@ -209,6 +209,15 @@ func importFromJSON(db *sql.DB, jsonPath string) (bool, error) {
 			return false, fmt.Errorf("failed to import subnet %s: %w", entry.Name, err)
 		}
 
+		cidrs := []string{entry.PrimaryCIDR, entry.IPv6CIDR}
+		for _, sr := range entry.SecondaryRanges {
+			cidrs = append(cidrs, sr.CIDR)
+		}
+
+		if err = indexSubnetRanges(tx, entry.Project, entry.Network, entry.Name, cidrs); err != nil {
+			return false, fmt.Errorf("failed to index ranges of subnet %s: %w", entry.Name, err)
+		}
+
 		subnetCount++
 	}
 
@@ -76,6 +76,7 @@ func TestImportFromJSON_ValidData(t *testing.T) {
 
 	err = initSchema(db)
 	require.NoError(t, err)
+	require.NoError(t, initNewDatabase(db))
 
 	// Run migration
 	migrated, err := importFromJSON(db, jsonPath)
@@ -112,6 +113,11 @@ func TestImportFromJSON_ValidData(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, 1, count)
 
+	// Verify primary and secondary ranges were indexed
+	err = db.QueryRow(`SELECT COUNT(*) FROM subnet_ranges WHERE name = ?`, "test-subnet").Scan(&count)
+	require.NoError(t, err)
+	require.Equal(t, 2, count)
+
 	// Verify JSON file was deleted
 	_, err = os.Stat(jsonPath)
 	require.True(t, os.IsNotExist(err))
@@ -290,6 +296,7 @@ func TestImportFromJSON_DeduplicatesSubnets(t *testing.T) {
 
 	err = initSchema(db)
 	require.NoError(t, err)
+	require.NoError(t, initNewDatabase(db))
 
 	migrated, err := importFromJSON(db, jsonPath)
 	require.NoError(t, err)
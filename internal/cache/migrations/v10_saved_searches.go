@@ -0,0 +1,40 @@
+package migrations
+
+import (
+	"database/sql"
+)
+
+func init() {
+	Register(&v10SavedSearches{})
+}
+
+// v10SavedSearches adds tables for named searches and the results of their last run.
+type v10SavedSearches struct{}
+
+func (m *v10SavedSearches) Version() int {
+	return 10
+}
+
+func (m *v10SavedSearches) Description() string {
+	return "Add saved searches and their last run results"
+}
+
+func (m *v10SavedSearches) Up(db *sql.DB) error {
+	statements := []string{
+		`CREATE TABLE IF NOT EXISTS saved_searches (
+			name TEXT PRIMARY KEY,
+			term TEXT NOT NULL,
+			types_json TEXT,
+			project TEXT,
+			created_at INTEGER NOT NULL,
+			updated_at INTEGER NOT NULL
+		)`,
+		`CREATE TABLE IF NOT EXISTS saved_search_runs (
+			name TEXT PRIMARY KEY,
+			run_at INTEGER NOT NULL,
+			results_json TEXT NOT NULL
+		)`,
+	}
+
+	return ExecStatements(db, statements)
+}
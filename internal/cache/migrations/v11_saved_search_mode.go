@@ -0,0 +1,27 @@
+package migrations
+
+import (
+	"database/sql"
+)
+
+func init() {
+	Register(&v11SavedSearchMode{})
+}
+
+// v11SavedSearchMode adds a match_mode column to the saved_searches table
+// so regex and exact searches are re-run with the same matching.
+type v11SavedSearchMode struct{}
+
+func (m *v11SavedSearchMode) Version() int {
+	return 11
+}
+
+func (m *v11SavedSearchMode) Description() string {
+	return "Add match_mode column to saved_searches table"
+}
+
+func (m *v11SavedSearchMode) Up(db *sql.DB) error {
+	return ExecStatements(db, []string{
+		`ALTER TABLE saved_searches ADD COLUMN match_mode TEXT`,
+	})
+}
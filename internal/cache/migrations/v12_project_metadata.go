@@ -0,0 +1,30 @@
+package migrations
+
+import (
+	"database/sql"
+)
+
+func init() {
+	Register(&v12ProjectMetadata{})
+}
+
+// v12ProjectMetadata adds the resource hierarchy details discovered on import
+// (display name, number, folder path and labels) to the projects table.
+type v12ProjectMetadata struct{}
+
+func (m *v12ProjectMetadata) Version() int {
+	return 12
+}
+
+func (m *v12ProjectMetadata) Description() string {
+	return "Add display name, number, folder path and labels to projects table"
+}
+
+func (m *v12ProjectMetadata) Up(db *sql.DB) error {
+	return ExecStatements(db, []string{
+		`ALTER TABLE projects ADD COLUMN display_name TEXT`,
+		`ALTER TABLE projects ADD COLUMN project_number TEXT`,
+		`ALTER TABLE projects ADD COLUMN folder_path TEXT`,
+		`ALTER TABLE projects ADD COLUMN labels_json TEXT`,
+	})
+}
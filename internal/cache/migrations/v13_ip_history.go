@@ -0,0 +1,39 @@
+package migrations
+
+import (
+	"database/sql"
+)
+
+func init() {
+	Register(&v13IPHistory{})
+}
+
+// v13IPHistory adds a table recording which resources owned an IP address and when.
+type v13IPHistory struct{}
+
+func (m *v13IPHistory) Version() int {
+	return 13
+}
+
+func (m *v13IPHistory) Description() string {
+	return "Add IP history table recording first and last sightings of IP associations"
+}
+
+func (m *v13IPHistory) Up(db *sql.DB) error {
+	return ExecStatements(db, []string{
+		`CREATE TABLE IF NOT EXISTS ip_history (
+			ip TEXT NOT NULL,
+			project TEXT NOT NULL,
+			kind TEXT NOT NULL,
+			resource TEXT NOT NULL,
+			location TEXT NOT NULL DEFAULT '',
+			details TEXT,
+			resource_link TEXT,
+			first_seen INTEGER NOT NULL,
+			last_seen INTEGER NOT NULL,
+			PRIMARY KEY (ip, project, kind, resource, location)
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_ip_history_last_seen ON ip_history(last_seen)`,
+		`CREATE INDEX IF NOT EXISTS idx_ip_history_project ON ip_history(project)`,
+	})
+}
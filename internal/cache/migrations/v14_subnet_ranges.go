@@ -0,0 +1,126 @@
+package migrations
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net"
+	"strings"
+)
+
+func init() {
+	Register(&v14SubnetRanges{})
+}
+
+// v14SubnetRanges replaces the IPv4-only integer bounds of subnets with a table of 128-bit
+// range bounds covering primary, secondary, internal and external IPv6 ranges, so every
+// range of both address families is found through the index.
+type v14SubnetRanges struct{}
+
+func (m *v14SubnetRanges) Version() int {
+	return 14
+}
+
+func (m *v14SubnetRanges) Description() string {
+	return "Add subnet ranges table with 128-bit bounds and external IPv6 range of subnets"
+}
+
+func (m *v14SubnetRanges) Up(db *sql.DB) error {
+	statements := []string{
+		`ALTER TABLE subnets ADD COLUMN external_ipv6_cidr TEXT`,
+		`CREATE TABLE IF NOT EXISTS subnet_ranges (
+			project TEXT NOT NULL,
+			network TEXT NOT NULL,
+			name TEXT NOT NULL,
+			cidr TEXT NOT NULL,
+			range_start BLOB NOT NULL,
+			range_end BLOB NOT NULL,
+			PRIMARY KEY (project, network, name, cidr)
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_subnet_ranges_bounds ON subnet_ranges(range_start, range_end)`,
+		`DROP INDEX IF EXISTS idx_subnets_cidr_range`,
+	}
+
+	if err := ExecStatements(db, statements); err != nil {
+		return err
+	}
+
+	// Index the ranges of existing subnets (best effort)
+	_ = m.indexExistingRanges(db)
+
+	return nil
+}
+
+// indexExistingRanges fills subnet_ranges from the ranges stored on subnet rows.
+func (m *v14SubnetRanges) indexExistingRanges(db *sql.DB) error {
+	rows, err := db.Query(`SELECT project, network, name, COALESCE(primary_cidr, ''),
+		COALESCE(secondary_ranges_json, ''), COALESCE(ipv6_cidr, '') FROM subnets`)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = rows.Close() }()
+
+	type subnetRanges struct {
+		project, network, name string
+		cidrs                  []string
+	}
+
+	var subnets []subnetRanges
+	for rows.Next() {
+		var project, network, name, primary, secondaryJSON, ipv6 string
+		if err := rows.Scan(&project, &network, &name, &primary, &secondaryJSON, &ipv6); err != nil {
+			continue
+		}
+
+		cidrs := []string{primary, ipv6}
+		var secondary []struct {
+			CIDR string `json:"cidr"`
+		}
+		if secondaryJSON != "" && json.Unmarshal([]byte(secondaryJSON), &secondary) == nil {
+			for _, sr := range secondary {
+				cidrs = append(cidrs, sr.CIDR)
+			}
+		}
+
+		subnets = append(subnets, subnetRanges{project: project, network: network, name: name, cidrs: cidrs})
+	}
+
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	insert := `INSERT OR IGNORE INTO subnet_ranges (project, network, name, cidr, range_start, range_end)
+		VALUES (?, ?, ?, ?, ?, ?)`
+
+	for _, subnet := range subnets {
+		for _, cidr := range subnet.cidrs {
+			_, network, err := net.ParseCIDR(strings.TrimSpace(cidr))
+			if err != nil {
+				continue
+			}
+
+			start, end := rangeBounds(network)
+			_, _ = db.Exec(insert, subnet.project, subnet.network, subnet.name, network.String(), start, end)
+		}
+	}
+
+	return nil
+}
+
+// rangeBounds returns the first and last address of a network as 16-byte big-endian values,
+// IPv4 networks being mapped into ::ffff:0:0/96.
+func rangeBounds(network *net.IPNet) ([]byte, []byte) {
+	start := make([]byte, net.IPv6len)
+	copy(start, network.IP.To16())
+
+	mask := network.Mask
+	if len(mask) == net.IPv4len {
+		mask = append(net.CIDRMask(96, 128)[:12:12], mask...)
+	}
+
+	end := make([]byte, net.IPv6len)
+	for i := range end {
+		end[i] = start[i] | ^mask[i]
+	}
+
+	return start, end
+}
@@ -0,0 +1,48 @@
+package migrations
+
+import (
+	"database/sql"
+)
+
+func init() {
+	Register(&v15IPHistoryPeriods{})
+}
+
+// v15IPHistoryPeriods keys IP history rows by their first sighting, so a resource owning an
+// address again after another one did gets a new ownership period instead of having its
+// previous period stretched over the other owner's.
+type v15IPHistoryPeriods struct{}
+
+func (m *v15IPHistoryPeriods) Version() int {
+	return 15
+}
+
+func (m *v15IPHistoryPeriods) Description() string {
+	return "Store IP history as ownership periods keyed by their first sighting"
+}
+
+func (m *v15IPHistoryPeriods) Up(db *sql.DB) error {
+	return ExecStatements(db, []string{
+		`CREATE TABLE IF NOT EXISTS ip_history_periods (
+			ip TEXT NOT NULL,
+			project TEXT NOT NULL,
+			kind TEXT NOT NULL,
+			resource TEXT NOT NULL,
+			location TEXT NOT NULL DEFAULT '',
+			details TEXT,
+			resource_link TEXT,
+			first_seen INTEGER NOT NULL,
+			last_seen INTEGER NOT NULL,
+			PRIMARY KEY (ip, project, kind, resource, location, first_seen)
+		)`,
+		`INSERT OR IGNORE INTO ip_history_periods
+			(ip, project, kind, resource, location, details, resource_link, first_seen, last_seen)
+			SELECT ip, project, kind, resource, location, details, resource_link, first_seen, last_seen
+			FROM ip_history`,
+		`DROP TABLE ip_history`,
+		`ALTER TABLE ip_history_periods RENAME TO ip_history`,
+		`CREATE INDEX IF NOT EXISTS idx_ip_history_last_seen ON ip_history(last_seen)`,
+		`CREATE INDEX IF NOT EXISTS idx_ip_history_project ON ip_history(project)`,
+		`CREATE INDEX IF NOT EXISTS idx_ip_history_ip_project ON ip_history(ip, project, last_seen)`,
+	})
+}
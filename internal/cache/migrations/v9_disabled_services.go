@@ -0,0 +1,33 @@
+package migrations
+
+import (
+	"database/sql"
+)
+
+func init() {
+	Register(&v9DisabledServices{})
+}
+
+// v9DisabledServices adds a table remembering APIs that are disabled in a project.
+type v9DisabledServices struct{}
+
+func (m *v9DisabledServices) Version() int {
+	return 9
+}
+
+func (m *v9DisabledServices) Description() string {
+	return "Add disabled services table for skipping disabled APIs during search"
+}
+
+func (m *v9DisabledServices) Up(db *sql.DB) error {
+	statements := []string{
+		`CREATE TABLE IF NOT EXISTS disabled_services (
+			project TEXT NOT NULL,
+			service TEXT NOT NULL,
+			detected_at INTEGER NOT NULL,
+			PRIMARY KEY (project, service)
+		)`,
+	}
+
+	return ExecStatements(db, statements)
+}
@@ -0,0 +1,94 @@
+package cache
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/kedare/compass/internal/logger"
+)
+
+// ProjectMetadata describes where a cached project lives in the resource hierarchy.
+type ProjectMetadata struct {
+	Name        string
+	DisplayName string
+	Number      string
+	FolderPath  string // Folder display names from the organization, e.g. "/prod/web"
+	Labels      map[string]string
+}
+
+// SetProjectMetadata stores the hierarchy details of a cached project.
+// The project must already be cached, see AddProject.
+func (c *Cache) SetProjectMetadata(meta ProjectMetadata) error {
+	if c.isNoOp() || meta.Name == "" {
+		return nil
+	}
+
+	start := time.Now()
+	defer func() {
+		c.stats.recordOperation("SetProjectMetadata", time.Since(start))
+	}()
+
+	var labelsJSON sql.NullString
+	if len(meta.Labels) > 0 {
+		data, err := json.Marshal(meta.Labels)
+		if err != nil {
+			return fmt.Errorf("failed to encode labels of project %s: %w", meta.Name, err)
+		}
+		labelsJSON = sql.NullString{String: string(data), Valid: true}
+	}
+
+	result, err := c.exec(
+		`UPDATE projects SET display_name = ?, project_number = ?, folder_path = ?, labels_json = ? WHERE name = ?`,
+		meta.DisplayName, meta.Number, meta.FolderPath, labelsJSON, meta.Name)
+	if err != nil {
+		return fmt.Errorf("failed to store metadata of project %s: %w", meta.Name, err)
+	}
+	if rows, err := result.RowsAffected(); err == nil && rows == 0 {
+		return fmt.Errorf("project %s is not cached", meta.Name)
+	}
+
+	logger.Log.Debugf("Stored metadata of project %s (folder %q)", meta.Name, meta.FolderPath)
+
+	return nil
+}
+
+// GetProjectMetadata returns the stored hierarchy details of a cached project.
+func (c *Cache) GetProjectMetadata(name string) (*ProjectMetadata, bool) {
+	if c.isNoOp() || name == "" {
+		return nil, false
+	}
+
+	start := time.Now()
+	defer func() {
+		c.stats.recordOperation("GetProjectMetadata", time.Since(start))
+	}()
+
+	var displayName, number, folderPath, labelsJSON sql.NullString
+	err := c.queryRow(
+		`SELECT display_name, project_number, folder_path, labels_json FROM projects WHERE name = ?`,
+		name,
+	).Scan(&displayName, &number, &folderPath, &labelsJSON)
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			logger.Log.Warnf("Failed to load metadata of project %s: %v", name, err)
+		}
+		return nil, false
+	}
+
+	meta := &ProjectMetadata{
+		Name:        name,
+		DisplayName: displayName.String,
+		Number:      number.String,
+		FolderPath:  folderPath.String,
+	}
+	if labelsJSON.Valid && labelsJSON.String != "" {
+		if err := json.Unmarshal([]byte(labelsJSON.String), &meta.Labels); err != nil {
+			logger.Log.Warnf("Failed to decode labels of project %s: %v", name, err)
+		}
+	}
+
+	return meta, true
+}
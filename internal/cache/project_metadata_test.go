@@ -0,0 +1,48 @@
+package cache
+
+import "testing"
+
+func TestProjectMetadata(t *testing.T) {
+	c := newTestCache(t)
+
+	if err := c.SetProjectMetadata(ProjectMetadata{Name: "missing"}); err == nil {
+		t.Fatal("expected error for a project that is not cached")
+	}
+
+	if err := c.AddProject("proj-a"); err != nil {
+		t.Fatalf("AddProject failed: %v", err)
+	}
+	meta := ProjectMetadata{
+		Name:        "proj-a",
+		DisplayName: "Project A",
+		Number:      "123456",
+		FolderPath:  "/prod/web",
+		Labels:      map[string]string{"team": "payments"},
+	}
+	if err := c.SetProjectMetadata(meta); err != nil {
+		t.Fatalf("SetProjectMetadata failed: %v", err)
+	}
+
+	// Re-adding the project (e.g. when it is used again) keeps its metadata.
+	if err := c.AddProject("proj-a"); err != nil {
+		t.Fatalf("AddProject failed: %v", err)
+	}
+
+	got, ok := c.GetProjectMetadata("proj-a")
+	if !ok {
+		t.Fatal("expected metadata to be found")
+	}
+	if got.DisplayName != "Project A" || got.Number != "123456" || got.FolderPath != "/prod/web" || got.Labels["team"] != "payments" {
+		t.Fatalf("unexpected metadata: %+v", got)
+	}
+
+	if err := c.AddProject("proj-b"); err != nil {
+		t.Fatalf("AddProject failed: %v", err)
+	}
+	if got, ok := c.GetProjectMetadata("proj-b"); !ok || got.FolderPath != "" || got.Labels != nil {
+		t.Fatalf("expected empty metadata for a project imported without it, got %+v", got)
+	}
+	if _, ok := c.GetProjectMetadata("unknown"); ok {
+		t.Fatal("expected no metadata for an unknown project")
+	}
+}
@@ -0,0 +1,265 @@
+package cache
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kedare/compass/internal/logger"
+)
+
+// SavedSearch is a named search definition that can be re-run later.
+type SavedSearch struct {
+	Name      string
+	Term      string
+	Types     []string
+	Project   string
+	Mode      string // Match mode (e.g. "regex"), empty for substring matching
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// SavedSearchResult is a search result as stored for the last run of a saved search.
+type SavedSearchResult struct {
+	Type     string            `json:"type"`
+	Name     string            `json:"name"`
+	Project  string            `json:"project"`
+	Location string            `json:"location"`
+	Details  map[string]string `json:"details,omitempty"`
+}
+
+// SaveSearch creates or replaces a saved search definition. Replacing the term, types,
+// project or match mode drops the results of the last run, which belong to the previous
+// definition and would otherwise be diffed against the new one.
+func (c *Cache) SaveSearch(search SavedSearch) error {
+	if c.isNoOp() {
+		return nil
+	}
+
+	name := strings.TrimSpace(search.Name)
+	if name == "" {
+		return errors.New("saved search name cannot be empty")
+	}
+
+	start := time.Now()
+	defer func() {
+		c.stats.recordOperation("SaveSearch", time.Since(start))
+	}()
+
+	typesJSON, err := json.Marshal(search.Types)
+	if err != nil {
+		return fmt.Errorf("failed to encode saved search types: %w", err)
+	}
+
+	previous, existed := c.GetSavedSearch(name)
+
+	now := time.Now().Unix()
+	_, err = c.exec(`
+		INSERT INTO saved_searches (name, term, types_json, project, match_mode, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(name) DO UPDATE SET
+			term = excluded.term,
+			types_json = excluded.types_json,
+			project = excluded.project,
+			match_mode = excluded.match_mode,
+			updated_at = excluded.updated_at`,
+		name, search.Term, string(typesJSON), search.Project, search.Mode, now, now)
+	if err != nil {
+		return fmt.Errorf("failed to save search %s: %w", name, err)
+	}
+
+	if existed && !sameSearchDefinition(previous, &search) {
+		if _, err := c.exec("DELETE FROM saved_search_runs WHERE name = ?", name); err != nil {
+			return fmt.Errorf("failed to reset results of saved search %s: %w", name, err)
+		}
+		logger.Log.Debugf("Saved search %s changed, dropped the results of its last run", name)
+	}
+
+	logger.Log.Debugf("Saved search %s", name)
+
+	return nil
+}
+
+// sameSearchDefinition reports whether two saved searches run the same query.
+func sameSearchDefinition(a, b *SavedSearch) bool {
+	if a.Term != b.Term || a.Project != b.Project || a.Mode != b.Mode || len(a.Types) != len(b.Types) {
+		return false
+	}
+
+	for i := range a.Types {
+		if a.Types[i] != b.Types[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// GetSavedSearch returns the saved search with the given name.
+func (c *Cache) GetSavedSearch(name string) (*SavedSearch, bool) {
+	if c.isNoOp() || name == "" {
+		return nil, false
+	}
+
+	start := time.Now()
+	defer func() {
+		c.stats.recordOperation("GetSavedSearch", time.Since(start))
+	}()
+
+	row := c.queryRow(
+		"SELECT name, term, types_json, project, match_mode, created_at, updated_at FROM saved_searches WHERE name = ?",
+		name,
+	)
+
+	search, err := scanSavedSearch(row)
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			logger.Log.Warnf("Failed to load saved search %s: %v", name, err)
+		}
+		return nil, false
+	}
+
+	return search, true
+}
+
+// ListSavedSearches returns all saved searches ordered by name.
+func (c *Cache) ListSavedSearches() ([]SavedSearch, error) {
+	if c.isNoOp() {
+		return nil, nil
+	}
+
+	start := time.Now()
+	defer func() {
+		c.stats.recordOperation("ListSavedSearches", time.Since(start))
+	}()
+
+	rows, err := c.query("SELECT name, term, types_json, project, match_mode, created_at, updated_at FROM saved_searches ORDER BY name")
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var searches []SavedSearch
+	for rows.Next() {
+		search, err := scanSavedSearch(rows)
+		if err != nil {
+			logger.Log.Warnf("Failed to scan saved search: %v", err)
+			continue
+		}
+		searches = append(searches, *search)
+	}
+
+	return searches, rows.Err()
+}
+
+// DeleteSavedSearch removes a saved search and its last run results.
+func (c *Cache) DeleteSavedSearch(name string) error {
+	if c.isNoOp() || name == "" {
+		return nil
+	}
+
+	start := time.Now()
+	defer func() {
+		c.stats.recordOperation("DeleteSavedSearch", time.Since(start))
+	}()
+
+	for _, table := range []string{"saved_searches", "saved_search_runs"} {
+		if _, err := c.exec(fmt.Sprintf("DELETE FROM %s WHERE name = ?", table), name); err != nil {
+			return fmt.Errorf("failed to delete saved search %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// SetSavedSearchResults stores the results of the latest run of a saved search,
+// replacing the previous run.
+func (c *Cache) SetSavedSearchResults(name string, results []SavedSearchResult) error {
+	if c.isNoOp() || name == "" {
+		return nil
+	}
+
+	start := time.Now()
+	defer func() {
+		c.stats.recordOperation("SetSavedSearchResults", time.Since(start))
+	}()
+
+	if results == nil {
+		results = []SavedSearchResult{}
+	}
+
+	data, err := json.Marshal(results)
+	if err != nil {
+		return fmt.Errorf("failed to encode saved search results: %w", err)
+	}
+
+	_, err = c.exec(`
+		INSERT INTO saved_search_runs (name, run_at, results_json)
+		VALUES (?, ?, ?)
+		ON CONFLICT(name) DO UPDATE SET
+			run_at = excluded.run_at,
+			results_json = excluded.results_json`,
+		name, time.Now().Unix(), string(data))
+	if err != nil {
+		return fmt.Errorf("failed to store results of saved search %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// GetSavedSearchResults returns the results of the last run of a saved search and when it ran.
+func (c *Cache) GetSavedSearchResults(name string) ([]SavedSearchResult, time.Time, bool) {
+	if c.isNoOp() || name == "" {
+		return nil, time.Time{}, false
+	}
+
+	start := time.Now()
+	defer func() {
+		c.stats.recordOperation("GetSavedSearchResults", time.Since(start))
+	}()
+
+	var runAt int64
+	var data string
+	err := c.queryRow("SELECT run_at, results_json FROM saved_search_runs WHERE name = ?", name).Scan(&runAt, &data)
+	if err != nil {
+		return nil, time.Time{}, false
+	}
+
+	var results []SavedSearchResult
+	if err := json.Unmarshal([]byte(data), &results); err != nil {
+		logger.Log.Warnf("Failed to decode results of saved search %s: %v", name, err)
+		return nil, time.Time{}, false
+	}
+
+	return results, time.Unix(runAt, 0), true
+}
+
+// rowScanner is implemented by *sql.Row and *sql.Rows.
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanSavedSearch(row rowScanner) (*SavedSearch, error) {
+	var search SavedSearch
+	var typesJSON, project, mode sql.NullString
+	var createdAt, updatedAt int64
+
+	if err := row.Scan(&search.Name, &search.Term, &typesJSON, &project, &mode, &createdAt, &updatedAt); err != nil {
+		return nil, err
+	}
+
+	if typesJSON.Valid && typesJSON.String != "" {
+		if err := json.Unmarshal([]byte(typesJSON.String), &search.Types); err != nil {
+			return nil, fmt.Errorf("failed to decode saved search types: %w", err)
+		}
+	}
+	search.Project = project.String
+	search.Mode = mode.String
+	search.CreatedAt = time.Unix(createdAt, 0)
+	search.UpdatedAt = time.Unix(updatedAt, 0)
+
+	return &search, nil
+}
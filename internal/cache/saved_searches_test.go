@@ -0,0 +1,99 @@
+package cache
+
+import "testing"
+
+func TestSavedSearches(t *testing.T) {
+	c := newTestCache(t)
+
+	if err := c.SaveSearch(SavedSearch{Name: " "}); err == nil {
+		t.Fatal("expected error for empty name")
+	}
+
+	err := c.SaveSearch(SavedSearch{Name: "unused-ips", Term: "RESERVED", Types: []string{"compute.address"}})
+	if err != nil {
+		t.Fatalf("SaveSearch failed: %v", err)
+	}
+	if err := c.SaveSearch(SavedSearch{Name: "web", Term: "web", Project: "proj-a"}); err != nil {
+		t.Fatalf("SaveSearch failed: %v", err)
+	}
+
+	saved, ok := c.GetSavedSearch("unused-ips")
+	if !ok {
+		t.Fatal("expected saved search to be found")
+	}
+	if saved.Term != "RESERVED" || len(saved.Types) != 1 || saved.Types[0] != "compute.address" || saved.Project != "" {
+		t.Fatalf("unexpected saved search: %+v", saved)
+	}
+
+	// Saving again replaces the definition.
+	if err := c.SaveSearch(SavedSearch{Name: "web", Term: "^web-", Project: "proj-b", Mode: "regex"}); err != nil {
+		t.Fatalf("SaveSearch failed: %v", err)
+	}
+	if saved, _ := c.GetSavedSearch("web"); saved.Term != "^web-" || saved.Project != "proj-b" || saved.Mode != "regex" {
+		t.Fatalf("expected updated definition, got %+v", saved)
+	}
+
+	searches, err := c.ListSavedSearches()
+	if err != nil {
+		t.Fatalf("ListSavedSearches failed: %v", err)
+	}
+	if len(searches) != 2 || searches[0].Name != "unused-ips" || searches[1].Name != "web" {
+		t.Fatalf("unexpected saved searches: %+v", searches)
+	}
+
+	if _, _, ok := c.GetSavedSearchResults("web"); ok {
+		t.Fatal("expected no results before the first run")
+	}
+
+	results := []SavedSearchResult{{Type: "compute.address", Name: "ip-1", Project: "p", Location: "us-east1", Details: map[string]string{"status": "RESERVED"}}}
+	if err := c.SetSavedSearchResults("web", results); err != nil {
+		t.Fatalf("SetSavedSearchResults failed: %v", err)
+	}
+	stored, runAt, ok := c.GetSavedSearchResults("web")
+	if !ok || runAt.IsZero() {
+		t.Fatal("expected stored results")
+	}
+	if len(stored) != 1 || stored[0].Details["status"] != "RESERVED" {
+		t.Fatalf("unexpected stored results: %+v", stored)
+	}
+
+	if err := c.DeleteSavedSearch("web"); err != nil {
+		t.Fatalf("DeleteSavedSearch failed: %v", err)
+	}
+	if _, ok := c.GetSavedSearch("web"); ok {
+		t.Fatal("expected saved search to be deleted")
+	}
+	if _, _, ok := c.GetSavedSearchResults("web"); ok {
+		t.Fatal("expected results to be deleted with the search")
+	}
+}
+
+func TestSaveSearchResetsResultsWhenDefinitionChanges(t *testing.T) {
+	c := newTestCache(t)
+
+	search := SavedSearch{Name: "web", Term: "web", Types: []string{"compute.instance"}}
+	if err := c.SaveSearch(search); err != nil {
+		t.Fatalf("SaveSearch failed: %v", err)
+	}
+	results := []SavedSearchResult{{Type: "compute.instance", Name: "web-1", Project: "p", Location: "us-east1-b"}}
+	if err := c.SetSavedSearchResults("web", results); err != nil {
+		t.Fatalf("SetSavedSearchResults failed: %v", err)
+	}
+
+	// Saving the same definition again keeps the baseline.
+	if err := c.SaveSearch(search); err != nil {
+		t.Fatalf("SaveSearch failed: %v", err)
+	}
+	if _, _, ok := c.GetSavedSearchResults("web"); !ok {
+		t.Fatal("expected results to be kept for an unchanged definition")
+	}
+
+	// A new term makes the stored run meaningless.
+	search.Term = "api"
+	if err := c.SaveSearch(search); err != nil {
+		t.Fatalf("SaveSearch failed: %v", err)
+	}
+	if _, _, ok := c.GetSavedSearchResults("web"); ok {
+		t.Fatal("expected results of the previous definition to be dropped")
+	}
+}
@@ -0,0 +1,125 @@
+package cache
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kedare/compass/internal/logger"
+)
+
+// DisabledService records an API detected as disabled in a project.
+type DisabledService struct {
+	Project    string
+	Service    string
+	DetectedAt time.Time
+}
+
+// IsServiceDisabled reports whether the service was detected as disabled in the project.
+func (c *Cache) IsServiceDisabled(project, service string) bool {
+	if c.isNoOp() || project == "" || service == "" {
+		return false
+	}
+
+	start := time.Now()
+	defer func() {
+		c.stats.recordOperation("IsServiceDisabled", time.Since(start))
+	}()
+
+	var detectedAt int64
+	err := c.queryRow(
+		"SELECT detected_at FROM disabled_services WHERE project = ? AND service = ?",
+		project, service,
+	).Scan(&detectedAt)
+
+	return err == nil
+}
+
+// MarkServiceDisabled remembers that the service is disabled in the project so
+// later searches can skip it until the project is refreshed.
+func (c *Cache) MarkServiceDisabled(project, service string) error {
+	if c.isNoOp() || project == "" || service == "" {
+		return nil
+	}
+
+	start := time.Now()
+	defer func() {
+		c.stats.recordOperation("MarkServiceDisabled", time.Since(start))
+	}()
+
+	_, err := c.exec(`
+		INSERT INTO disabled_services (project, service, detected_at)
+		VALUES (?, ?, ?)
+		ON CONFLICT(project, service) DO UPDATE SET detected_at = excluded.detected_at`,
+		project, service, time.Now().Unix())
+	if err != nil {
+		return fmt.Errorf("failed to mark %s disabled for project %s: %w", service, project, err)
+	}
+
+	logger.Log.Debugf("Marked service %s as disabled for project %s", service, project)
+
+	return nil
+}
+
+// ClearDisabledServices forgets the disabled services of a project so they are
+// probed again. An empty project clears the entries of every project.
+func (c *Cache) ClearDisabledServices(project string) error {
+	if c.isNoOp() {
+		return nil
+	}
+
+	start := time.Now()
+	defer func() {
+		c.stats.recordOperation("ClearDisabledServices", time.Since(start))
+	}()
+
+	query := "DELETE FROM disabled_services"
+	var args []any
+	if project != "" {
+		query += " WHERE project = ?"
+		args = append(args, project)
+	}
+
+	result, err := c.exec(query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to clear disabled services: %w", err)
+	}
+
+	count, _ := result.RowsAffected()
+	if count > 0 {
+		logger.Log.Debugf("Cleared %d disabled service entries from cache", count)
+	}
+
+	return nil
+}
+
+// DisabledServices returns all services detected as disabled, ordered by project and service.
+func (c *Cache) DisabledServices() ([]DisabledService, error) {
+	if c.isNoOp() {
+		return nil, nil
+	}
+
+	start := time.Now()
+	defer func() {
+		c.stats.recordOperation("DisabledServices", time.Since(start))
+	}()
+
+	rows, err := c.query("SELECT project, service, detected_at FROM disabled_services ORDER BY project, service")
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var services []DisabledService
+	for rows.Next() {
+		var entry DisabledService
+		var detectedAt int64
+		if err := rows.Scan(&entry.Project, &entry.Service, &detectedAt); err != nil {
+			logger.Log.Warnf("Failed to scan disabled service: %v", err)
+			continue
+		}
+		entry.DetectedAt = time.Unix(detectedAt, 0)
+		services = append(services, entry)
+	}
+
+	return services, rows.Err()
+}
@@ -0,0 +1,53 @@
+package cache
+
+import "testing"
+
+func TestDisabledServices(t *testing.T) {
+	c := newTestCache(t)
+
+	if c.IsServiceDisabled("proj-a", "redis.googleapis.com") {
+		t.Fatal("expected service to be enabled initially")
+	}
+
+	if err := c.MarkServiceDisabled("proj-a", "redis.googleapis.com"); err != nil {
+		t.Fatalf("MarkServiceDisabled failed: %v", err)
+	}
+	if err := c.MarkServiceDisabled("proj-a", "redis.googleapis.com"); err != nil {
+		t.Fatalf("MarkServiceDisabled twice failed: %v", err)
+	}
+	if err := c.MarkServiceDisabled("proj-b", "spanner.googleapis.com"); err != nil {
+		t.Fatalf("MarkServiceDisabled failed: %v", err)
+	}
+
+	if !c.IsServiceDisabled("proj-a", "redis.googleapis.com") {
+		t.Fatal("expected service to be disabled")
+	}
+	if c.IsServiceDisabled("proj-b", "redis.googleapis.com") {
+		t.Fatal("expected service to be enabled in another project")
+	}
+
+	services, err := c.DisabledServices()
+	if err != nil {
+		t.Fatalf("DisabledServices failed: %v", err)
+	}
+	if len(services) != 2 || services[0].Project != "proj-a" || services[1].Service != "spanner.googleapis.com" {
+		t.Fatalf("unexpected disabled services: %+v", services)
+	}
+
+	if err := c.ClearDisabledServices("proj-a"); err != nil {
+		t.Fatalf("ClearDisabledServices failed: %v", err)
+	}
+	if c.IsServiceDisabled("proj-a", "redis.googleapis.com") {
+		t.Fatal("expected project entries to be cleared")
+	}
+	if !c.IsServiceDisabled("proj-b", "spanner.googleapis.com") {
+		t.Fatal("expected other project entries to be kept")
+	}
+
+	if err := c.ClearDisabledServices(""); err != nil {
+		t.Fatalf("ClearDisabledServices failed: %v", err)
+	}
+	if c.IsServiceDisabled("proj-b", "spanner.googleapis.com") {
+		t.Fatal("expected all entries to be cleared")
+	}
+}
@@ -23,6 +23,8 @@ const (
 	TTLTypeProjects TTLType = "projects"
 	// TTLTypeSubnets is the TTL for subnet entries.
 	TTLTypeSubnets TTLType = "subnets"
+	// TTLTypeIPHistory is the retention of IP ownership history, from the last sighting.
+	TTLTypeIPHistory TTLType = "ip_history"
 )
 
 // ValidTTLTypes returns all valid TTL types.
@@ -33,6 +35,7 @@ func ValidTTLTypes() []TTLType {
 		TTLTypeZones,
 		TTLTypeProjects,
 		TTLTypeSubnets,
+		TTLTypeIPHistory,
 	}
 }
 
@@ -0,0 +1,66 @@
+package cache
+
+import (
+	"database/sql"
+	"net"
+	"strings"
+)
+
+// sqlExecer is implemented by both *sql.DB and *sql.Tx.
+type sqlExecer interface {
+	Exec(query string, args ...any) (sql.Result, error)
+}
+
+// indexSubnetRanges replaces the indexed ranges of a subnet with the given CIDRs.
+// Each range is stored with 16-byte bounds so IPv4 and IPv6 lookups share the same index.
+func indexSubnetRanges(db sqlExecer, project, network, name string, cidrs []string) error {
+	query := `DELETE FROM subnet_ranges WHERE project = ? AND network = ? AND name = ?`
+	logSQL(query, project, network, name)
+
+	if _, err := db.Exec(query, project, network, name); err != nil {
+		return err
+	}
+
+	query = `INSERT OR IGNORE INTO subnet_ranges (project, network, name, cidr, range_start, range_end)
+		 VALUES (?, ?, ?, ?, ?, ?)`
+
+	for _, cidr := range cidrs {
+		_, ipNet, err := net.ParseCIDR(strings.TrimSpace(cidr))
+		if err != nil || ipNet == nil {
+			continue
+		}
+
+		start, end := rangeBounds(ipNet)
+		logSQL(query, project, network, name, ipNet.String(), start, end)
+
+		if _, err := db.Exec(query, project, network, name, ipNet.String(), start, end); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// rangeBounds returns the first and last address of a network as 16-byte big-endian values,
+// IPv4 networks being mapped into ::ffff:0:0/96.
+func rangeBounds(network *net.IPNet) ([]byte, []byte) {
+	start := make([]byte, net.IPv6len)
+	copy(start, network.IP.To16())
+
+	mask := network.Mask
+	if len(mask) == net.IPv4len {
+		mask = append(net.CIDRMask(96, 128)[:12:12], mask...)
+	}
+
+	end := make([]byte, net.IPv6len)
+	for i := range end {
+		end[i] = start[i] | ^mask[i]
+	}
+
+	return start, end
+}
+
+// ipKey returns the 16-byte form of an address compared against the indexed range bounds.
+func ipKey(ip net.IP) []byte {
+	return []byte(ip.To16())
+}
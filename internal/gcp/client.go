@@ -4,11 +4,9 @@ package gcp
 import (
 	"context"
 	"fmt"
-	"sort"
 
 	"github.com/kedare/compass/internal/cache"
 	"github.com/kedare/compass/internal/logger"
-	"google.golang.org/api/cloudresourcemanager/v1"
 	"google.golang.org/api/compute/v1"
 	"google.golang.org/api/option"
 )
@@ -81,57 +79,6 @@ func (c *Client) ProjectID() string {
 	return c.project
 }
 
-// ListAllProjects lists all GCP projects the user has access to using Cloud Resource Manager API
-func ListAllProjects(ctx context.Context) ([]string, error) {
-	logger.Log.Debug("Listing all accessible GCP projects")
-
-	httpClient, err := newHTTPClientWithLogging(ctx, cloudresourcemanager.CloudPlatformReadOnlyScope)
-	if err != nil {
-		logger.Log.Errorf("Failed to create HTTP client for Resource Manager: %v", err)
-		return nil, fmt.Errorf("failed to create HTTP client: %w", err)
-	}
-
-	service, err := cloudresourcemanager.NewService(ctx, option.WithHTTPClient(httpClient))
-	if err != nil {
-		logger.Log.Errorf("Failed to create Resource Manager service: %v", err)
-		return nil, fmt.Errorf("failed to create resource manager service: %w", err)
-	}
-
-	var projects []string
-	pageToken := ""
-
-	for {
-		call := service.Projects.List()
-		if pageToken != "" {
-			call = call.PageToken(pageToken)
-		}
-
-		resp, err := call.Context(ctx).Do()
-		if err != nil {
-			logger.Log.Errorf("Failed to list projects: %v", err)
-			return nil, fmt.Errorf("failed to list projects: %w", err)
-		}
-
-		for _, project := range resp.Projects {
-			if project.LifecycleState == "ACTIVE" && project.ProjectId != "" {
-				projects = append(projects, project.ProjectId)
-			}
-		}
-
-		if resp.NextPageToken == "" {
-			break
-		}
-		pageToken = resp.NextPageToken
-	}
-
-	logger.Log.Debugf("Found %d active projects", len(projects))
-
-	// Sort projects by name for better UX
-	sort.Strings(projects)
-
-	return projects, nil
-}
-
 // RememberProject persists the client's project in the local cache when available.
 func (c *Client) RememberProject() {
 	if c == nil {
@@ -2,13 +2,20 @@ package gcp
 
 import (
 	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 	"testing"
 
 	"github.com/kedare/compass/internal/cache"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/api/appengine/v1"
+	"google.golang.org/api/cloudfunctions/v2"
 	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/file/v1"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/iam/v1"
+	runv2 "google.golang.org/api/run/v2"
 )
 
 func TestExtractInstanceName(t *testing.T) {
@@ -42,6 +49,232 @@ func TestExtractInstanceName(t *testing.T) {
 	}
 }
 
+func TestServiceAccountProject(t *testing.T) {
+	tests := []struct {
+		name     string
+		email    string
+		expected string
+	}{
+		{name: "user-created account", email: "svc-foo@my-project.iam.gserviceaccount.com", expected: "my-project"},
+		{name: "default compute account", email: "123456789-compute@developer.gserviceaccount.com", expected: ""},
+		{name: "not an email", email: "svc-foo", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, ServiceAccountProject(tt.email))
+		})
+	}
+}
+
+func TestSummarizeServiceAccountKeys(t *testing.T) {
+	count, oldest := summarizeServiceAccountKeys([]*iam.ServiceAccountKey{
+		{ValidAfterTime: "2024-05-01T10:00:00Z"},
+		nil,
+		{ValidAfterTime: "2023-01-15T08:30:00Z"},
+		{ValidAfterTime: "not-a-date"},
+	})
+
+	require.Equal(t, 3, count)
+	require.Equal(t, "2023-01-15T08:30:00Z", oldest.Format("2006-01-02T15:04:05Z07:00"))
+
+	count, oldest = summarizeServiceAccountKeys(nil)
+	require.Zero(t, count)
+	require.True(t, oldest.IsZero())
+}
+
+func TestExtractLocationAndName(t *testing.T) {
+	location, name := extractLocationAndName("projects/p/locations/us-central1/functions/resize")
+	require.Equal(t, "us-central1", location)
+	require.Equal(t, "resize", name)
+
+	location, name = extractLocationAndName("plain-name")
+	require.Empty(t, location)
+	require.Equal(t, "plain-name", name)
+}
+
+func TestExtractResourceCollection(t *testing.T) {
+	require.Equal(t, "targetPools", extractResourceCollection("https://www.googleapis.com/compute/v1/projects/p/regions/us-central1/targetPools/pool"))
+	require.Equal(t, "targetHttpsProxies", extractResourceCollection("projects/p/global/targetHttpsProxies/proxy"))
+	require.Empty(t, extractResourceCollection(""))
+	require.Empty(t, extractResourceCollection("plain-name"))
+}
+
+func TestConvertCloudFunction(t *testing.T) {
+	fn := convertCloudFunction(&cloudfunctions.Function{
+		Name:        "projects/p/locations/europe-west1/functions/on-upload",
+		Environment: "GEN_1",
+		State:       "ACTIVE",
+		BuildConfig: &cloudfunctions.BuildConfig{Runtime: "nodejs20", EntryPoint: "handler"},
+		ServiceConfig: &cloudfunctions.ServiceConfig{
+			IngressSettings: "ALLOW_INTERNAL_ONLY",
+			Uri:             "https://on-upload-xyz.a.run.app",
+		},
+		EventTrigger: &cloudfunctions.EventTrigger{
+			EventType:    "google.cloud.storage.object.v1.finalized",
+			EventFilters: []*cloudfunctions.EventFilter{{Attribute: "bucket", Value: "uploads"}},
+		},
+	})
+
+	require.Equal(t, "on-upload", fn.Name)
+	require.Equal(t, "europe-west1", fn.Region)
+	require.Equal(t, "gen1", fn.Generation)
+	require.Equal(t, "nodejs20", fn.Runtime)
+	require.Equal(t, "event", fn.TriggerType)
+	require.Equal(t, "uploads", fn.TriggerResource)
+	require.Equal(t, "ALLOW_INTERNAL_ONLY", fn.IngressSettings)
+	require.Equal(t, "https://on-upload-xyz.a.run.app", fn.URL)
+
+	fn = convertCloudFunction(&cloudfunctions.Function{
+		Name:        "projects/p/locations/us-central1/functions/api",
+		Environment: "GEN_2",
+		Url:         "https://us-central1-p.cloudfunctions.net/api",
+	})
+	require.Equal(t, "gen2", fn.Generation)
+	require.Equal(t, "http", fn.TriggerType)
+	require.Equal(t, "https://us-central1-p.cloudfunctions.net/api", fn.URL)
+}
+
+func TestConvertCloudRunJob(t *testing.T) {
+	job := convertCloudRunJob(&runv2.GoogleCloudRunV2Job{
+		Name:           "projects/p/locations/us-east1/jobs/nightly",
+		ExecutionCount: 7,
+		Template: &runv2.GoogleCloudRunV2ExecutionTemplate{
+			TaskCount:   3,
+			Parallelism: 1,
+			Template: &runv2.GoogleCloudRunV2TaskTemplate{
+				ServiceAccount: "runner@p.iam.gserviceaccount.com",
+				Containers:     []*runv2.GoogleCloudRunV2Container{{Image: "gcr.io/p/nightly:1"}},
+			},
+		},
+		LatestCreatedExecution: &runv2.GoogleCloudRunV2ExecutionReference{
+			Name:             "projects/p/locations/us-east1/jobs/nightly/executions/nightly-x1",
+			CompletionStatus: "EXECUTION_FAILED",
+			CreateTime:       "2025-01-01T00:00:00Z",
+		},
+	})
+
+	require.Equal(t, "nightly", job.Name)
+	require.Equal(t, "us-east1", job.Region)
+	require.Equal(t, "gcr.io/p/nightly:1", job.Image)
+	require.Equal(t, int64(3), job.TaskCount)
+	require.Equal(t, "nightly-x1", job.LatestExecution)
+	require.Equal(t, "EXECUTION_FAILED", job.LatestExecutionStatus)
+	require.Equal(t, "2025-01-01T00:00:00Z", job.LatestExecutionTime)
+}
+
+func TestConvertAppEngineService(t *testing.T) {
+	app := &appengine.Application{LocationId: "europe-west", DefaultHostname: "p.ew.r.appspot.com"}
+
+	svc := convertAppEngineService(&appengine.Service{
+		Id:              "admin",
+		NetworkSettings: &appengine.NetworkSettings{IngressTrafficAllowed: "INGRESS_TRAFFIC_ALLOWED_INTERNAL_ONLY"},
+		Split:           &appengine.TrafficSplit{Allocations: map[string]float64{"v1": 0.2, "v2": 0.8}},
+	}, app)
+
+	require.Equal(t, "admin", svc.Name)
+	require.Equal(t, "europe-west", svc.Location)
+	require.Equal(t, "https://admin-dot-p.ew.r.appspot.com", svc.URL)
+	require.Equal(t, "INGRESS_TRAFFIC_ALLOWED_INTERNAL_ONLY", svc.IngressSettings)
+	require.Equal(t, "v2", svc.Version)
+
+	applyAppEngineVersion(svc, []*appengine.Version{
+		{Id: "v1", Runtime: "python311", Env: "standard"},
+		{Id: "v2", Runtime: "python312", Env: "flex", ServingStatus: "SERVING"},
+	})
+	require.Equal(t, "python312", svc.Runtime)
+	require.Equal(t, "flexible", svc.Environment)
+	require.Equal(t, "SERVING", svc.ServingStatus)
+	require.Equal(t, 2, svc.VersionCount)
+
+	defaultSvc := convertAppEngineService(&appengine.Service{Id: "default"}, app)
+	require.Equal(t, "https://p.ew.r.appspot.com", defaultSvc.URL)
+
+	// Without traffic split the most recent version is used
+	applyAppEngineVersion(defaultSvc, []*appengine.Version{
+		{Id: "old", Runtime: "go121", CreateTime: "2024-01-01T00:00:00Z"},
+		{Id: "new", Runtime: "go122", CreateTime: "2025-01-01T00:00:00Z"},
+	})
+	require.Equal(t, "new", defaultSvc.Version)
+	require.Equal(t, "go122", defaultSvc.Runtime)
+	require.Equal(t, "standard", defaultSvc.Environment)
+}
+
+func TestConvertFilestoreInstance(t *testing.T) {
+	instance := convertFilestoreInstance(&file.Instance{
+		Name:       "projects/p/locations/us-central1-a/instances/shared-nfs",
+		Tier:       "BASIC_HDD",
+		State:      "READY",
+		FileShares: []*file.FileShareConfig{{Name: "vol1", CapacityGb: 1024}},
+		Networks: []*file.NetworkConfig{
+			{Network: "projects/p/global/networks/prod-vpc", IpAddresses: []string{"10.40.0.2"}},
+			{Network: "projects/p/global/networks/other", IpAddresses: []string{"10.50.0.2"}},
+		},
+	})
+
+	require.Equal(t, "shared-nfs", instance.Name)
+	require.Equal(t, "us-central1-a", instance.Location)
+	require.Equal(t, "prod-vpc", instance.Network)
+	require.Equal(t, []string{"10.40.0.2", "10.50.0.2"}, instance.IPAddresses)
+	require.Equal(t, []string{"vol1 (1024 GB)"}, instance.FileShares)
+}
+
+func TestConvertRouterAndNATStatus(t *testing.T) {
+	router := &compute.Router{
+		Name:    "hybrid-router",
+		Region:  "https://www.googleapis.com/compute/v1/projects/p/regions/europe-west1",
+		Network: "https://www.googleapis.com/compute/v1/projects/p/global/networks/prod-vpc",
+		Bgp: &compute.RouterBgp{
+			Asn:                64512,
+			AdvertiseMode:      "CUSTOM",
+			AdvertisedIpRanges: []*compute.RouterAdvertisedIpRange{{Range: "10.0.0.0/8"}},
+		},
+		BgpPeers: []*compute.RouterBgpPeer{{Name: "peer", PeerAsn: 65010, PeerIpAddress: "169.254.10.2"}},
+		Nats: []*compute.RouterNat{{
+			Name:                "egress-nat",
+			NatIpAllocateOption: "MANUAL_ONLY",
+			NatIps:              []string{"https://www.googleapis.com/compute/v1/projects/p/regions/europe-west1/addresses/nat-ip-1"},
+			LogConfig:           &compute.RouterNatLogConfig{Enable: true, Filter: "ALL"},
+		}},
+	}
+
+	converted := convertRouter(router)
+	require.Equal(t, "europe-west1", converted.Region)
+	require.Equal(t, "prod-vpc", converted.Network)
+	require.Equal(t, int64(64512), converted.ASN)
+	require.Equal(t, []string{"10.0.0.0/8"}, converted.AdvertisedRanges)
+	require.Equal(t, []RouterBGPPeer{{Name: "peer", PeerASN: 65010, PeerIPAddress: "169.254.10.2"}}, converted.BGPPeers)
+	require.Equal(t, []string{"egress-nat"}, converted.NATs)
+
+	nat := convertRouterNAT(router, "europe-west1", router.Nats[0])
+	require.Equal(t, "hybrid-router", nat.Router)
+	require.Equal(t, []string{"nat-ip-1"}, nat.NATIPResources)
+	require.Equal(t, "ALL", nat.LogFilter)
+	require.Empty(t, nat.NATIPs)
+
+	applyRouterNATStatus([]*RouterNAT{nat}, []*compute.RouterStatusNatStatus{
+		{Name: "other-nat", AutoAllocatedNatIps: []string{"35.0.0.1"}},
+		{Name: "egress-nat", UserAllocatedNatIps: []string{"34.1.2.3"}, AutoAllocatedNatIps: []string{"34.1.2.9"}},
+	})
+	require.Equal(t, []string{"34.1.2.3", "34.1.2.9"}, nat.NATIPs)
+}
+
+func TestConvertInterconnectAttachment(t *testing.T) {
+	attachment := convertInterconnectAttachment(&compute.InterconnectAttachment{
+		Name:            "vlan-paris-1",
+		Region:          "https://www.googleapis.com/compute/v1/projects/p/regions/europe-west9",
+		Router:          "https://www.googleapis.com/compute/v1/projects/p/regions/europe-west9/routers/hybrid-router",
+		Type:            "PARTNER",
+		PairingKey:      "key/europe-west9/1",
+		PartnerMetadata: &compute.InterconnectAttachmentPartnerMetadata{PartnerName: "Equinix"},
+	})
+
+	require.Equal(t, "europe-west9", attachment.Region)
+	require.Equal(t, "hybrid-router", attachment.Router)
+	require.Equal(t, "key/europe-west9/1", attachment.PairingKey)
+	require.Equal(t, "Equinix", attachment.PartnerName)
+}
+
 func TestExtractZoneName(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -625,3 +858,35 @@ func TestCacheInstancePreservesIAPPreference(t *testing.T) {
 	require.NotNil(t, stored.IAP)
 	require.True(t, *stored.IAP)
 }
+
+func TestUserLabels(t *testing.T) {
+	require.Nil(t, userLabels(nil))
+	require.Nil(t, userLabels(map[string]string{"cloud.googleapis.com/location": "europe-west1"}))
+
+	got := userLabels(map[string]string{
+		"cloud.googleapis.com/location": "europe-west1",
+		"run.googleapis.com/ingress":    "all",
+		"env":                           "prod",
+		"team":                          "payments",
+	})
+	require.Equal(t, map[string]string{"env": "prod", "team": "payments"}, got)
+}
+
+func TestIsServiceDisabledError(t *testing.T) {
+	disabled := &googleapi.Error{
+		Code:    403,
+		Message: "Cloud Memorystore for Redis API has not been used in project p before or it is disabled.",
+		Errors:  []googleapi.ErrorItem{{Reason: "accessNotConfigured"}},
+	}
+	forbidden := &googleapi.Error{
+		Code:   403,
+		Errors: []googleapi.ErrorItem{{Reason: "forbidden"}},
+	}
+
+	require.True(t, IsServiceDisabledError(disabled))
+	require.True(t, IsServiceDisabledError(fmt.Errorf("failed to list redis instances in p: %w", disabled)))
+	require.True(t, IsServiceDisabledError(errors.New("rpc error: code = PermissionDenied desc = reason: SERVICE_DISABLED")))
+	require.False(t, IsServiceDisabledError(forbidden))
+	require.False(t, IsServiceDisabledError(errors.New("connection reset")))
+	require.False(t, IsServiceDisabledError(nil))
+}
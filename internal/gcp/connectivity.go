@@ -45,6 +45,7 @@ type ConnectivityTestResult struct {
 	Destination               *EndpointInfo
 	ReachabilityDetails       *ReachabilityDetails
 	ReturnReachabilityDetails *ReachabilityDetails
+	Labels                    map[string]string
 	Name                      string
 	ProjectID                 string
 	DisplayName               string
@@ -390,6 +391,7 @@ func (c *ConnectivityClient) convertTestResult(test *networkmanagement.Connectiv
 		Protocol:    test.Protocol,
 		Source:      c.convertEndpoint(test.Source),
 		Destination: c.convertEndpoint(test.Destination),
+		Labels:      test.Labels,
 	}
 
 	if test.ReachabilityDetails != nil {
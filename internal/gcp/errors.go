@@ -0,0 +1,41 @@
+package gcp
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"google.golang.org/api/googleapi"
+)
+
+// serviceDisabledReasons are the error reasons returned when an API is not enabled in a project.
+var serviceDisabledReasons = []string{"SERVICE_DISABLED", "accessNotConfigured"}
+
+// IsServiceDisabledError reports whether err indicates that the called API is
+// disabled (or has never been enabled) in the target project.
+func IsServiceDisabledError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var apiErr *googleapi.Error
+	if errors.As(err, &apiErr) && apiErr.Code == http.StatusForbidden {
+		for _, item := range apiErr.Errors {
+			for _, reason := range serviceDisabledReasons {
+				if item.Reason == reason {
+					return true
+				}
+			}
+		}
+	}
+
+	// gRPC based clients and wrapped errors only expose the reason in the message.
+	msg := err.Error()
+	for _, reason := range serviceDisabledReasons {
+		if strings.Contains(msg, reason) {
+			return true
+		}
+	}
+
+	return strings.Contains(msg, "has not been used in project")
+}
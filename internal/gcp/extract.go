@@ -18,6 +18,19 @@ func extractZoneName(zoneURL string) string {
 	return parts[len(parts)-1]
 }
 
+// extractResourceLocation returns the zone or region of a zonal or regional resource URL
+// (e.g. "us-central1-a" for .../zones/us-central1-a/disks/my-disk), or "" for global ones.
+func extractResourceLocation(url string) string {
+	parts := strings.Split(url, "/")
+	for i, part := range parts {
+		if (part == "zones" || part == "regions") && i+1 < len(parts) {
+			return parts[i+1]
+		}
+	}
+
+	return ""
+}
+
 func extractZoneFromInstanceURL(instanceURL string) string {
 	// Instance URL format: https://www.googleapis.com/compute/v1/projects/PROJECT/zones/ZONE/instances/INSTANCE
 	parts := strings.Split(instanceURL, "/")
@@ -90,6 +103,17 @@ func extractResourceName(url string) string {
 	return parts[len(parts)-1]
 }
 
+// extractResourceCollection returns the collection segment of a resource URL
+// (e.g. "targetPools" for .../regions/us-central1/targetPools/my-pool).
+func extractResourceCollection(url string) string {
+	parts := strings.Split(url, "/")
+	if len(parts) < 2 {
+		return ""
+	}
+
+	return parts[len(parts)-2]
+}
+
 // extractMIGNameFromCreatedBy extracts the MIG name from a created-by metadata URL.
 // The URL format is like:
 // https://www.googleapis.com/compute/v1/projects/my-project/zones/us-central1-a/instanceGroupManagers/my-mig
@@ -158,3 +182,49 @@ func extractHealthCheckPort(hc *compute.HealthCheck) int64 {
 
 	return 0
 }
+
+// ServiceAccountProject returns the project owning a user-created service account, derived from
+// its email (<name>@<project>.iam.gserviceaccount.com). Google-managed accounts such as the
+// default compute account only expose the project number, so an empty string is returned.
+func ServiceAccountProject(email string) string {
+	const suffix = ".iam.gserviceaccount.com"
+
+	at := strings.LastIndex(email, "@")
+	if at < 0 || !strings.HasSuffix(email, suffix) {
+		return ""
+	}
+
+	return strings.TrimSuffix(email[at+1:], suffix)
+}
+
+// extractLocationAndName splits a location-scoped resource name
+// (projects/PROJECT/locations/LOCATION/COLLECTION/NAME) into its location and short name.
+func extractLocationAndName(resourceName string) (string, string) {
+	parts := strings.Split(resourceName, "/")
+	location := ""
+	for i := 0; i+1 < len(parts); i++ {
+		if parts[i] == "locations" {
+			location = parts[i+1]
+			break
+		}
+	}
+
+	return location, parts[len(parts)-1]
+}
+
+// userLabels returns a copy of labels without the system labels that Google
+// attaches to serverless resources (e.g. "cloud.googleapis.com/location").
+func userLabels(labels map[string]string) map[string]string {
+	var result map[string]string
+	for k, v := range labels {
+		if strings.Contains(k, "googleapis.com/") {
+			continue
+		}
+		if result == nil {
+			result = make(map[string]string, len(labels))
+		}
+		result[k] = v
+	}
+
+	return result
+}
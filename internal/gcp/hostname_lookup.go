@@ -0,0 +1,226 @@
+package gcp
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/kedare/compass/internal/logger"
+	"google.golang.org/api/dns/v1"
+)
+
+const (
+	// HostnameSourceSystem marks an address returned by the system resolver.
+	HostnameSourceSystem = "system"
+	// HostnameSourceCloudDNS marks an address found in a private Cloud DNS zone.
+	HostnameSourceCloudDNS = "cloud_dns"
+
+	// dnsServiceName is the API name used to remember projects where Cloud DNS is disabled.
+	dnsServiceName = "dns.googleapis.com"
+
+	// maxCNAMEHops bounds the CNAME chain followed inside Cloud DNS zones.
+	maxCNAMEHops = 8
+)
+
+// HostnameAddress is an address a hostname resolves to, along with what produced it.
+type HostnameAddress struct {
+	Hostname string `json:"hostname"`
+	Address  string `json:"address"`
+	// Source is HostnameSourceSystem or HostnameSourceCloudDNS.
+	Source  string `json:"source"`
+	Project string `json:"project,omitempty"`
+	Zone    string `json:"zone,omitempty"`
+	// Record is the name and type of the record holding the address, e.g. "db.internal.example.com. A".
+	Record string `json:"record,omitempty"`
+	// CNAMEs lists the aliases followed from the hostname to Record.
+	CNAMEs []string `json:"cnames,omitempty"`
+}
+
+// ResolveHostnames looks the hostnames up in the private Cloud DNS zones of the project,
+// which resolvers outside of the attached VPC networks cannot see, following CNAME records
+// between them. Projects where the Cloud DNS API is disabled resolve nothing.
+func (c *Client) ResolveHostnames(ctx context.Context, hostnames []string) ([]HostnameAddress, error) {
+	if c == nil {
+		return nil, fmt.Errorf("client is not initialized")
+	}
+
+	if c.cache != nil && c.cache.IsServiceDisabled(c.project, dnsServiceName) {
+		logger.Log.Debugf("[%s] Cloud DNS is disabled, skipping hostname resolution", c.project)
+
+		return nil, nil
+	}
+
+	dnsService, err := c.newDNSService(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	zones, err := c.listDNSManagedZones(ctx, dnsService)
+	if err != nil {
+		if IsServiceDisabledError(err) {
+			if c.cache != nil {
+				_ = c.cache.MarkServiceDisabled(c.project, dnsServiceName)
+			}
+
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	zones = privateRecordZones(zones)
+	if len(zones) == 0 {
+		return nil, nil
+	}
+
+	results := make([]HostnameAddress, 0)
+	for _, hostname := range hostnames {
+		fqdn := canonicalFQDN(hostname)
+		if fqdn == "" {
+			continue
+		}
+
+		addresses, err := c.resolveInZones(ctx, dnsService, zones, fqdn)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, addresses...)
+	}
+
+	return results, nil
+}
+
+// resolveInZones returns the A and AAAA records of the name in the zones, following CNAME
+// records until one resolves.
+func (c *Client) resolveInZones(ctx context.Context, dnsService *dns.Service, zones []*dns.ManagedZone, fqdn string) ([]HostnameAddress, error) {
+	results := make([]HostnameAddress, 0)
+	seen := make(map[string]struct{})
+	var cnames []string
+
+	for name := fqdn; len(cnames) <= maxCNAMEHops; {
+		var next string
+		for _, zone := range zonesForName(zones, name) {
+			rrsets, err := dnsService.ResourceRecordSets.List(c.project, zone.Name).Name(name).Context(ctx).Do()
+			if err != nil {
+				return nil, fmt.Errorf("failed to list dns record sets in zone %s: %w", zone.Name, err)
+			}
+
+			for _, rrset := range rrsets.Rrsets {
+				if rrset == nil {
+					continue
+				}
+
+				switch rrset.Type {
+				case "A", "AAAA":
+					for _, address := range recordAddresses(rrset) {
+						if _, ok := seen[address]; ok {
+							continue
+						}
+						seen[address] = struct{}{}
+
+						results = append(results, HostnameAddress{
+							Hostname: strings.TrimSuffix(fqdn, "."),
+							Address:  address,
+							Source:   HostnameSourceCloudDNS,
+							Project:  c.project,
+							Zone:     zone.Name,
+							Record:   fmt.Sprintf("%s %s", rrset.Name, rrset.Type),
+							CNAMEs:   append([]string(nil), cnames...),
+						})
+					}
+				case "CNAME":
+					if next == "" && len(rrset.Rrdatas) > 0 {
+						next = canonicalFQDN(rrset.Rrdatas[0])
+					}
+				}
+			}
+		}
+
+		if len(results) > 0 || next == "" {
+			break
+		}
+
+		cnames = append(cnames, next)
+		name = next
+	}
+
+	return results, nil
+}
+
+// privateRecordZones returns the private zones holding records, leaving out forwarding and
+// peering zones which delegate resolution elsewhere.
+func privateRecordZones(zones []*dns.ManagedZone) []*dns.ManagedZone {
+	filtered := make([]*dns.ManagedZone, 0, len(zones))
+	for _, zone := range zones {
+		if zone == nil || !strings.EqualFold(zone.Visibility, "private") {
+			continue
+		}
+
+		if zone.ForwardingConfig != nil || zone.PeeringConfig != nil {
+			continue
+		}
+
+		filtered = append(filtered, zone)
+	}
+
+	return filtered
+}
+
+// zonesForName returns the zones whose domain contains the fully qualified name.
+func zonesForName(zones []*dns.ManagedZone, fqdn string) []*dns.ManagedZone {
+	matches := make([]*dns.ManagedZone, 0, 1)
+	for _, zone := range zones {
+		domain := canonicalFQDN(zone.DnsName)
+		if domain == "" {
+			continue
+		}
+
+		if fqdn == domain || strings.HasSuffix(fqdn, "."+domain) {
+			matches = append(matches, zone)
+		}
+	}
+
+	return matches
+}
+
+// recordAddresses returns the canonical addresses of an A or AAAA record set, including the
+// ones of geo and weighted round robin routing policies.
+func recordAddresses(rrset *dns.ResourceRecordSet) []string {
+	rrdatas := append([]string(nil), rrset.Rrdatas...)
+	if policy := rrset.RoutingPolicy; policy != nil {
+		if policy.Geo != nil {
+			for _, item := range policy.Geo.Items {
+				if item != nil {
+					rrdatas = append(rrdatas, item.Rrdatas...)
+				}
+			}
+		}
+		if policy.Wrr != nil {
+			for _, item := range policy.Wrr.Items {
+				if item != nil {
+					rrdatas = append(rrdatas, item.Rrdatas...)
+				}
+			}
+		}
+	}
+
+	addresses := make([]string, 0, len(rrdatas))
+	for _, value := range rrdatas {
+		if ip := net.ParseIP(strings.TrimSpace(value)); ip != nil {
+			addresses = append(addresses, ip.String())
+		}
+	}
+
+	return addresses
+}
+
+// canonicalFQDN lowercases a hostname and terminates it with a dot, as Cloud DNS names are.
+func canonicalFQDN(hostname string) string {
+	hostname = strings.ToLower(strings.TrimSuffix(strings.TrimSpace(hostname), "."))
+	if hostname == "" {
+		return ""
+	}
+
+	return hostname + "."
+}
@@ -0,0 +1,53 @@
+package gcp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/dns/v1"
+)
+
+func TestZonesForName(t *testing.T) {
+	zones := privateRecordZones([]*dns.ManagedZone{
+		{Name: "internal", DnsName: "internal.example.com.", Visibility: "private"},
+		{Name: "db", DnsName: "db.internal.example.com.", Visibility: "private"},
+		{Name: "public", DnsName: "example.com.", Visibility: "public"},
+		{Name: "forward", DnsName: "corp.example.com.", Visibility: "private", ForwardingConfig: &dns.ManagedZoneForwardingConfig{}},
+		{Name: "other", DnsName: "myinternal.example.com.", Visibility: "private"},
+	})
+	require.Len(t, zones, 3, "public, forwarding and peering zones are left out")
+
+	names := func(matches []*dns.ManagedZone) []string {
+		result := make([]string, 0, len(matches))
+		for _, zone := range matches {
+			result = append(result, zone.Name)
+		}
+
+		return result
+	}
+
+	require.Equal(t, []string{"internal", "db"}, names(zonesForName(zones, "primary.db.internal.example.com.")))
+	require.Equal(t, []string{"internal"}, names(zonesForName(zones, "internal.example.com.")))
+	require.Empty(t, zonesForName(zones, "www.example.com."))
+}
+
+func TestRecordAddresses(t *testing.T) {
+	rrset := &dns.ResourceRecordSet{
+		Name:    "api.internal.example.com.",
+		Type:    "AAAA",
+		Rrdatas: []string{"FD20:0:0:1::5", "not-an-ip"},
+		RoutingPolicy: &dns.RRSetRoutingPolicy{
+			Geo: &dns.RRSetRoutingPolicyGeoPolicy{Items: []*dns.RRSetRoutingPolicyGeoPolicyGeoPolicyItem{
+				{Location: "europe-west1", Rrdatas: []string{"fd20:0:0:2::5"}},
+			}},
+		},
+	}
+
+	require.Equal(t, []string{"fd20:0:0:1::5", "fd20:0:0:2::5"}, recordAddresses(rrset))
+}
+
+func TestCanonicalFQDN(t *testing.T) {
+	require.Equal(t, "db.internal.example.com.", canonicalFQDN(" DB.Internal.example.com "))
+	require.Equal(t, "db.internal.example.com.", canonicalFQDN("db.internal.example.com."))
+	require.Empty(t, canonicalFQDN(" "))
+}
@@ -81,6 +81,10 @@ func (t loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 			}
 		}
 
+		if err := apiLimiter.Wait(req.Context(), req.URL.Host); err != nil {
+			return nil, err
+		}
+
 		start := time.Now()
 		resp, err = base.RoundTrip(req)
 		elapsed := time.Since(start)
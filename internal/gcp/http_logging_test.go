@@ -2,10 +2,12 @@ package gcp
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"io"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -49,6 +51,28 @@ func TestLoggingTransportError(t *testing.T) {
 	require.ErrorIs(t, err, sentinel)
 }
 
+func TestLoggingTransportAppliesAPIRateLimit(t *testing.T) {
+	previous := apiLimiter
+	apiLimiter = &hostLimiter{rate: 0.001}
+	t.Cleanup(func() { apiLimiter = previous })
+
+	stub := &stubRoundTripper{resp: &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}}
+	transport := loggingTransport{base: stub}
+
+	req, _ := http.NewRequest(http.MethodGet, "https://compute.googleapis.com/compute/v1/projects/p/zones", nil)
+	_, err := transport.RoundTrip(req)
+	require.NoError(t, err)
+	require.Same(t, req, stub.req)
+
+	stub.req = nil
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err = transport.RoundTrip(req.WithContext(ctx))
+	require.ErrorIs(t, err, context.DeadlineExceeded, "the second request to the host waits for a token")
+	require.Nil(t, stub.req)
+}
+
 func TestAttachLoggingTransport(t *testing.T) {
 	client := &http.Client{}
 	attachLoggingTransport(client)
@@ -0,0 +1,237 @@
+package gcp
+
+import (
+	"context"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/kedare/compass/internal/cache"
+	"github.com/kedare/compass/internal/logger"
+	"google.golang.org/api/compute/v1"
+)
+
+// recordIPAssociations adds the discovered associations to the IP history of the cache.
+// Subnet matches are left out: a subnet contains an address but does not own it.
+func (c *Client) recordIPAssociations(associations []IPAssociation) {
+	if c == nil || c.cache == nil || len(associations) == 0 {
+		return
+	}
+
+	sightings := make([]cache.IPSighting, 0, len(associations))
+	for _, assoc := range associations {
+		if assoc.Kind == IPAssociationSubnet || net.ParseIP(assoc.IPAddress) == nil {
+			continue
+		}
+
+		sightings = append(sightings, cache.IPSighting{
+			IP:           assoc.IPAddress,
+			Project:      assoc.Project,
+			Kind:         string(assoc.Kind),
+			Resource:     assoc.Resource,
+			Location:     assoc.Location,
+			Details:      assoc.Details,
+			ResourceLink: assoc.ResourceLink,
+		})
+	}
+
+	if err := c.cache.RecordIPSightings(sightings, time.Now()); err != nil {
+		logger.Log.Warnf("Failed to record IP history for project %s: %v", c.project, err)
+	}
+}
+
+// scanIPAddresses records the owner of every address of the project in the IP history and
+// returns the number of addresses seen. listed, when set, holds the instances and subnets the
+// scan already listed, which are reused instead of being listed again.
+func (c *Client) scanIPAddresses(ctx context.Context, listed *ipInventory) (int, error) {
+	if c.cache == nil {
+		return 0, nil
+	}
+
+	inventory, err := c.fetchIPInventory(ctx, true, listed)
+	if err != nil {
+		return 0, err
+	}
+
+	associations := c.inventoryAssociations(inventory)
+	c.recordIPAssociations(associations)
+
+	addresses := make(map[string]struct{}, len(associations))
+	for _, assoc := range associations {
+		addresses[assoc.IPAddress] = struct{}{}
+	}
+
+	return len(addresses), nil
+}
+
+// inventoryAssociations returns the owners of the addresses held by the resources of the
+// inventory, in a single pass over them: instance interfaces, forwarding rules, reserved
+// addresses, Cloud SQL instances, Cloud NAT gateways and Cloud VPN gateways and peers.
+func (c *Client) inventoryAssociations(inventory *ipInventory) []IPAssociation {
+	results := make([]IPAssociation, 0)
+	seen := make(map[string]struct{})
+
+	// each calls match with every distinct address of one resource
+	each := func(values []string, match func(ip net.IP, canonical string)) {
+		done := make(map[string]struct{}, len(values))
+		for _, value := range values {
+			ip := net.ParseIP(strings.TrimSpace(value))
+			if ip == nil {
+				continue
+			}
+
+			canonical := ip.String()
+			if _, ok := done[canonical]; ok {
+				continue
+			}
+			done[canonical] = struct{}{}
+
+			match(ip, canonical)
+		}
+	}
+
+	for _, item := range inventory.instances {
+		each(instanceAddresses(item.instance), func(ip net.IP, canonical string) {
+			for _, match := range instanceIPMatches(item.instance, ip) {
+				if match.kind == IPAssociationAliasRange {
+					continue
+				}
+
+				appendAssociation(&results, seen, IPAssociation{
+					Project:      c.project,
+					Kind:         match.kind,
+					Resource:     item.instance.Name,
+					Location:     item.location,
+					IPAddress:    canonical,
+					Details:      match.details,
+					Network:      match.network,
+					Subnet:       match.subnet,
+					ResourceLink: item.instance.SelfLink,
+				})
+			}
+		})
+	}
+
+	for _, item := range inventory.forwardingRules {
+		each([]string{item.rule.IPAddress}, func(_ net.IP, canonical string) {
+			appendAssociation(&results, seen, IPAssociation{
+				Project:      c.project,
+				Kind:         forwardingRuleKind(item.rule),
+				Resource:     item.rule.Name,
+				Location:     item.location,
+				IPAddress:    canonical,
+				Details:      describeForwardingRule(item.rule),
+				Network:      lastComponent(item.rule.Network),
+				Subnet:       lastComponent(item.rule.Subnetwork),
+				ResourceLink: item.rule.SelfLink,
+			})
+		})
+	}
+
+	for _, item := range inventory.addresses {
+		each([]string{item.address.Address}, func(_ net.IP, canonical string) {
+			appendAssociation(&results, seen, IPAssociation{
+				Project:      c.project,
+				Kind:         IPAssociationAddress,
+				Resource:     item.address.Name,
+				Location:     item.location,
+				IPAddress:    canonical,
+				Details:      describeAddress(item.address),
+				Network:      lastComponent(item.address.Network),
+				Subnet:       lastComponent(item.address.Subnetwork),
+				ResourceLink: item.address.SelfLink,
+			})
+		})
+	}
+
+	services := inventory.services
+	if services == nil {
+		return results
+	}
+
+	addMatches := func(matches []serviceMatch, canonical string) {
+		for _, match := range matches {
+			appendAssociation(&results, seen, c.serviceAssociation(match, canonical))
+		}
+	}
+
+	for _, instance := range services.sqlInstances {
+		if instance == nil {
+			continue
+		}
+
+		values := make([]string, 0, len(instance.IpAddresses))
+		for _, mapping := range instance.IpAddresses {
+			if mapping != nil {
+				values = append(values, mapping.IpAddress)
+			}
+		}
+		each(values, func(ip net.IP, canonical string) {
+			addMatches(sqlInstanceMatches(instance, ip), canonical)
+		})
+	}
+
+	for _, nat := range services.nats {
+		if nat == nil {
+			continue
+		}
+
+		each(nat.NATIPs, func(ip net.IP, canonical string) {
+			addMatches(natMatches(nat, ip), canonical)
+		})
+	}
+
+	for _, gateway := range services.gateways {
+		if gateway == nil {
+			continue
+		}
+
+		values := make([]string, 0, len(gateway.Interfaces))
+		for _, iface := range gateway.Interfaces {
+			if iface != nil {
+				values = append(values, iface.IpAddress)
+			}
+		}
+		each(values, func(ip net.IP, canonical string) {
+			addMatches(vpnGatewayMatches(gateway, ip), canonical)
+		})
+	}
+
+	for _, tunnel := range services.tunnels {
+		if tunnel == nil {
+			continue
+		}
+
+		each([]string{tunnel.PeerIP}, func(ip net.IP, canonical string) {
+			addMatches(vpnTunnelMatches(tunnel, ip), canonical)
+		})
+	}
+
+	return results
+}
+
+// instanceAddresses returns the internal and external addresses of the interfaces of an instance.
+func instanceAddresses(inst *compute.Instance) []string {
+	var values []string
+	for _, nic := range inst.NetworkInterfaces {
+		if nic == nil {
+			continue
+		}
+
+		values = append(values, nic.NetworkIP, nic.Ipv6Address)
+
+		for _, cfg := range nic.AccessConfigs {
+			if cfg != nil {
+				values = append(values, cfg.NatIP)
+			}
+		}
+
+		for _, cfg := range nic.Ipv6AccessConfigs {
+			if cfg != nil {
+				values = append(values, cfg.ExternalIpv6)
+			}
+		}
+	}
+
+	return values
+}
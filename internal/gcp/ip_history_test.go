@@ -0,0 +1,79 @@
+package gcp
+
+import (
+	"testing"
+
+	"github.com/kedare/compass/internal/cache"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/sqladmin/v1beta4"
+)
+
+func TestInventoryAssociations(t *testing.T) {
+	inventory := &ipInventory{
+		instances: []scopedInstance{{
+			location: "us-central1-a",
+			instance: &compute.Instance{
+				Name: "vm-1",
+				NetworkInterfaces: []*compute.NetworkInterface{{
+					Name:          "nic0",
+					Network:       "projects/proj/global/networks/vpc",
+					Subnetwork:    "projects/proj/regions/us-central1/subnetworks/subnet-a",
+					NetworkIP:     "10.0.0.5",
+					AccessConfigs: []*compute.AccessConfig{{NatIP: "34.1.2.3"}, nil},
+					AliasIpRanges: []*compute.AliasIpRange{{IpCidrRange: "10.4.0.0/24"}},
+				}, nil},
+			},
+		}},
+		forwardingRules: []scopedForwardingRule{{location: "us-central1", rule: &compute.ForwardingRule{Name: "fr", IPAddress: "10.0.0.9"}}},
+		addresses:       []scopedAddress{{location: "us-central1", address: &compute.Address{Name: "addr", Address: "10.0.0.9"}}},
+		services: &serviceInventory{
+			sqlInstances: []*sqladmin.DatabaseInstance{{Name: "db", IpAddresses: []*sqladmin.IpMapping{{IpAddress: "10.8.0.3"}}}},
+			nats:         []*RouterNAT{{Name: "nat", NATIPs: []string{"35.1.2.3"}}},
+			gateways:     []*VPNGatewayInfo{{Name: "gw", Interfaces: []*compute.VpnGatewayVpnGatewayInterface{{IpAddress: "35.2.0.1"}}}},
+			tunnels:      []*VPNTunnelInfo{{Name: "tunnel", PeerIP: "203.0.113.10"}, nil},
+		},
+	}
+
+	client := &Client{project: "proj"}
+	owners := make([]string, 0)
+	for _, assoc := range client.inventoryAssociations(inventory) {
+		owners = append(owners, assoc.IPAddress+" "+string(assoc.Kind)+" "+assoc.Resource)
+	}
+
+	require.Equal(t, []string{
+		"10.0.0.5 instance_internal vm-1",
+		"34.1.2.3 instance_external vm-1",
+		"10.0.0.9 forwarding_rule fr",
+		"10.0.0.9 address addr",
+		"10.8.0.3 cloud_sql db",
+		"35.1.2.3 cloud_nat nat",
+		"35.2.0.1 vpn_gateway gw",
+		"203.0.113.10 vpn_peer tunnel",
+	}, owners)
+
+	internal := client.inventoryAssociations(inventory)[0]
+	require.Equal(t, "vpc", internal.Network)
+	require.Equal(t, "subnet-a", internal.Subnet)
+}
+
+func TestRecordIPAssociations(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	c, err := cache.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = c.Close() })
+
+	client := &Client{cache: c, project: "proj"}
+	client.recordIPAssociations([]IPAssociation{
+		{Project: "proj", Kind: IPAssociationInstanceInternal, Resource: "vm-1", Location: "us-central1-a", IPAddress: "10.0.0.5"},
+		{Project: "proj", Kind: IPAssociationSubnet, Resource: "subnet-a", Location: "us-central1", IPAddress: "10.0.0.5"},
+		{Project: "proj", Kind: IPAssociationAliasRange, Resource: "vm-2", Location: "us-central1-a", IPAddress: "10.4.0.0/24"},
+	})
+
+	history, err := c.GetIPHistory("10.0.0.5")
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	require.Equal(t, "vm-1", history[0].Resource)
+	require.Equal(t, string(IPAssociationInstanceInternal), history[0].Kind)
+}
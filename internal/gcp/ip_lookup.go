@@ -27,9 +27,30 @@ const (
 	IPAssociationAddress IPAssociationKind = "address"
 	// IPAssociationSubnet indicates the IP falls within a subnet CIDR range.
 	IPAssociationSubnet IPAssociationKind = "subnet_range"
+	// IPAssociationAliasRange indicates the IP falls within an alias IP range of a Compute Engine VM.
+	IPAssociationAliasRange IPAssociationKind = "alias_ip_range"
+	// IPAssociationPSCEndpoint indicates the IP belongs to a Private Service Connect endpoint.
+	IPAssociationPSCEndpoint IPAssociationKind = "psc_endpoint"
+	// IPAssociationGKEPodRange indicates the IP falls within the pod range of a GKE cluster or node pool.
+	IPAssociationGKEPodRange IPAssociationKind = "gke_pod_range"
+	// IPAssociationGKEServiceRange indicates the IP falls within the service range of a GKE cluster.
+	IPAssociationGKEServiceRange IPAssociationKind = "gke_service_range"
+	// IPAssociationGKEEndpoint indicates the IP is a GKE control plane endpoint or within its range.
+	IPAssociationGKEEndpoint IPAssociationKind = "gke_control_plane"
+	// IPAssociationCloudSQL indicates the IP belongs to a Cloud SQL instance.
+	IPAssociationCloudSQL IPAssociationKind = "cloud_sql"
+	// IPAssociationCloudNAT indicates the IP is used by a Cloud NAT gateway.
+	IPAssociationCloudNAT IPAssociationKind = "cloud_nat"
+	// IPAssociationVPNGateway indicates the IP belongs to an interface of an HA VPN gateway.
+	IPAssociationVPNGateway IPAssociationKind = "vpn_gateway"
+	// IPAssociationVPNPeer indicates the IP is the remote peer of a Cloud VPN tunnel.
+	IPAssociationVPNPeer IPAssociationKind = "vpn_peer"
+	// IPAssociationRouterInterface indicates the IP belongs to a Cloud Router interface or BGP peer.
+	IPAssociationRouterInterface IPAssociationKind = "router_interface"
 )
 
 // IPAssociation captures metadata about a resource that owns or references an IP address.
+// Network and Subnet name the VPC network and subnet of the resource, when it has any.
 type IPAssociation struct {
 	Project      string            `json:"project"`
 	Kind         IPAssociationKind `json:"kind"`
@@ -37,11 +58,14 @@ type IPAssociation struct {
 	Location     string            `json:"location"`
 	IPAddress    string            `json:"ip_address"`
 	Details      string            `json:"details,omitempty"`
+	Network      string            `json:"network,omitempty"`
+	Subnet       string            `json:"subnet,omitempty"`
 	ResourceLink string            `json:"resource_link,omitempty"`
 }
 
 // LookupIPAddress searches for resources in the current project that reference the provided IP address.
-// It inspects Compute Engine instances, forwarding rules, and address reservations. The IP must be a
+// It inspects Compute Engine instances, forwarding rules, address reservations, subnets and managed
+// services (GKE clusters, Cloud SQL, Cloud NAT, Cloud VPN and Cloud Router interfaces). The IP must be a
 // valid IPv4 or IPv6 string. Returns all matching resources, sorted by project, kind, and resource name.
 func (c *Client) LookupIPAddress(ctx context.Context, ip string) ([]IPAssociation, error) {
 	if c == nil || c.service == nil {
@@ -76,6 +100,7 @@ func (c *Client) LookupIPAddress(ctx context.Context, ip string) ([]IPAssociatio
 		forwardingResults []IPAssociation
 		addressResults    []IPAssociation
 		subnetResults     []IPAssociation
+		serviceResults    []IPAssociation
 	)
 
 	group.Go(func() error {
@@ -108,7 +133,7 @@ func (c *Client) LookupIPAddress(ctx context.Context, ip string) ([]IPAssociatio
 		return nil
 	})
 
-	if targetIP.IsPrivate() {
+	if inSubnetRange(targetIP) {
 		group.Go(func() error {
 			results, err := c.collectSubnetMatches(groupCtx, targetIP, canonicalIP)
 			if err != nil {
@@ -120,11 +145,21 @@ func (c *Client) LookupIPAddress(ctx context.Context, ip string) ([]IPAssociatio
 		})
 	}
 
+	group.Go(func() error {
+		results, err := c.collectServiceMatches(groupCtx, targetIP, canonicalIP)
+		if err != nil {
+			return fmt.Errorf("failed to inspect managed services: %w", err)
+		}
+		serviceResults = results
+
+		return nil
+	})
+
 	if err := group.Wait(); err != nil {
 		return nil, err
 	}
 
-	results := make([]IPAssociation, 0, len(instanceResults)+len(forwardingResults)+len(addressResults)+len(subnetResults))
+	results := make([]IPAssociation, 0, len(instanceResults)+len(forwardingResults)+len(addressResults)+len(subnetResults)+len(serviceResults))
 	seen := make(map[string]struct{}, len(results))
 
 	for _, association := range instanceResults {
@@ -143,17 +178,12 @@ func (c *Client) LookupIPAddress(ctx context.Context, ip string) ([]IPAssociatio
 		appendAssociation(&results, seen, association)
 	}
 
-	sort.Slice(results, func(i, j int) bool {
-		if results[i].Project == results[j].Project {
-			if results[i].Kind == results[j].Kind {
-				return results[i].Resource < results[j].Resource
-			}
-
-			return results[i].Kind < results[j].Kind
-		}
+	for _, association := range serviceResults {
+		appendAssociation(&results, seen, association)
+	}
 
-		return results[i].Project < results[j].Project
-	})
+	sortAssociationsByResource(results)
+	c.recordIPAssociations(results)
 
 	return results, nil
 }
@@ -198,6 +228,8 @@ func (c *Client) collectInstanceIPMatches(ctx context.Context, target net.IP, ca
 							Location:     location,
 							IPAddress:    canonical,
 							Details:      match.details,
+							Network:      match.network,
+							Subnet:       match.subnet,
 							ResourceLink: inst.SelfLink,
 						}
 
@@ -248,17 +280,19 @@ func (c *Client) collectForwardingRuleMatches(ctx context.Context, target net.IP
 					continue
 				}
 
-				if !equalIP(rule.IPAddress, target) {
+				if !forwardingRuleMatches(rule, target) {
 					continue
 				}
 
 				association := IPAssociation{
 					Project:      c.project,
-					Kind:         IPAssociationForwardingRule,
+					Kind:         forwardingRuleKind(rule),
 					Resource:     rule.Name,
 					Location:     location,
 					IPAddress:    canonical,
 					Details:      describeForwardingRule(rule),
+					Network:      lastComponent(rule.Network),
+					Subnet:       lastComponent(rule.Subnetwork),
 					ResourceLink: rule.SelfLink,
 				}
 
@@ -307,7 +341,7 @@ func (c *Client) collectAddressMatches(ctx context.Context, target net.IP, canon
 					continue
 				}
 
-				if !equalIP(addr.Address, target) {
+				if !reservedAddressMatches(addr, target) {
 					continue
 				}
 
@@ -318,6 +352,8 @@ func (c *Client) collectAddressMatches(ctx context.Context, target net.IP, canon
 					Location:     location,
 					IPAddress:    canonical,
 					Details:      describeAddress(addr),
+					Network:      lastComponent(addr.Network),
+					Subnet:       lastComponent(addr.Subnetwork),
 					ResourceLink: addr.SelfLink,
 				}
 
@@ -365,40 +401,7 @@ func (c *Client) collectSubnetMatches(ctx context.Context, target net.IP, canoni
 					continue
 				}
 
-				if c.cache != nil {
-					secondary := make([]cache.SubnetSecondaryRange, 0, len(subnet.SecondaryIpRanges))
-					for _, sr := range subnet.SecondaryIpRanges {
-						if sr == nil {
-							continue
-						}
-
-						cidr := strings.TrimSpace(sr.IpCidrRange)
-						if cidr == "" {
-							continue
-						}
-
-						secondary = append(secondary, cache.SubnetSecondaryRange{
-							Name: strings.TrimSpace(sr.RangeName),
-							CIDR: cidr,
-						})
-					}
-
-					entry := &cache.SubnetEntry{
-						Project:         c.project,
-						Network:         lastComponent(subnet.Network),
-						Region:          lastComponent(subnet.Region),
-						Name:            subnet.Name,
-						SelfLink:        strings.TrimSpace(subnet.SelfLink),
-						PrimaryCIDR:     strings.TrimSpace(subnet.IpCidrRange),
-						SecondaryRanges: secondary,
-						IPv6CIDR:        strings.TrimSpace(subnet.Ipv6CidrRange),
-						Gateway:         strings.TrimSpace(subnet.GatewayAddress),
-					}
-
-					if err := c.cache.RememberSubnet(entry); err != nil {
-						logger.Log.Debugf("Failed to cache subnet %s in project %s: %v", subnet.Name, c.project, err)
-					}
-				}
+				c.rememberSubnet(subnet)
 
 				matched, detail := subnetMatchDetails(subnet, target)
 				if !matched {
@@ -412,6 +415,8 @@ func (c *Client) collectSubnetMatches(ctx context.Context, target net.IP, canoni
 					Location:     location,
 					IPAddress:    canonical,
 					Details:      detail,
+					Network:      lastComponent(subnet.Network),
+					Subnet:       subnet.Name,
 					ResourceLink: subnet.SelfLink,
 				}
 
@@ -429,10 +434,56 @@ func (c *Client) collectSubnetMatches(ctx context.Context, target net.IP, canoni
 	return results, nil
 }
 
+// rememberSubnet stores a listed subnet in the cache so later lookups can pick projects from it.
+func (c *Client) rememberSubnet(subnet *compute.Subnetwork) {
+	if c.cache == nil {
+		return
+	}
+
+	if err := c.cache.RememberSubnet(c.subnetEntry(subnet)); err != nil {
+		logger.Log.Debugf("Failed to cache subnet %s in project %s: %v", subnet.Name, c.project, err)
+	}
+}
+
+// subnetEntry converts a subnet of the project to its cached form.
+func (c *Client) subnetEntry(subnet *compute.Subnetwork) *cache.SubnetEntry {
+	secondary := make([]cache.SubnetSecondaryRange, 0, len(subnet.SecondaryIpRanges))
+	for _, sr := range subnet.SecondaryIpRanges {
+		if sr == nil {
+			continue
+		}
+
+		cidr := strings.TrimSpace(sr.IpCidrRange)
+		if cidr == "" {
+			continue
+		}
+
+		secondary = append(secondary, cache.SubnetSecondaryRange{
+			Name: strings.TrimSpace(sr.RangeName),
+			CIDR: cidr,
+		})
+	}
+
+	return &cache.SubnetEntry{
+		Project:          c.project,
+		Network:          lastComponent(subnet.Network),
+		Region:           lastComponent(subnet.Region),
+		Name:             subnet.Name,
+		SelfLink:         strings.TrimSpace(subnet.SelfLink),
+		PrimaryCIDR:      strings.TrimSpace(subnet.IpCidrRange),
+		SecondaryRanges:  secondary,
+		IPv6CIDR:         strings.TrimSpace(subnet.Ipv6CidrRange),
+		ExternalIPv6CIDR: strings.TrimSpace(subnet.ExternalIpv6Prefix),
+		Gateway:          strings.TrimSpace(subnet.GatewayAddress),
+	}
+}
+
 // ipMatch captures the association kind and descriptive text for a matched interface.
 type ipMatch struct {
 	kind    IPAssociationKind
 	details string
+	network string
+	subnet  string
 }
 
 // instanceIPMatches returns instance interface matches for the provided IP address.
@@ -449,31 +500,16 @@ func instanceIPMatches(inst *compute.Instance, target net.IP) []ipMatch {
 			continue
 		}
 
-		if equalIP(nic.NetworkIP, target) || equalIP(nic.Ipv6Address, target) {
-			if nic.Name != "" {
-				descParts = append(descParts, fmt.Sprintf("Internal interface %s", nic.Name))
-			} else {
-				descParts = append(descParts, "Internal interface")
-			}
+		network, subnet := lastComponent(nic.Network), lastComponent(nic.Subnetwork)
 
-			networkName := lastComponent(nic.Network)
-			if networkName != "" {
-				descParts = append(descParts, fmt.Sprintf("network=%s", networkName))
-			}
-
-			subnetName := lastComponent(nic.Subnetwork)
-			if subnetName != "" {
-				descParts = append(descParts, fmt.Sprintf("subnet=%s", subnetName))
-			}
-
-			subnetLink := strings.TrimSpace(nic.Subnetwork)
-			if subnetLink != "" {
-				descParts = append(descParts, fmt.Sprintf("subnet_link=%s", subnetLink))
-			}
+		if equalIP(nic.NetworkIP, target) || equalIP(nic.Ipv6Address, target) {
+			descParts = append(descParts, internalInterfaceParts(nic)...)
 
 			matches = append(matches, ipMatch{
 				kind:    IPAssociationInstanceInternal,
 				details: strings.Join(descParts, ", "),
+				network: network,
+				subnet:  subnet,
 			})
 		}
 
@@ -483,14 +519,41 @@ func instanceIPMatches(inst *compute.Instance, target net.IP) []ipMatch {
 			}
 
 			if equalIP(cfg.NatIP, target) {
-				desc := "External interface"
-				if cfg.Name != "" {
-					desc = fmt.Sprintf("External access config %s", cfg.Name)
-				}
+				matches = append(matches, ipMatch{
+					kind:    IPAssociationInstanceExternal,
+					details: describeAccessConfig(cfg),
+					network: network,
+					subnet:  subnet,
+				})
+			}
+		}
 
+		for _, cfg := range nic.Ipv6AccessConfigs {
+			if cfg == nil {
+				continue
+			}
+
+			if equalIP(cfg.ExternalIpv6, target) || ipv6RangeContains(cfg.ExternalIpv6, cfg.ExternalIpv6PrefixLength, target) {
 				matches = append(matches, ipMatch{
 					kind:    IPAssociationInstanceExternal,
-					details: desc,
+					details: describeAccessConfig(cfg),
+					network: network,
+					subnet:  subnet,
+				})
+			}
+		}
+
+		for _, alias := range nic.AliasIpRanges {
+			if alias == nil {
+				continue
+			}
+
+			if _, ok := rangeOverlapsPrefix(alias.IpCidrRange, nic.NetworkIP, hostPrefix(target)); ok {
+				matches = append(matches, ipMatch{
+					kind:    IPAssociationAliasRange,
+					details: strings.Join(aliasRangeParts(nic, alias), ", "),
+					network: network,
+					subnet:  subnet,
 				})
 			}
 		}
@@ -499,6 +562,39 @@ func instanceIPMatches(inst *compute.Instance, target net.IP) []ipMatch {
 	return matches
 }
 
+// internalInterfaceParts describes an internal network interface as detail segments.
+func internalInterfaceParts(nic *compute.NetworkInterface) []string {
+	parts := make([]string, 0, 4)
+	if nic.Name != "" {
+		parts = append(parts, fmt.Sprintf("Internal interface %s", nic.Name))
+	} else {
+		parts = append(parts, "Internal interface")
+	}
+
+	if networkName := lastComponent(nic.Network); networkName != "" {
+		parts = append(parts, fmt.Sprintf("network=%s", networkName))
+	}
+
+	if subnetName := lastComponent(nic.Subnetwork); subnetName != "" {
+		parts = append(parts, fmt.Sprintf("subnet=%s", subnetName))
+	}
+
+	if subnetLink := strings.TrimSpace(nic.Subnetwork); subnetLink != "" {
+		parts = append(parts, fmt.Sprintf("subnet_link=%s", subnetLink))
+	}
+
+	return parts
+}
+
+// describeAccessConfig summarizes an external access config for display.
+func describeAccessConfig(cfg *compute.AccessConfig) string {
+	if cfg.Name != "" {
+		return fmt.Sprintf("External access config %s", cfg.Name)
+	}
+
+	return "External interface"
+}
+
 // describeForwardingRule summarizes forwarding rule metadata for display.
 func describeForwardingRule(rule *compute.ForwardingRule) string {
 	if rule == nil {
@@ -628,6 +724,55 @@ func equalIP(value string, target net.IP) bool {
 	return parsed.Equal(target)
 }
 
+// inSubnetRange reports whether an address may belong to a subnet range: private IPv4
+// addresses and any IPv6 address, as subnets also hold external IPv6 ranges.
+func inSubnetRange(ip net.IP) bool {
+	return ip.IsPrivate() || (ip.To4() == nil && ip.To16() != nil)
+}
+
+// forwardingRuleMatches reports whether a forwarding rule serves the target address. IPv6
+// rules may hold a range such as "2600:1900:4001:a2b:8000::/96" instead of a single address.
+func forwardingRuleMatches(rule *compute.ForwardingRule, target net.IP) bool {
+	if rule == nil {
+		return false
+	}
+
+	return equalIP(rule.IPAddress, target) || ipv6RangeContains(rule.IPAddress, 0, target)
+}
+
+// reservedAddressMatches reports whether a reserved address is the target address or, for an
+// IPv6 reservation, whether its range contains it.
+func reservedAddressMatches(addr *compute.Address, target net.IP) bool {
+	if addr == nil {
+		return false
+	}
+
+	return equalIP(addr.Address, target) || ipv6RangeContains(addr.Address, addr.PrefixLength, target)
+}
+
+// ipv6RangeContains reports whether the IPv6 range given as "address/length", or as an address
+// and prefix length, contains the target.
+func ipv6RangeContains(value string, prefixLength int64, target net.IP) bool {
+	if target == nil {
+		return false
+	}
+
+	value = strings.TrimSpace(value)
+	if !strings.Contains(value, "/") {
+		if prefixLength <= 0 {
+			return false
+		}
+		value = fmt.Sprintf("%s/%d", value, prefixLength)
+	}
+
+	ip, network, err := net.ParseCIDR(value)
+	if err != nil || ip.To4() != nil {
+		return false
+	}
+
+	return network.Contains(target)
+}
+
 func ipInCIDR(target net.IP, cidr string) bool {
 	if target == nil {
 		return false
@@ -686,36 +831,48 @@ func subnetMatchDetails(subnet *compute.Subnetwork, target net.IP) (bool, string
 		}
 	}
 
+	if matchedCIDR == "" {
+		if cidr := strings.TrimSpace(subnet.ExternalIpv6Prefix); cidr != "" && ipInCIDR(target, cidr) {
+			matchedCIDR = cidr
+			source = "external_ipv6"
+		}
+	}
+
 	if matchedCIDR == "" {
 		return false, ""
 	}
 
-	detailParts := make([]string, 0, 4)
+	detailParts := subnetRangeParts(subnet, matchedCIDR, source)
+
+	if equalIP(subnet.GatewayAddress, target) {
+		detailParts = append(detailParts, "gateway=true")
+	}
+
+	return true, strings.Join(detailParts, ", ")
+}
+
+// subnetRangeParts describes one range of a subnet as detail segments.
+func subnetRangeParts(subnet *compute.Subnetwork, cidr, source string) []string {
+	parts := make([]string, 0, 5)
 	if networkName := lastComponent(subnet.Network); networkName != "" {
-		detailParts = append(detailParts, fmt.Sprintf("network=%s", networkName))
+		parts = append(parts, fmt.Sprintf("network=%s", networkName))
 	}
 
-	detailParts = append(detailParts, fmt.Sprintf("cidr=%s", matchedCIDR))
+	parts = append(parts, fmt.Sprintf("cidr=%s", cidr))
 	if source != "" {
-		detailParts = append(detailParts, fmt.Sprintf("range=%s", source))
+		parts = append(parts, fmt.Sprintf("range=%s", source))
 	}
 
-	gateway := strings.TrimSpace(subnet.GatewayAddress)
-	if gateway != "" {
-		detailParts = append(detailParts, fmt.Sprintf("gateway_ip=%s", gateway))
-		if equalIP(gateway, target) {
-			detailParts = append(detailParts, "gateway=true")
-		}
-	} else if subnet.GatewayAddress != "" && equalIP(subnet.GatewayAddress, target) {
-		detailParts = append(detailParts, "gateway=true")
+	if gateway := strings.TrimSpace(subnet.GatewayAddress); gateway != "" {
+		parts = append(parts, fmt.Sprintf("gateway_ip=%s", gateway))
 	}
 
-	return true, strings.Join(detailParts, ", ")
+	return parts
 }
 
 // appendAssociation adds a unique association to the results slice while preventing duplicates.
 func appendAssociation(results *[]IPAssociation, seen map[string]struct{}, association IPAssociation) {
-	key := fmt.Sprintf("%s|%s|%s|%s", association.Project, association.Kind, association.Resource, association.Location)
+	key := fmt.Sprintf("%s|%s|%s|%s|%s", association.Project, association.Kind, association.Resource, association.Location, association.IPAddress)
 	if _, exists := seen[key]; exists {
 		return
 	}
@@ -726,3 +883,18 @@ func appendAssociation(results *[]IPAssociation, seen map[string]struct{}, assoc
 	logger.Log.Tracef("Matched IP %s with %s %s in project %s (%s)",
 		association.IPAddress, association.Kind, association.Resource, association.Project, association.Location)
 }
+
+// sortAssociationsByResource orders associations by project, kind and resource name.
+func sortAssociationsByResource(results []IPAssociation) {
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Project == results[j].Project {
+			if results[i].Kind == results[j].Kind {
+				return results[i].Resource < results[j].Resource
+			}
+
+			return results[i].Kind < results[j].Kind
+		}
+
+		return results[i].Project < results[j].Project
+	})
+}
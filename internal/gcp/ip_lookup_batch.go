@@ -0,0 +1,378 @@
+package gcp
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/api/compute/v1"
+)
+
+// ipInventory holds the resources of a project that can own IP addresses, fetched once
+// so that many addresses can be looked up without listing them again.
+type ipInventory struct {
+	instances       []scopedInstance
+	forwardingRules []scopedForwardingRule
+	addresses       []scopedAddress
+	subnets         []scopedSubnet
+	services        *serviceInventory
+}
+
+type scopedInstance struct {
+	location string
+	instance *compute.Instance
+}
+
+type scopedForwardingRule struct {
+	location string
+	rule     *compute.ForwardingRule
+}
+
+type scopedAddress struct {
+	location string
+	address  *compute.Address
+}
+
+type scopedSubnet struct {
+	location string
+	subnet   *compute.Subnetwork
+}
+
+// LookupIPAddresses looks up several IP addresses or CIDR prefixes in the project, listing
+// its instances, forwarding rules, addresses, subnets and managed services only once. The result maps each
+// target, as given, to its associations (nil when nothing references it), sorted like
+// LookupIPAddress for addresses and LookupCIDR for prefixes.
+func (c *Client) LookupIPAddresses(ctx context.Context, targets []string) (map[string][]IPAssociation, error) {
+	if c == nil || c.service == nil {
+		return nil, fmt.Errorf("client is not initialized")
+	}
+
+	for _, target := range targets {
+		if _, ok := ParseIPPrefix(target); !ok && net.ParseIP(strings.TrimSpace(target)) == nil {
+			return nil, fmt.Errorf("invalid IP address %q", target)
+		}
+	}
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	// Managed services only hold single addresses, prefixes are matched without them
+	withServices := false
+	for _, target := range targets {
+		if _, ok := ParseIPPrefix(target); !ok {
+			withServices = true
+
+			break
+		}
+	}
+
+	inventory, err := c.fetchIPInventory(ctx, withServices, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make(map[string][]IPAssociation, len(targets))
+	var discovered []IPAssociation
+	for _, target := range targets {
+		results[target] = c.matchInventory(inventory, target)
+		discovered = append(discovered, results[target]...)
+	}
+
+	c.recordIPAssociations(discovered)
+
+	return results, nil
+}
+
+// fetchIPInventory lists the project resources that can own IP addresses, caching its subnets.
+// Managed services are only listed when withServices is set. When listed is set, its instances
+// and subnets, already listed by the caller, are used instead of being listed again.
+func (c *Client) fetchIPInventory(ctx context.Context, withServices bool, listed *ipInventory) (*ipInventory, error) {
+	inventory := &ipInventory{}
+	if listed != nil {
+		inventory.instances = listed.instances
+		inventory.subnets = listed.subnets
+	}
+
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(3)
+
+	listInstances := func() error {
+		err := c.service.Instances.AggregatedList(c.project).Context(groupCtx).Pages(groupCtx, func(page *compute.InstanceAggregatedList) error {
+			for scope, scopedList := range page.Items {
+				location := locationFromScope(scope)
+				for _, inst := range scopedList.Instances {
+					if inst != nil {
+						inventory.instances = append(inventory.instances, scopedInstance{location: location, instance: inst})
+					}
+				}
+			}
+
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to inspect instances: %w", err)
+		}
+
+		return nil
+	}
+	if listed == nil {
+		group.Go(listInstances)
+	}
+
+	group.Go(func() error {
+		err := c.service.ForwardingRules.AggregatedList(c.project).Context(groupCtx).Pages(groupCtx, func(page *compute.ForwardingRuleAggregatedList) error {
+			for scope, scopedList := range page.Items {
+				location := locationFromScope(scope)
+				for _, rule := range scopedList.ForwardingRules {
+					if rule != nil {
+						inventory.forwardingRules = append(inventory.forwardingRules, scopedForwardingRule{location: location, rule: rule})
+					}
+				}
+			}
+
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to inspect forwarding rules: %w", err)
+		}
+
+		return nil
+	})
+
+	group.Go(func() error {
+		err := c.service.Addresses.AggregatedList(c.project).Context(groupCtx).Pages(groupCtx, func(page *compute.AddressAggregatedList) error {
+			for scope, scopedList := range page.Items {
+				location := locationFromScope(scope)
+				for _, addr := range scopedList.Addresses {
+					if addr != nil {
+						inventory.addresses = append(inventory.addresses, scopedAddress{location: location, address: addr})
+					}
+				}
+			}
+
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to inspect addresses: %w", err)
+		}
+
+		return nil
+	})
+
+	listSubnets := func() error {
+		err := c.service.Subnetworks.AggregatedList(c.project).Context(groupCtx).Pages(groupCtx, func(page *compute.SubnetworkAggregatedList) error {
+			for scope, scopedList := range page.Items {
+				location := locationFromScope(scope)
+				for _, subnet := range scopedList.Subnetworks {
+					if subnet != nil {
+						c.rememberSubnet(subnet)
+						inventory.subnets = append(inventory.subnets, scopedSubnet{location: location, subnet: subnet})
+					}
+				}
+			}
+
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to inspect subnets: %w", err)
+		}
+
+		return nil
+	}
+	if listed == nil {
+		group.Go(listSubnets)
+	}
+
+	if withServices {
+		group.Go(func() error {
+			services, err := c.fetchServiceInventory(groupCtx, nil)
+			if err != nil {
+				return fmt.Errorf("failed to inspect managed services: %w", err)
+			}
+			inventory.services = services
+
+			return nil
+		})
+	}
+
+	if err := group.Wait(); err != nil {
+		return nil, err
+	}
+
+	return inventory, nil
+}
+
+// matchInventory returns the associations of a single IP address or CIDR prefix.
+func (c *Client) matchInventory(inventory *ipInventory, target string) []IPAssociation {
+	if prefix, ok := ParseIPPrefix(target); ok {
+		return c.matchInventoryPrefix(inventory, prefix)
+	}
+
+	ip := net.ParseIP(strings.TrimSpace(target))
+	if ip == nil {
+		return nil
+	}
+
+	canonical := ip.String()
+	results := make([]IPAssociation, 0)
+	seen := make(map[string]struct{})
+
+	for _, item := range inventory.instances {
+		for _, match := range instanceIPMatches(item.instance, ip) {
+			appendAssociation(&results, seen, IPAssociation{
+				Project:      c.project,
+				Kind:         match.kind,
+				Resource:     item.instance.Name,
+				Location:     item.location,
+				IPAddress:    canonical,
+				Details:      match.details,
+				Network:      match.network,
+				Subnet:       match.subnet,
+				ResourceLink: item.instance.SelfLink,
+			})
+		}
+	}
+
+	for _, item := range inventory.forwardingRules {
+		if forwardingRuleMatches(item.rule, ip) {
+			appendAssociation(&results, seen, IPAssociation{
+				Project:      c.project,
+				Kind:         forwardingRuleKind(item.rule),
+				Resource:     item.rule.Name,
+				Location:     item.location,
+				IPAddress:    canonical,
+				Details:      describeForwardingRule(item.rule),
+				Network:      lastComponent(item.rule.Network),
+				Subnet:       lastComponent(item.rule.Subnetwork),
+				ResourceLink: item.rule.SelfLink,
+			})
+		}
+	}
+
+	for _, item := range inventory.addresses {
+		if reservedAddressMatches(item.address, ip) {
+			appendAssociation(&results, seen, IPAssociation{
+				Project:      c.project,
+				Kind:         IPAssociationAddress,
+				Resource:     item.address.Name,
+				Location:     item.location,
+				IPAddress:    canonical,
+				Details:      describeAddress(item.address),
+				Network:      lastComponent(item.address.Network),
+				Subnet:       lastComponent(item.address.Subnetwork),
+				ResourceLink: item.address.SelfLink,
+			})
+		}
+	}
+
+	if inSubnetRange(ip) {
+		for _, item := range inventory.subnets {
+			if matched, detail := subnetMatchDetails(item.subnet, ip); matched {
+				appendAssociation(&results, seen, IPAssociation{
+					Project:      c.project,
+					Kind:         IPAssociationSubnet,
+					Resource:     item.subnet.Name,
+					Location:     item.location,
+					IPAddress:    canonical,
+					Details:      detail,
+					Network:      lastComponent(item.subnet.Network),
+					Subnet:       item.subnet.Name,
+					ResourceLink: item.subnet.SelfLink,
+				})
+			}
+		}
+	}
+
+	for _, association := range c.serviceAssociations(inventory.services, ip, canonical) {
+		appendAssociation(&results, seen, association)
+	}
+
+	if len(results) == 0 {
+		return nil
+	}
+
+	sortAssociationsByResource(results)
+
+	return results
+}
+
+// matchInventoryPrefix returns the associations inside a CIDR prefix, see LookupCIDR.
+func (c *Client) matchInventoryPrefix(inventory *ipInventory, prefix *net.IPNet) []IPAssociation {
+	results := make([]IPAssociation, 0)
+	seen := make(map[string]struct{})
+
+	for _, item := range inventory.instances {
+		for _, match := range instancePrefixMatches(item.instance, prefix) {
+			appendAssociation(&results, seen, IPAssociation{
+				Project:      c.project,
+				Kind:         match.kind,
+				Resource:     item.instance.Name,
+				Location:     item.location,
+				IPAddress:    match.address,
+				Details:      match.details,
+				Network:      match.network,
+				Subnet:       match.subnet,
+				ResourceLink: item.instance.SelfLink,
+			})
+		}
+	}
+
+	for _, item := range inventory.forwardingRules {
+		if address, ok := forwardingRuleInPrefix(item.rule, prefix); ok {
+			appendAssociation(&results, seen, IPAssociation{
+				Project:      c.project,
+				Kind:         forwardingRuleKind(item.rule),
+				Resource:     item.rule.Name,
+				Location:     item.location,
+				IPAddress:    address,
+				Details:      describeForwardingRule(item.rule),
+				Network:      lastComponent(item.rule.Network),
+				Subnet:       lastComponent(item.rule.Subnetwork),
+				ResourceLink: item.rule.SelfLink,
+			})
+		}
+	}
+
+	for _, item := range inventory.addresses {
+		if address, ok := reservedAddressInPrefix(item.address, prefix); ok {
+			appendAssociation(&results, seen, IPAssociation{
+				Project:      c.project,
+				Kind:         IPAssociationAddress,
+				Resource:     item.address.Name,
+				Location:     item.location,
+				IPAddress:    address,
+				Details:      describeAddress(item.address),
+				Network:      lastComponent(item.address.Network),
+				Subnet:       lastComponent(item.address.Subnetwork),
+				ResourceLink: item.address.SelfLink,
+			})
+		}
+	}
+
+	for _, item := range inventory.subnets {
+		for _, match := range subnetPrefixMatches(item.subnet, prefix) {
+			appendAssociation(&results, seen, IPAssociation{
+				Project:      c.project,
+				Kind:         IPAssociationSubnet,
+				Resource:     item.subnet.Name,
+				Location:     item.location,
+				IPAddress:    match.address,
+				Details:      match.details,
+				Network:      lastComponent(item.subnet.Network),
+				Subnet:       item.subnet.Name,
+				ResourceLink: item.subnet.SelfLink,
+			})
+		}
+	}
+
+	if len(results) == 0 {
+		return nil
+	}
+
+	SortAssociationsByAddress(results)
+
+	return results
+}
@@ -0,0 +1,61 @@
+package gcp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/compute/v1"
+)
+
+func TestMatchInventory(t *testing.T) {
+	client := &Client{project: "proj"}
+	inventory := &ipInventory{
+		instances: []scopedInstance{{
+			location: "us-central1-a",
+			instance: &compute.Instance{
+				Name: "vm-1",
+				NetworkInterfaces: []*compute.NetworkInterface{{
+					Name:       "nic0",
+					NetworkIP:  "10.0.0.5",
+					Network:    "projects/proj/global/networks/vpc",
+					Subnetwork: "projects/proj/regions/us-central1/subnetworks/subnet-a",
+				}},
+			},
+		}},
+		forwardingRules: []scopedForwardingRule{{
+			location: "us-central1",
+			rule:     &compute.ForwardingRule{Name: "lb", IPAddress: "10.0.0.9"},
+		}},
+		addresses: []scopedAddress{{
+			location: "us-central1",
+			address:  &compute.Address{Name: "lb-ip", Address: "10.0.0.9", PrefixLength: 32},
+		}},
+		subnets: []scopedSubnet{{
+			location: "us-central1",
+			subnet: &compute.Subnetwork{
+				Name:        "subnet-a",
+				IpCidrRange: "10.0.0.0/24",
+				Network:     "projects/proj/global/networks/vpc",
+			},
+		}},
+	}
+
+	matches := client.matchInventory(inventory, "10.0.0.9")
+	require.Len(t, matches, 3)
+	require.Equal(t, IPAssociationAddress, matches[0].Kind)
+	require.Equal(t, IPAssociationForwardingRule, matches[1].Kind)
+	require.Equal(t, IPAssociationSubnet, matches[2].Kind)
+
+	matches = client.matchInventory(inventory, "10.0.0.5")
+	require.Len(t, matches, 2)
+	require.Equal(t, "vm-1", matches[0].Resource)
+
+	require.Nil(t, client.matchInventory(inventory, "203.0.113.1"))
+
+	matches = client.matchInventory(inventory, "10.0.0.0/28")
+	require.NotEmpty(t, matches)
+	require.Equal(t, "10.0.0.0/24", matches[0].IPAddress)
+	for _, match := range matches[1:] {
+		require.NotEqual(t, IPAssociationSubnet, match.Kind)
+	}
+}
@@ -0,0 +1,352 @@
+package gcp
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+
+	"google.golang.org/api/compute/v1"
+)
+
+// ParseIPPrefix parses a CIDR prefix such as "10.20.0.0/22", returning false for
+// anything else, including plain IP addresses.
+func ParseIPPrefix(value string) (*net.IPNet, bool) {
+	value = strings.TrimSpace(value)
+	if !strings.Contains(value, "/") {
+		return nil, false
+	}
+
+	_, prefix, err := net.ParseCIDR(value)
+	if err != nil || prefix == nil {
+		return nil, false
+	}
+
+	return prefix, true
+}
+
+// LookupCIDR searches for resources in the current project whose addresses fall inside the
+// provided CIDR prefix: instance interfaces, alias IP ranges, forwarding rules, reserved
+// addresses and overlapping subnet ranges. Each association carries the matching address
+// (or range for alias IP ranges and subnets) and results are sorted by address.
+func (c *Client) LookupCIDR(ctx context.Context, cidr string) ([]IPAssociation, error) {
+	if c == nil || c.service == nil {
+		return nil, fmt.Errorf("client is not initialized")
+	}
+
+	prefix, ok := ParseIPPrefix(cidr)
+	if !ok {
+		return nil, fmt.Errorf("invalid CIDR prefix %q", cidr)
+	}
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	inventory, err := c.fetchIPInventory(ctx, false, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	results := c.matchInventoryPrefix(inventory, prefix)
+	if results == nil {
+		results = make([]IPAssociation, 0)
+	}
+
+	c.recordIPAssociations(results)
+
+	return results, nil
+}
+
+// prefixMatch captures an address or range found inside a looked up prefix.
+type prefixMatch struct {
+	kind    IPAssociationKind
+	address string
+	details string
+	network string
+	subnet  string
+}
+
+// instancePrefixMatches returns the interface addresses and alias IP ranges of an instance inside the prefix.
+func instancePrefixMatches(inst *compute.Instance, prefix *net.IPNet) []prefixMatch {
+	if inst == nil || prefix == nil {
+		return nil
+	}
+
+	matches := make([]prefixMatch, 0)
+	for _, nic := range inst.NetworkInterfaces {
+		if nic == nil {
+			continue
+		}
+
+		network, subnet := lastComponent(nic.Network), lastComponent(nic.Subnetwork)
+
+		for _, value := range []string{nic.NetworkIP, nic.Ipv6Address} {
+			if address, ok := addressInPrefix(value, prefix); ok {
+				matches = append(matches, prefixMatch{
+					kind:    IPAssociationInstanceInternal,
+					address: address,
+					details: strings.Join(internalInterfaceParts(nic), ", "),
+					network: network,
+					subnet:  subnet,
+				})
+			}
+		}
+
+		for _, cfg := range nic.AccessConfigs {
+			if cfg == nil {
+				continue
+			}
+
+			if address, ok := addressInPrefix(cfg.NatIP, prefix); ok {
+				matches = append(matches, prefixMatch{
+					kind:    IPAssociationInstanceExternal,
+					address: address,
+					details: describeAccessConfig(cfg),
+					network: network,
+					subnet:  subnet,
+				})
+			}
+		}
+
+		for _, cfg := range nic.Ipv6AccessConfigs {
+			if cfg == nil {
+				continue
+			}
+
+			if address, ok := externalIPv6InPrefix(cfg, prefix); ok {
+				matches = append(matches, prefixMatch{
+					kind:    IPAssociationInstanceExternal,
+					address: address,
+					details: describeAccessConfig(cfg),
+					network: network,
+					subnet:  subnet,
+				})
+			}
+		}
+
+		for _, alias := range nic.AliasIpRanges {
+			if alias == nil {
+				continue
+			}
+
+			aliasRange, ok := rangeOverlapsPrefix(alias.IpCidrRange, nic.NetworkIP, prefix)
+			if !ok {
+				continue
+			}
+
+			matches = append(matches, prefixMatch{
+				kind:    IPAssociationAliasRange,
+				address: aliasRange.String(),
+				details: strings.Join(aliasRangeParts(nic, alias), ", "),
+				network: network,
+				subnet:  subnet,
+			})
+		}
+	}
+
+	return matches
+}
+
+// subnetPrefixMatches returns the ranges of a subnet overlapping the prefix.
+func subnetPrefixMatches(subnet *compute.Subnetwork, prefix *net.IPNet) []prefixMatch {
+	if subnet == nil || prefix == nil {
+		return nil
+	}
+
+	matches := make([]prefixMatch, 0)
+	add := func(cidr, source string) {
+		network, ok := rangeOverlapsPrefix(cidr, "", prefix)
+		if !ok {
+			return
+		}
+
+		matches = append(matches, prefixMatch{
+			kind:    IPAssociationSubnet,
+			address: network.String(),
+			network: lastComponent(subnet.Network),
+			subnet:  subnet.Name,
+			details: strings.Join(subnetRangeParts(subnet, network.String(), source), ", "),
+		})
+	}
+
+	add(subnet.IpCidrRange, "primary")
+	for _, sec := range subnet.SecondaryIpRanges {
+		if sec == nil {
+			continue
+		}
+
+		source := "secondary"
+		if sec.RangeName != "" {
+			source = fmt.Sprintf("secondary:%s", sec.RangeName)
+		}
+		add(sec.IpCidrRange, source)
+	}
+	add(subnet.Ipv6CidrRange, "ipv6")
+	add(subnet.ExternalIpv6Prefix, "external_ipv6")
+
+	return matches
+}
+
+// reservedAddressInPrefix reports whether a reserved address, or the range it reserves
+// (e.g. for VPC peering), is inside the prefix.
+func reservedAddressInPrefix(addr *compute.Address, prefix *net.IPNet) (string, bool) {
+	if addr.PrefixLength > 0 {
+		network, ok := rangeOverlapsPrefix(fmt.Sprintf("%s/%d", strings.TrimSpace(addr.Address), addr.PrefixLength), "", prefix)
+		if !ok {
+			return "", false
+		}
+
+		return network.String(), true
+	}
+
+	return addressInPrefix(addr.Address, prefix)
+}
+
+// externalIPv6InPrefix reports whether the external IPv6 range of an access config is inside the prefix.
+func externalIPv6InPrefix(cfg *compute.AccessConfig, prefix *net.IPNet) (string, bool) {
+	if cfg.ExternalIpv6PrefixLength > 0 {
+		network, ok := rangeOverlapsPrefix(fmt.Sprintf("%s/%d", strings.TrimSpace(cfg.ExternalIpv6), cfg.ExternalIpv6PrefixLength), "", prefix)
+		if !ok {
+			return "", false
+		}
+
+		return network.String(), true
+	}
+
+	return addressInPrefix(cfg.ExternalIpv6, prefix)
+}
+
+// forwardingRuleInPrefix reports whether the address of a forwarding rule, or the IPv6 range
+// it serves, is inside the prefix.
+func forwardingRuleInPrefix(rule *compute.ForwardingRule, prefix *net.IPNet) (string, bool) {
+	if value := strings.TrimSpace(rule.IPAddress); strings.Contains(value, "/") {
+		network, ok := rangeOverlapsPrefix(value, "", prefix)
+		if !ok {
+			return "", false
+		}
+
+		return network.String(), true
+	}
+
+	return addressInPrefix(rule.IPAddress, prefix)
+}
+
+// addressInPrefix returns the canonical form of value when it is an IP inside the prefix.
+func addressInPrefix(value string, prefix *net.IPNet) (string, bool) {
+	ip := net.ParseIP(strings.TrimSpace(value))
+	if ip == nil || !prefix.Contains(ip) {
+		return "", false
+	}
+
+	return ip.String(), true
+}
+
+// rangeOverlapsPrefix parses a CIDR range and reports whether it overlaps the prefix.
+// Alias IP ranges may be given as a bare mask ("/32"), which is resolved against base.
+func rangeOverlapsPrefix(cidr, base string, prefix *net.IPNet) (*net.IPNet, bool) {
+	cidr = strings.TrimSpace(cidr)
+	if strings.HasPrefix(cidr, "/") {
+		cidr = strings.TrimSpace(base) + cidr
+	}
+
+	_, network, err := net.ParseCIDR(cidr)
+	if err != nil || network == nil || prefix == nil {
+		return nil, false
+	}
+
+	if !network.Contains(prefix.IP) && !prefix.Contains(network.IP) {
+		return nil, false
+	}
+
+	return network, true
+}
+
+// aliasRangeParts describes an alias IP range of an interface as detail segments.
+func aliasRangeParts(nic *compute.NetworkInterface, alias *compute.AliasIpRange) []string {
+	parts := []string{fmt.Sprintf("Alias IP range on %s", nicLabel(nic))}
+	if networkName := lastComponent(nic.Network); networkName != "" {
+		parts = append(parts, fmt.Sprintf("network=%s", networkName))
+	}
+	if subnetName := lastComponent(nic.Subnetwork); subnetName != "" {
+		parts = append(parts, fmt.Sprintf("subnet=%s", subnetName))
+	}
+	if alias.SubnetworkRangeName != "" {
+		parts = append(parts, fmt.Sprintf("range=secondary:%s", alias.SubnetworkRangeName))
+	} else {
+		parts = append(parts, "range=primary")
+	}
+
+	return parts
+}
+
+// hostPrefix returns the single-address prefix of ip.
+func hostPrefix(ip net.IP) *net.IPNet {
+	if v4 := ip.To4(); v4 != nil {
+		return &net.IPNet{IP: v4, Mask: net.CIDRMask(32, 32)}
+	}
+
+	return &net.IPNet{IP: ip, Mask: net.CIDRMask(128, 128)}
+}
+
+// nicLabel names a network interface for display.
+func nicLabel(nic *compute.NetworkInterface) string {
+	if nic.Name != "" {
+		return fmt.Sprintf("interface %s", nic.Name)
+	}
+
+	return "interface"
+}
+
+// SortAssociationsByAddress orders associations by address, ranges starting at the same
+// address being listed from the widest, then by project, kind and resource name.
+func SortAssociationsByAddress(results []IPAssociation) {
+	sort.SliceStable(results, func(i, j int) bool {
+		ipI, bitsI := associationAddressKey(results[i].IPAddress)
+		ipJ, bitsJ := associationAddressKey(results[j].IPAddress)
+		if cmp := bytes.Compare(ipI, ipJ); cmp != 0 {
+			return cmp < 0
+		}
+
+		if bitsI != bitsJ {
+			return bitsI < bitsJ
+		}
+
+		if results[i].Project != results[j].Project {
+			return results[i].Project < results[j].Project
+		}
+
+		if results[i].Kind != results[j].Kind {
+			return results[i].Kind < results[j].Kind
+		}
+
+		return results[i].Resource < results[j].Resource
+	})
+}
+
+// associationAddressKey returns the sortable 16-byte form of an address or range and its prefix
+// length, single addresses having the longest prefix. Unparsable values sort first.
+func associationAddressKey(value string) (net.IP, int) {
+	value = strings.TrimSpace(value)
+	if ip := net.ParseIP(value); ip != nil {
+		return ip.To16(), 129
+	}
+
+	if addr, bits, ok := strings.Cut(value, "/"); ok {
+		if ip := net.ParseIP(addr); ip != nil {
+			length, err := strconv.Atoi(bits)
+			if err == nil {
+				return ip.To16(), length
+			}
+		}
+	}
+
+	return nil, -1
+}
@@ -0,0 +1,135 @@
+package gcp
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/compute/v1"
+)
+
+func mustPrefix(t *testing.T, cidr string) *net.IPNet {
+	t.Helper()
+
+	prefix, ok := ParseIPPrefix(cidr)
+	require.True(t, ok, "expected %q to parse as a prefix", cidr)
+
+	return prefix
+}
+
+func TestParseIPPrefix(t *testing.T) {
+	prefix, ok := ParseIPPrefix(" 10.20.1.7/22 ")
+	require.True(t, ok)
+	require.Equal(t, "10.20.0.0/22", prefix.String())
+
+	_, ok = ParseIPPrefix("10.20.0.1")
+	require.False(t, ok)
+
+	_, ok = ParseIPPrefix("10.20.0.0/40")
+	require.False(t, ok)
+
+	prefix, ok = ParseIPPrefix("2600:abcd::/64")
+	require.True(t, ok)
+	require.Equal(t, "2600:abcd::/64", prefix.String())
+}
+
+func TestInstancePrefixMatches(t *testing.T) {
+	instance := &compute.Instance{
+		NetworkInterfaces: []*compute.NetworkInterface{
+			{
+				Name:       "nic0",
+				NetworkIP:  "10.20.1.5",
+				Network:    "projects/p/global/networks/vpc-1",
+				Subnetwork: "projects/p/regions/r1/subnetworks/subnet-a",
+				AccessConfigs: []*compute.AccessConfig{
+					{Name: "External NAT", NatIP: "203.0.113.10"},
+				},
+				AliasIpRanges: []*compute.AliasIpRange{
+					{IpCidrRange: "10.20.2.0/28", SubnetworkRangeName: "pods"},
+					{IpCidrRange: "/32"},
+					{IpCidrRange: "10.30.0.0/24"},
+				},
+			},
+			{Name: "nic1", NetworkIP: "10.30.0.5"},
+		},
+	}
+
+	matches := instancePrefixMatches(instance, mustPrefix(t, "10.20.0.0/22"))
+	require.Len(t, matches, 3)
+
+	require.Equal(t, IPAssociationInstanceInternal, matches[0].kind)
+	require.Equal(t, "10.20.1.5", matches[0].address)
+	require.Contains(t, matches[0].details, "subnet=subnet-a")
+
+	require.Equal(t, IPAssociationAliasRange, matches[1].kind)
+	require.Equal(t, "10.20.2.0/28", matches[1].address)
+	require.Contains(t, matches[1].details, "range=secondary:pods")
+
+	require.Equal(t, IPAssociationAliasRange, matches[2].kind)
+	require.Equal(t, "10.20.1.5/32", matches[2].address, "bare masks are resolved against the interface address")
+
+	external := instancePrefixMatches(instance, mustPrefix(t, "203.0.113.0/24"))
+	require.Len(t, external, 1)
+	require.Equal(t, IPAssociationInstanceExternal, external[0].kind)
+	require.Equal(t, "External access config External NAT", external[0].details)
+
+	require.Empty(t, instancePrefixMatches(instance, mustPrefix(t, "192.168.0.0/16")))
+}
+
+func TestSubnetPrefixMatches(t *testing.T) {
+	subnet := &compute.Subnetwork{
+		Name:           "subnet-a",
+		Network:        "projects/p/global/networks/vpc-1",
+		IpCidrRange:    "10.20.0.0/24",
+		GatewayAddress: "10.20.0.1",
+		SecondaryIpRanges: []*compute.SubnetworkSecondaryRange{
+			{RangeName: "pods", IpCidrRange: "10.24.0.0/14"},
+			{RangeName: "services", IpCidrRange: "10.20.3.0/24"},
+		},
+	}
+
+	matches := subnetPrefixMatches(subnet, mustPrefix(t, "10.20.0.0/22"))
+	require.Len(t, matches, 2)
+	require.Equal(t, "10.20.0.0/24", matches[0].address)
+	require.Equal(t, "network=vpc-1, cidr=10.20.0.0/24, range=primary, gateway_ip=10.20.0.1", matches[0].details)
+	require.Equal(t, "10.20.3.0/24", matches[1].address)
+	require.Contains(t, matches[1].details, "range=secondary:services")
+
+	wide := subnetPrefixMatches(subnet, mustPrefix(t, "10.25.1.0/24"))
+	require.Len(t, wide, 1, "ranges containing the prefix overlap it")
+	require.Equal(t, "10.24.0.0/14", wide[0].address)
+}
+
+func TestReservedAddressInPrefix(t *testing.T) {
+	prefix := mustPrefix(t, "10.20.0.0/22")
+
+	address, ok := reservedAddressInPrefix(&compute.Address{Address: "10.20.3.4"}, prefix)
+	require.True(t, ok)
+	require.Equal(t, "10.20.3.4", address)
+
+	address, ok = reservedAddressInPrefix(&compute.Address{Address: "10.16.0.0", PrefixLength: 12}, prefix)
+	require.True(t, ok)
+	require.Equal(t, "10.16.0.0/12", address)
+
+	_, ok = reservedAddressInPrefix(&compute.Address{Address: "10.21.0.1"}, prefix)
+	require.False(t, ok)
+}
+
+func TestSortAssociationsByAddress(t *testing.T) {
+	results := []IPAssociation{
+		{Project: "p", Kind: IPAssociationInstanceInternal, Resource: "vm-b", IPAddress: "10.20.0.10"},
+		{Project: "p", Kind: IPAssociationAddress, Resource: "addr", IPAddress: "10.20.0.9"},
+		{Project: "p", Kind: IPAssociationSubnet, Resource: "subnet-a", IPAddress: "10.20.0.0/24"},
+		{Project: "p", Kind: IPAssociationInstanceInternal, Resource: "vm-a", IPAddress: "10.20.0.10"},
+		{Project: "p", Kind: IPAssociationAliasRange, Resource: "vm-c", IPAddress: "10.20.0.0/28"},
+		{Project: "p", Kind: IPAssociationInstanceExternal, Resource: "vm-d", IPAddress: "2600:abcd::1"},
+	}
+
+	SortAssociationsByAddress(results)
+
+	order := make([]string, 0, len(results))
+	for _, r := range results {
+		order = append(order, r.Resource)
+	}
+	require.Equal(t, []string{"subnet-a", "vm-c", "addr", "vm-a", "vm-b", "vm-d"}, order)
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"net"
-	"sort"
 	"strings"
 
 	"github.com/kedare/compass/internal/cache"
@@ -22,6 +21,7 @@ type SubnetHint struct {
 // LookupIPAddressFast performs an optimized IP lookup using cached subnet information
 // to narrow the search scope and reduce API calls. Uses StrategySmartFallback by default:
 // tries cache-optimized search first, falls back to full scan if no results found.
+// A CIDR prefix lists every resource inside it, see LookupCIDR.
 func (c *Client) LookupIPAddressFast(ctx context.Context, ip string) ([]IPAssociation, error) {
 	if c == nil || c.service == nil {
 		return nil, fmt.Errorf("client is not initialized")
@@ -32,8 +32,7 @@ func (c *Client) LookupIPAddressFast(ctx context.Context, ip string) ([]IPAssoci
 		return nil, fmt.Errorf("ip address is required")
 	}
 
-	targetIP := net.ParseIP(target)
-	if targetIP == nil {
+	if _, ok := ParseIPPrefix(target); !ok && net.ParseIP(target) == nil {
 		return nil, fmt.Errorf("invalid IP address %q", ip)
 	}
 
@@ -87,6 +86,7 @@ func (c *Client) lookupWithHints(ctx context.Context, target net.IP, canonical s
 		forwardingResults []IPAssociation
 		addressResults    []IPAssociation
 		subnetResults     []IPAssociation
+		serviceResults    []IPAssociation
 	)
 
 	// Extract unique regions from hints
@@ -134,12 +134,22 @@ func (c *Client) lookupWithHints(ctx context.Context, target net.IP, canonical s
 		return nil
 	})
 
+	// Managed services (GKE ranges, Cloud SQL, routers, VPN) are listed project-wide, one call each
+	group.Go(func() error {
+		results, err := c.collectServiceMatches(groupCtx, target, canonical)
+		if err != nil {
+			return fmt.Errorf("failed to inspect managed services: %w", err)
+		}
+		serviceResults = results
+		return nil
+	})
+
 	if err := group.Wait(); err != nil {
 		return nil, err
 	}
 
 	// Combine and deduplicate results
-	results := make([]IPAssociation, 0, len(instanceResults)+len(forwardingResults)+len(addressResults)+len(subnetResults))
+	results := make([]IPAssociation, 0, len(instanceResults)+len(forwardingResults)+len(addressResults)+len(subnetResults)+len(serviceResults))
 	seen := make(map[string]struct{})
 
 	for _, association := range instanceResults {
@@ -158,15 +168,12 @@ func (c *Client) lookupWithHints(ctx context.Context, target net.IP, canonical s
 		appendAssociation(&results, seen, association)
 	}
 
-	sort.Slice(results, func(i, j int) bool {
-		if results[i].Project == results[j].Project {
-			if results[i].Kind == results[j].Kind {
-				return results[i].Resource < results[j].Resource
-			}
-			return results[i].Kind < results[j].Kind
-		}
-		return results[i].Project < results[j].Project
-	})
+	for _, association := range serviceResults {
+		appendAssociation(&results, seen, association)
+	}
+
+	sortAssociationsByResource(results)
+	c.recordIPAssociations(results)
 
 	return results, nil
 }
@@ -237,6 +244,8 @@ func (c *Client) collectInstanceIPMatchesTargeted(ctx context.Context, target ne
 							Location:     location,
 							IPAddress:    canonical,
 							Details:      match.details,
+							Network:      match.network,
+							Subnet:       match.subnet,
 							ResourceLink: inst.SelfLink,
 						}
 						appendAssociation(&results, seen, association)
@@ -290,17 +299,19 @@ func (c *Client) collectForwardingRuleMatchesTargeted(ctx context.Context, targe
 					return err
 				}
 
-				if rule == nil || !equalIP(rule.IPAddress, target) {
+				if !forwardingRuleMatches(rule, target) {
 					continue
 				}
 
 				association := IPAssociation{
 					Project:      c.project,
-					Kind:         IPAssociationForwardingRule,
+					Kind:         forwardingRuleKind(rule),
 					Resource:     rule.Name,
 					Location:     location,
 					IPAddress:    canonical,
 					Details:      describeForwardingRule(rule),
+					Network:      lastComponent(rule.Network),
+					Subnet:       lastComponent(rule.Subnetwork),
 					ResourceLink: rule.SelfLink,
 				}
 				appendAssociation(&results, seen, association)
@@ -352,7 +363,7 @@ func (c *Client) collectAddressMatchesTargeted(ctx context.Context, target net.I
 					return err
 				}
 
-				if addr == nil || !equalIP(addr.Address, target) {
+				if !reservedAddressMatches(addr, target) {
 					continue
 				}
 
@@ -363,6 +374,8 @@ func (c *Client) collectAddressMatchesTargeted(ctx context.Context, target net.I
 					Location:     location,
 					IPAddress:    canonical,
 					Details:      describeAddress(addr),
+					Network:      lastComponent(addr.Network),
+					Subnet:       lastComponent(addr.Subnetwork),
 					ResourceLink: addr.SelfLink,
 				}
 				appendAssociation(&results, seen, association)
@@ -397,6 +410,8 @@ func (c *Client) buildSubnetAssociationsFromHints(target net.IP, canonical strin
 						Location:     entry.Region,
 						IPAddress:    canonical,
 						Details:      detail,
+						Network:      entry.Network,
+						Subnet:       entry.Name,
 						ResourceLink: entry.SelfLink,
 					}
 					appendAssociation(&results, seen, association)
@@ -468,6 +483,11 @@ func (c *Client) subnetMatchDetailsFromEntry(entry *cache.SubnetEntry, target ne
 		source = "ipv6"
 	}
 
+	if matchedCIDR == "" && entry.ExternalIPv6CIDR != "" && ipInCIDR(target, entry.ExternalIPv6CIDR) {
+		matchedCIDR = entry.ExternalIPv6CIDR
+		source = "external_ipv6"
+	}
+
 	if matchedCIDR == "" {
 		return false, ""
 	}
@@ -0,0 +1,481 @@
+package gcp
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/kedare/compass/internal/logger"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/container/v1"
+	"google.golang.org/api/sqladmin/v1beta4"
+)
+
+// serviceInventory holds the managed services of a project that reference IP addresses
+// outside of instance interfaces, forwarding rules and reserved addresses.
+type serviceInventory struct {
+	clusters     []*container.Cluster
+	sqlInstances []*sqladmin.DatabaseInstance
+	routers      []*compute.Router
+	nats         []*RouterNAT
+	gateways     []*VPNGatewayInfo
+	tunnels      []*VPNTunnelInfo
+}
+
+// serviceMatch is a match on a managed service resource, see serviceInventory.
+type serviceMatch struct {
+	kind     IPAssociationKind
+	resource string
+	location string
+	details  string
+	network  string
+	subnet   string
+	link     string
+}
+
+// collectServiceMatches gathers the GKE, Cloud SQL, Cloud NAT, Cloud VPN and Cloud Router
+// associations of the target IP.
+func (c *Client) collectServiceMatches(ctx context.Context, target net.IP, canonical string) ([]IPAssociation, error) {
+	inventory, err := c.fetchServiceInventory(ctx, target)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.serviceAssociations(inventory, target, canonical), nil
+}
+
+// fetchServiceInventory lists the managed services that may reference the target, or all of
+// them when target is nil. Services whose API is disabled or not allowed in the project are
+// skipped: they are optional and must not fail a lookup.
+func (c *Client) fetchServiceInventory(ctx context.Context, target net.IP) (*serviceInventory, error) {
+	inventory := &serviceInventory{}
+	linkLocal := target != nil && target.IsLinkLocalUnicast()
+	// NAT IPs are external addresses, only resolve them (one status call per router) when they can match
+	withNAT := target == nil || (!target.IsPrivate() && !linkLocal)
+
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(3)
+
+	optional := func(name string, fetch func() error) {
+		group.Go(func() error {
+			if err := fetch(); err != nil {
+				if ctxErr := groupCtx.Err(); ctxErr != nil {
+					return ctxErr
+				}
+				logger.Log.Debugf("[%s] Skipping %s in IP lookup: %v", c.project, name, err)
+			}
+
+			return nil
+		})
+	}
+
+	if !linkLocal {
+		optional("GKE clusters", func() (err error) {
+			inventory.clusters, err = c.listContainerClusters(groupCtx)
+
+			return err
+		})
+
+		optional("Cloud SQL instances", func() (err error) {
+			inventory.sqlInstances, err = c.listSQLInstances(groupCtx)
+
+			return err
+		})
+	}
+
+	optional("Cloud Routers", func() error {
+		routers, err := c.listRouters(groupCtx)
+		if err != nil {
+			return err
+		}
+		inventory.routers = routers
+
+		if withNAT {
+			inventory.nats, err = c.routerNATs(groupCtx, routers)
+		}
+
+		return err
+	})
+
+	if !linkLocal {
+		optional("VPN gateways", func() (err error) {
+			inventory.gateways, err = c.listVpnGateways(groupCtx, nil)
+
+			return err
+		})
+
+		optional("VPN tunnels", func() (err error) {
+			inventory.tunnels, err = c.listVpnTunnels(groupCtx, nil)
+
+			return err
+		})
+	}
+
+	if err := group.Wait(); err != nil {
+		return nil, err
+	}
+
+	return inventory, nil
+}
+
+// serviceAssociations matches the target IP against the listed managed services.
+func (c *Client) serviceAssociations(inventory *serviceInventory, target net.IP, canonical string) []IPAssociation {
+	if inventory == nil || target == nil {
+		return nil
+	}
+
+	matches := make([]serviceMatch, 0)
+	for _, cluster := range inventory.clusters {
+		matches = append(matches, gkeClusterMatches(cluster, target)...)
+	}
+	for _, instance := range inventory.sqlInstances {
+		matches = append(matches, sqlInstanceMatches(instance, target)...)
+	}
+	for _, router := range inventory.routers {
+		matches = append(matches, routerInterfaceMatches(router, target)...)
+	}
+	for _, nat := range inventory.nats {
+		matches = append(matches, natMatches(nat, target)...)
+	}
+	for _, gateway := range inventory.gateways {
+		matches = append(matches, vpnGatewayMatches(gateway, target)...)
+	}
+	for _, tunnel := range inventory.tunnels {
+		matches = append(matches, vpnTunnelMatches(tunnel, target)...)
+	}
+
+	results := make([]IPAssociation, 0, len(matches))
+	seen := make(map[string]struct{}, len(matches))
+	for _, match := range matches {
+		appendAssociation(&results, seen, c.serviceAssociation(match, canonical))
+	}
+
+	return results
+}
+
+// serviceAssociation converts a match on a managed service to an association of the address.
+func (c *Client) serviceAssociation(match serviceMatch, canonical string) IPAssociation {
+	return IPAssociation{
+		Project:      c.project,
+		Kind:         match.kind,
+		Resource:     match.resource,
+		Location:     match.location,
+		IPAddress:    canonical,
+		Details:      match.details,
+		Network:      match.network,
+		Subnet:       match.subnet,
+		ResourceLink: match.link,
+	}
+}
+
+// gkeClusterMatches reports the pod and service ranges and the control plane endpoints of a
+// GKE cluster containing the target.
+func gkeClusterMatches(cluster *container.Cluster, target net.IP) []serviceMatch {
+	if cluster == nil {
+		return nil
+	}
+
+	matches := make([]serviceMatch, 0)
+	add := func(kind IPAssociationKind, parts ...string) {
+		parts = append(parts, detailPart("network", lastComponent(cluster.Network)), detailPart("subnet", lastComponent(cluster.Subnetwork)))
+
+		matches = append(matches, serviceMatch{
+			kind:     kind,
+			resource: cluster.Name,
+			location: cluster.Location,
+			details:  joinDetailParts(parts),
+			network:  lastComponent(cluster.Network),
+			subnet:   lastComponent(cluster.Subnetwork),
+			link:     cluster.SelfLink,
+		})
+	}
+
+	podRange, podRangeName := cluster.ClusterIpv4Cidr, ""
+	serviceRange, serviceRangeName := cluster.ServicesIpv4Cidr, ""
+	if policy := cluster.IpAllocationPolicy; policy != nil {
+		podRange = firstNonEmpty(policy.ClusterIpv4CidrBlock, podRange)
+		podRangeName = policy.ClusterSecondaryRangeName
+		serviceRange = firstNonEmpty(policy.ServicesIpv4CidrBlock, serviceRange)
+		serviceRangeName = policy.ServicesSecondaryRangeName
+	}
+
+	podRanges := map[string]struct{}{}
+	if ipInCIDR(target, podRange) {
+		podRanges[podRange] = struct{}{}
+		add(IPAssociationGKEPodRange, "Pod range of cluster", fmt.Sprintf("cidr=%s", podRange), detailPart("range", secondaryRangeName(podRangeName)))
+	}
+
+	for _, pool := range cluster.NodePools {
+		if pool == nil || pool.NetworkConfig == nil {
+			continue
+		}
+
+		cidr := strings.TrimSpace(pool.NetworkConfig.PodIpv4CidrBlock)
+		if _, reported := podRanges[cidr]; reported || !ipInCIDR(target, cidr) {
+			continue
+		}
+		podRanges[cidr] = struct{}{}
+
+		add(IPAssociationGKEPodRange, fmt.Sprintf("Pod range of node pool %s", pool.Name), fmt.Sprintf("cidr=%s", cidr), detailPart("range", secondaryRangeName(pool.NetworkConfig.PodRange)))
+	}
+
+	if ipInCIDR(target, serviceRange) {
+		add(IPAssociationGKEServiceRange, "Service range of cluster", fmt.Sprintf("cidr=%s", serviceRange), detailPart("range", secondaryRangeName(serviceRangeName)))
+	}
+
+	endpoints := map[string]string{}
+	if private := cluster.PrivateClusterConfig; private != nil {
+		endpoints[private.PrivateEndpoint] = "private"
+		endpoints[private.PublicEndpoint] = "public"
+	}
+	if config := cluster.ControlPlaneEndpointsConfig; config != nil && config.IpEndpointsConfig != nil {
+		endpoints[config.IpEndpointsConfig.PrivateEndpoint] = "private"
+		endpoints[config.IpEndpointsConfig.PublicEndpoint] = "public"
+	}
+	if _, known := endpoints[cluster.Endpoint]; !known {
+		endpoints[cluster.Endpoint] = ""
+	}
+
+	endpointMatched := false
+	for address, access := range endpoints {
+		if equalIP(address, target) {
+			add(IPAssociationGKEEndpoint, strings.Join(filterEmpty([]string{"Control plane", access, "endpoint"}), " "))
+			endpointMatched = true
+
+			break
+		}
+	}
+
+	if private := cluster.PrivateClusterConfig; private != nil && !endpointMatched && ipInCIDR(target, private.MasterIpv4CidrBlock) {
+		add(IPAssociationGKEEndpoint, "Control plane range", fmt.Sprintf("cidr=%s", private.MasterIpv4CidrBlock))
+	}
+
+	return matches
+}
+
+// sqlInstanceMatches reports the Cloud SQL instance addresses equal to the target.
+func sqlInstanceMatches(instance *sqladmin.DatabaseInstance, target net.IP) []serviceMatch {
+	if instance == nil {
+		return nil
+	}
+
+	matches := make([]serviceMatch, 0)
+	for _, mapping := range instance.IpAddresses {
+		if mapping == nil || !equalIP(mapping.IpAddress, target) {
+			continue
+		}
+
+		network := ""
+		if settings := instance.Settings; settings != nil && settings.IpConfiguration != nil {
+			network = lastComponent(settings.IpConfiguration.PrivateNetwork)
+		}
+		parts := []string{
+			detailPart("type", strings.ToLower(mapping.Type)),
+			detailPart("version", strings.ToLower(instance.DatabaseVersion)),
+			detailPart("state", strings.ToLower(instance.State)),
+			detailPart("network", network),
+		}
+
+		matches = append(matches, serviceMatch{
+			kind:     IPAssociationCloudSQL,
+			resource: instance.Name,
+			location: instance.Region,
+			details:  joinDetailParts(parts),
+			network:  network,
+			link:     instance.SelfLink,
+		})
+	}
+
+	return matches
+}
+
+// routerInterfaceMatches reports Cloud Router interfaces and BGP peers using the target,
+// typically link-local addresses of VPN and Interconnect BGP sessions.
+func routerInterfaceMatches(router *compute.Router, target net.IP) []serviceMatch {
+	if router == nil {
+		return nil
+	}
+
+	region := lastComponent(router.Region)
+	network := lastComponent(router.Network)
+	matches := make([]serviceMatch, 0)
+	add := func(parts ...string) {
+		parts = append(parts, detailPart("network", network))
+		matches = append(matches, serviceMatch{
+			kind:     IPAssociationRouterInterface,
+			resource: router.Name,
+			location: region,
+			details:  joinDetailParts(parts),
+			network:  network,
+			link:     router.SelfLink,
+		})
+	}
+
+	for _, iface := range router.Interfaces {
+		if iface == nil {
+			continue
+		}
+
+		address, cidr := routerInterfaceAddress(iface.IpRange)
+		if !equalIP(address, target) && !equalIP(iface.PrivateIpAddress, target) {
+			continue
+		}
+
+		add(
+			fmt.Sprintf("Interface %s", iface.Name),
+			detailPart("cidr", cidr),
+			detailPart("tunnel", lastComponent(iface.LinkedVpnTunnel)),
+			detailPart("attachment", lastComponent(iface.LinkedInterconnectAttachment)),
+		)
+	}
+
+	for _, peer := range router.BgpPeers {
+		if peer == nil || !equalIP(peer.PeerIpAddress, target) {
+			continue
+		}
+
+		peerASN := ""
+		if peer.PeerAsn != 0 {
+			peerASN = fmt.Sprintf("%d", peer.PeerAsn)
+		}
+		add(fmt.Sprintf("BGP peer %s (remote side)", peer.Name), detailPart("peer_asn", peerASN), detailPart("interface", peer.InterfaceName))
+	}
+
+	return matches
+}
+
+// routerInterfaceAddress splits an interface range such as "169.254.0.1/30" into the
+// interface address and its network.
+func routerInterfaceAddress(ipRange string) (string, string) {
+	ip, network, err := net.ParseCIDR(strings.TrimSpace(ipRange))
+	if err != nil {
+		return "", ""
+	}
+
+	return ip.String(), network.String()
+}
+
+// natMatches reports the Cloud NAT gateway whose NAT IPs include the target.
+func natMatches(nat *RouterNAT, target net.IP) []serviceMatch {
+	if nat == nil {
+		return nil
+	}
+
+	for _, address := range nat.NATIPs {
+		if !equalIP(address, target) {
+			continue
+		}
+
+		parts := []string{
+			detailPart("router", nat.Router),
+			detailPart("allocation", strings.ToLower(nat.IPAllocateOption)),
+			detailPart("network", nat.Network),
+		}
+
+		return []serviceMatch{{
+			kind:     IPAssociationCloudNAT,
+			resource: nat.Name,
+			location: nat.Region,
+			details:  joinDetailParts(parts),
+			network:  nat.Network,
+		}}
+	}
+
+	return nil
+}
+
+// vpnGatewayMatches reports the HA VPN gateway interface using the target.
+func vpnGatewayMatches(gateway *VPNGatewayInfo, target net.IP) []serviceMatch {
+	if gateway == nil {
+		return nil
+	}
+
+	for _, iface := range gateway.Interfaces {
+		if iface == nil || (!equalIP(iface.IpAddress, target) && !equalIP(iface.Ipv6Address, target)) {
+			continue
+		}
+
+		return []serviceMatch{{
+			kind:     IPAssociationVPNGateway,
+			resource: gateway.Name,
+			location: gateway.Region,
+			details:  joinDetailParts([]string{fmt.Sprintf("Interface %d", iface.Id), detailPart("network", lastComponent(gateway.Network))}),
+			network:  lastComponent(gateway.Network),
+			link:     gateway.SelfLink,
+		}}
+	}
+
+	return nil
+}
+
+// vpnTunnelMatches reports the VPN tunnel whose remote peer is the target.
+func vpnTunnelMatches(tunnel *VPNTunnelInfo, target net.IP) []serviceMatch {
+	if tunnel == nil || !equalIP(tunnel.PeerIP, target) {
+		return nil
+	}
+
+	parts := []string{
+		"Remote peer of tunnel",
+		detailPart("peer_gateway", lastComponent(tunnel.PeerGateway)),
+		detailPart("status", strings.ToLower(tunnel.Status)),
+		detailPart("router", tunnel.RouterName),
+	}
+
+	return []serviceMatch{{
+		kind:     IPAssociationVPNPeer,
+		resource: tunnel.Name,
+		location: tunnel.Region,
+		details:  joinDetailParts(parts),
+		link:     tunnel.SelfLink,
+	}}
+}
+
+// forwardingRuleKind distinguishes Private Service Connect endpoints from other forwarding rules.
+func forwardingRuleKind(rule *compute.ForwardingRule) IPAssociationKind {
+	if rule == nil {
+		return IPAssociationForwardingRule
+	}
+
+	target := strings.TrimSpace(rule.Target)
+	if rule.PscConnectionId != 0 || strings.Contains(target, "/serviceAttachments/") || target == "all-apis" || target == "vpc-sc" {
+		return IPAssociationPSCEndpoint
+	}
+
+	return IPAssociationForwardingRule
+}
+
+// secondaryRangeName formats a named secondary range like subnet details, e.g. "secondary:pods".
+func secondaryRangeName(name string) string {
+	if name == "" {
+		return ""
+	}
+
+	return RangeSecondary + ":" + name
+}
+
+// detailPart formats a "key=value" detail segment, or nothing when the value is empty.
+func detailPart(key, value string) string {
+	if value = strings.TrimSpace(value); value == "" {
+		return ""
+	}
+
+	return fmt.Sprintf("%s=%s", key, value)
+}
+
+// joinDetailParts joins the non-empty detail segments.
+func joinDetailParts(parts []string) string {
+	return strings.Join(filterEmpty(parts), ", ")
+}
+
+func filterEmpty(values []string) []string {
+	kept := make([]string, 0, len(values))
+	for _, value := range values {
+		if value != "" {
+			kept = append(kept, value)
+		}
+	}
+
+	return kept
+}
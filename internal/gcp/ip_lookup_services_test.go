@@ -0,0 +1,160 @@
+package gcp
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/container/v1"
+	"google.golang.org/api/sqladmin/v1beta4"
+)
+
+func TestGKEClusterMatches(t *testing.T) {
+	cluster := &container.Cluster{
+		Name:       "prod",
+		Location:   "europe-west1",
+		Network:    "vpc",
+		Subnetwork: "gke-nodes",
+		IpAllocationPolicy: &container.IPAllocationPolicy{
+			ClusterIpv4CidrBlock:       "10.8.0.0/14",
+			ClusterSecondaryRangeName:  "pods",
+			ServicesIpv4CidrBlock:      "10.12.0.0/20",
+			ServicesSecondaryRangeName: "services",
+		},
+		NodePools: []*container.NodePool{{
+			Name:          "extra",
+			NetworkConfig: &container.NodeNetworkConfig{PodIpv4CidrBlock: "10.20.0.0/16", PodRange: "extra-pods"},
+		}},
+		PrivateClusterConfig: &container.PrivateClusterConfig{
+			PrivateEndpoint:     "172.16.0.2",
+			PublicEndpoint:      "34.77.1.2",
+			MasterIpv4CidrBlock: "172.16.0.0/28",
+		},
+		Endpoint: "34.77.1.2",
+	}
+
+	matches := gkeClusterMatches(cluster, net.ParseIP("10.9.1.5"))
+	require.Len(t, matches, 1)
+	require.Equal(t, IPAssociationGKEPodRange, matches[0].kind)
+	require.Equal(t, "prod", matches[0].resource)
+	require.Equal(t, "Pod range of cluster, cidr=10.8.0.0/14, range=secondary:pods, network=vpc, subnet=gke-nodes", matches[0].details)
+
+	matches = gkeClusterMatches(cluster, net.ParseIP("10.20.3.4"))
+	require.Len(t, matches, 1)
+	require.Contains(t, matches[0].details, "Pod range of node pool extra")
+
+	matches = gkeClusterMatches(cluster, net.ParseIP("10.12.0.10"))
+	require.Len(t, matches, 1)
+	require.Equal(t, IPAssociationGKEServiceRange, matches[0].kind)
+
+	matches = gkeClusterMatches(cluster, net.ParseIP("34.77.1.2"))
+	require.Len(t, matches, 1)
+	require.Equal(t, IPAssociationGKEEndpoint, matches[0].kind)
+	require.Contains(t, matches[0].details, "Control plane public endpoint")
+
+	matches = gkeClusterMatches(cluster, net.ParseIP("172.16.0.5"))
+	require.Len(t, matches, 1)
+	require.Contains(t, matches[0].details, "Control plane range")
+
+	require.Empty(t, gkeClusterMatches(cluster, net.ParseIP("192.168.0.1")))
+}
+
+func TestSQLInstanceMatches(t *testing.T) {
+	instance := &sqladmin.DatabaseInstance{
+		Name:            "orders",
+		Region:          "europe-west1",
+		DatabaseVersion: "POSTGRES_15",
+		State:           "RUNNABLE",
+		IpAddresses: []*sqladmin.IpMapping{
+			{IpAddress: "10.50.0.3", Type: "PRIVATE"},
+			{IpAddress: "35.1.2.3", Type: "PRIMARY"},
+		},
+		Settings: &sqladmin.Settings{
+			IpConfiguration: &sqladmin.IpConfiguration{PrivateNetwork: "projects/p/global/networks/vpc"},
+		},
+	}
+
+	matches := sqlInstanceMatches(instance, net.ParseIP("10.50.0.3"))
+	require.Len(t, matches, 1)
+	require.Equal(t, IPAssociationCloudSQL, matches[0].kind)
+	require.Equal(t, "type=private, version=postgres_15, state=runnable, network=vpc", matches[0].details)
+
+	require.Empty(t, sqlInstanceMatches(instance, net.ParseIP("10.50.0.4")))
+}
+
+func TestRouterInterfaceMatches(t *testing.T) {
+	router := &compute.Router{
+		Name:    "edge",
+		Region:  "projects/p/regions/europe-west1",
+		Network: "projects/p/global/networks/vpc",
+		Interfaces: []*compute.RouterInterface{{
+			Name:            "if-tunnel-1",
+			IpRange:         "169.254.10.1/30",
+			LinkedVpnTunnel: "projects/p/regions/europe-west1/vpnTunnels/tunnel-1",
+		}},
+		BgpPeers: []*compute.RouterBgpPeer{{
+			Name:          "peer-1",
+			InterfaceName: "if-tunnel-1",
+			IpAddress:     "169.254.10.1",
+			PeerIpAddress: "169.254.10.2",
+			PeerAsn:       65010,
+		}},
+	}
+
+	matches := routerInterfaceMatches(router, net.ParseIP("169.254.10.1"))
+	require.Len(t, matches, 1)
+	require.Equal(t, IPAssociationRouterInterface, matches[0].kind)
+	require.Equal(t, "europe-west1", matches[0].location)
+	require.Equal(t, "Interface if-tunnel-1, cidr=169.254.10.0/30, tunnel=tunnel-1, network=vpc", matches[0].details)
+
+	matches = routerInterfaceMatches(router, net.ParseIP("169.254.10.2"))
+	require.Len(t, matches, 1)
+	require.Equal(t, "BGP peer peer-1 (remote side), peer_asn=65010, interface=if-tunnel-1, network=vpc", matches[0].details)
+}
+
+func TestNATAndVPNMatches(t *testing.T) {
+	nat := &RouterNAT{Name: "egress", Router: "edge", Region: "europe-west1", Network: "vpc", IPAllocateOption: "AUTO_ONLY", NATIPs: []string{"34.1.1.1"}}
+	matches := natMatches(nat, net.ParseIP("34.1.1.1"))
+	require.Len(t, matches, 1)
+	require.Equal(t, IPAssociationCloudNAT, matches[0].kind)
+	require.Equal(t, "router=edge, allocation=auto_only, network=vpc", matches[0].details)
+	require.Empty(t, natMatches(nat, net.ParseIP("34.1.1.2")))
+
+	gateway := &VPNGatewayInfo{
+		Name:       "ha-gw",
+		Region:     "europe-west1",
+		Network:    "projects/p/global/networks/vpc",
+		Interfaces: []*compute.VpnGatewayVpnGatewayInterface{{Id: 1, IpAddress: "35.2.2.2"}},
+	}
+	matches = vpnGatewayMatches(gateway, net.ParseIP("35.2.2.2"))
+	require.Len(t, matches, 1)
+	require.Equal(t, "Interface 1, network=vpc", matches[0].details)
+
+	tunnel := &VPNTunnelInfo{Name: "tunnel-1", Region: "europe-west1", PeerIP: "203.0.113.9", Status: "ESTABLISHED"}
+	matches = vpnTunnelMatches(tunnel, net.ParseIP("203.0.113.9"))
+	require.Len(t, matches, 1)
+	require.Equal(t, IPAssociationVPNPeer, matches[0].kind)
+	require.Equal(t, "Remote peer of tunnel, status=established", matches[0].details)
+}
+
+func TestForwardingRuleKind(t *testing.T) {
+	require.Equal(t, IPAssociationForwardingRule, forwardingRuleKind(&compute.ForwardingRule{Target: "projects/p/regions/r/targetPools/pool"}))
+	require.Equal(t, IPAssociationPSCEndpoint, forwardingRuleKind(&compute.ForwardingRule{Target: "projects/p/regions/r/serviceAttachments/sa"}))
+	require.Equal(t, IPAssociationPSCEndpoint, forwardingRuleKind(&compute.ForwardingRule{Target: "all-apis"}))
+	require.Equal(t, IPAssociationPSCEndpoint, forwardingRuleKind(&compute.ForwardingRule{PscConnectionId: 42}))
+}
+
+func TestServiceAssociations(t *testing.T) {
+	client := &Client{project: "proj"}
+	inventory := &serviceInventory{
+		nats:    []*RouterNAT{{Name: "egress", Region: "europe-west1", NATIPs: []string{"34.1.1.1"}}},
+		tunnels: []*VPNTunnelInfo{{Name: "tunnel-1", Region: "europe-west1", PeerIP: "34.1.1.1"}},
+	}
+
+	results := client.serviceAssociations(inventory, net.ParseIP("34.1.1.1"), "34.1.1.1")
+	require.Len(t, results, 2)
+	require.Equal(t, "proj", results[0].Project)
+	require.Equal(t, "34.1.1.1", results[0].IPAddress)
+	require.Nil(t, client.serviceAssociations(nil, net.ParseIP("34.1.1.1"), "34.1.1.1"))
+}
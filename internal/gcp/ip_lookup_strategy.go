@@ -22,13 +22,18 @@ const (
 	StrategyAlwaysComplete
 )
 
-// LookupIPAddressWithStrategy performs IP lookup using the specified strategy
+// LookupIPAddressWithStrategy performs IP lookup using the specified strategy.
+// CIDR prefixes are always looked up with a full scan, see LookupCIDR.
 func (c *Client) LookupIPAddressWithStrategy(ctx context.Context, ip string, strategy IPLookupStrategy) ([]IPAssociation, error) {
 	target := strings.TrimSpace(ip)
 	if target == "" {
 		return nil, nil
 	}
 
+	if _, ok := ParseIPPrefix(target); ok {
+		return c.LookupCIDR(ctx, target)
+	}
+
 	targetIP := net.ParseIP(target)
 	if targetIP == nil {
 		return nil, nil
@@ -51,8 +56,8 @@ func (c *Client) LookupIPAddressWithStrategy(ctx context.Context, ip string, str
 
 // lookupFastOnly uses only cache-optimized search
 func (c *Client) lookupFastOnly(ctx context.Context, ip string, targetIP net.IP) ([]IPAssociation, error) {
-	// For private IPs, try cache-optimized search
-	if targetIP.IsPrivate() && c.cache != nil {
+	// For private and IPv6 addresses, try cache-optimized search
+	if inSubnetRange(targetIP) && c.cache != nil {
 		hints := c.getSubnetHintsFromCache(targetIP)
 		if len(hints) > 0 {
 			logger.Log.Debugf("[%s] Fast-only strategy: found %d subnet hints", c.project, len(hints))
@@ -69,8 +74,8 @@ func (c *Client) lookupFastOnly(ctx context.Context, ip string, targetIP net.IP)
 
 // lookupSmartFallback tries fast first, falls back to full scan if nothing found
 func (c *Client) lookupSmartFallback(ctx context.Context, ip string, targetIP net.IP) ([]IPAssociation, error) {
-	// For private IPs with cache hints, try optimized search first
-	if targetIP.IsPrivate() && c.cache != nil {
+	// For private and IPv6 addresses with cache hints, try optimized search first
+	if inSubnetRange(targetIP) && c.cache != nil {
 		hints := c.getSubnetHintsFromCache(targetIP)
 		if len(hints) > 0 {
 			logger.Log.Debugf("[%s] Smart fallback: trying fast search with %d hints", c.project, len(hints))
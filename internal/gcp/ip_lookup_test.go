@@ -59,6 +59,52 @@ func TestInstanceIPMatchesIPv6(t *testing.T) {
 	require.Equal(t, IPAssociationInstanceExternal, external[0].kind)
 }
 
+func TestInstanceIPMatchesExternalIPv6Range(t *testing.T) {
+	instance := &compute.Instance{
+		NetworkInterfaces: []*compute.NetworkInterface{
+			{
+				Name: "nic0",
+				Ipv6AccessConfigs: []*compute.AccessConfig{
+					{Name: "external-ipv6", ExternalIpv6: "2600:1900:4001:a2b:0:5::", ExternalIpv6PrefixLength: 96},
+				},
+			},
+		},
+	}
+
+	matches := instanceIPMatches(instance, net.ParseIP("2600:1900:4001:a2b:0:5:0:7"))
+	require.Len(t, matches, 1)
+	require.Equal(t, IPAssociationInstanceExternal, matches[0].kind)
+	require.Equal(t, "External access config external-ipv6", matches[0].details)
+
+	require.Empty(t, instanceIPMatches(instance, net.ParseIP("2600:1900:4001:a2b:0:6::1")))
+
+	_, prefix, err := net.ParseCIDR("2600:1900:4001:a2b::/64")
+	require.NoError(t, err)
+	prefixMatches := instancePrefixMatches(instance, prefix)
+	require.Len(t, prefixMatches, 1)
+	require.Equal(t, "2600:1900:4001:a2b:0:5::/96", prefixMatches[0].address)
+}
+
+func TestForwardingRuleAndAddressMatchesIPv6Ranges(t *testing.T) {
+	target := net.ParseIP("2600:1900:4001:a2b:8000::9")
+
+	require.True(t, forwardingRuleMatches(&compute.ForwardingRule{IPAddress: "2600:1900:4001:a2b:8000::/96"}, target))
+	require.False(t, forwardingRuleMatches(&compute.ForwardingRule{IPAddress: "2600:1900:4001:a2b:9000::/96"}, target))
+	require.True(t, forwardingRuleMatches(&compute.ForwardingRule{IPAddress: "10.0.0.1"}, net.ParseIP("10.0.0.1")))
+	require.False(t, forwardingRuleMatches(nil, target))
+
+	require.True(t, reservedAddressMatches(&compute.Address{Address: "2600:1900:4001:a2b:8000::", PrefixLength: 96}, target))
+	require.False(t, reservedAddressMatches(&compute.Address{Address: "2600:1900:4001:a2b:8000::"}, target))
+	// IPv4 ranges reserved for VPC peering do not own the addresses inside them
+	require.False(t, reservedAddressMatches(&compute.Address{Address: "10.10.0.0", PrefixLength: 16}, net.ParseIP("10.10.0.5")))
+}
+
+func TestInSubnetRange(t *testing.T) {
+	require.True(t, inSubnetRange(net.ParseIP("10.0.0.1")))
+	require.True(t, inSubnetRange(net.ParseIP("2600:1900:4001:a2b::1")))
+	require.False(t, inSubnetRange(net.ParseIP("8.8.8.8")))
+}
+
 func TestDescribeForwardingRule(t *testing.T) {
 	rule := &compute.ForwardingRule{
 		LoadBalancingScheme: "EXTERNAL",
@@ -125,3 +171,25 @@ func TestEqualIP(t *testing.T) {
 	require.True(t, equalIP("2001:0db8:0:0::1", ipv6))
 	require.False(t, equalIP("not-an-ip", ipv6))
 }
+
+func TestInstanceIPMatchesAliasRange(t *testing.T) {
+	instance := &compute.Instance{
+		NetworkInterfaces: []*compute.NetworkInterface{{
+			Name:       "nic0",
+			NetworkIP:  "10.0.0.2",
+			Network:    "projects/p/global/networks/vpc",
+			Subnetwork: "projects/p/regions/r/subnetworks/subnet-a",
+			AliasIpRanges: []*compute.AliasIpRange{
+				{IpCidrRange: "10.4.0.0/24", SubnetworkRangeName: "pods"},
+				{IpCidrRange: "/32"},
+			},
+		}},
+	}
+
+	matches := instanceIPMatches(instance, net.ParseIP("10.4.0.17"))
+	require.Len(t, matches, 1)
+	require.Equal(t, IPAssociationAliasRange, matches[0].kind)
+	require.Equal(t, "Alias IP range on interface nic0, network=vpc, subnet=subnet-a, range=secondary:pods", matches[0].details)
+
+	require.Empty(t, instanceIPMatches(instance, net.ParseIP("10.4.1.1")))
+}
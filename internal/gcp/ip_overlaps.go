@@ -0,0 +1,292 @@
+package gcp
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/kedare/compass/internal/cache"
+)
+
+const (
+	// PrefixSourceSubnet marks a prefix taken from a subnet range.
+	PrefixSourceSubnet = "subnet"
+	// PrefixSourceBGP marks a prefix learned by a Cloud VPN BGP session.
+	PrefixSourceBGP = "bgp"
+)
+
+// NetworkPrefix is an address range in use in a VPC network.
+type NetworkPrefix struct {
+	Project string `json:"project"`
+	Network string `json:"network,omitempty"`
+	Region  string `json:"region,omitempty"`
+	// Source is PrefixSourceSubnet or PrefixSourceBGP.
+	Source string `json:"source"`
+	// Resource is the subnet name, or "<tunnel>/<bgp peer>" for learned prefixes.
+	Resource string `json:"resource"`
+	// Range is "primary", "secondary:<name>" or "ipv6" for subnets and "learned" for BGP prefixes.
+	Range string `json:"range"`
+	CIDR  string `json:"cidr"`
+}
+
+// Key identifies the network a prefix belongs to, e.g. "my-project/my-vpc".
+func (p NetworkPrefix) Key() string {
+	return p.Project + "/" + p.Network
+}
+
+// PrefixOverlap is a pair of prefixes sharing at least one address.
+type PrefixOverlap struct {
+	A NetworkPrefix `json:"a"`
+	B NetworkPrefix `json:"b"`
+}
+
+// SubnetPrefixes returns the primary, secondary and IPv6 ranges of cached subnets.
+func SubnetPrefixes(entries []*cache.SubnetEntry) []NetworkPrefix {
+	prefixes := make([]NetworkPrefix, 0, len(entries))
+	for _, entry := range entries {
+		if entry == nil {
+			continue
+		}
+
+		add := func(rangeName, cidr string) {
+			if cidr = strings.TrimSpace(cidr); cidr == "" {
+				return
+			}
+
+			prefixes = append(prefixes, NetworkPrefix{
+				Project:  entry.Project,
+				Network:  entry.Network,
+				Region:   entry.Region,
+				Source:   PrefixSourceSubnet,
+				Resource: entry.Name,
+				Range:    rangeName,
+				CIDR:     cidr,
+			})
+		}
+
+		add(RangePrimary, entry.PrimaryCIDR)
+		for _, sr := range entry.SecondaryRanges {
+			if sr.Name != "" {
+				add(RangeSecondary+":"+sr.Name, sr.CIDR)
+			} else {
+				add(RangeSecondary, sr.CIDR)
+			}
+		}
+		add("ipv6", entry.IPv6CIDR)
+	}
+
+	return prefixes
+}
+
+// LearnedPrefixes returns the prefixes learned by the Cloud VPN BGP sessions of the project.
+// Default routes are skipped, as they overlap every range by design.
+func (c *Client) LearnedPrefixes(ctx context.Context, progress func(string)) ([]NetworkPrefix, error) {
+	overview, err := c.ListVPNOverview(ctx, progress)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list VPN sessions: %w", err)
+	}
+
+	prefixes := make([]NetworkPrefix, 0)
+	addTunnel := func(tunnel *VPNTunnelInfo, network string) {
+		if tunnel == nil {
+			return
+		}
+
+		for _, session := range tunnel.BgpSessions {
+			if session == nil {
+				continue
+			}
+
+			for _, cidr := range session.LearnedPrefixes {
+				if isDefaultRoute(cidr) {
+					continue
+				}
+
+				prefixes = append(prefixes, NetworkPrefix{
+					Project:  c.project,
+					Network:  network,
+					Region:   tunnel.Region,
+					Source:   PrefixSourceBGP,
+					Resource: tunnel.Name + "/" + session.Name,
+					Range:    "learned",
+					CIDR:     strings.TrimSpace(cidr),
+				})
+			}
+		}
+	}
+
+	for _, gateway := range overview.Gateways {
+		if gateway == nil {
+			continue
+		}
+
+		for _, tunnel := range gateway.Tunnels {
+			addTunnel(tunnel, resourceName(gateway.Network))
+		}
+	}
+
+	for _, tunnel := range overview.OrphanTunnels {
+		addTunnel(tunnel, "")
+	}
+
+	return prefixes, nil
+}
+
+// isDefaultRoute reports whether cidr is 0.0.0.0/0 or ::/0.
+func isDefaultRoute(cidr string) bool {
+	_, network, err := net.ParseCIDR(strings.TrimSpace(cidr))
+	if err != nil {
+		return false
+	}
+
+	ones, _ := network.Mask.Size()
+
+	return ones == 0
+}
+
+// prefixBounds is a prefix with its first and last address, see ipRangeBounds.
+type prefixBounds struct {
+	prefix NetworkPrefix
+	first  []byte
+	last   []byte
+}
+
+// FindOverlaps returns every pair of prefixes sharing addresses, ordered by network and address.
+// Identical prefixes learned over redundant tunnels of the same network are not reported,
+// nor are ranges of the same subnet.
+func FindOverlaps(prefixes []NetworkPrefix) []PrefixOverlap {
+	bounds := sortedPrefixBounds(prefixes)
+	overlaps := make([]PrefixOverlap, 0)
+
+	for i := range bounds {
+		for j := i + 1; j < len(bounds); j++ {
+			// Sorted by first address: once a prefix starts after the end of i, none of the following overlap it
+			if bytes.Compare(bounds[j].first, bounds[i].last) > 0 {
+				break
+			}
+
+			a, b := bounds[i].prefix, bounds[j].prefix
+			if redundantOverlap(a, b) {
+				continue
+			}
+
+			if overlapLess(b, a) {
+				a, b = b, a
+			}
+			overlaps = append(overlaps, PrefixOverlap{A: a, B: b})
+		}
+	}
+
+	sort.SliceStable(overlaps, func(i, j int) bool {
+		if overlaps[i].A.Key() != overlaps[j].A.Key() {
+			return overlaps[i].A.Key() < overlaps[j].A.Key()
+		}
+		if overlaps[i].B.Key() != overlaps[j].B.Key() {
+			return overlaps[i].B.Key() < overlaps[j].B.Key()
+		}
+
+		first, _ := associationAddressKey(overlaps[i].A.CIDR)
+		second, _ := associationAddressKey(overlaps[j].A.CIDR)
+
+		return bytes.Compare(first, second) < 0
+	})
+
+	return overlaps
+}
+
+// FindConflicts returns the prefixes overlapping the candidate range, ordered by address.
+func FindConflicts(candidate *net.IPNet, prefixes []NetworkPrefix) []NetworkPrefix {
+	if candidate == nil {
+		return nil
+	}
+
+	first, last := ipRangeBounds(candidate)
+	conflicts := make([]NetworkPrefix, 0)
+	for _, b := range sortedPrefixBounds(prefixes) {
+		if bytes.Compare(b.first, last) <= 0 && bytes.Compare(b.last, first) >= 0 {
+			conflicts = append(conflicts, b.prefix)
+		}
+	}
+
+	return conflicts
+}
+
+// sortedPrefixBounds parses and deduplicates prefixes, sorted by first address.
+func sortedPrefixBounds(prefixes []NetworkPrefix) []prefixBounds {
+	seen := make(map[NetworkPrefix]struct{}, len(prefixes))
+	bounds := make([]prefixBounds, 0, len(prefixes))
+
+	for _, prefix := range prefixes {
+		_, network, err := net.ParseCIDR(strings.TrimSpace(prefix.CIDR))
+		if err != nil {
+			continue
+		}
+
+		prefix.CIDR = network.String()
+		if _, ok := seen[prefix]; ok {
+			continue
+		}
+		seen[prefix] = struct{}{}
+
+		first, last := ipRangeBounds(network)
+		bounds = append(bounds, prefixBounds{prefix: prefix, first: first, last: last})
+	}
+
+	sort.SliceStable(bounds, func(i, j int) bool {
+		if cmp := bytes.Compare(bounds[i].first, bounds[j].first); cmp != 0 {
+			return cmp < 0
+		}
+		return bytes.Compare(bounds[i].last, bounds[j].last) > 0
+	})
+
+	return bounds
+}
+
+// ipRangeBounds returns the first and last address of a network in a comparable form:
+// the address family followed by the 16-byte address, so IPv4 and IPv6 prefixes never overlap.
+func ipRangeBounds(network *net.IPNet) ([]byte, []byte) {
+	family := byte(6)
+	mask := network.Mask
+	if len(mask) == net.IPv4len {
+		family = 4
+		mask = append(net.CIDRMask(96, 128)[:12:12], mask...)
+	}
+
+	ip := network.IP.To16()
+	first := make([]byte, 1+net.IPv6len)
+	last := make([]byte, 1+net.IPv6len)
+	first[0], last[0] = family, family
+	for i := range ip {
+		first[1+i] = ip[i] & mask[i]
+		last[1+i] = ip[i] | ^mask[i]
+	}
+
+	return first, last
+}
+
+// redundantOverlap reports overlaps that are expected rather than conflicts.
+func redundantOverlap(a, b NetworkPrefix) bool {
+	if a.Source == PrefixSourceSubnet && b.Source == PrefixSourceSubnet {
+		return a.Project == b.Project && a.Region == b.Region && a.Resource == b.Resource
+	}
+
+	if a.Source == PrefixSourceBGP && b.Source == PrefixSourceBGP {
+		return a.Key() == b.Key() && a.CIDR == b.CIDR
+	}
+
+	return false
+}
+
+func overlapLess(a, b NetworkPrefix) bool {
+	if a.Key() != b.Key() {
+		return a.Key() < b.Key()
+	}
+	if a.Source != b.Source {
+		return a.Source == PrefixSourceSubnet
+	}
+
+	return a.Resource < b.Resource
+}
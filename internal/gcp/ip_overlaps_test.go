@@ -0,0 +1,80 @@
+package gcp
+
+import (
+	"testing"
+
+	"github.com/kedare/compass/internal/cache"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubnetPrefixes(t *testing.T) {
+	prefixes := SubnetPrefixes([]*cache.SubnetEntry{{
+		Project:         "proj-a",
+		Network:         "vpc-1",
+		Region:          "us-central1",
+		Name:            "subnet-a",
+		PrimaryCIDR:     "10.0.0.0/24",
+		SecondaryRanges: []cache.SubnetSecondaryRange{{Name: "pods", CIDR: "10.4.0.0/14"}},
+		IPv6CIDR:        "2600:abcd::/64",
+	}})
+
+	require.Len(t, prefixes, 3)
+	require.Equal(t, "primary", prefixes[0].Range)
+	require.Equal(t, "secondary:pods", prefixes[1].Range)
+	require.Equal(t, "ipv6", prefixes[2].Range)
+	require.Equal(t, PrefixSourceSubnet, prefixes[0].Source)
+	require.Equal(t, "proj-a/vpc-1", prefixes[0].Key())
+}
+
+func TestFindOverlaps(t *testing.T) {
+	prefixes := []NetworkPrefix{
+		{Project: "proj-b", Network: "vpc-2", Region: "europe-west1", Source: PrefixSourceSubnet, Resource: "subnet-b", Range: "primary", CIDR: "10.0.0.128/25"},
+		{Project: "proj-a", Network: "vpc-1", Region: "us-central1", Source: PrefixSourceSubnet, Resource: "subnet-a", Range: "primary", CIDR: "10.0.0.0/24"},
+		{Project: "proj-a", Network: "vpc-1", Region: "us-central1", Source: PrefixSourceSubnet, Resource: "subnet-c", Range: "primary", CIDR: "10.1.0.0/24"},
+		{Project: "proj-a", Network: "vpc-1", Region: "us-central1", Source: PrefixSourceBGP, Resource: "tunnel-1/peer-1", Range: "learned", CIDR: "172.16.0.0/12"},
+		{Project: "proj-a", Network: "vpc-1", Region: "us-central1", Source: PrefixSourceBGP, Resource: "tunnel-2/peer-2", Range: "learned", CIDR: "172.16.0.0/12"},
+		{Project: "proj-c", Network: "vpc-3", Region: "us-east1", Source: PrefixSourceSubnet, Resource: "subnet-d", Range: "primary", CIDR: "172.20.0.0/24"},
+		{Project: "proj-c", Network: "vpc-3", Region: "us-east1", Source: PrefixSourceSubnet, Resource: "subnet-e", Range: "ipv6", CIDR: "::ffff:10.0.0.0/120"},
+	}
+
+	overlaps := FindOverlaps(prefixes)
+
+	pairs := make([][2]string, 0, len(overlaps))
+	for _, overlap := range overlaps {
+		pairs = append(pairs, [2]string{overlap.A.Resource, overlap.B.Resource})
+	}
+
+	require.Equal(t, [][2]string{
+		{"subnet-a", "subnet-b"},
+		{"tunnel-1/peer-1", "subnet-d"},
+		{"tunnel-2/peer-2", "subnet-d"},
+	}, pairs, "redundant tunnels learning the same prefix are not reported")
+}
+
+func TestFindConflicts(t *testing.T) {
+	prefixes := []NetworkPrefix{
+		{Project: "proj-a", Network: "vpc-1", Source: PrefixSourceSubnet, Resource: "subnet-a", Range: "primary", CIDR: "10.40.8.0/24"},
+		{Project: "proj-a", Network: "vpc-1", Source: PrefixSourceSubnet, Resource: "subnet-b", Range: "primary", CIDR: "10.0.0.0/8"},
+		{Project: "proj-a", Network: "vpc-1", Source: PrefixSourceSubnet, Resource: "subnet-c", Range: "primary", CIDR: "10.41.0.0/24"},
+		{Project: "proj-a", Network: "vpc-1", Source: PrefixSourceSubnet, Resource: "subnet-d", Range: "ipv6", CIDR: "2600:abcd::/64"},
+	}
+
+	candidate, ok := ParseIPPrefix("10.40.0.0/20")
+	require.True(t, ok)
+
+	conflicts := FindConflicts(candidate, prefixes)
+	require.Len(t, conflicts, 2)
+	require.Equal(t, "subnet-b", conflicts[0].Resource)
+	require.Equal(t, "subnet-a", conflicts[1].Resource)
+
+	free, ok := ParseIPPrefix("192.168.0.0/16")
+	require.True(t, ok)
+	require.Empty(t, FindConflicts(free, prefixes))
+}
+
+func TestIsDefaultRoute(t *testing.T) {
+	require.True(t, isDefaultRoute("0.0.0.0/0"))
+	require.True(t, isDefaultRoute("::/0"))
+	require.False(t, isDefaultRoute("10.0.0.0/8"))
+	require.False(t, isDefaultRoute("garbage"))
+}
@@ -0,0 +1,267 @@
+package gcp
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+)
+
+const (
+	// FirewallActionAllow marks a firewall rule allowing the matched traffic.
+	FirewallActionAllow = "allow"
+	// FirewallActionDeny marks a firewall rule denying the matched traffic.
+	FirewallActionDeny = "deny"
+
+	// FirewallRangeSource marks an address matched by the source ranges of a rule.
+	FirewallRangeSource = "source"
+	// FirewallRangeDestination marks an address matched by the destination ranges of a rule.
+	FirewallRangeDestination = "destination"
+)
+
+// IPPolicy lists the firewall rules and routes whose ranges contain a looked up address.
+type IPPolicy struct {
+	FirewallRules []FirewallRuleMatch `json:"firewall_rules"`
+	Routes        []RouteMatch        `json:"routes"`
+}
+
+// FirewallRuleMatch is a firewall rule with a source or destination range containing the address.
+type FirewallRuleMatch struct {
+	Project   string `json:"project"`
+	Name      string `json:"name"`
+	Network   string `json:"network"`
+	Direction string `json:"direction"`
+	// Action is FirewallActionAllow or FirewallActionDeny.
+	Action    string   `json:"action"`
+	Protocols []string `json:"protocols,omitempty"`
+	Priority  int64    `json:"priority"`
+	Disabled  bool     `json:"disabled,omitempty"`
+	// Field is FirewallRangeSource or FirewallRangeDestination.
+	Field string `json:"field"`
+	// Range is the most specific range of the rule containing the address.
+	Range      string   `json:"range"`
+	TargetTags []string `json:"target_tags,omitempty"`
+}
+
+// RouteMatch is a route whose destination range contains the address.
+type RouteMatch struct {
+	Project   string   `json:"project"`
+	Name      string   `json:"name"`
+	Network   string   `json:"network"`
+	DestRange string   `json:"dest_range"`
+	NextHop   string   `json:"next_hop,omitempty"`
+	RouteType string   `json:"route_type,omitempty"`
+	Priority  int64    `json:"priority"`
+	Tags      []string `json:"tags,omitempty"`
+}
+
+// LookupIPPolicy returns, for each target address or CIDR prefix, the firewall rules of the
+// project whose source or destination ranges contain it and the routes whose destination range
+// contains it. Firewall rules and routes are listed only once for all targets.
+func (c *Client) LookupIPPolicy(ctx context.Context, targets []string) (map[string]IPPolicy, error) {
+	if c == nil || c.service == nil {
+		return nil, fmt.Errorf("client is not initialized")
+	}
+
+	rules, err := c.ListFirewallRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	routes, err := c.ListRoutes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	policies := make(map[string]IPPolicy, len(targets))
+	for _, target := range targets {
+		network, ok := parsePolicyRange(target)
+		if !ok {
+			return nil, fmt.Errorf("invalid IP address or CIDR prefix: %s", target)
+		}
+
+		policy := IPPolicy{
+			FirewallRules: matchFirewallRules(c.project, rules, network),
+			Routes:        matchRoutes(c.project, routes, network),
+		}
+		SortIPPolicy(&policy)
+		policies[target] = policy
+	}
+
+	return policies, nil
+}
+
+// SortIPPolicy orders firewall rules by evaluation order (priority, deny rules first at equal
+// priority) and routes from the longest destination prefix, then by priority.
+func SortIPPolicy(policy *IPPolicy) {
+	if policy == nil {
+		return
+	}
+
+	sort.SliceStable(policy.FirewallRules, func(i, j int) bool {
+		a, b := policy.FirewallRules[i], policy.FirewallRules[j]
+		if a.Priority != b.Priority {
+			return a.Priority < b.Priority
+		}
+
+		if a.Action != b.Action {
+			return a.Action == FirewallActionDeny
+		}
+
+		if a.Project != b.Project {
+			return a.Project < b.Project
+		}
+
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+
+		return a.Field < b.Field
+	})
+
+	sort.SliceStable(policy.Routes, func(i, j int) bool {
+		a, b := policy.Routes[i], policy.Routes[j]
+		if lenA, lenB := prefixLength(a.DestRange), prefixLength(b.DestRange); lenA != lenB {
+			return lenA > lenB
+		}
+
+		if a.Priority != b.Priority {
+			return a.Priority < b.Priority
+		}
+
+		if a.Project != b.Project {
+			return a.Project < b.Project
+		}
+
+		return a.Name < b.Name
+	})
+}
+
+// matchFirewallRules returns the rules with a source or destination range containing the network,
+// once per matching field.
+func matchFirewallRules(project string, rules []*FirewallRule, network *net.IPNet) []FirewallRuleMatch {
+	matches := make([]FirewallRuleMatch, 0)
+	for _, rule := range rules {
+		if rule == nil {
+			continue
+		}
+
+		action, protocols := FirewallActionAllow, rule.Allowed
+		if len(rule.Denied) > 0 {
+			action, protocols = FirewallActionDeny, rule.Denied
+		}
+
+		fields := []struct {
+			name   string
+			ranges []string
+		}{
+			{FirewallRangeSource, rule.SourceRanges},
+			{FirewallRangeDestination, rule.DestinationRanges},
+		}
+
+		for _, field := range fields {
+			matched, ok := mostSpecificRange(field.ranges, network)
+			if !ok {
+				continue
+			}
+
+			matches = append(matches, FirewallRuleMatch{
+				Project:    project,
+				Name:       rule.Name,
+				Network:    rule.Network,
+				Direction:  strings.ToLower(rule.Direction),
+				Action:     action,
+				Protocols:  protocols,
+				Priority:   rule.Priority,
+				Disabled:   rule.Disabled,
+				Field:      field.name,
+				Range:      matched,
+				TargetTags: rule.TargetTags,
+			})
+		}
+	}
+
+	return matches
+}
+
+// matchRoutes returns the routes whose destination range contains the network.
+func matchRoutes(project string, routes []*Route, network *net.IPNet) []RouteMatch {
+	matches := make([]RouteMatch, 0)
+	for _, route := range routes {
+		if route == nil {
+			continue
+		}
+
+		if _, ok := mostSpecificRange([]string{route.DestRange}, network); !ok {
+			continue
+		}
+
+		matches = append(matches, RouteMatch{
+			Project:   project,
+			Name:      route.Name,
+			Network:   route.Network,
+			DestRange: route.DestRange,
+			NextHop:   route.NextHop,
+			RouteType: strings.ToLower(route.RouteType),
+			Priority:  route.Priority,
+			Tags:      route.Tags,
+		})
+	}
+
+	return matches
+}
+
+// mostSpecificRange returns the longest of the ranges containing the whole network.
+func mostSpecificRange(ranges []string, network *net.IPNet) (string, bool) {
+	networkOnes, networkBits := network.Mask.Size()
+
+	best, bestOnes := "", -1
+	for _, value := range ranges {
+		candidate, ok := parsePolicyRange(value)
+		if !ok {
+			continue
+		}
+
+		ones, bits := candidate.Mask.Size()
+		if bits != networkBits || ones > networkOnes || !candidate.Contains(network.IP) {
+			continue
+		}
+
+		if ones > bestOnes {
+			best, bestOnes = strings.TrimSpace(value), ones
+		}
+	}
+
+	return best, bestOnes >= 0
+}
+
+// parsePolicyRange parses a CIDR prefix or a single address, taken as a host prefix.
+func parsePolicyRange(value string) (*net.IPNet, bool) {
+	if prefix, ok := ParseIPPrefix(value); ok {
+		return prefix, true
+	}
+
+	ip := net.ParseIP(strings.TrimSpace(value))
+	if ip == nil {
+		return nil, false
+	}
+
+	if ip4 := ip.To4(); ip4 != nil {
+		return &net.IPNet{IP: ip4, Mask: net.CIDRMask(32, 32)}, true
+	}
+
+	return &net.IPNet{IP: ip, Mask: net.CIDRMask(128, 128)}, true
+}
+
+// prefixLength returns the prefix length of a range, or -1 when it cannot be parsed.
+func prefixLength(value string) int {
+	prefix, ok := parsePolicyRange(value)
+	if !ok {
+		return -1
+	}
+
+	ones, _ := prefix.Mask.Size()
+
+	return ones
+}
@@ -0,0 +1,81 @@
+package gcp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchFirewallRules(t *testing.T) {
+	rules := []*FirewallRule{
+		{Name: "allow-internal", Network: "vpc", Direction: "INGRESS", Priority: 1000, SourceRanges: []string{"10.0.0.0/8", "10.128.0.0/9"}, Allowed: []string{"tcp", "udp"}},
+		{Name: "deny-db", Network: "vpc", Direction: "INGRESS", Priority: 1000, SourceRanges: []string{"10.128.0.5"}, Denied: []string{"tcp:5432"}},
+		{Name: "egress-onprem", Network: "vpc", Direction: "EGRESS", Priority: 900, DestinationRanges: []string{"10.128.0.0/16"}, Allowed: []string{"all"}},
+		{Name: "allow-ipv6", Network: "vpc", Direction: "INGRESS", Priority: 1000, SourceRanges: []string{"::/0"}, Allowed: []string{"tcp:443"}},
+		{Name: "other", Network: "vpc", Direction: "INGRESS", Priority: 1000, SourceRanges: []string{"192.168.0.0/16"}, Allowed: []string{"tcp:22"}},
+	}
+
+	network, ok := parsePolicyRange("10.128.0.5")
+	require.True(t, ok)
+
+	policy := IPPolicy{FirewallRules: matchFirewallRules("proj", rules, network)}
+	SortIPPolicy(&policy)
+
+	names := make([]string, 0, len(policy.FirewallRules))
+	for _, match := range policy.FirewallRules {
+		names = append(names, match.Name)
+	}
+	require.Equal(t, []string{"egress-onprem", "deny-db", "allow-internal"}, names)
+
+	require.Equal(t, FirewallRangeDestination, policy.FirewallRules[0].Field)
+	require.Equal(t, "egress", policy.FirewallRules[0].Direction)
+	require.Equal(t, FirewallActionDeny, policy.FirewallRules[1].Action)
+	require.Equal(t, []string{"tcp:5432"}, policy.FirewallRules[1].Protocols)
+	require.Equal(t, "10.128.0.0/9", policy.FirewallRules[2].Range, "the most specific range is reported")
+
+	prefix, ok := parsePolicyRange("10.128.0.0/20")
+	require.True(t, ok)
+	matches := matchFirewallRules("proj", rules, prefix)
+	require.Len(t, matches, 2, "a prefix only matches ranges containing all of it")
+}
+
+func TestMatchRoutes(t *testing.T) {
+	routes := []*Route{
+		{Name: "default-internet", Network: "vpc", DestRange: "0.0.0.0/0", Priority: 1000, NextHop: "default-internet-gateway", RouteType: "STATIC"},
+		{Name: "to-onprem", Network: "vpc", DestRange: "10.128.0.0/16", Priority: 100, NextHop: "tunnel-1", RouteType: "STATIC"},
+		{Name: "to-onprem-backup", Network: "vpc", DestRange: "10.128.0.0/16", Priority: 200, NextHop: "tunnel-2", RouteType: "STATIC"},
+		{Name: "subnet", Network: "vpc", DestRange: "10.128.0.0/20", Priority: 0, RouteType: "SUBNET"},
+		{Name: "ipv6-default", Network: "vpc", DestRange: "::/0", Priority: 1000},
+		{Name: "elsewhere", Network: "vpc", DestRange: "192.168.0.0/16", Priority: 1000},
+	}
+
+	network, ok := parsePolicyRange("10.128.0.5")
+	require.True(t, ok)
+
+	policy := IPPolicy{Routes: matchRoutes("proj", routes, network)}
+	SortIPPolicy(&policy)
+
+	names := make([]string, 0, len(policy.Routes))
+	for _, match := range policy.Routes {
+		names = append(names, match.Name)
+	}
+	require.Equal(t, []string{"subnet", "to-onprem", "to-onprem-backup", "default-internet"}, names)
+	require.Equal(t, "static", policy.Routes[1].RouteType)
+	require.Equal(t, "tunnel-1", policy.Routes[1].NextHop)
+}
+
+func TestParsePolicyRange(t *testing.T) {
+	network, ok := parsePolicyRange("10.0.0.1")
+	require.True(t, ok)
+	require.Equal(t, "10.0.0.1/32", network.String())
+
+	network, ok = parsePolicyRange("2001:db8::1")
+	require.True(t, ok)
+	require.Equal(t, "2001:db8::1/128", network.String())
+
+	_, ok = parsePolicyRange("not-a-range")
+	require.False(t, ok)
+
+	require.Equal(t, 16, prefixLength("10.128.0.0/16"))
+	require.Equal(t, -1, prefixLength(""))
+}
@@ -0,0 +1,586 @@
+package gcp
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"math/bits"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/kedare/compass/internal/cache"
+	"github.com/kedare/compass/internal/logger"
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/container/v1"
+)
+
+const (
+	// RangePrimary identifies the primary IPv4 range of a subnet.
+	RangePrimary = "primary"
+	// RangeSecondary identifies a secondary IPv4 range of a subnet (e.g. GKE pods or services).
+	RangeSecondary = "secondary"
+	// RangeIPv6 identifies the internal IPv6 range of a dual-stack subnet.
+	RangeIPv6 = "ipv6"
+	// RangeExternalIPv6 identifies the external IPv6 range of a dual-stack subnet.
+	RangeExternalIPv6 = "external_ipv6"
+
+	// maxFreeBlocks bounds the number of free blocks reported per range.
+	maxFreeBlocks = 5
+
+	// ipv6UnitPrefixLength is the size of the blocks IPv6 ranges are counted in: Google Cloud
+	// hands out IPv6 addresses to instances and forwarding rules as /96 ranges.
+	ipv6UnitPrefixLength = 96
+)
+
+// SubnetUsage reports the address utilisation of the IPv4 and IPv6 ranges of a subnet.
+type SubnetUsage struct {
+	Project string       `json:"project"`
+	Network string       `json:"network"`
+	Region  string       `json:"region"`
+	Subnet  string       `json:"subnet"`
+	Ranges  []RangeUsage `json:"ranges"`
+}
+
+// RangeUsage reports how many addresses of a subnet range are allocated.
+type RangeUsage struct {
+	// Name is "primary", the name of the secondary range, "ipv6" or "external_ipv6".
+	Name string `json:"name"`
+	// Kind is RangePrimary, RangeSecondary, RangeIPv6 or RangeExternalIPv6.
+	Kind string `json:"kind"`
+	CIDR string `json:"cidr"`
+	// UnitPrefixLength is set when the counts below are blocks rather than addresses,
+	// to 96 for IPv6 ranges counted in /96 blocks.
+	UnitPrefixLength int `json:"unit_prefix_length,omitempty"`
+	// Total is the size of the range, Reserved the addresses Google Cloud keeps for itself
+	// (network, gateway, second-to-last and broadcast addresses of primary ranges).
+	Total    uint64 `json:"total"`
+	Reserved uint64 `json:"reserved"`
+	Used     uint64 `json:"used"`
+	Free     uint64 `json:"free"`
+	// Utilization is the percentage of non-reserved addresses in use.
+	Utilization float64 `json:"utilization_percent"`
+	// NextFree is the lowest address (or /96 block) neither reserved nor allocated.
+	NextFree string `json:"next_free_ip,omitempty"`
+	// LargestFreeBlocks are the largest aligned CIDR blocks without any allocation.
+	LargestFreeBlocks []string        `json:"largest_free_blocks,omitempty"`
+	Allocations       []IPAssociation `json:"allocations,omitempty"`
+}
+
+// subnetRange is one IPv4 or IPv6 range of a cached subnet.
+type subnetRange struct {
+	name    string
+	kind    string
+	network *net.IPNet
+}
+
+// SubnetUsage computes the utilisation of the ranges of a subnet of the project from the
+// instance interfaces, alias IP ranges, forwarding rules and reserved addresses inside them.
+// Secondary ranges holding the services of a GKE cluster are counted as fully allocated to it.
+// When only is set, ranges not overlapping it are skipped.
+func (c *Client) SubnetUsage(ctx context.Context, entry *cache.SubnetEntry, only *net.IPNet) (*SubnetUsage, error) {
+	if c == nil || c.service == nil {
+		return nil, fmt.Errorf("client is not initialized")
+	}
+
+	if entry == nil {
+		return nil, fmt.Errorf("subnet is required")
+	}
+
+	ranges := subnetRanges(entry, only)
+	usage := &SubnetUsage{
+		Project: entry.Project,
+		Network: entry.Network,
+		Region:  entry.Region,
+		Subnet:  entry.Name,
+		Ranges:  make([]RangeUsage, 0, len(ranges)),
+	}
+
+	if len(ranges) == 0 {
+		return usage, nil
+	}
+
+	// Ranges grouped by address family (address length in bits)
+	families := make(map[int][]*net.IPNet)
+	order := make([]int, 0, 2)
+	for _, r := range ranges {
+		_, size := r.network.Mask.Size()
+		if _, ok := families[size]; !ok {
+			order = append(order, size)
+		}
+		families[size] = append(families[size], r.network)
+	}
+
+	// A single scan of the prefix covering the ranges of each family, split per range below
+	associations := make(map[int][]IPAssociation, len(families))
+	for _, size := range order {
+		covering := coveringPrefix(families[size])
+		logger.Log.Debugf("[%s] Collecting allocations of subnet %s inside %s", c.project, entry.Name, covering)
+
+		found, err := c.LookupCIDR(ctx, covering.String())
+		if err != nil {
+			return nil, err
+		}
+		associations[size] = found
+	}
+
+	var clusters []*container.Cluster
+	if hasSecondaryRange(ranges) {
+		var err error
+		if clusters, err = c.listContainerClusters(ctx); err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return nil, ctxErr
+			}
+			logger.Log.Debugf("[%s] Skipping GKE service ranges of subnet %s: %v", c.project, entry.Name, err)
+		}
+	}
+
+	for _, r := range ranges {
+		_, size := r.network.Mask.Size()
+		allocations := make([]IPAssociation, 0)
+		for _, assoc := range associations[size] {
+			if allocationInRange(assoc, entry, r.network) {
+				allocations = append(allocations, assoc)
+			}
+		}
+		allocations = append(allocations, serviceRangeAllocations(c.project, entry, r, clusters)...)
+
+		usage.Ranges = append(usage.Ranges, computeRangeUsage(r, allocations))
+	}
+
+	return usage, nil
+}
+
+// FindSubnets lists the subnets of the project with the given name, caching them.
+func (c *Client) FindSubnets(ctx context.Context, name string) ([]*cache.SubnetEntry, error) {
+	if c == nil || c.service == nil {
+		return nil, fmt.Errorf("client is not initialized")
+	}
+
+	call := c.service.Subnetworks.AggregatedList(c.project).Context(ctx).Filter(fmt.Sprintf("name = %q", name))
+
+	var entries []*cache.SubnetEntry
+	err := call.Pages(ctx, func(page *compute.SubnetworkAggregatedList) error {
+		for _, scopedList := range page.Items {
+			for _, subnet := range scopedList.Subnetworks {
+				if subnet == nil || subnet.Name != name {
+					continue
+				}
+
+				c.rememberSubnet(subnet)
+				entries = append(entries, c.subnetEntry(subnet))
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list subnets: %w", err)
+	}
+
+	return entries, nil
+}
+
+// subnetRanges returns the ranges of a subnet overlapping only (when set).
+func subnetRanges(entry *cache.SubnetEntry, only *net.IPNet) []subnetRange {
+	ranges := make([]subnetRange, 0, 3+len(entry.SecondaryRanges))
+	add := func(name, kind, cidr string) {
+		_, network, err := net.ParseCIDR(strings.TrimSpace(cidr))
+		if err != nil {
+			return
+		}
+
+		// Ranges too large to count in 64 bits of units are left out
+		if ones, _ := network.Mask.Size(); unitPrefixLength(network)-ones >= 64 {
+			return
+		}
+
+		if only != nil && !network.Contains(only.IP) && !only.Contains(network.IP) {
+			return
+		}
+
+		ranges = append(ranges, subnetRange{name: name, kind: kind, network: network})
+	}
+
+	add(RangePrimary, RangePrimary, entry.PrimaryCIDR)
+	for _, sr := range entry.SecondaryRanges {
+		add(sr.Name, RangeSecondary, sr.CIDR)
+	}
+	add(RangeIPv6, RangeIPv6, entry.IPv6CIDR)
+	add(RangeExternalIPv6, RangeExternalIPv6, entry.ExternalIPv6CIDR)
+
+	return ranges
+}
+
+// hasSecondaryRange reports whether one of the ranges is a secondary range.
+func hasSecondaryRange(ranges []subnetRange) bool {
+	for _, r := range ranges {
+		if r.kind == RangeSecondary {
+			return true
+		}
+	}
+
+	return false
+}
+
+// serviceRangeAllocations returns the GKE clusters using a secondary range of the subnet for
+// their services. GKE hands out service IPs without any Compute Engine resource, so the whole
+// range is allocated to the cluster rather than reported as free.
+func serviceRangeAllocations(project string, entry *cache.SubnetEntry, r subnetRange, clusters []*container.Cluster) []IPAssociation {
+	if r.kind != RangeSecondary {
+		return nil
+	}
+
+	allocations := make([]IPAssociation, 0)
+	for _, cluster := range clusters {
+		if cluster == nil || cluster.IpAllocationPolicy == nil {
+			continue
+		}
+
+		if lastComponent(cluster.Subnetwork) != entry.Name {
+			continue
+		}
+
+		if network := lastComponent(cluster.Network); network != "" && entry.Network != "" && network != entry.Network {
+			continue
+		}
+
+		policy := cluster.IpAllocationPolicy
+		if policy.ServicesSecondaryRangeName != "" {
+			if policy.ServicesSecondaryRangeName != r.name {
+				continue
+			}
+		} else if _, block, err := net.ParseCIDR(strings.TrimSpace(policy.ServicesIpv4CidrBlock)); err != nil || block.String() != r.network.String() {
+			continue
+		}
+
+		allocations = append(allocations, IPAssociation{
+			Project:      project,
+			Kind:         IPAssociationGKEServiceRange,
+			Resource:     cluster.Name,
+			Location:     cluster.Location,
+			IPAddress:    r.network.String(),
+			Details:      joinDetailParts([]string{"Service range of cluster", detailPart("range", secondaryRangeName(r.name))}),
+			Network:      lastComponent(cluster.Network),
+			Subnet:       lastComponent(cluster.Subnetwork),
+			ResourceLink: cluster.SelfLink,
+		})
+	}
+
+	return allocations
+}
+
+// allocationInRange reports whether an association allocates addresses of the subnet range.
+// Associations naming another subnet or network (e.g. a peered VPC reusing the range) are ignored.
+func allocationInRange(assoc IPAssociation, entry *cache.SubnetEntry, network *net.IPNet) bool {
+	switch assoc.Kind {
+	case IPAssociationInstanceInternal, IPAssociationInstanceExternal, IPAssociationAliasRange,
+		IPAssociationForwardingRule, IPAssociationPSCEndpoint, IPAssociationAddress:
+	default:
+		return false
+	}
+
+	if assoc.Subnet != "" && assoc.Subnet != entry.Name {
+		return false
+	}
+
+	if assoc.Network != "" && entry.Network != "" && assoc.Network != entry.Network {
+		return false
+	}
+
+	_, ok := unitInterval(network, assoc.IPAddress)
+
+	return ok
+}
+
+// computeRangeUsage counts the reserved, used and free addresses of a range, or /96 blocks
+// of an IPv6 range.
+func computeRangeUsage(r subnetRange, allocations []IPAssociation) RangeUsage {
+	ones, size := r.network.Mask.Size()
+	unit := unitPrefixLength(r.network)
+	total := uint64(1) << uint(unit-ones)
+	end := total - 1
+
+	var reserved []addressInterval
+	if r.kind == RangePrimary && size == 8*net.IPv4len && total >= 4 {
+		reserved = []addressInterval{
+			{0, 1},
+			{end - 1, end},
+		}
+	}
+
+	used := make([]addressInterval, 0, len(allocations))
+	for _, assoc := range allocations {
+		if interval, ok := unitInterval(r.network, assoc.IPAddress); ok {
+			used = append(used, interval)
+		}
+	}
+
+	reservedMerged := mergeIntervals(reserved)
+	taken := mergeIntervals(append(append([]addressInterval{}, reserved...), used...))
+
+	reservedCount := intervalsSize(reservedMerged)
+	usedCount := intervalsSize(taken) - reservedCount
+	free := freeIntervals(taken, 0, end)
+
+	usage := RangeUsage{
+		Name:        r.name,
+		Kind:        r.kind,
+		CIDR:        r.network.String(),
+		Total:       total,
+		Reserved:    reservedCount,
+		Used:        usedCount,
+		Free:        total - reservedCount - usedCount,
+		Allocations: allocations,
+	}
+
+	if unit != size {
+		usage.UnitPrefixLength = unit
+	}
+
+	if usable := total - reservedCount; usable > 0 {
+		usage.Utilization = float64(usedCount) / float64(usable) * 100
+	}
+
+	if len(free) > 0 {
+		usage.NextFree = unitAddress(r.network, free[0].start).String()
+		usage.LargestFreeBlocks = largestFreeBlocks(r.network, free, maxFreeBlocks)
+	}
+
+	return usage
+}
+
+// addressInterval is an inclusive range of units (addresses, or /96 blocks for IPv6)
+// counted from the start of a subnet range.
+type addressInterval struct {
+	start uint64
+	end   uint64
+}
+
+// unitPrefixLength returns the prefix length of the units a range is counted in: single
+// addresses for IPv4, /96 blocks for IPv6.
+func unitPrefixLength(network *net.IPNet) int {
+	ones, size := network.Mask.Size()
+	if size == 8*net.IPv4len || ones >= ipv6UnitPrefixLength {
+		return size
+	}
+
+	return ipv6UnitPrefixLength
+}
+
+// unitInterval returns the units of the range covered by an address or CIDR, clipped to the
+// range. It reports false when the value is of another family or outside the range.
+func unitInterval(network *net.IPNet, value string) (addressInterval, bool) {
+	start, end, size, ok := parseAddressInterval(value)
+	if _, rangeSize := network.Mask.Size(); !ok || size != rangeSize {
+		return addressInterval{}, false
+	}
+
+	rangeStart, rangeEnd := prefixInterval(network)
+	if start.Cmp(rangeEnd) > 0 || end.Cmp(rangeStart) < 0 {
+		return addressInterval{}, false
+	}
+
+	if start.Cmp(rangeStart) < 0 {
+		start = rangeStart
+	}
+	if end.Cmp(rangeEnd) > 0 {
+		end = rangeEnd
+	}
+
+	shift := uint(size - unitPrefixLength(network))
+	first := new(big.Int).Rsh(new(big.Int).Sub(start, rangeStart), shift)
+	last := new(big.Int).Rsh(new(big.Int).Sub(end, rangeStart), shift)
+
+	return addressInterval{first.Uint64(), last.Uint64()}, true
+}
+
+// unitAddress returns the first address of the unit at index of the range.
+func unitAddress(network *net.IPNet, index uint64) net.IP {
+	_, size := network.Mask.Size()
+	start, _ := prefixInterval(network)
+
+	offset := new(big.Int).Lsh(new(big.Int).SetUint64(index), uint(size-unitPrefixLength(network)))
+
+	return bigToIP(new(big.Int).Add(start, offset), size)
+}
+
+// parseAddressInterval parses an address or CIDR into its inclusive address range and the
+// length of its addresses in bits (32 or 128).
+func parseAddressInterval(value string) (*big.Int, *big.Int, int, bool) {
+	value = strings.TrimSpace(value)
+	if strings.Contains(value, "/") {
+		_, network, err := net.ParseCIDR(value)
+		if err != nil {
+			return nil, nil, 0, false
+		}
+
+		start, end := prefixInterval(network)
+		_, size := network.Mask.Size()
+
+		return start, end, size, true
+	}
+
+	ip := net.ParseIP(value)
+	if ip == nil {
+		return nil, nil, 0, false
+	}
+
+	if v4 := ip.To4(); v4 != nil {
+		ip = v4
+	}
+	addr := new(big.Int).SetBytes(ip)
+
+	return addr, addr, 8 * len(ip), true
+}
+
+// prefixInterval returns the first and last address of a prefix.
+func prefixInterval(network *net.IPNet) (*big.Int, *big.Int) {
+	ones, size := network.Mask.Size()
+
+	ip := network.IP.To16()
+	if size == 8*net.IPv4len {
+		ip = network.IP.To4()
+	}
+
+	start := new(big.Int).SetBytes(ip)
+	end := new(big.Int).Lsh(big.NewInt(1), uint(size-ones))
+	end.Add(end, start)
+	end.Sub(end, big.NewInt(1))
+
+	return start, end
+}
+
+// bigToIP converts an address held in a big integer back to an IP of size bits.
+func bigToIP(value *big.Int, size int) net.IP {
+	return value.FillBytes(make(net.IP, size/8))
+}
+
+// mergeIntervals sorts and merges overlapping or adjacent intervals.
+func mergeIntervals(intervals []addressInterval) []addressInterval {
+	if len(intervals) == 0 {
+		return nil
+	}
+
+	sorted := append([]addressInterval{}, intervals...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].start < sorted[j].start })
+
+	merged := []addressInterval{sorted[0]}
+	for _, next := range sorted[1:] {
+		last := &merged[len(merged)-1]
+		if next.start <= last.end+1 {
+			last.end = max(last.end, next.end)
+			continue
+		}
+		merged = append(merged, next)
+	}
+
+	return merged
+}
+
+func intervalsSize(intervals []addressInterval) uint64 {
+	var size uint64
+	for _, interval := range intervals {
+		size += interval.end - interval.start + 1
+	}
+
+	return size
+}
+
+// freeIntervals returns the gaps between merged taken intervals within [start, end].
+func freeIntervals(taken []addressInterval, start, end uint64) []addressInterval {
+	free := make([]addressInterval, 0)
+	next := start
+	for _, interval := range taken {
+		if interval.start > next {
+			free = append(free, addressInterval{next, interval.start - 1})
+		}
+		next = max(next, interval.end+1)
+	}
+
+	if next <= end {
+		free = append(free, addressInterval{next, end})
+	}
+
+	return free
+}
+
+// largestFreeBlocks splits free intervals of a range into aligned CIDR blocks and returns
+// the largest ones, lowest address first among blocks of the same size.
+func largestFreeBlocks(network *net.IPNet, free []addressInterval, limit int) []string {
+	ones, _ := network.Mask.Size()
+	unit := unitPrefixLength(network)
+	width := unit - ones
+
+	type block struct {
+		start uint64
+		bits  int
+	}
+
+	blocks := make([]block, 0)
+	for _, interval := range free {
+		for current := interval.start; current <= interval.end; {
+			// Largest power of two both aligned on current and fitting the interval
+			hostBits := width
+			if current != 0 {
+				hostBits = min(bits.TrailingZeros64(current), width)
+			}
+			for hostBits > 0 && current+(uint64(1)<<uint(hostBits))-1 > interval.end {
+				hostBits--
+			}
+
+			blocks = append(blocks, block{start: current, bits: unit - hostBits})
+			current += uint64(1) << uint(hostBits)
+		}
+	}
+
+	sort.SliceStable(blocks, func(i, j int) bool {
+		if blocks[i].bits != blocks[j].bits {
+			return blocks[i].bits < blocks[j].bits
+		}
+		return blocks[i].start < blocks[j].start
+	})
+
+	if len(blocks) > limit {
+		blocks = blocks[:limit]
+	}
+
+	result := make([]string, 0, len(blocks))
+	for _, b := range blocks {
+		result = append(result, fmt.Sprintf("%s/%d", unitAddress(network, b.start), b.bits))
+	}
+
+	return result
+}
+
+// coveringPrefix returns the smallest prefix containing every network, all of the same family.
+func coveringPrefix(networks []*net.IPNet) *net.IPNet {
+	first := networks[0]
+	ones, size := first.Mask.Size()
+	for _, network := range networks[1:] {
+		networkOnes, _ := network.Mask.Size()
+		ones = min(ones, networkOnes, commonPrefixLength(first.IP, network.IP))
+	}
+
+	mask := net.CIDRMask(ones, size)
+
+	return &net.IPNet{IP: first.IP.Mask(mask), Mask: mask}
+}
+
+// commonPrefixLength returns the number of leading bits two addresses of the same family share.
+func commonPrefixLength(a, b net.IP) int {
+	a16, b16 := a.To16(), b.To16()
+
+	offset := 0
+	if a.To4() != nil {
+		offset = 8 * (net.IPv6len - net.IPv4len)
+	}
+
+	for i := range a16 {
+		if diff := a16[i] ^ b16[i]; diff != 0 {
+			return 8*i + bits.LeadingZeros8(diff) - offset
+		}
+	}
+
+	return 8*net.IPv6len - offset
+}
@@ -0,0 +1,163 @@
+package gcp
+
+import (
+	"net"
+	"testing"
+
+	"github.com/kedare/compass/internal/cache"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/container/v1"
+)
+
+func TestComputeRangeUsagePrimary(t *testing.T) {
+	_, network, err := net.ParseCIDR("10.0.0.0/28")
+	require.NoError(t, err)
+
+	usage := computeRangeUsage(subnetRange{name: RangePrimary, kind: RangePrimary, network: network}, []IPAssociation{
+		{Kind: IPAssociationInstanceInternal, IPAddress: "10.0.0.2"},
+		{Kind: IPAssociationAddress, IPAddress: "10.0.0.2"},
+		{Kind: IPAssociationForwardingRule, IPAddress: "10.0.0.3"},
+		{Kind: IPAssociationAliasRange, IPAddress: "10.0.0.8/30"},
+	})
+
+	require.Equal(t, uint64(16), usage.Total)
+	require.Equal(t, uint64(4), usage.Reserved)
+	require.Equal(t, uint64(6), usage.Used, "addresses shared by several resources are counted once")
+	require.Equal(t, uint64(6), usage.Free)
+	require.InDelta(t, 50.0, usage.Utilization, 0.001)
+	require.Equal(t, "10.0.0.4", usage.NextFree)
+	require.Equal(t, []string{"10.0.0.4/30", "10.0.0.12/31"}, usage.LargestFreeBlocks)
+}
+
+func TestComputeRangeUsageSecondary(t *testing.T) {
+	_, network, err := net.ParseCIDR("10.4.0.0/24")
+	require.NoError(t, err)
+
+	usage := computeRangeUsage(subnetRange{name: "pods", kind: RangeSecondary, network: network}, nil)
+
+	require.Equal(t, uint64(256), usage.Total)
+	require.Equal(t, uint64(0), usage.Reserved, "secondary ranges have no reserved addresses")
+	require.Equal(t, uint64(256), usage.Free)
+	require.Equal(t, "10.4.0.0", usage.NextFree)
+	require.Equal(t, []string{"10.4.0.0/24"}, usage.LargestFreeBlocks)
+}
+
+func TestComputeRangeUsageIPv6(t *testing.T) {
+	_, network, err := net.ParseCIDR("2600:1900:4001:a2b::/64")
+	require.NoError(t, err)
+
+	usage := computeRangeUsage(subnetRange{name: RangeExternalIPv6, kind: RangeExternalIPv6, network: network}, []IPAssociation{
+		{Kind: IPAssociationInstanceExternal, IPAddress: "2600:1900:4001:a2b:0:0::/96"},
+		{Kind: IPAssociationForwardingRule, IPAddress: "2600:1900:4001:a2b:0:1::"},
+		{Kind: IPAssociationInstanceInternal, IPAddress: "10.0.0.2"},
+	})
+
+	require.Equal(t, 96, usage.UnitPrefixLength)
+	require.Equal(t, uint64(1)<<32, usage.Total)
+	require.Equal(t, uint64(0), usage.Reserved)
+	require.Equal(t, uint64(2), usage.Used, "IPv4 allocations are ignored")
+	require.Equal(t, "2600:1900:4001:a2b:0:2::", usage.NextFree)
+	require.Equal(t, []string{
+		"2600:1900:4001:a2b:8000::/65",
+		"2600:1900:4001:a2b:4000::/66",
+		"2600:1900:4001:a2b:2000::/67",
+		"2600:1900:4001:a2b:1000::/68",
+		"2600:1900:4001:a2b:800::/69",
+	}, usage.LargestFreeBlocks)
+}
+
+func TestAllocationInRange(t *testing.T) {
+	entry := &cache.SubnetEntry{Name: "subnet-a", Network: "vpc-1"}
+	_, network, err := net.ParseCIDR("10.0.0.0/24")
+	require.NoError(t, err)
+
+	require.True(t, allocationInRange(IPAssociation{Kind: IPAssociationInstanceInternal, IPAddress: "10.0.0.5", Network: "vpc-1", Subnet: "subnet-a"}, entry, network))
+	require.True(t, allocationInRange(IPAssociation{Kind: IPAssociationForwardingRule, IPAddress: "10.0.0.6", Details: "scheme=internal"}, entry, network))
+	require.False(t, allocationInRange(IPAssociation{Kind: IPAssociationInstanceInternal, IPAddress: "10.0.0.5", Network: "vpc-2", Subnet: "subnet-b"}, entry, network))
+	require.False(t, allocationInRange(IPAssociation{Kind: IPAssociationSubnet, IPAddress: "10.0.0.0/24"}, entry, network))
+	require.False(t, allocationInRange(IPAssociation{Kind: IPAssociationAddress, IPAddress: "10.0.1.5"}, entry, network))
+
+	_, v6, err := net.ParseCIDR("fd20:a:b:c::/64")
+	require.NoError(t, err)
+	require.True(t, allocationInRange(IPAssociation{Kind: IPAssociationInstanceInternal, IPAddress: "fd20:a:b:c::2"}, entry, v6))
+	require.False(t, allocationInRange(IPAssociation{Kind: IPAssociationInstanceInternal, IPAddress: "10.0.0.5"}, entry, v6))
+}
+
+func TestServiceRangeAllocations(t *testing.T) {
+	entry := &cache.SubnetEntry{
+		Name:        "subnet-a",
+		Network:     "vpc-1",
+		PrimaryCIDR: "10.0.0.0/24",
+		SecondaryRanges: []cache.SubnetSecondaryRange{
+			{Name: "pods", CIDR: "10.4.0.0/14"},
+			{Name: "services", CIDR: "10.0.8.0/24"},
+			{Name: "gke-auto-services", CIDR: "10.0.9.0/24"},
+		},
+	}
+	clusters := []*container.Cluster{
+		{
+			Name: "named", Location: "us-central1", Network: "vpc-1", Subnetwork: "subnet-a",
+			IpAllocationPolicy: &container.IPAllocationPolicy{ClusterSecondaryRangeName: "pods", ServicesSecondaryRangeName: "services"},
+		},
+		{
+			Name: "auto", Location: "us-central1-a", Network: "vpc-1", Subnetwork: "subnet-a",
+			IpAllocationPolicy: &container.IPAllocationPolicy{ServicesIpv4CidrBlock: "10.0.9.0/24"},
+		},
+		{
+			Name: "other-subnet", Network: "vpc-1", Subnetwork: "subnet-b",
+			IpAllocationPolicy: &container.IPAllocationPolicy{ServicesSecondaryRangeName: "services"},
+		},
+	}
+
+	ranges := subnetRanges(entry, nil)
+	require.Len(t, ranges, 4)
+	require.Empty(t, serviceRangeAllocations("proj", entry, ranges[0], clusters), "primary ranges are never service ranges")
+	require.Empty(t, serviceRangeAllocations("proj", entry, ranges[1], clusters), "pod ranges are measured from node alias ranges")
+
+	services := serviceRangeAllocations("proj", entry, ranges[2], clusters)
+	require.Len(t, services, 1)
+	require.Equal(t, "named", services[0].Resource)
+	require.Equal(t, IPAssociationGKEServiceRange, services[0].Kind)
+	require.Equal(t, "10.0.8.0/24", services[0].IPAddress)
+
+	auto := serviceRangeAllocations("proj", entry, ranges[3], clusters)
+	require.Len(t, auto, 1)
+	require.Equal(t, "auto", auto[0].Resource)
+
+	usage := computeRangeUsage(ranges[2], services)
+	require.Equal(t, uint64(256), usage.Used)
+	require.Equal(t, uint64(0), usage.Free)
+	require.Equal(t, float64(100), usage.Utilization)
+	require.Empty(t, usage.NextFree)
+}
+
+func TestSubnetRangesAndCoveringPrefix(t *testing.T) {
+	entry := &cache.SubnetEntry{
+		PrimaryCIDR: "10.0.0.0/24",
+		SecondaryRanges: []cache.SubnetSecondaryRange{
+			{Name: "pods", CIDR: "10.4.0.0/14"},
+			{Name: "services", CIDR: "10.0.8.0/24"},
+		},
+		IPv6CIDR: "2600:abcd::/64",
+	}
+
+	ranges := subnetRanges(entry, nil)
+	require.Len(t, ranges, 4)
+	require.Equal(t, RangeIPv6, ranges[3].kind)
+
+	networks := make([]*net.IPNet, 0, len(ranges))
+	for _, r := range ranges[:3] {
+		networks = append(networks, r.network)
+	}
+	require.Equal(t, "10.0.0.0/13", coveringPrefix(networks).String())
+
+	_, external, err := net.ParseCIDR("2600:abcd:0:8000::/64")
+	require.NoError(t, err)
+	require.Equal(t, "2600:abcd::/48", coveringPrefix([]*net.IPNet{ranges[3].network, external}).String())
+
+	_, only, err := net.ParseCIDR("10.0.8.0/28")
+	require.NoError(t, err)
+	filtered := subnetRanges(entry, only)
+	require.Len(t, filtered, 1)
+	require.Equal(t, "services", filtered[0].name)
+}
@@ -0,0 +1,368 @@
+package gcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/kedare/compass/internal/logger"
+	crmv3 "google.golang.org/api/cloudresourcemanager/v3"
+	"google.golang.org/api/option"
+)
+
+const (
+	organizationPrefix = "organizations/"
+	folderPrefix       = "folders/"
+	projectPrefix      = "projects/"
+)
+
+// ProjectInfo describes an accessible project and where it lives in the resource hierarchy.
+type ProjectInfo struct {
+	ID          string
+	DisplayName string
+	Number      string
+	// FolderPath is the path of folder display names from the organization, e.g. "/prod/web".
+	// Projects directly under an organization have "/" and projects without one are empty.
+	FolderPath string
+	Labels     map[string]string
+}
+
+// ProjectSelector restricts project discovery. The zero value selects every accessible project.
+type ProjectSelector struct {
+	// Organization is an organization ID ("123" or "organizations/123") whose projects are walked.
+	Organization string
+	// Folders are folder IDs ("456" or "folders/456") or display name paths such as "/prod".
+	// Paths are resolved under Organization, or under every accessible organization.
+	Folders []string
+	// Labels must all be present on a project with the given values.
+	Labels map[string]string
+}
+
+// IsZero reports whether the selector selects every accessible project.
+func (s ProjectSelector) IsZero() bool {
+	return s.Organization == "" && len(s.Folders) == 0 && len(s.Labels) == 0
+}
+
+// ParseProjectLabels converts "key=value" selectors into a label map.
+func ParseProjectLabels(selectors []string) (map[string]string, error) {
+	if len(selectors) == 0 {
+		return nil, nil
+	}
+
+	labels := make(map[string]string, len(selectors))
+	for _, selector := range selectors {
+		key, value, ok := strings.Cut(strings.TrimSpace(selector), "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid label selector %q, expected key=value", selector)
+		}
+		labels[key] = strings.TrimSpace(value)
+	}
+
+	return labels, nil
+}
+
+// resourceHierarchy is the subset of Resource Manager v3 used to discover projects.
+type resourceHierarchy interface {
+	SearchProjects(ctx context.Context) ([]*crmv3.Project, error)
+	ListProjects(ctx context.Context, parent string) ([]*crmv3.Project, error)
+	ListFolders(ctx context.Context, parent string) ([]*crmv3.Folder, error)
+	GetFolder(ctx context.Context, name string) (*crmv3.Folder, error)
+	SearchOrganizations(ctx context.Context) ([]*crmv3.Organization, error)
+}
+
+// DiscoverProjects lists the active projects matching the selector along with their
+// folder path, display name, number and labels, sorted by project ID.
+func DiscoverProjects(ctx context.Context, selector ProjectSelector) ([]ProjectInfo, error) {
+	logger.Log.Debug("Discovering GCP projects with Resource Manager v3")
+
+	httpClient, err := newHTTPClientWithLogging(ctx, crmv3.CloudPlatformReadOnlyScope)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create HTTP client: %w", err)
+	}
+
+	service, err := crmv3.NewService(ctx, option.WithHTTPClient(httpClient))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create resource manager service: %w", err)
+	}
+
+	return newProjectDiscoverer(&resourceManagerHierarchy{service: service}).discover(ctx, selector)
+}
+
+// projectDiscoverer walks the resource hierarchy, remembering folder paths.
+type projectDiscoverer struct {
+	hierarchy   resourceHierarchy
+	folderPaths map[string]string // folder or organization name -> path
+}
+
+func newProjectDiscoverer(hierarchy resourceHierarchy) *projectDiscoverer {
+	return &projectDiscoverer{hierarchy: hierarchy, folderPaths: make(map[string]string)}
+}
+
+func (d *projectDiscoverer) discover(ctx context.Context, selector ProjectSelector) ([]ProjectInfo, error) {
+	var projects []*crmv3.Project
+
+	switch {
+	case len(selector.Folders) > 0:
+		for _, folder := range selector.Folders {
+			name, err := d.resolveFolder(ctx, folder, selector.Organization)
+			if err != nil {
+				return nil, err
+			}
+			found, err := d.walk(ctx, name)
+			if err != nil {
+				return nil, err
+			}
+			projects = append(projects, found...)
+		}
+	case selector.Organization != "":
+		found, err := d.walk(ctx, qualifyResourceName(selector.Organization, organizationPrefix))
+		if err != nil {
+			return nil, err
+		}
+		projects = found
+	default:
+		found, err := d.hierarchy.SearchProjects(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to search projects: %w", err)
+		}
+		projects = found
+	}
+
+	seen := make(map[string]struct{}, len(projects))
+	results := make([]ProjectInfo, 0, len(projects))
+	for _, p := range projects {
+		if p.ProjectId == "" || (p.State != "" && p.State != "ACTIVE") {
+			continue
+		}
+		if _, ok := seen[p.ProjectId]; ok {
+			continue
+		}
+		if !matchesProjectLabels(p.Labels, selector.Labels) {
+			continue
+		}
+		seen[p.ProjectId] = struct{}{}
+
+		results = append(results, ProjectInfo{
+			ID:          p.ProjectId,
+			DisplayName: p.DisplayName,
+			Number:      strings.TrimPrefix(p.Name, projectPrefix),
+			FolderPath:  d.pathOf(ctx, p.Parent),
+			Labels:      p.Labels,
+		})
+	}
+
+	sort.Slice(results, func(i, j int) bool { return results[i].ID < results[j].ID })
+
+	logger.Log.Debugf("Found %d active projects", len(results))
+
+	return results, nil
+}
+
+// walk returns the projects under parent (an organization or folder) and its subfolders.
+func (d *projectDiscoverer) walk(ctx context.Context, parent string) ([]*crmv3.Project, error) {
+	projects, err := d.hierarchy.ListProjects(ctx, parent)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list projects of %s: %w", parent, err)
+	}
+
+	folders, err := d.hierarchy.ListFolders(ctx, parent)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list folders of %s: %w", parent, err)
+	}
+
+	parentPath := d.pathOf(ctx, parent)
+	for _, folder := range folders {
+		if folder.State != "" && folder.State != "ACTIVE" {
+			continue
+		}
+		d.folderPaths[folder.Name] = joinFolderPath(parentPath, folder.DisplayName)
+
+		found, err := d.walk(ctx, folder.Name)
+		if err != nil {
+			return nil, err
+		}
+		projects = append(projects, found...)
+	}
+
+	return projects, nil
+}
+
+// pathOf returns the folder path of a parent resource, looking up unknown folders.
+// Lookup failures leave the path empty rather than failing the discovery.
+func (d *projectDiscoverer) pathOf(ctx context.Context, parent string) string {
+	if parent == "" {
+		return ""
+	}
+	if path, ok := d.folderPaths[parent]; ok {
+		return path
+	}
+	if strings.HasPrefix(parent, organizationPrefix) {
+		d.folderPaths[parent] = "/"
+		return "/"
+	}
+	if !strings.HasPrefix(parent, folderPrefix) {
+		return ""
+	}
+
+	folder, err := d.hierarchy.GetFolder(ctx, parent)
+	if err != nil {
+		logger.Log.Debugf("Failed to get folder %s: %v", parent, err)
+		d.folderPaths[parent] = ""
+		return ""
+	}
+
+	path := joinFolderPath(d.pathOf(ctx, folder.Parent), folder.DisplayName)
+	d.folderPaths[parent] = path
+
+	return path
+}
+
+// resolveFolder turns a folder ID or display name path into a folder resource name.
+func (d *projectDiscoverer) resolveFolder(ctx context.Context, folder, organization string) (string, error) {
+	folder = strings.TrimSpace(folder)
+	if !strings.HasPrefix(folder, "/") {
+		return qualifyResourceName(folder, folderPrefix), nil
+	}
+
+	segments := strings.FieldsFunc(folder, func(r rune) bool { return r == '/' })
+	if len(segments) == 0 {
+		return "", fmt.Errorf("invalid folder path %q", folder)
+	}
+
+	var roots []string
+	if organization != "" {
+		roots = []string{qualifyResourceName(organization, organizationPrefix)}
+	} else {
+		orgs, err := d.hierarchy.SearchOrganizations(ctx)
+		if err != nil {
+			return "", fmt.Errorf("failed to search organizations: %w", err)
+		}
+		for _, org := range orgs {
+			roots = append(roots, org.Name)
+		}
+	}
+
+	var matches []string
+	for _, root := range roots {
+		name, err := d.findFolder(ctx, root, segments)
+		if err != nil {
+			return "", err
+		}
+		if name != "" {
+			matches = append(matches, name)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("folder %q not found", folder)
+	case 1:
+		return matches[0], nil
+	default:
+		return "", fmt.Errorf("folder %q exists in several organizations, select one with --org", folder)
+	}
+}
+
+// findFolder follows display name segments from root, returning "" when one is missing.
+func (d *projectDiscoverer) findFolder(ctx context.Context, root string, segments []string) (string, error) {
+	current := root
+	for _, segment := range segments {
+		folders, err := d.hierarchy.ListFolders(ctx, current)
+		if err != nil {
+			return "", fmt.Errorf("failed to list folders of %s: %w", current, err)
+		}
+
+		next := ""
+		for _, folder := range folders {
+			if folder.DisplayName == segment {
+				next = folder.Name
+				d.folderPaths[folder.Name] = joinFolderPath(d.pathOf(ctx, current), folder.DisplayName)
+				break
+			}
+		}
+		if next == "" {
+			return "", nil
+		}
+		current = next
+	}
+
+	return current, nil
+}
+
+func joinFolderPath(parentPath, name string) string {
+	return strings.TrimSuffix(parentPath, "/") + "/" + name
+}
+
+// qualifyResourceName adds prefix to bare IDs ("123" -> "folders/123").
+func qualifyResourceName(id, prefix string) string {
+	id = strings.TrimSpace(id)
+	if strings.HasPrefix(id, prefix) {
+		return id
+	}
+	return prefix + id
+}
+
+// matchesProjectLabels reports whether labels contains every wanted key with its value.
+func matchesProjectLabels(labels, wanted map[string]string) bool {
+	for key, value := range wanted {
+		if got, ok := labels[key]; !ok || got != value {
+			return false
+		}
+	}
+	return true
+}
+
+// resourceManagerHierarchy implements resourceHierarchy with the Resource Manager v3 API.
+type resourceManagerHierarchy struct {
+	service *crmv3.Service
+}
+
+func (h *resourceManagerHierarchy) SearchProjects(ctx context.Context) ([]*crmv3.Project, error) {
+	var projects []*crmv3.Project
+	err := h.service.Projects.Search().Query("state:ACTIVE").Pages(ctx, func(resp *crmv3.SearchProjectsResponse) error {
+		projects = append(projects, resp.Projects...)
+		return nil
+	})
+
+	return projects, err
+}
+
+func (h *resourceManagerHierarchy) ListProjects(ctx context.Context, parent string) ([]*crmv3.Project, error) {
+	var projects []*crmv3.Project
+	err := h.service.Projects.List().Parent(parent).Pages(ctx, func(resp *crmv3.ListProjectsResponse) error {
+		projects = append(projects, resp.Projects...)
+		return nil
+	})
+
+	return projects, err
+}
+
+func (h *resourceManagerHierarchy) ListFolders(ctx context.Context, parent string) ([]*crmv3.Folder, error) {
+	var folders []*crmv3.Folder
+	err := h.service.Folders.List().Parent(parent).Pages(ctx, func(resp *crmv3.ListFoldersResponse) error {
+		folders = append(folders, resp.Folders...)
+		return nil
+	})
+
+	return folders, err
+}
+
+func (h *resourceManagerHierarchy) GetFolder(ctx context.Context, name string) (*crmv3.Folder, error) {
+	return h.service.Folders.Get(name).Context(ctx).Do()
+}
+
+func (h *resourceManagerHierarchy) SearchOrganizations(ctx context.Context) ([]*crmv3.Organization, error) {
+	var orgs []*crmv3.Organization
+	err := h.service.Organizations.Search().Pages(ctx, func(resp *crmv3.SearchOrganizationsResponse) error {
+		orgs = append(orgs, resp.Organizations...)
+		return nil
+	})
+	if err == nil && len(orgs) == 0 {
+		return nil, errors.New("no accessible organization, pass --org")
+	}
+
+	return orgs, err
+}
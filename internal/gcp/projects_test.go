@@ -0,0 +1,153 @@
+package gcp
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	crmv3 "google.golang.org/api/cloudresourcemanager/v3"
+)
+
+// fakeHierarchy is an in-memory organization used to test project discovery.
+type fakeHierarchy struct {
+	orgs     []*crmv3.Organization
+	folders  []*crmv3.Folder
+	projects []*crmv3.Project
+	gets     int
+}
+
+func (f *fakeHierarchy) SearchProjects(context.Context) ([]*crmv3.Project, error) {
+	return f.projects, nil
+}
+
+func (f *fakeHierarchy) ListProjects(_ context.Context, parent string) ([]*crmv3.Project, error) {
+	var projects []*crmv3.Project
+	for _, p := range f.projects {
+		if p.Parent == parent {
+			projects = append(projects, p)
+		}
+	}
+	return projects, nil
+}
+
+func (f *fakeHierarchy) ListFolders(_ context.Context, parent string) ([]*crmv3.Folder, error) {
+	var folders []*crmv3.Folder
+	for _, folder := range f.folders {
+		if folder.Parent == parent {
+			folders = append(folders, folder)
+		}
+	}
+	return folders, nil
+}
+
+func (f *fakeHierarchy) GetFolder(_ context.Context, name string) (*crmv3.Folder, error) {
+	f.gets++
+	for _, folder := range f.folders {
+		if folder.Name == name {
+			return folder, nil
+		}
+	}
+	return nil, fmt.Errorf("folder %s not found", name)
+}
+
+func (f *fakeHierarchy) SearchOrganizations(context.Context) ([]*crmv3.Organization, error) {
+	return f.orgs, nil
+}
+
+func newFakeHierarchy() *fakeHierarchy {
+	return &fakeHierarchy{
+		orgs: []*crmv3.Organization{{Name: "organizations/1"}},
+		folders: []*crmv3.Folder{
+			{Name: "folders/10", DisplayName: "prod", Parent: "organizations/1"},
+			{Name: "folders/11", DisplayName: "web", Parent: "folders/10"},
+			{Name: "folders/20", DisplayName: "shared-network", Parent: "organizations/1"},
+		},
+		projects: []*crmv3.Project{
+			{ProjectId: "admin", Name: "projects/100", Parent: "organizations/1", State: "ACTIVE"},
+			{ProjectId: "web-prod", Name: "projects/101", DisplayName: "Web", Parent: "folders/11", State: "ACTIVE", Labels: map[string]string{"team": "web"}},
+			{ProjectId: "db-prod", Name: "projects/102", Parent: "folders/10", State: "ACTIVE", Labels: map[string]string{"team": "db"}},
+			{ProjectId: "net-host", Name: "projects/103", Parent: "folders/20", State: "ACTIVE", Labels: map[string]string{"team": "net"}},
+			{ProjectId: "old-prod", Name: "projects/104", Parent: "folders/10", State: "DELETE_REQUESTED"},
+			{ProjectId: "personal", Name: "projects/105", State: "ACTIVE"},
+		},
+	}
+}
+
+func projectIDs(projects []ProjectInfo) []string {
+	ids := make([]string, 0, len(projects))
+	for _, p := range projects {
+		ids = append(ids, p.ID)
+	}
+	return ids
+}
+
+func TestDiscoverProjectsWithoutSelector(t *testing.T) {
+	hierarchy := newFakeHierarchy()
+	projects, err := newProjectDiscoverer(hierarchy).discover(context.Background(), ProjectSelector{})
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"admin", "db-prod", "net-host", "personal", "web-prod"}, projectIDs(projects))
+
+	paths := map[string]string{}
+	for _, p := range projects {
+		paths[p.ID] = p.FolderPath
+	}
+	require.Equal(t, map[string]string{
+		"admin":    "/",
+		"db-prod":  "/prod",
+		"net-host": "/shared-network",
+		"personal": "",
+		"web-prod": "/prod/web",
+	}, paths)
+	require.Equal(t, 3, hierarchy.gets, "folders are looked up once")
+
+	web := projects[4]
+	require.Equal(t, "Web", web.DisplayName)
+	require.Equal(t, "101", web.Number)
+	require.Equal(t, map[string]string{"team": "web"}, web.Labels)
+}
+
+func TestDiscoverProjectsByOrganizationAndLabels(t *testing.T) {
+	projects, err := newProjectDiscoverer(newFakeHierarchy()).discover(context.Background(), ProjectSelector{Organization: "1"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"admin", "db-prod", "net-host", "web-prod"}, projectIDs(projects))
+
+	projects, err = newProjectDiscoverer(newFakeHierarchy()).discover(context.Background(), ProjectSelector{
+		Organization: "organizations/1",
+		Labels:       map[string]string{"team": "web"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"web-prod"}, projectIDs(projects))
+	require.Equal(t, "/prod/web", projects[0].FolderPath)
+}
+
+func TestDiscoverProjectsByFolder(t *testing.T) {
+	hierarchy := newFakeHierarchy()
+
+	projects, err := newProjectDiscoverer(hierarchy).discover(context.Background(), ProjectSelector{Folders: []string{"/prod"}})
+	require.NoError(t, err)
+	require.Equal(t, []string{"db-prod", "web-prod"}, projectIDs(projects))
+	require.Equal(t, "/prod/web", projects[1].FolderPath)
+
+	projects, err = newProjectDiscoverer(hierarchy).discover(context.Background(), ProjectSelector{Folders: []string{"11", "folders/20"}})
+	require.NoError(t, err)
+	require.Equal(t, []string{"net-host", "web-prod"}, projectIDs(projects))
+	require.Equal(t, "/prod/web", projects[1].FolderPath, "paths of folders selected by ID are resolved up to the organization")
+
+	_, err = newProjectDiscoverer(hierarchy).discover(context.Background(), ProjectSelector{Folders: []string{"/prod/missing"}})
+	require.ErrorContains(t, err, "not found")
+}
+
+func TestParseProjectLabels(t *testing.T) {
+	labels, err := ParseProjectLabels([]string{"team=web", " env = prod "})
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"team": "web", "env": "prod"}, labels)
+
+	_, err = ParseProjectLabels([]string{"team"})
+	require.Error(t, err)
+
+	labels, err = ParseProjectLabels(nil)
+	require.NoError(t, err)
+	require.Nil(t, labels)
+}
@@ -0,0 +1,116 @@
+package gcp
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// DefaultAPIRateLimit is the number of requests per second sent to each Google API host.
+const DefaultAPIRateLimit = 50.0
+
+// apiLimiter rate limits every HTTP request sent by the clients of the process, see SetAPIRateLimit.
+var apiLimiter = &hostLimiter{rate: DefaultAPIRateLimit}
+
+// SetAPIRateLimit sets the number of requests per second sent to each Google API host
+// (e.g. compute.googleapis.com), retries included. Zero or a negative rate disables the limit.
+func SetAPIRateLimit(rate float64) {
+	apiLimiter.setRate(rate)
+}
+
+// hostLimiter keeps one token bucket per API host, so that a busy API does not slow down the others.
+type hostLimiter struct {
+	mu      sync.Mutex
+	rate    float64
+	buckets map[string]*tokenBucket
+}
+
+func (l *hostLimiter) setRate(rate float64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.rate = rate
+	l.buckets = nil
+}
+
+// Wait blocks until a request may be sent to the host or the context is done.
+func (l *hostLimiter) Wait(ctx context.Context, host string) error {
+	l.mu.Lock()
+	if l.rate <= 0 || host == "" {
+		l.mu.Unlock()
+
+		return nil
+	}
+
+	if l.buckets == nil {
+		l.buckets = make(map[string]*tokenBucket)
+	}
+	bucket, ok := l.buckets[host]
+	if !ok {
+		bucket = newTokenBucket(l.rate)
+		l.buckets[host] = bucket
+	}
+	l.mu.Unlock()
+
+	return bucket.Wait(ctx)
+}
+
+// tokenBucket is a minimal token-bucket rate limiter. Tokens refill continuously
+// at rate per second up to burst, and every request consumes one token.
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+	now    func() time.Time
+}
+
+// newTokenBucket creates a full bucket refilling at rate tokens per second.
+// The burst size is the rate rounded up, with a minimum of one token.
+func newTokenBucket(rate float64) *tokenBucket {
+	burst := math.Max(1, math.Ceil(rate))
+	return &tokenBucket{
+		rate:   rate,
+		burst:  burst,
+		tokens: burst,
+		last:   time.Now(),
+		now:    time.Now,
+	}
+}
+
+// reserve consumes a token and returns how long the caller must wait before using it.
+func (b *tokenBucket) reserve() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	b.tokens = math.Min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+	b.tokens--
+
+	if b.tokens >= 0 {
+		return 0
+	}
+
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// Wait blocks until a token is available or the context is done.
+func (b *tokenBucket) Wait(ctx context.Context) error {
+	delay := b.reserve()
+	if delay <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
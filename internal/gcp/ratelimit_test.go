@@ -0,0 +1,47 @@
+package gcp
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenBucketReserve(t *testing.T) {
+	now := time.Unix(0, 0)
+	bucket := newTokenBucket(2)
+	bucket.now = func() time.Time { return now }
+	bucket.last = now
+
+	require.Zero(t, bucket.reserve(), "first token is available immediately")
+	require.Zero(t, bucket.reserve(), "burst token is available immediately")
+	require.Equal(t, 500*time.Millisecond, bucket.reserve(), "the next token waits once the burst is spent")
+
+	now = now.Add(2 * time.Second)
+	require.Zero(t, bucket.reserve(), "tokens refill over time")
+}
+
+func TestTokenBucketWaitHonoursContext(t *testing.T) {
+	bucket := newTokenBucket(0.001)
+	require.NoError(t, bucket.Wait(context.Background()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	require.Error(t, bucket.Wait(ctx), "wait fails once the context expires")
+}
+
+func TestHostLimiterSeparatesHosts(t *testing.T) {
+	limiter := &hostLimiter{rate: 0.001}
+	require.NoError(t, limiter.Wait(context.Background(), "compute.googleapis.com"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	require.Error(t, limiter.Wait(ctx, "compute.googleapis.com"), "the host has spent its token")
+	require.NoError(t, limiter.Wait(context.Background(), "sqladmin.googleapis.com"), "other hosts have their own bucket")
+
+	limiter.setRate(0)
+	require.NoError(t, limiter.Wait(ctx, "compute.googleapis.com"), "a zero rate disables the limit")
+}
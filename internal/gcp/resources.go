@@ -2,15 +2,33 @@ package gcp
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
+	"sort"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/kedare/compass/internal/logger"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/api/appengine/v1"
+	"google.golang.org/api/bigquery/v2"
+	"google.golang.org/api/cloudfunctions/v2"
 	"google.golang.org/api/compute/v1"
 	"google.golang.org/api/container/v1"
+	"google.golang.org/api/dns/v1"
+	"google.golang.org/api/file/v1"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/iam/v1"
+	"google.golang.org/api/memcache/v1"
 	"google.golang.org/api/option"
+	"google.golang.org/api/pubsub/v1"
+	"google.golang.org/api/redis/v1"
 	"google.golang.org/api/run/v1"
+	runv2 "google.golang.org/api/run/v2"
 	"google.golang.org/api/secretmanager/v1"
+	"google.golang.org/api/spanner/v1"
 	"google.golang.org/api/sqladmin/v1beta4"
 	"google.golang.org/api/storage/v1"
 )
@@ -185,6 +203,7 @@ func (c *Client) ListAddresses(ctx context.Context) ([]*Address, error) {
 					Description: addr.Description,
 					Subnetwork:  extractResourceName(addr.Subnetwork),
 					Users:       users,
+					Labels:      addr.Labels,
 				})
 			}
 		}
@@ -237,6 +256,7 @@ func (c *Client) ListDisks(ctx context.Context) ([]*Disk, error) {
 					SizeGb: disk.SizeGb,
 					Type:   extractDiskType(disk.Type),
 					Status: disk.Status,
+					Labels: disk.Labels,
 				})
 			}
 		}
@@ -281,11 +301,13 @@ func (c *Client) ListSnapshots(ctx context.Context) ([]*Snapshot, error) {
 		}
 
 		results = append(results, &Snapshot{
-			Name:         item.Name,
-			DiskSizeGb:   item.DiskSizeGb,
-			StorageBytes: item.StorageBytes,
-			Status:       item.Status,
-			SourceDisk:   extractDiskName(item.SourceDisk),
+			Name:               item.Name,
+			DiskSizeGb:         item.DiskSizeGb,
+			StorageBytes:       item.StorageBytes,
+			Status:             item.Status,
+			SourceDisk:         extractDiskName(item.SourceDisk),
+			SourceDiskLocation: extractResourceLocation(item.SourceDisk),
+			Labels:             item.Labels,
 		})
 	}
 
@@ -340,6 +362,7 @@ func (c *Client) ListBuckets(ctx context.Context) ([]*Bucket, error) {
 			Location:     item.Location,
 			StorageClass: item.StorageClass,
 			LocationType: item.LocationType,
+			Labels:       item.Labels,
 		})
 	}
 
@@ -385,6 +408,10 @@ func (c *Client) ListForwardingRules(ctx context.Context) ([]*ForwardingRule, er
 					IPProtocol:          rule.IPProtocol,
 					PortRange:           rule.PortRange,
 					LoadBalancingScheme: rule.LoadBalancingScheme,
+					Labels:              rule.Labels,
+					Target:              extractResourceName(rule.Target),
+					TargetType:          extractResourceCollection(rule.Target),
+					BackendService:      extractResourceName(rule.BackendService),
 				})
 			}
 		}
@@ -431,14 +458,27 @@ func (c *Client) ListBackendServices(ctx context.Context) ([]*BackendService, er
 					continue
 				}
 
-				results = append(results, &BackendService{
+				backend := &BackendService{
 					Name:                svc.Name,
 					Region:              regionName,
 					Protocol:            svc.Protocol,
 					LoadBalancingScheme: svc.LoadBalancingScheme,
 					HealthCheckCount:    len(svc.HealthChecks),
 					BackendCount:        len(svc.Backends),
-				})
+				}
+				for _, hc := range svc.HealthChecks {
+					backend.HealthChecks = append(backend.HealthChecks, extractResourceName(hc))
+				}
+				for _, b := range svc.Backends {
+					if b != nil && b.Group != "" {
+						backend.Backends = append(backend.Backends, BackendGroup{
+							Name:     extractResourceName(b.Group),
+							Location: extractResourceLocation(b.Group),
+						})
+					}
+				}
+
+				results = append(results, backend)
 			}
 		}
 
@@ -484,12 +524,17 @@ func (c *Client) ListTargetPools(ctx context.Context) ([]*TargetPool, error) {
 					continue
 				}
 
-				results = append(results, &TargetPool{
+				targetPool := &TargetPool{
 					Name:            pool.Name,
 					Region:          regionName,
 					SessionAffinity: pool.SessionAffinity,
 					InstanceCount:   len(pool.Instances),
-				})
+				}
+				for _, instance := range pool.Instances {
+					targetPool.Instances = append(targetPool.Instances, extractResourceName(instance))
+				}
+
+				results = append(results, targetPool)
 			}
 		}
 
@@ -586,21 +631,43 @@ func (c *Client) ListURLMaps(ctx context.Context) ([]*URLMap, error) {
 func (c *Client) ListCloudSQLInstances(ctx context.Context) ([]*CloudSQLInstance, error) {
 	logger.Log.Debug("Listing Cloud SQL instances")
 
-	httpClient, err := newHTTPClientWithLogging(ctx, sqladmin.CloudPlatformScope)
+	instances, err := c.listSQLInstances(ctx)
 	if err != nil {
-		logger.Log.Errorf("Failed to create HTTP client for SQL Admin: %v", err)
+		logger.Log.Errorf("Failed to list Cloud SQL instances: %v", err)
 
-		return nil, fmt.Errorf("failed to create HTTP client: %w", err)
+		return nil, err
 	}
 
-	sqlService, err := sqladmin.NewService(ctx, option.WithHTTPClient(httpClient))
-	if err != nil {
-		logger.Log.Errorf("Failed to create SQL Admin service: %v", err)
+	results := make([]*CloudSQLInstance, 0, len(instances))
+	for _, item := range instances {
+		tier := ""
+		var labels map[string]string
+		if item.Settings != nil {
+			tier = item.Settings.Tier
+			labels = item.Settings.UserLabels
+		}
+
+		results = append(results, &CloudSQLInstance{
+			Name:            item.Name,
+			Region:          item.Region,
+			DatabaseVersion: item.DatabaseVersion,
+			Tier:            tier,
+			State:           item.State,
+			Labels:          labels,
+		})
+	}
 
-		return nil, fmt.Errorf("failed to create sql admin service: %w", err)
+	return results, nil
+}
+
+// listSQLInstances returns the raw Cloud SQL instances of the project.
+func (c *Client) listSQLInstances(ctx context.Context) ([]*sqladmin.DatabaseInstance, error) {
+	sqlService, err := c.newSQLAdminService(ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	var results []*CloudSQLInstance
+	var results []*sqladmin.DatabaseInstance
 	pageToken := ""
 
 	for {
@@ -611,23 +678,13 @@ func (c *Client) ListCloudSQLInstances(ctx context.Context) ([]*CloudSQLInstance
 
 		resp, err := call.Do()
 		if err != nil {
-			logger.Log.Errorf("Failed to list Cloud SQL instances: %v", err)
-
 			return nil, fmt.Errorf("failed to list cloud sql instances: %w", err)
 		}
 
 		for _, item := range resp.Items {
-			if item == nil {
-				continue
+			if item != nil {
+				results = append(results, item)
 			}
-
-			results = append(results, &CloudSQLInstance{
-				Name:            item.Name,
-				Region:          item.Region,
-				DatabaseVersion: item.DatabaseVersion,
-				Tier:            item.Settings.Tier,
-				State:           item.State,
-			})
 		}
 
 		if resp.NextPageToken == "" {
@@ -640,145 +697,207 @@ func (c *Client) ListCloudSQLInstances(ctx context.Context) ([]*CloudSQLInstance
 	return results, nil
 }
 
-// ListGKEClusters returns the GKE clusters available in the project.
-func (c *Client) ListGKEClusters(ctx context.Context) ([]*GKECluster, error) {
-	logger.Log.Debug("Listing GKE clusters")
-
-	httpClient, err := newHTTPClientWithLogging(ctx, container.CloudPlatformScope)
+// newSQLAdminService creates a Cloud SQL Admin API service using the logging HTTP client.
+func (c *Client) newSQLAdminService(ctx context.Context) (*sqladmin.Service, error) {
+	httpClient, err := newHTTPClientWithLogging(ctx, sqladmin.CloudPlatformScope)
 	if err != nil {
-		logger.Log.Errorf("Failed to create HTTP client for Container: %v", err)
+		logger.Log.Errorf("Failed to create HTTP client for SQL Admin: %v", err)
 
 		return nil, fmt.Errorf("failed to create HTTP client: %w", err)
 	}
 
-	containerService, err := container.NewService(ctx, option.WithHTTPClient(httpClient))
+	sqlService, err := sqladmin.NewService(ctx, option.WithHTTPClient(httpClient))
 	if err != nil {
-		logger.Log.Errorf("Failed to create Container service: %v", err)
+		logger.Log.Errorf("Failed to create SQL Admin service: %v", err)
 
-		return nil, fmt.Errorf("failed to create container service: %w", err)
+		return nil, fmt.Errorf("failed to create sql admin service: %w", err)
 	}
 
-	// Use "-" to list clusters in all locations
-	parent := fmt.Sprintf("projects/%s/locations/-", c.project)
-	resp, err := containerService.Projects.Locations.Clusters.List(parent).Context(ctx).Do()
+	return sqlService, nil
+}
+
+// ListRedisInstances returns the Memorystore for Redis instances available in the project across all regions.
+func (c *Client) ListRedisInstances(ctx context.Context) ([]*RedisInstance, error) {
+	logger.Log.Debug("Listing Memorystore Redis instances")
+
+	httpClient, err := newHTTPClientWithLogging(ctx, redis.CloudPlatformScope)
 	if err != nil {
-		logger.Log.Errorf("Failed to list GKE clusters: %v", err)
+		logger.Log.Errorf("Failed to create HTTP client for Memorystore Redis: %v", err)
 
-		return nil, fmt.Errorf("failed to list gke clusters: %w", err)
+		return nil, fmt.Errorf("failed to create HTTP client: %w", err)
 	}
 
-	results := make([]*GKECluster, 0, len(resp.Clusters))
-	for _, cluster := range resp.Clusters {
-		if cluster == nil {
-			continue
+	redisService, err := redis.NewService(ctx, option.WithHTTPClient(httpClient))
+	if err != nil {
+		logger.Log.Errorf("Failed to create Memorystore Redis service: %v", err)
+
+		return nil, fmt.Errorf("failed to create redis service: %w", err)
+	}
+
+	var results []*RedisInstance
+	pageToken := ""
+	parent := fmt.Sprintf("projects/%s/locations/-", c.project)
+
+	for {
+		call := redisService.Projects.Locations.Instances.List(parent).Context(ctx)
+		if pageToken != "" {
+			call = call.PageToken(pageToken)
 		}
 
-		nodeCount := 0
-		for _, pool := range cluster.NodePools {
-			if pool != nil {
-				nodeCount += int(pool.InitialNodeCount)
+		resp, err := call.Do()
+		if err != nil {
+			logger.Log.Errorf("Failed to list Memorystore Redis instances: %v", err)
+
+			return nil, fmt.Errorf("failed to list redis instances: %w", err)
+		}
+
+		for _, item := range resp.Instances {
+			if item == nil {
+				continue
 			}
+
+			region, name := extractLocationAndName(item.Name)
+			results = append(results, &RedisInstance{
+				Name:            name,
+				Region:          region,
+				DisplayName:     item.DisplayName,
+				Tier:            item.Tier,
+				RedisVersion:    item.RedisVersion,
+				MemorySizeGB:    item.MemorySizeGb,
+				Host:            item.Host,
+				Port:            item.Port,
+				ReadEndpoint:    item.ReadEndpoint,
+				Network:         extractResourceName(item.AuthorizedNetwork),
+				ReservedIPRange: item.ReservedIpRange,
+				ConnectMode:     item.ConnectMode,
+				State:           item.State,
+				Labels:          item.Labels,
+			})
 		}
 
-		results = append(results, &GKECluster{
-			Name:                 cluster.Name,
-			Location:             cluster.Location,
-			Status:               cluster.Status,
-			CurrentMasterVersion: cluster.CurrentMasterVersion,
-			NodeCount:            nodeCount,
-		})
+		if resp.NextPageToken == "" {
+			break
+		}
+
+		pageToken = resp.NextPageToken
 	}
 
 	return results, nil
 }
 
-// ListGKENodePools returns the GKE node pools available in the project.
-func (c *Client) ListGKENodePools(ctx context.Context) ([]*GKENodePool, error) {
-	logger.Log.Debug("Listing GKE node pools")
+// ListMemcacheInstances returns the Memorystore for Memcached instances available in the project across all regions.
+func (c *Client) ListMemcacheInstances(ctx context.Context) ([]*MemcacheInstance, error) {
+	logger.Log.Debug("Listing Memorystore Memcached instances")
 
-	httpClient, err := newHTTPClientWithLogging(ctx, container.CloudPlatformScope)
+	httpClient, err := newHTTPClientWithLogging(ctx, memcache.CloudPlatformScope)
 	if err != nil {
-		logger.Log.Errorf("Failed to create HTTP client for Container: %v", err)
+		logger.Log.Errorf("Failed to create HTTP client for Memorystore Memcached: %v", err)
 
 		return nil, fmt.Errorf("failed to create HTTP client: %w", err)
 	}
 
-	containerService, err := container.NewService(ctx, option.WithHTTPClient(httpClient))
+	memcacheService, err := memcache.NewService(ctx, option.WithHTTPClient(httpClient))
 	if err != nil {
-		logger.Log.Errorf("Failed to create Container service: %v", err)
+		logger.Log.Errorf("Failed to create Memorystore Memcached service: %v", err)
 
-		return nil, fmt.Errorf("failed to create container service: %w", err)
+		return nil, fmt.Errorf("failed to create memcache service: %w", err)
 	}
 
-	// First list all clusters
+	var results []*MemcacheInstance
+	pageToken := ""
 	parent := fmt.Sprintf("projects/%s/locations/-", c.project)
-	clustersResp, err := containerService.Projects.Locations.Clusters.List(parent).Context(ctx).Do()
-	if err != nil {
-		logger.Log.Errorf("Failed to list GKE clusters for node pools: %v", err)
 
-		return nil, fmt.Errorf("failed to list gke clusters: %w", err)
-	}
+	for {
+		call := memcacheService.Projects.Locations.Instances.List(parent).Context(ctx)
+		if pageToken != "" {
+			call = call.PageToken(pageToken)
+		}
 
-	var results []*GKENodePool
-	for _, cluster := range clustersResp.Clusters {
-		if cluster == nil {
-			continue
+		resp, err := call.Do()
+		if err != nil {
+			logger.Log.Errorf("Failed to list Memorystore Memcached instances: %v", err)
+
+			return nil, fmt.Errorf("failed to list memcache instances: %w", err)
 		}
 
-		for _, pool := range cluster.NodePools {
-			if pool == nil {
+		for _, item := range resp.Instances {
+			if item == nil {
 				continue
 			}
 
-			machineType := ""
-			if pool.Config != nil {
-				machineType = pool.Config.MachineType
+			region, name := extractLocationAndName(item.Name)
+			instance := &MemcacheInstance{
+				Name:              name,
+				Region:            region,
+				DisplayName:       item.DisplayName,
+				MemcacheVersion:   item.MemcacheVersion,
+				NodeCount:         item.NodeCount,
+				DiscoveryEndpoint: item.DiscoveryEndpoint,
+				Network:           extractResourceName(item.AuthorizedNetwork),
+				State:             item.State,
+				Labels:            item.Labels,
 			}
 
-			results = append(results, &GKENodePool{
-				Name:        pool.Name,
-				ClusterName: cluster.Name,
-				Location:    cluster.Location,
-				MachineType: machineType,
-				NodeCount:   int(pool.InitialNodeCount),
-				Status:      pool.Status,
-			})
+			for _, node := range item.MemcacheNodes {
+				if node != nil && node.Host != "" {
+					instance.NodeHosts = append(instance.NodeHosts, node.Host)
+				}
+			}
+
+			results = append(results, instance)
+		}
+
+		if resp.NextPageToken == "" {
+			break
 		}
+
+		pageToken = resp.NextPageToken
 	}
 
 	return results, nil
 }
 
-// ListVPCNetworks returns the VPC networks available in the project.
-func (c *Client) ListVPCNetworks(ctx context.Context) ([]*VPCNetwork, error) {
-	logger.Log.Debug("Listing VPC networks")
+// ListFilestoreInstances returns the Filestore instances available in the project across all locations.
+func (c *Client) ListFilestoreInstances(ctx context.Context) ([]*FilestoreInstance, error) {
+	logger.Log.Debug("Listing Filestore instances")
+
+	httpClient, err := newHTTPClientWithLogging(ctx, file.CloudPlatformScope)
+	if err != nil {
+		logger.Log.Errorf("Failed to create HTTP client for Filestore: %v", err)
+
+		return nil, fmt.Errorf("failed to create HTTP client: %w", err)
+	}
+
+	fileService, err := file.NewService(ctx, option.WithHTTPClient(httpClient))
+	if err != nil {
+		logger.Log.Errorf("Failed to create Filestore service: %v", err)
 
+		return nil, fmt.Errorf("failed to create filestore service: %w", err)
+	}
+
+	var results []*FilestoreInstance
 	pageToken := ""
-	var results []*VPCNetwork
+	parent := fmt.Sprintf("projects/%s/locations/-", c.project)
 
 	for {
-		call := c.service.Networks.List(c.project).Context(ctx)
+		call := fileService.Projects.Locations.Instances.List(parent).Context(ctx)
 		if pageToken != "" {
 			call = call.PageToken(pageToken)
 		}
 
 		resp, err := call.Do()
 		if err != nil {
-			logger.Log.Errorf("Failed to list VPC networks: %v", err)
+			logger.Log.Errorf("Failed to list Filestore instances: %v", err)
 
-			return nil, fmt.Errorf("failed to list vpc networks: %w", err)
+			return nil, fmt.Errorf("failed to list filestore instances: %w", err)
 		}
 
-		for _, network := range resp.Items {
-			if network == nil {
+		for _, item := range resp.Instances {
+			if item == nil {
 				continue
 			}
 
-			results = append(results, &VPCNetwork{
-				Name:                  network.Name,
-				AutoCreateSubnetworks: network.AutoCreateSubnetworks,
-				SubnetCount:           len(network.Subnetworks),
-			})
+			results = append(results, convertFilestoreInstance(item))
 		}
 
 		if resp.NextPageToken == "" {
@@ -791,106 +910,408 @@ func (c *Client) ListVPCNetworks(ctx context.Context) ([]*VPCNetwork, error) {
 	return results, nil
 }
 
-// ListSubnets returns the VPC subnets available in the project.
-func (c *Client) ListSubnets(ctx context.Context) ([]*Subnet, error) {
-	logger.Log.Debug("Listing VPC subnets")
+// convertFilestoreInstance flattens a Filestore API instance into a FilestoreInstance.
+func convertFilestoreInstance(item *file.Instance) *FilestoreInstance {
+	location, name := extractLocationAndName(item.Name)
+
+	instance := &FilestoreInstance{
+		Name:        name,
+		Location:    location,
+		Tier:        item.Tier,
+		Description: item.Description,
+		Protocol:    item.Protocol,
+		State:       item.State,
+		Labels:      item.Labels,
+	}
+
+	for _, network := range item.Networks {
+		if network == nil {
+			continue
+		}
+		if instance.Network == "" {
+			instance.Network = extractResourceName(network.Network)
+		}
+		instance.IPAddresses = append(instance.IPAddresses, network.IpAddresses...)
+	}
+
+	for _, share := range item.FileShares {
+		if share == nil {
+			continue
+		}
+		instance.FileShares = append(instance.FileShares, fmt.Sprintf("%s (%d GB)", share.Name, share.CapacityGb))
+	}
 
+	return instance
+}
+
+// ListSpannerInstances returns the Cloud Spanner instances available in the project.
+func (c *Client) ListSpannerInstances(ctx context.Context) ([]*SpannerInstance, error) {
+	logger.Log.Debug("Listing Spanner instances")
+
+	httpClient, err := newHTTPClientWithLogging(ctx, spanner.SpannerAdminScope)
+	if err != nil {
+		logger.Log.Errorf("Failed to create HTTP client for Spanner: %v", err)
+
+		return nil, fmt.Errorf("failed to create HTTP client: %w", err)
+	}
+
+	spannerService, err := spanner.NewService(ctx, option.WithHTTPClient(httpClient))
+	if err != nil {
+		logger.Log.Errorf("Failed to create Spanner service: %v", err)
+
+		return nil, fmt.Errorf("failed to create spanner service: %w", err)
+	}
+
+	var results []*SpannerInstance
 	pageToken := ""
-	var results []*Subnet
 
 	for {
-		call := c.service.Subnetworks.AggregatedList(c.project).Context(ctx)
+		call := spannerService.Projects.Instances.List(fmt.Sprintf("projects/%s", c.project)).Context(ctx)
 		if pageToken != "" {
 			call = call.PageToken(pageToken)
 		}
 
-		list, err := call.Do()
+		resp, err := call.Do()
 		if err != nil {
-			logger.Log.Errorf("Failed to list aggregated subnets: %v", err)
+			logger.Log.Errorf("Failed to list Spanner instances: %v", err)
 
-			return nil, fmt.Errorf("failed to list subnets: %w", err)
+			return nil, fmt.Errorf("failed to list spanner instances: %w", err)
 		}
 
-		for scope, scopedList := range list.Items {
-			if len(scopedList.Subnetworks) == 0 {
+		for _, item := range resp.Instances {
+			if item == nil {
 				continue
 			}
 
-			regionName := extractRegionFromScope(scope)
-
-			for _, subnet := range scopedList.Subnetworks {
-				if subnet == nil {
-					continue
-				}
-
-				results = append(results, &Subnet{
-					Name:        subnet.Name,
-					Region:      regionName,
-					Network:     extractResourceName(subnet.Network),
-					IPCidrRange: subnet.IpCidrRange,
-					Purpose:     subnet.Purpose,
-				})
-			}
+			results = append(results, &SpannerInstance{
+				Name:            extractResourceName(item.Name),
+				DisplayName:     item.DisplayName,
+				Config:          extractResourceName(item.Config),
+				Edition:         item.Edition,
+				NodeCount:       item.NodeCount,
+				ProcessingUnits: item.ProcessingUnits,
+				EndpointURIs:    item.EndpointUris,
+				State:           item.State,
+				Labels:          item.Labels,
+			})
 		}
 
-		if list.NextPageToken == "" {
+		if resp.NextPageToken == "" {
 			break
 		}
 
-		pageToken = list.NextPageToken
+		pageToken = resp.NextPageToken
 	}
 
 	return results, nil
 }
 
-// ListCloudRunServices returns the Cloud Run services available in the project.
-func (c *Client) ListCloudRunServices(ctx context.Context) ([]*CloudRunService, error) {
-	logger.Log.Debug("Listing Cloud Run services")
+// ListBigQueryDatasets returns the BigQuery datasets available in the project.
+func (c *Client) ListBigQueryDatasets(ctx context.Context) ([]*BigQueryDataset, error) {
+	logger.Log.Debug("Listing BigQuery datasets")
 
-	httpClient, err := newHTTPClientWithLogging(ctx, run.CloudPlatformScope)
+	httpClient, err := newHTTPClientWithLogging(ctx, bigquery.CloudPlatformReadOnlyScope)
 	if err != nil {
-		logger.Log.Errorf("Failed to create HTTP client for Cloud Run: %v", err)
+		logger.Log.Errorf("Failed to create HTTP client for BigQuery: %v", err)
 
 		return nil, fmt.Errorf("failed to create HTTP client: %w", err)
 	}
 
-	runService, err := run.NewService(ctx, option.WithHTTPClient(httpClient))
+	bigqueryService, err := bigquery.NewService(ctx, option.WithHTTPClient(httpClient))
 	if err != nil {
-		logger.Log.Errorf("Failed to create Cloud Run service: %v", err)
+		logger.Log.Errorf("Failed to create BigQuery service: %v", err)
 
-		return nil, fmt.Errorf("failed to create cloud run service: %w", err)
+		return nil, fmt.Errorf("failed to create bigquery service: %w", err)
 	}
 
-	// List services using the namespaces API (v1)
-	parent := fmt.Sprintf("namespaces/%s", c.project)
-	resp, err := runService.Namespaces.Services.List(parent).Context(ctx).Do()
-	if err != nil {
-		logger.Log.Errorf("Failed to list Cloud Run services: %v", err)
-
-		return nil, fmt.Errorf("failed to list cloud run services: %w", err)
-	}
+	var results []*BigQueryDataset
+	pageToken := ""
 
-	results := make([]*CloudRunService, 0, len(resp.Items))
-	for _, svc := range resp.Items {
-		if svc == nil {
-			continue
+	for {
+		call := bigqueryService.Datasets.List(c.project).Context(ctx)
+		if pageToken != "" {
+			call = call.PageToken(pageToken)
 		}
 
-		region := ""
-		if svc.Metadata != nil && svc.Metadata.Labels != nil {
-			region = svc.Metadata.Labels["cloud.googleapis.com/location"]
-		}
+		resp, err := call.Do()
+		if err != nil {
+			logger.Log.Errorf("Failed to list BigQuery datasets: %v", err)
 
-		url := ""
-		latestRevision := ""
-		if svc.Status != nil {
-			url = svc.Status.Url
-			latestRevision = svc.Status.LatestReadyRevisionName
+			return nil, fmt.Errorf("failed to list bigquery datasets: %w", err)
 		}
 
-		name := ""
-		if svc.Metadata != nil {
-			name = svc.Metadata.Name
+		for _, item := range resp.Datasets {
+			if item == nil || item.DatasetReference == nil {
+				continue
+			}
+
+			results = append(results, &BigQueryDataset{
+				Name:         item.DatasetReference.DatasetId,
+				Location:     item.Location,
+				FriendlyName: item.FriendlyName,
+				Labels:       item.Labels,
+			})
+		}
+
+		if resp.NextPageToken == "" {
+			break
+		}
+
+		pageToken = resp.NextPageToken
+	}
+
+	return results, nil
+}
+
+// ListGKEClusters returns the GKE clusters available in the project.
+func (c *Client) ListGKEClusters(ctx context.Context) ([]*GKECluster, error) {
+	logger.Log.Debug("Listing GKE clusters")
+
+	clusters, err := c.listContainerClusters(ctx)
+	if err != nil {
+		logger.Log.Errorf("Failed to list GKE clusters: %v", err)
+
+		return nil, err
+	}
+
+	results := make([]*GKECluster, 0, len(clusters))
+	for _, cluster := range clusters {
+		nodeCount := 0
+		for _, pool := range cluster.NodePools {
+			if pool != nil {
+				nodeCount += int(pool.InitialNodeCount)
+			}
+		}
+
+		results = append(results, &GKECluster{
+			Name:                 cluster.Name,
+			Location:             cluster.Location,
+			Status:               cluster.Status,
+			CurrentMasterVersion: cluster.CurrentMasterVersion,
+			NodeCount:            nodeCount,
+			Labels:               cluster.ResourceLabels,
+		})
+	}
+
+	return results, nil
+}
+
+// ListGKENodePools returns the GKE node pools available in the project.
+func (c *Client) ListGKENodePools(ctx context.Context) ([]*GKENodePool, error) {
+	logger.Log.Debug("Listing GKE node pools")
+
+	clusters, err := c.listContainerClusters(ctx)
+	if err != nil {
+		logger.Log.Errorf("Failed to list GKE clusters for node pools: %v", err)
+
+		return nil, err
+	}
+
+	var results []*GKENodePool
+	for _, cluster := range clusters {
+		for _, pool := range cluster.NodePools {
+			if pool == nil {
+				continue
+			}
+
+			machineType := ""
+			if pool.Config != nil {
+				machineType = pool.Config.MachineType
+			}
+
+			results = append(results, &GKENodePool{
+				Name:        pool.Name,
+				ClusterName: cluster.Name,
+				Location:    cluster.Location,
+				MachineType: machineType,
+				NodeCount:   int(pool.InitialNodeCount),
+				Status:      pool.Status,
+			})
+		}
+	}
+
+	return results, nil
+}
+
+// listContainerClusters returns the raw GKE clusters of the project across all locations.
+func (c *Client) listContainerClusters(ctx context.Context) ([]*container.Cluster, error) {
+	httpClient, err := newHTTPClientWithLogging(ctx, container.CloudPlatformScope)
+	if err != nil {
+		logger.Log.Errorf("Failed to create HTTP client for Container: %v", err)
+
+		return nil, fmt.Errorf("failed to create HTTP client: %w", err)
+	}
+
+	containerService, err := container.NewService(ctx, option.WithHTTPClient(httpClient))
+	if err != nil {
+		logger.Log.Errorf("Failed to create Container service: %v", err)
+
+		return nil, fmt.Errorf("failed to create container service: %w", err)
+	}
+
+	// Use "-" to list clusters in all locations
+	parent := fmt.Sprintf("projects/%s/locations/-", c.project)
+	resp, err := containerService.Projects.Locations.Clusters.List(parent).Context(ctx).Do()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list gke clusters: %w", err)
+	}
+
+	results := make([]*container.Cluster, 0, len(resp.Clusters))
+	for _, cluster := range resp.Clusters {
+		if cluster != nil {
+			results = append(results, cluster)
+		}
+	}
+
+	return results, nil
+}
+
+// ListVPCNetworks returns the VPC networks available in the project.
+func (c *Client) ListVPCNetworks(ctx context.Context) ([]*VPCNetwork, error) {
+	logger.Log.Debug("Listing VPC networks")
+
+	pageToken := ""
+	var results []*VPCNetwork
+
+	for {
+		call := c.service.Networks.List(c.project).Context(ctx)
+		if pageToken != "" {
+			call = call.PageToken(pageToken)
+		}
+
+		resp, err := call.Do()
+		if err != nil {
+			logger.Log.Errorf("Failed to list VPC networks: %v", err)
+
+			return nil, fmt.Errorf("failed to list vpc networks: %w", err)
+		}
+
+		for _, network := range resp.Items {
+			if network == nil {
+				continue
+			}
+
+			results = append(results, &VPCNetwork{
+				Name:                  network.Name,
+				AutoCreateSubnetworks: network.AutoCreateSubnetworks,
+				SubnetCount:           len(network.Subnetworks),
+			})
+		}
+
+		if resp.NextPageToken == "" {
+			break
+		}
+
+		pageToken = resp.NextPageToken
+	}
+
+	return results, nil
+}
+
+// ListSubnets returns the VPC subnets available in the project.
+func (c *Client) ListSubnets(ctx context.Context) ([]*Subnet, error) {
+	logger.Log.Debug("Listing VPC subnets")
+
+	pageToken := ""
+	var results []*Subnet
+
+	for {
+		call := c.service.Subnetworks.AggregatedList(c.project).Context(ctx)
+		if pageToken != "" {
+			call = call.PageToken(pageToken)
+		}
+
+		list, err := call.Do()
+		if err != nil {
+			logger.Log.Errorf("Failed to list aggregated subnets: %v", err)
+
+			return nil, fmt.Errorf("failed to list subnets: %w", err)
+		}
+
+		for scope, scopedList := range list.Items {
+			if len(scopedList.Subnetworks) == 0 {
+				continue
+			}
+
+			regionName := extractRegionFromScope(scope)
+
+			for _, subnet := range scopedList.Subnetworks {
+				if subnet == nil {
+					continue
+				}
+
+				results = append(results, &Subnet{
+					Name:        subnet.Name,
+					Region:      regionName,
+					Network:     extractResourceName(subnet.Network),
+					IPCidrRange: subnet.IpCidrRange,
+					Purpose:     subnet.Purpose,
+				})
+			}
+		}
+
+		if list.NextPageToken == "" {
+			break
+		}
+
+		pageToken = list.NextPageToken
+	}
+
+	return results, nil
+}
+
+// ListCloudRunServices returns the Cloud Run services available in the project.
+func (c *Client) ListCloudRunServices(ctx context.Context) ([]*CloudRunService, error) {
+	logger.Log.Debug("Listing Cloud Run services")
+
+	httpClient, err := newHTTPClientWithLogging(ctx, run.CloudPlatformScope)
+	if err != nil {
+		logger.Log.Errorf("Failed to create HTTP client for Cloud Run: %v", err)
+
+		return nil, fmt.Errorf("failed to create HTTP client: %w", err)
+	}
+
+	runService, err := run.NewService(ctx, option.WithHTTPClient(httpClient))
+	if err != nil {
+		logger.Log.Errorf("Failed to create Cloud Run service: %v", err)
+
+		return nil, fmt.Errorf("failed to create cloud run service: %w", err)
+	}
+
+	// List services using the namespaces API (v1)
+	parent := fmt.Sprintf("namespaces/%s", c.project)
+	resp, err := runService.Namespaces.Services.List(parent).Context(ctx).Do()
+	if err != nil {
+		logger.Log.Errorf("Failed to list Cloud Run services: %v", err)
+
+		return nil, fmt.Errorf("failed to list cloud run services: %w", err)
+	}
+
+	results := make([]*CloudRunService, 0, len(resp.Items))
+	for _, svc := range resp.Items {
+		if svc == nil {
+			continue
+		}
+
+		region := ""
+		if svc.Metadata != nil && svc.Metadata.Labels != nil {
+			region = svc.Metadata.Labels["cloud.googleapis.com/location"]
+		}
+
+		url := ""
+		latestRevision := ""
+		if svc.Status != nil {
+			url = svc.Status.Url
+			latestRevision = svc.Status.LatestReadyRevisionName
+		}
+
+		name := ""
+		if svc.Metadata != nil {
+			name = svc.Metadata.Name
+		}
+		labels := map[string]string(nil)
+		if svc.Metadata != nil {
+			labels = userLabels(svc.Metadata.Labels)
 		}
 
 		results = append(results, &CloudRunService{
@@ -898,58 +1319,935 @@ func (c *Client) ListCloudRunServices(ctx context.Context) ([]*CloudRunService,
 			Region:         region,
 			URL:            url,
 			LatestRevision: latestRevision,
+			Labels:         labels,
 		})
 	}
 
 	return results, nil
 }
 
-// ListFirewallRules returns the firewall rules available in the project.
-func (c *Client) ListFirewallRules(ctx context.Context) ([]*FirewallRule, error) {
-	logger.Log.Debug("Listing firewall rules")
+// ListCloudRunJobs returns the Cloud Run jobs available in the project across all regions.
+func (c *Client) ListCloudRunJobs(ctx context.Context) ([]*CloudRunJob, error) {
+	logger.Log.Debug("Listing Cloud Run jobs")
+
+	httpClient, err := newHTTPClientWithLogging(ctx, runv2.CloudPlatformScope)
+	if err != nil {
+		logger.Log.Errorf("Failed to create HTTP client for Cloud Run: %v", err)
+
+		return nil, fmt.Errorf("failed to create HTTP client: %w", err)
+	}
+
+	runService, err := runv2.NewService(ctx, option.WithHTTPClient(httpClient))
+	if err != nil {
+		logger.Log.Errorf("Failed to create Cloud Run service: %v", err)
+
+		return nil, fmt.Errorf("failed to create cloud run service: %w", err)
+	}
+
+	var results []*CloudRunJob
+	pageToken := ""
+	parent := fmt.Sprintf("projects/%s/locations/-", c.project)
+
+	for {
+		call := runService.Projects.Locations.Jobs.List(parent).Context(ctx)
+		if pageToken != "" {
+			call = call.PageToken(pageToken)
+		}
+
+		resp, err := call.Do()
+		if err != nil {
+			logger.Log.Errorf("Failed to list Cloud Run jobs: %v", err)
+
+			return nil, fmt.Errorf("failed to list cloud run jobs: %w", err)
+		}
+
+		for _, job := range resp.Jobs {
+			if job == nil {
+				continue
+			}
+
+			results = append(results, convertCloudRunJob(job))
+		}
+
+		if resp.NextPageToken == "" {
+			break
+		}
+
+		pageToken = resp.NextPageToken
+	}
+
+	return results, nil
+}
+
+// convertCloudRunJob flattens a Cloud Run v2 job into a CloudRunJob.
+func convertCloudRunJob(job *runv2.GoogleCloudRunV2Job) *CloudRunJob {
+	region, name := extractLocationAndName(job.Name)
+
+	result := &CloudRunJob{
+		Name:           name,
+		Region:         region,
+		ExecutionCount: job.ExecutionCount,
+		Labels:         job.Labels,
+	}
+
+	if job.Template != nil {
+		result.TaskCount = job.Template.TaskCount
+		result.Parallelism = job.Template.Parallelism
+
+		if task := job.Template.Template; task != nil {
+			result.MaxRetries = task.MaxRetries
+			result.ServiceAccount = task.ServiceAccount
+
+			if len(task.Containers) > 0 && task.Containers[0] != nil {
+				result.Image = task.Containers[0].Image
+			}
+		}
+	}
+
+	if exec := job.LatestCreatedExecution; exec != nil {
+		_, result.LatestExecution = extractLocationAndName(exec.Name)
+		result.LatestExecutionStatus = exec.CompletionStatus
+		result.LatestExecutionTime = exec.CompletionTime
+		if result.LatestExecutionTime == "" {
+			result.LatestExecutionTime = exec.CreateTime
+		}
+	}
+
+	return result
+}
+
+// ListCloudFunctions returns the Cloud Functions (1st and 2nd gen) available in the project across all regions.
+func (c *Client) ListCloudFunctions(ctx context.Context) ([]*CloudFunction, error) {
+	logger.Log.Debug("Listing Cloud Functions")
+
+	httpClient, err := newHTTPClientWithLogging(ctx, cloudfunctions.CloudPlatformScope)
+	if err != nil {
+		logger.Log.Errorf("Failed to create HTTP client for Cloud Functions: %v", err)
+
+		return nil, fmt.Errorf("failed to create HTTP client: %w", err)
+	}
+
+	functionsService, err := cloudfunctions.NewService(ctx, option.WithHTTPClient(httpClient))
+	if err != nil {
+		logger.Log.Errorf("Failed to create Cloud Functions service: %v", err)
+
+		return nil, fmt.Errorf("failed to create cloud functions service: %w", err)
+	}
+
+	var results []*CloudFunction
+	pageToken := ""
+	parent := fmt.Sprintf("projects/%s/locations/-", c.project)
+
+	for {
+		call := functionsService.Projects.Locations.Functions.List(parent).Context(ctx)
+		if pageToken != "" {
+			call = call.PageToken(pageToken)
+		}
+
+		resp, err := call.Do()
+		if err != nil {
+			logger.Log.Errorf("Failed to list Cloud Functions: %v", err)
+
+			return nil, fmt.Errorf("failed to list cloud functions: %w", err)
+		}
+
+		if len(resp.Unreachable) > 0 {
+			logger.Log.Debugf("Cloud Functions locations unreachable: %s", strings.Join(resp.Unreachable, ", "))
+		}
+
+		for _, fn := range resp.Functions {
+			if fn == nil {
+				continue
+			}
+
+			results = append(results, convertCloudFunction(fn))
+		}
+
+		if resp.NextPageToken == "" {
+			break
+		}
+
+		pageToken = resp.NextPageToken
+	}
+
+	return results, nil
+}
+
+// convertCloudFunction flattens a Cloud Functions v2 API function into a CloudFunction.
+func convertCloudFunction(fn *cloudfunctions.Function) *CloudFunction {
+	region, name := extractLocationAndName(fn.Name)
+
+	result := &CloudFunction{
+		Name:        name,
+		Region:      region,
+		Generation:  strings.ReplaceAll(strings.ToLower(fn.Environment), "_", ""),
+		State:       fn.State,
+		URL:         fn.Url,
+		TriggerType: "http",
+		Labels:      fn.Labels,
+	}
+
+	if fn.BuildConfig != nil {
+		result.Runtime = fn.BuildConfig.Runtime
+		result.EntryPoint = fn.BuildConfig.EntryPoint
+	}
+
+	if svc := fn.ServiceConfig; svc != nil {
+		result.IngressSettings = svc.IngressSettings
+		result.ServiceAccount = svc.ServiceAccountEmail
+		if result.URL == "" {
+			result.URL = svc.Uri
+		}
+	}
+
+	if trigger := fn.EventTrigger; trigger != nil {
+		result.TriggerType = "event"
+		result.EventType = trigger.EventType
+
+		if trigger.PubsubTopic != "" {
+			result.TriggerResource = trigger.PubsubTopic
+		} else {
+			values := make([]string, 0, len(trigger.EventFilters))
+			for _, filter := range trigger.EventFilters {
+				if filter != nil && filter.Value != "" {
+					values = append(values, filter.Value)
+				}
+			}
+			result.TriggerResource = strings.Join(values, ", ")
+		}
+	}
+
+	return result
+}
+
+// ListAppEngineServices returns the App Engine services of the project's application.
+// Projects without an App Engine application return an empty list.
+func (c *Client) ListAppEngineServices(ctx context.Context) ([]*AppEngineService, error) {
+	logger.Log.Debug("Listing App Engine services")
+
+	httpClient, err := newHTTPClientWithLogging(ctx, appengine.CloudPlatformReadOnlyScope)
+	if err != nil {
+		logger.Log.Errorf("Failed to create HTTP client for App Engine: %v", err)
+
+		return nil, fmt.Errorf("failed to create HTTP client: %w", err)
+	}
+
+	appService, err := appengine.NewService(ctx, option.WithHTTPClient(httpClient))
+	if err != nil {
+		logger.Log.Errorf("Failed to create App Engine service: %v", err)
+
+		return nil, fmt.Errorf("failed to create app engine service: %w", err)
+	}
+
+	app, err := appService.Apps.Get(c.project).Context(ctx).Do()
+	if err != nil {
+		var apiErr *googleapi.Error
+		if errors.As(err, &apiErr) && apiErr.Code == http.StatusNotFound {
+			logger.Log.Debugf("Project %s has no App Engine application", c.project)
+
+			return nil, nil
+		}
+
+		logger.Log.Errorf("Failed to get App Engine application: %v", err)
+
+		return nil, fmt.Errorf("failed to get app engine application: %w", err)
+	}
+
+	var results []*AppEngineService
+	var services []*appengine.Service
+	pageToken := ""
+
+	for {
+		call := appService.Apps.Services.List(c.project).Context(ctx)
+		if pageToken != "" {
+			call = call.PageToken(pageToken)
+		}
+
+		resp, err := call.Do()
+		if err != nil {
+			logger.Log.Errorf("Failed to list App Engine services: %v", err)
+
+			return nil, fmt.Errorf("failed to list app engine services: %w", err)
+		}
+
+		for _, svc := range resp.Services {
+			if svc == nil {
+				continue
+			}
+
+			services = append(services, svc)
+			results = append(results, convertAppEngineService(svc, app))
+		}
+
+		if resp.NextPageToken == "" {
+			break
+		}
+
+		pageToken = resp.NextPageToken
+	}
+
+	// Runtime details live on versions, which require one call per service
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(DefaultLookupConcurrency)
+
+	var mu sync.Mutex
+	for i, svc := range services {
+		svc, result := svc, results[i]
+		group.Go(func() error {
+			versions, err := listAppEngineVersions(groupCtx, appService, c.project, svc.Id)
+			if err != nil {
+				// Versions are informational only, keep the service even if they cannot be listed
+				logger.Log.Debugf("Failed to list versions for App Engine service %s: %v", svc.Id, err)
+
+				return nil
+			}
+
+			mu.Lock()
+			applyAppEngineVersion(result, versions)
+			mu.Unlock()
+
+			return nil
+		})
+	}
+
+	if err := group.Wait(); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// listAppEngineVersions returns every version of an App Engine service.
+func listAppEngineVersions(ctx context.Context, appService *appengine.APIService, appID, serviceID string) ([]*appengine.Version, error) {
+	var versions []*appengine.Version
+	pageToken := ""
+
+	for {
+		call := appService.Apps.Services.Versions.List(appID, serviceID).Context(ctx)
+		if pageToken != "" {
+			call = call.PageToken(pageToken)
+		}
+
+		resp, err := call.Do()
+		if err != nil {
+			return nil, err
+		}
+
+		versions = append(versions, resp.Versions...)
+
+		if resp.NextPageToken == "" {
+			break
+		}
+
+		pageToken = resp.NextPageToken
+	}
+
+	return versions, nil
+}
+
+// convertAppEngineService builds an AppEngineService from the API service and its application.
+func convertAppEngineService(svc *appengine.Service, app *appengine.Application) *AppEngineService {
+	result := &AppEngineService{
+		Name:     svc.Id,
+		Location: app.LocationId,
+		Labels:   svc.Labels,
+	}
+
+	if app.DefaultHostname != "" {
+		if svc.Id == "default" {
+			result.URL = "https://" + app.DefaultHostname
+		} else {
+			result.URL = fmt.Sprintf("https://%s-dot-%s", svc.Id, app.DefaultHostname)
+		}
+	}
+
+	if svc.NetworkSettings != nil {
+		result.IngressSettings = svc.NetworkSettings.IngressTrafficAllowed
+	}
+
+	if svc.Split != nil {
+		result.Version = mainAppEngineVersion(svc.Split.Allocations)
+	}
+
+	return result
+}
+
+// mainAppEngineVersion returns the version receiving the largest traffic share.
+// Ties are broken alphabetically so the result is stable.
+func mainAppEngineVersion(allocations map[string]float64) string {
+	ids := make([]string, 0, len(allocations))
+	for id := range allocations {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	mainVersion := ""
+	best := -1.0
+	for _, id := range ids {
+		if allocations[id] > best {
+			mainVersion = id
+			best = allocations[id]
+		}
+	}
+
+	return mainVersion
+}
+
+// applyAppEngineVersion fills runtime information from the service's main version.
+// When no traffic split is known the most recent version is used.
+func applyAppEngineVersion(svc *AppEngineService, versions []*appengine.Version) {
+	svc.VersionCount = len(versions)
+
+	var selected *appengine.Version
+	for _, version := range versions {
+		if version == nil {
+			continue
+		}
+		if svc.Version != "" && version.Id == svc.Version {
+			selected = version
+			break
+		}
+		if svc.Version == "" && (selected == nil || version.CreateTime > selected.CreateTime) {
+			selected = version
+		}
+	}
+
+	if selected == nil {
+		return
+	}
+
+	svc.Version = selected.Id
+	svc.Runtime = selected.Runtime
+	svc.ServingStatus = selected.ServingStatus
+	svc.Environment = "standard"
+	if selected.Env == "flex" || selected.Env == "flexible" {
+		svc.Environment = "flexible"
+	}
+}
+
+// ListFirewallRules returns the firewall rules available in the project.
+func (c *Client) ListFirewallRules(ctx context.Context) ([]*FirewallRule, error) {
+	logger.Log.Debug("Listing firewall rules")
+
+	pageToken := ""
+	var results []*FirewallRule
+
+	for {
+		call := c.service.Firewalls.List(c.project).Context(ctx)
+		if pageToken != "" {
+			call = call.PageToken(pageToken)
+		}
+
+		resp, err := call.Do()
+		if err != nil {
+			logger.Log.Errorf("Failed to list firewall rules: %v", err)
+
+			return nil, fmt.Errorf("failed to list firewall rules: %w", err)
+		}
+
+		for _, rule := range resp.Items {
+			if rule == nil {
+				continue
+			}
+
+			// Format allowed and denied rules as "protocol:ports" strings
+			allowed := make([]string, 0, len(rule.Allowed))
+			for _, a := range rule.Allowed {
+				entry := a.IPProtocol
+				if len(a.Ports) > 0 {
+					entry += ":" + strings.Join(a.Ports, ",")
+				}
+				allowed = append(allowed, entry)
+			}
+
+			denied := make([]string, 0, len(rule.Denied))
+			for _, d := range rule.Denied {
+				entry := d.IPProtocol
+				if len(d.Ports) > 0 {
+					entry += ":" + strings.Join(d.Ports, ",")
+				}
+				denied = append(denied, entry)
+			}
+
+			results = append(results, &FirewallRule{
+				Name:              rule.Name,
+				Network:           extractResourceName(rule.Network),
+				Direction:         rule.Direction,
+				Priority:          rule.Priority,
+				Disabled:          rule.Disabled,
+				Description:       rule.Description,
+				SourceRanges:      rule.SourceRanges,
+				DestinationRanges: rule.DestinationRanges,
+				TargetTags:        rule.TargetTags,
+				Allowed:           allowed,
+				Denied:            denied,
+			})
+		}
+
+		if resp.NextPageToken == "" {
+			break
+		}
+
+		pageToken = resp.NextPageToken
+	}
+
+	return results, nil
+}
+
+// ListRoutes returns the VPC routes available in the project.
+func (c *Client) ListRoutes(ctx context.Context) ([]*Route, error) {
+	logger.Log.Debug("Listing routes")
+
+	pageToken := ""
+	var results []*Route
+
+	for {
+		call := c.service.Routes.List(c.project).Context(ctx)
+		if pageToken != "" {
+			call = call.PageToken(pageToken)
+		}
+
+		resp, err := call.Do()
+		if err != nil {
+			logger.Log.Errorf("Failed to list routes: %v", err)
+
+			return nil, fmt.Errorf("failed to list routes: %w", err)
+		}
+
+		for _, route := range resp.Items {
+			if route == nil {
+				continue
+			}
+
+			// Determine next hop
+			nextHop := ""
+			switch {
+			case route.NextHopGateway != "":
+				nextHop = extractResourceName(route.NextHopGateway)
+			case route.NextHopInstance != "":
+				nextHop = extractResourceName(route.NextHopInstance)
+			case route.NextHopIp != "":
+				nextHop = route.NextHopIp
+			case route.NextHopVpnTunnel != "":
+				nextHop = extractResourceName(route.NextHopVpnTunnel)
+			case route.NextHopIlb != "":
+				nextHop = route.NextHopIlb
+			case route.NextHopPeering != "":
+				nextHop = route.NextHopPeering
+			}
+
+			results = append(results, &Route{
+				Name:        route.Name,
+				Description: route.Description,
+				DestRange:   route.DestRange,
+				Network:     extractResourceName(route.Network),
+				Priority:    route.Priority,
+				NextHop:     nextHop,
+				RouteType:   route.RouteType,
+				Tags:        route.Tags,
+			})
+		}
+
+		if resp.NextPageToken == "" {
+			break
+		}
+
+		pageToken = resp.NextPageToken
+	}
+
+	return results, nil
+}
+
+// ListRouters returns the Cloud Routers available in the project across all regions.
+func (c *Client) ListRouters(ctx context.Context) ([]*Router, error) {
+	logger.Log.Debug("Listing Cloud Routers")
+
+	routers, err := c.listRouters(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*Router, 0, len(routers))
+	for _, router := range routers {
+		results = append(results, convertRouter(router))
+	}
+
+	return results, nil
+}
+
+// ListRouterNATs returns the Cloud NAT gateways configured on the project's Cloud Routers.
+// NAT IPs are resolved from the router status so auto-allocated addresses are included.
+func (c *Client) ListRouterNATs(ctx context.Context) ([]*RouterNAT, error) {
+	logger.Log.Debug("Listing Cloud NAT gateways")
+
+	routers, err := c.listRouters(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.routerNATs(ctx, routers)
+}
+
+// routerNATs returns the NAT gateways of already listed routers, with their NAT IPs in use.
+func (c *Client) routerNATs(ctx context.Context, routers []*compute.Router) ([]*RouterNAT, error) {
+	var results []*RouterNAT
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(DefaultLookupConcurrency)
+
+	// Each status lookup only updates the NATs of its own router, so no locking is needed
+	for _, router := range routers {
+		if len(router.Nats) == 0 {
+			continue
+		}
+
+		region := extractResourceName(router.Region)
+		nats := make([]*RouterNAT, 0, len(router.Nats))
+		for _, nat := range router.Nats {
+			if nat == nil {
+				continue
+			}
+			nats = append(nats, convertRouterNAT(router, region, nat))
+		}
+		results = append(results, nats...)
+
+		routerName := router.Name
+		group.Go(func() error {
+			resp, err := c.service.Routers.GetRouterStatus(c.project, region, routerName).Context(groupCtx).Do()
+			if err != nil {
+				// NAT IPs are informational only, keep the configuration even if the status is unavailable
+				logger.Log.Debugf("Failed to get status for router %s/%s: %v", region, routerName, err)
+
+				return nil
+			}
+			if resp.Result == nil {
+				return nil
+			}
+
+			applyRouterNATStatus(nats, resp.Result.NatStatus)
+
+			return nil
+		})
+	}
+
+	if err := group.Wait(); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// listRouters returns the raw Cloud Router resources across all regions.
+func (c *Client) listRouters(ctx context.Context) ([]*compute.Router, error) {
+	pageToken := ""
+	var results []*compute.Router
+
+	for {
+		call := c.service.Routers.AggregatedList(c.project).Context(ctx)
+		if pageToken != "" {
+			call = call.PageToken(pageToken)
+		}
+
+		list, err := call.Do()
+		if err != nil {
+			logger.Log.Errorf("Failed to list aggregated routers: %v", err)
+
+			return nil, fmt.Errorf("failed to list routers: %w", err)
+		}
+
+		for _, scopedList := range list.Items {
+			for _, router := range scopedList.Routers {
+				if router != nil {
+					results = append(results, router)
+				}
+			}
+		}
+
+		if list.NextPageToken == "" {
+			break
+		}
+
+		pageToken = list.NextPageToken
+	}
+
+	return results, nil
+}
+
+// convertRouter flattens a compute Router into a Router.
+func convertRouter(router *compute.Router) *Router {
+	result := &Router{
+		Name:        router.Name,
+		Region:      extractResourceName(router.Region),
+		Network:     extractResourceName(router.Network),
+		Description: router.Description,
+		Interfaces:  len(router.Interfaces),
+	}
+
+	if bgp := router.Bgp; bgp != nil {
+		result.ASN = bgp.Asn
+		result.AdvertiseMode = bgp.AdvertiseMode
+		result.AdvertisedGroups = bgp.AdvertisedGroups
+		for _, r := range bgp.AdvertisedIpRanges {
+			if r != nil && r.Range != "" {
+				result.AdvertisedRanges = append(result.AdvertisedRanges, r.Range)
+			}
+		}
+	}
+
+	for _, peer := range router.BgpPeers {
+		if peer == nil {
+			continue
+		}
+		result.BGPPeers = append(result.BGPPeers, RouterBGPPeer{
+			Name:          peer.Name,
+			PeerASN:       peer.PeerAsn,
+			PeerIPAddress: peer.PeerIpAddress,
+			IPAddress:     peer.IpAddress,
+		})
+	}
+
+	for _, nat := range router.Nats {
+		if nat != nil {
+			result.NATs = append(result.NATs, nat.Name)
+		}
+	}
+
+	return result
+}
+
+// convertRouterNAT flattens a NAT configuration of a compute Router into a RouterNAT.
+func convertRouterNAT(router *compute.Router, region string, nat *compute.RouterNat) *RouterNAT {
+	result := &RouterNAT{
+		Name:                   nat.Name,
+		Router:                 router.Name,
+		Region:                 region,
+		Network:                extractResourceName(router.Network),
+		IPAllocateOption:       nat.NatIpAllocateOption,
+		SourceSubnetworkRanges: nat.SourceSubnetworkIpRangesToNat,
+		MinPortsPerVM:          nat.MinPortsPerVm,
+		EndpointIndependent:    nat.EnableEndpointIndependentMapping,
+	}
+
+	for _, ip := range nat.NatIps {
+		result.NATIPResources = append(result.NATIPResources, extractResourceName(ip))
+	}
+
+	if nat.LogConfig != nil && nat.LogConfig.Enable {
+		result.LogFilter = nat.LogConfig.Filter
+	}
+
+	return result
+}
+
+// applyRouterNATStatus fills the NAT IPs in use from the router status.
+func applyRouterNATStatus(nats []*RouterNAT, statuses []*compute.RouterStatusNatStatus) {
+	byName := make(map[string]*compute.RouterStatusNatStatus, len(statuses))
+	for _, status := range statuses {
+		if status != nil {
+			byName[status.Name] = status
+		}
+	}
+
+	for _, nat := range nats {
+		status, ok := byName[nat.Name]
+		if !ok {
+			continue
+		}
+
+		ips := make([]string, 0, len(status.UserAllocatedNatIps)+len(status.AutoAllocatedNatIps))
+		ips = append(ips, status.UserAllocatedNatIps...)
+		ips = append(ips, status.AutoAllocatedNatIps...)
+		nat.NATIPs = ips
+	}
+}
+
+// ListInterconnectAttachments returns the Interconnect VLAN attachments available in the project.
+func (c *Client) ListInterconnectAttachments(ctx context.Context) ([]*InterconnectAttachment, error) {
+	logger.Log.Debug("Listing Interconnect attachments")
+
+	pageToken := ""
+	var results []*InterconnectAttachment
+
+	for {
+		call := c.service.InterconnectAttachments.AggregatedList(c.project).Context(ctx)
+		if pageToken != "" {
+			call = call.PageToken(pageToken)
+		}
+
+		list, err := call.Do()
+		if err != nil {
+			logger.Log.Errorf("Failed to list aggregated interconnect attachments: %v", err)
+
+			return nil, fmt.Errorf("failed to list interconnect attachments: %w", err)
+		}
+
+		for _, scopedList := range list.Items {
+			for _, attachment := range scopedList.InterconnectAttachments {
+				if attachment == nil {
+					continue
+				}
+
+				results = append(results, convertInterconnectAttachment(attachment))
+			}
+		}
+
+		if list.NextPageToken == "" {
+			break
+		}
+
+		pageToken = list.NextPageToken
+	}
+
+	return results, nil
+}
+
+// convertInterconnectAttachment flattens a compute InterconnectAttachment.
+func convertInterconnectAttachment(attachment *compute.InterconnectAttachment) *InterconnectAttachment {
+	result := &InterconnectAttachment{
+		Name:                    attachment.Name,
+		Region:                  extractResourceName(attachment.Region),
+		Description:             attachment.Description,
+		Type:                    attachment.Type,
+		Interconnect:            extractResourceName(attachment.Interconnect),
+		Router:                  extractResourceName(attachment.Router),
+		Bandwidth:               attachment.Bandwidth,
+		State:                   attachment.State,
+		OperationalStatus:       attachment.OperationalStatus,
+		AdminEnabled:            attachment.AdminEnabled,
+		PairingKey:              attachment.PairingKey,
+		PartnerASN:              attachment.PartnerAsn,
+		VLANTag:                 attachment.VlanTag8021q,
+		CloudRouterIPAddress:    attachment.CloudRouterIpAddress,
+		CustomerRouterIPAddress: attachment.CustomerRouterIpAddress,
+		EdgeAvailabilityDomain:  attachment.EdgeAvailabilityDomain,
+		MTU:                     attachment.Mtu,
+		Labels:                  attachment.Labels,
+	}
+
+	if attachment.PartnerMetadata != nil {
+		result.PartnerName = attachment.PartnerMetadata.PartnerName
+	}
+
+	return result
+}
+
+// ListSecrets returns the Secret Manager secrets available in the project.
+func (c *Client) ListSecrets(ctx context.Context) ([]*Secret, error) {
+	logger.Log.Debug("Listing Secret Manager secrets")
+
+	httpClient, err := newHTTPClientWithLogging(ctx, secretmanager.CloudPlatformScope)
+	if err != nil {
+		logger.Log.Errorf("Failed to create HTTP client for Secret Manager: %v", err)
+
+		return nil, fmt.Errorf("failed to create HTTP client: %w", err)
+	}
+
+	secretService, err := secretmanager.NewService(ctx, option.WithHTTPClient(httpClient))
+	if err != nil {
+		logger.Log.Errorf("Failed to create Secret Manager service: %v", err)
+
+		return nil, fmt.Errorf("failed to create secret manager service: %w", err)
+	}
+
+	var results []*Secret
+	pageToken := ""
+
+	for {
+		parent := fmt.Sprintf("projects/%s", c.project)
+		call := secretService.Projects.Secrets.List(parent).Context(ctx)
+		if pageToken != "" {
+			call = call.PageToken(pageToken)
+		}
+
+		resp, err := call.Do()
+		if err != nil {
+			logger.Log.Errorf("Failed to list secrets: %v", err)
+
+			return nil, fmt.Errorf("failed to list secrets: %w", err)
+		}
+
+		for _, secret := range resp.Secrets {
+			if secret == nil {
+				continue
+			}
+
+			replication := ""
+			if secret.Replication != nil {
+				if secret.Replication.Automatic != nil {
+					replication = "automatic"
+				} else if secret.Replication.UserManaged != nil {
+					replication = "user-managed"
+				}
+			}
+
+			// Extract just the secret name from the full resource name
+			name := extractResourceName(secret.Name)
+
+			results = append(results, &Secret{
+				Name:         name,
+				Replication:  replication,
+				VersionCount: 0, // Would need additional API call to get version count
+				Labels:       secret.Labels,
+			})
+		}
+
+		if resp.NextPageToken == "" {
+			break
+		}
+
+		pageToken = resp.NextPageToken
+	}
+
+	return results, nil
+}
+
+// ListPubSubTopics returns the Pub/Sub topics available in the project.
+func (c *Client) ListPubSubTopics(ctx context.Context) ([]*PubSubTopic, error) {
+	logger.Log.Debug("Listing Pub/Sub topics")
 
+	pubsubService, err := c.newPubSubService(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []*PubSubTopic
 	pageToken := ""
-	var results []*FirewallRule
 
 	for {
-		call := c.service.Firewalls.List(c.project).Context(ctx)
+		call := pubsubService.Projects.Topics.List(fmt.Sprintf("projects/%s", c.project)).Context(ctx)
 		if pageToken != "" {
 			call = call.PageToken(pageToken)
 		}
 
 		resp, err := call.Do()
 		if err != nil {
-			logger.Log.Errorf("Failed to list firewall rules: %v", err)
+			logger.Log.Errorf("Failed to list Pub/Sub topics: %v", err)
 
-			return nil, fmt.Errorf("failed to list firewall rules: %w", err)
+			return nil, fmt.Errorf("failed to list pubsub topics: %w", err)
 		}
 
-		for _, rule := range resp.Items {
-			if rule == nil {
+		for _, topic := range resp.Topics {
+			if topic == nil {
 				continue
 			}
 
-			// Format allowed rules as "protocol:ports" strings
-			allowed := make([]string, 0, len(rule.Allowed))
-			for _, a := range rule.Allowed {
-				entry := a.IPProtocol
-				if len(a.Ports) > 0 {
-					entry += ":" + strings.Join(a.Ports, ",")
-				}
-				allowed = append(allowed, entry)
+			result := &PubSubTopic{
+				Name:                     extractResourceName(topic.Name),
+				KMSKeyName:               topic.KmsKeyName,
+				MessageRetentionDuration: topic.MessageRetentionDuration,
+				State:                    topic.State,
+				Labels:                   topic.Labels,
 			}
 
-			results = append(results, &FirewallRule{
-				Name:         rule.Name,
-				Network:      extractResourceName(rule.Network),
-				Direction:    rule.Direction,
-				Priority:     rule.Priority,
-				Disabled:     rule.Disabled,
-				Description:  rule.Description,
-				SourceRanges: rule.SourceRanges,
-				TargetTags:   rule.TargetTags,
-				Allowed:      allowed,
-			})
+			if topic.SchemaSettings != nil {
+				result.SchemaName = extractResourceName(topic.SchemaSettings.Schema)
+				result.SchemaEncoding = topic.SchemaSettings.Encoding
+			}
+
+			if topic.MessageStoragePolicy != nil {
+				result.AllowedRegions = topic.MessageStoragePolicy.AllowedPersistenceRegions
+			}
+
+			results = append(results, result)
 		}
 
 		if resp.NextPageToken == "" {
@@ -962,58 +2260,37 @@ func (c *Client) ListFirewallRules(ctx context.Context) ([]*FirewallRule, error)
 	return results, nil
 }
 
-// ListRoutes returns the VPC routes available in the project.
-func (c *Client) ListRoutes(ctx context.Context) ([]*Route, error) {
-	logger.Log.Debug("Listing routes")
+// ListPubSubSubscriptions returns the Pub/Sub subscriptions available in the project.
+func (c *Client) ListPubSubSubscriptions(ctx context.Context) ([]*PubSubSubscription, error) {
+	logger.Log.Debug("Listing Pub/Sub subscriptions")
+
+	pubsubService, err := c.newPubSubService(ctx)
+	if err != nil {
+		return nil, err
+	}
 
+	var results []*PubSubSubscription
 	pageToken := ""
-	var results []*Route
 
 	for {
-		call := c.service.Routes.List(c.project).Context(ctx)
+		call := pubsubService.Projects.Subscriptions.List(fmt.Sprintf("projects/%s", c.project)).Context(ctx)
 		if pageToken != "" {
 			call = call.PageToken(pageToken)
 		}
 
 		resp, err := call.Do()
 		if err != nil {
-			logger.Log.Errorf("Failed to list routes: %v", err)
+			logger.Log.Errorf("Failed to list Pub/Sub subscriptions: %v", err)
 
-			return nil, fmt.Errorf("failed to list routes: %w", err)
+			return nil, fmt.Errorf("failed to list pubsub subscriptions: %w", err)
 		}
 
-		for _, route := range resp.Items {
-			if route == nil {
+		for _, sub := range resp.Subscriptions {
+			if sub == nil {
 				continue
 			}
 
-			// Determine next hop
-			nextHop := ""
-			switch {
-			case route.NextHopGateway != "":
-				nextHop = extractResourceName(route.NextHopGateway)
-			case route.NextHopInstance != "":
-				nextHop = extractResourceName(route.NextHopInstance)
-			case route.NextHopIp != "":
-				nextHop = route.NextHopIp
-			case route.NextHopVpnTunnel != "":
-				nextHop = extractResourceName(route.NextHopVpnTunnel)
-			case route.NextHopIlb != "":
-				nextHop = route.NextHopIlb
-			case route.NextHopPeering != "":
-				nextHop = route.NextHopPeering
-			}
-
-			results = append(results, &Route{
-				Name:        route.Name,
-				Description: route.Description,
-				DestRange:   route.DestRange,
-				Network:     extractResourceName(route.Network),
-				Priority:    route.Priority,
-				NextHop:     nextHop,
-				RouteType:   route.RouteType,
-				Tags:        route.Tags,
-			})
+			results = append(results, convertPubSubSubscription(sub))
 		}
 
 		if resp.NextPageToken == "" {
@@ -1026,62 +2303,312 @@ func (c *Client) ListRoutes(ctx context.Context) ([]*Route, error) {
 	return results, nil
 }
 
-// ListSecrets returns the Secret Manager secrets available in the project.
-func (c *Client) ListSecrets(ctx context.Context) ([]*Secret, error) {
-	logger.Log.Debug("Listing Secret Manager secrets")
+// newPubSubService creates a Pub/Sub API service using the logging HTTP client.
+func (c *Client) newPubSubService(ctx context.Context) (*pubsub.Service, error) {
+	httpClient, err := newHTTPClientWithLogging(ctx, pubsub.PubsubScope)
+	if err != nil {
+		logger.Log.Errorf("Failed to create HTTP client for Pub/Sub: %v", err)
 
-	httpClient, err := newHTTPClientWithLogging(ctx, secretmanager.CloudPlatformScope)
+		return nil, fmt.Errorf("failed to create HTTP client: %w", err)
+	}
+
+	pubsubService, err := pubsub.NewService(ctx, option.WithHTTPClient(httpClient))
 	if err != nil {
-		logger.Log.Errorf("Failed to create HTTP client for Secret Manager: %v", err)
+		logger.Log.Errorf("Failed to create Pub/Sub service: %v", err)
+
+		return nil, fmt.Errorf("failed to create pubsub service: %w", err)
+	}
+
+	return pubsubService, nil
+}
+
+// convertPubSubSubscription flattens a Pub/Sub API subscription into the compass representation.
+// Topic and dead-letter topic names are kept in their "projects/<p>/topics/<t>" form because
+// subscriptions frequently reference topics owned by other projects.
+func convertPubSubSubscription(sub *pubsub.Subscription) *PubSubSubscription {
+	result := &PubSubSubscription{
+		Name:                     extractResourceName(sub.Name),
+		Topic:                    sub.Topic,
+		AckDeadlineSeconds:       sub.AckDeadlineSeconds,
+		MessageRetentionDuration: sub.MessageRetentionDuration,
+		RetainAckedMessages:      sub.RetainAckedMessages,
+		EnableMessageOrdering:    sub.EnableMessageOrdering,
+		ExactlyOnceDelivery:      sub.EnableExactlyOnceDelivery,
+		Filter:                   sub.Filter,
+		Detached:                 sub.Detached,
+		State:                    sub.State,
+		Labels:                   sub.Labels,
+	}
+
+	if sub.PushConfig != nil {
+		result.PushEndpoint = sub.PushConfig.PushEndpoint
+	}
+
+	if sub.BigqueryConfig != nil {
+		result.BigQueryTable = sub.BigqueryConfig.Table
+	}
+
+	if sub.CloudStorageConfig != nil {
+		result.CloudStorageBucket = sub.CloudStorageConfig.Bucket
+	}
+
+	if sub.DeadLetterPolicy != nil {
+		result.DeadLetterTopic = sub.DeadLetterPolicy.DeadLetterTopic
+		result.MaxDeliveryAttempts = sub.DeadLetterPolicy.MaxDeliveryAttempts
+	}
+
+	return result
+}
+
+// ListDNSManagedZones returns the Cloud DNS managed zones available in the project.
+func (c *Client) ListDNSManagedZones(ctx context.Context) ([]*DNSManagedZone, error) {
+	logger.Log.Debug("Listing Cloud DNS managed zones")
+
+	dnsService, err := c.newDNSService(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	zones, err := c.listDNSManagedZones(ctx, dnsService)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*DNSManagedZone, 0, len(zones))
+	for _, zone := range zones {
+		results = append(results, convertDNSManagedZone(zone))
+	}
+
+	return results, nil
+}
+
+// ListDNSRecordSets returns the resource record sets of every Cloud DNS managed zone in the project.
+func (c *Client) ListDNSRecordSets(ctx context.Context) ([]*DNSRecordSet, error) {
+	logger.Log.Debug("Listing Cloud DNS record sets")
+
+	dnsService, err := c.newDNSService(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	zones, err := c.listDNSManagedZones(ctx, dnsService)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []*DNSRecordSet
+	for _, zone := range zones {
+		pageToken := ""
+
+		for {
+			call := dnsService.ResourceRecordSets.List(c.project, zone.Name).Context(ctx)
+			if pageToken != "" {
+				call = call.PageToken(pageToken)
+			}
+
+			resp, err := call.Do()
+			if err != nil {
+				logger.Log.Errorf("Failed to list record sets in zone %s: %v", zone.Name, err)
+
+				return nil, fmt.Errorf("failed to list dns record sets in zone %s: %w", zone.Name, err)
+			}
+
+			for _, rrset := range resp.Rrsets {
+				if rrset == nil {
+					continue
+				}
+
+				record := &DNSRecordSet{
+					Name:        rrset.Name,
+					Type:        rrset.Type,
+					TTL:         rrset.Ttl,
+					RRDatas:     rrset.Rrdatas,
+					Zone:        zone.Name,
+					ZoneDNSName: zone.DnsName,
+					Visibility:  zone.Visibility,
+					Networks:    dnsZoneNetworks(zone),
+				}
+
+				// Routing policy records carry their data inside the policy items
+				if policy := rrset.RoutingPolicy; policy != nil {
+					if policy.Geo != nil {
+						record.RoutingPolicy = "geo"
+						for _, item := range policy.Geo.Items {
+							if item != nil {
+								record.RRDatas = append(record.RRDatas, item.Rrdatas...)
+							}
+						}
+					} else if policy.Wrr != nil {
+						record.RoutingPolicy = "wrr"
+						for _, item := range policy.Wrr.Items {
+							if item != nil {
+								record.RRDatas = append(record.RRDatas, item.Rrdatas...)
+							}
+						}
+					}
+				}
+
+				results = append(results, record)
+			}
+
+			if resp.NextPageToken == "" {
+				break
+			}
+
+			pageToken = resp.NextPageToken
+		}
+	}
+
+	return results, nil
+}
+
+// newDNSService creates a Cloud DNS API service using the logging HTTP client.
+func (c *Client) newDNSService(ctx context.Context) (*dns.Service, error) {
+	httpClient, err := newHTTPClientWithLogging(ctx, dns.NdevClouddnsReadonlyScope)
+	if err != nil {
+		logger.Log.Errorf("Failed to create HTTP client for Cloud DNS: %v", err)
 
 		return nil, fmt.Errorf("failed to create HTTP client: %w", err)
 	}
 
-	secretService, err := secretmanager.NewService(ctx, option.WithHTTPClient(httpClient))
+	dnsService, err := dns.NewService(ctx, option.WithHTTPClient(httpClient))
 	if err != nil {
-		logger.Log.Errorf("Failed to create Secret Manager service: %v", err)
+		logger.Log.Errorf("Failed to create Cloud DNS service: %v", err)
 
-		return nil, fmt.Errorf("failed to create secret manager service: %w", err)
+		return nil, fmt.Errorf("failed to create dns service: %w", err)
 	}
 
-	var results []*Secret
+	return dnsService, nil
+}
+
+// listDNSManagedZones pages through the raw managed zones of the project.
+func (c *Client) listDNSManagedZones(ctx context.Context, dnsService *dns.Service) ([]*dns.ManagedZone, error) {
+	var zones []*dns.ManagedZone
 	pageToken := ""
 
 	for {
-		parent := fmt.Sprintf("projects/%s", c.project)
-		call := secretService.Projects.Secrets.List(parent).Context(ctx)
+		call := dnsService.ManagedZones.List(c.project).Context(ctx)
 		if pageToken != "" {
 			call = call.PageToken(pageToken)
 		}
 
 		resp, err := call.Do()
 		if err != nil {
-			logger.Log.Errorf("Failed to list secrets: %v", err)
+			logger.Log.Errorf("Failed to list Cloud DNS managed zones: %v", err)
 
-			return nil, fmt.Errorf("failed to list secrets: %w", err)
+			return nil, fmt.Errorf("failed to list dns managed zones: %w", err)
 		}
 
-		for _, secret := range resp.Secrets {
-			if secret == nil {
+		for _, zone := range resp.ManagedZones {
+			if zone != nil {
+				zones = append(zones, zone)
+			}
+		}
+
+		if resp.NextPageToken == "" {
+			break
+		}
+
+		pageToken = resp.NextPageToken
+	}
+
+	return zones, nil
+}
+
+// dnsZoneNetworks returns the names of the VPC networks a private zone is attached to.
+func dnsZoneNetworks(zone *dns.ManagedZone) []string {
+	if zone.PrivateVisibilityConfig == nil {
+		return nil
+	}
+
+	var networks []string
+	for _, network := range zone.PrivateVisibilityConfig.Networks {
+		if network != nil && network.NetworkUrl != "" {
+			networks = append(networks, extractResourceName(network.NetworkUrl))
+		}
+	}
+
+	return networks
+}
+
+// convertDNSManagedZone flattens a Cloud DNS API managed zone into the compass representation.
+func convertDNSManagedZone(zone *dns.ManagedZone) *DNSManagedZone {
+	result := &DNSManagedZone{
+		Name:        zone.Name,
+		DNSName:     zone.DnsName,
+		Description: zone.Description,
+		Visibility:  zone.Visibility,
+		NameServers: zone.NameServers,
+		Labels:      zone.Labels,
+	}
+
+	result.Networks = dnsZoneNetworks(zone)
+
+	if zone.DnssecConfig != nil {
+		result.DNSSECState = zone.DnssecConfig.State
+	}
+
+	if zone.ForwardingConfig != nil {
+		for _, target := range zone.ForwardingConfig.TargetNameServers {
+			if target == nil {
 				continue
 			}
 
-			replication := ""
-			if secret.Replication != nil {
-				if secret.Replication.Automatic != nil {
-					replication = "automatic"
-				} else if secret.Replication.UserManaged != nil {
-					replication = "user-managed"
-				}
+			switch {
+			case target.Ipv4Address != "":
+				result.ForwardingTargets = append(result.ForwardingTargets, target.Ipv4Address)
+			case target.Ipv6Address != "":
+				result.ForwardingTargets = append(result.ForwardingTargets, target.Ipv6Address)
+			case target.DomainName != "":
+				result.ForwardingTargets = append(result.ForwardingTargets, target.DomainName)
 			}
+		}
+	}
 
-			// Extract just the secret name from the full resource name
-			name := extractResourceName(secret.Name)
+	if zone.PeeringConfig != nil && zone.PeeringConfig.TargetNetwork != nil {
+		result.PeeringNetwork = extractResourceName(zone.PeeringConfig.TargetNetwork.NetworkUrl)
+	}
 
-			results = append(results, &Secret{
-				Name:         name,
-				Replication:  replication,
-				VersionCount: 0, // Would need additional API call to get version count
+	return result
+}
+
+// ListServiceAccounts returns the IAM service accounts of the project. Their keys are
+// summarized separately by ServiceAccountKeySummary.
+func (c *Client) ListServiceAccounts(ctx context.Context) ([]*ServiceAccount, error) {
+	logger.Log.Debug("Listing IAM service accounts")
+
+	iamService, err := newIAMService(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []*ServiceAccount
+	pageToken := ""
+
+	for {
+		call := iamService.Projects.ServiceAccounts.List(fmt.Sprintf("projects/%s", c.project)).Context(ctx)
+		if pageToken != "" {
+			call = call.PageToken(pageToken)
+		}
+
+		resp, err := call.Do()
+		if err != nil {
+			logger.Log.Errorf("Failed to list service accounts: %v", err)
+
+			return nil, fmt.Errorf("failed to list service accounts: %w", err)
+		}
+
+		for _, account := range resp.Accounts {
+			if account == nil {
+				continue
+			}
+
+			results = append(results, &ServiceAccount{
+				Email:       account.Email,
+				DisplayName: account.DisplayName,
+				Description: account.Description,
+				UniqueID:    account.UniqueId,
+				Disabled:    account.Disabled,
 			})
 		}
 
@@ -1094,3 +2621,95 @@ func (c *Client) ListSecrets(ctx context.Context) ([]*Secret, error) {
 
 	return results, nil
 }
+
+// ServiceAccountKeySummary fills the user-managed key count and oldest key creation time of the
+// accounts. Key listing requires one call per account, so callers should only pass the accounts
+// they display. Accounts whose keys cannot be listed are left with KeysKnown unset.
+func (c *Client) ServiceAccountKeySummary(ctx context.Context, accounts []*ServiceAccount) error {
+	if len(accounts) == 0 {
+		return nil
+	}
+
+	iamService, err := newIAMService(ctx)
+	if err != nil {
+		return err
+	}
+
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(DefaultLookupConcurrency)
+
+	var mu sync.Mutex
+	for _, account := range accounts {
+		if account == nil {
+			continue
+		}
+
+		account := account
+		group.Go(func() error {
+			name := fmt.Sprintf("projects/%s/serviceAccounts/%s", c.project, account.Email)
+			resp, err := iamService.Projects.ServiceAccounts.Keys.List(name).KeyTypes("USER_MANAGED").Context(groupCtx).Do()
+			if err != nil {
+				// Keys are informational only, keep the account even if they cannot be listed
+				logger.Log.Debugf("Failed to list keys for service account %s: %v", account.Email, err)
+
+				return nil
+			}
+
+			count, oldest := summarizeServiceAccountKeys(resp.Keys)
+
+			mu.Lock()
+			account.KeyCount = count
+			account.OldestKeyCreated = oldest
+			account.KeysKnown = true
+			mu.Unlock()
+
+			return nil
+		})
+	}
+
+	return group.Wait()
+}
+
+// newIAMService creates an IAM API client with HTTP logging.
+func newIAMService(ctx context.Context) (*iam.Service, error) {
+	httpClient, err := newHTTPClientWithLogging(ctx, iam.CloudPlatformScope)
+	if err != nil {
+		logger.Log.Errorf("Failed to create HTTP client for IAM: %v", err)
+
+		return nil, fmt.Errorf("failed to create HTTP client: %w", err)
+	}
+
+	iamService, err := iam.NewService(ctx, option.WithHTTPClient(httpClient))
+	if err != nil {
+		logger.Log.Errorf("Failed to create IAM service: %v", err)
+
+		return nil, fmt.Errorf("failed to create iam service: %w", err)
+	}
+
+	return iamService, nil
+}
+
+// summarizeServiceAccountKeys returns the number of keys and the creation time of the oldest one.
+func summarizeServiceAccountKeys(keys []*iam.ServiceAccountKey) (int, time.Time) {
+	count := 0
+	var oldest time.Time
+
+	for _, key := range keys {
+		if key == nil {
+			continue
+		}
+
+		count++
+
+		created, err := time.Parse(time.RFC3339, key.ValidAfterTime)
+		if err != nil {
+			continue
+		}
+
+		if oldest.IsZero() || created.Before(oldest) {
+			oldest = created
+		}
+	}
+
+	return count, oldest
+}
@@ -3,7 +3,6 @@ package gcp
 import (
 	"context"
 	"fmt"
-	"strings"
 
 	"github.com/kedare/compass/internal/cache"
 	"github.com/kedare/compass/internal/logger"
@@ -12,18 +11,21 @@ import (
 
 // ScanStats holds statistics about a resource scan operation.
 type ScanStats struct {
-	Zones     int
-	Instances int
-	MIGs      int
-	Subnets   int
+	Zones       int
+	Instances   int
+	MIGs        int
+	Subnets     int
+	IPAddresses int // Addresses recorded in the IP history
 }
 
 // ScanProgress is called to report progress during scanning.
 type ScanProgress func(resource string, count int)
 
 // ScanProjectResources scans all resources in a project and caches them.
-// This includes zones, instances, and subnets.
-// Stale instances/MIGs are automatically removed before rescanning.
+// This includes zones, instances, and subnets, and records the owner of every
+// IP address of the project in the IP history.
+// Stale instances/MIGs are automatically removed before rescanning, and APIs
+// remembered as disabled are forgotten so searches check them again.
 func (c *Client) ScanProjectResources(ctx context.Context, progress ScanProgress) (*ScanStats, error) {
 	if c == nil || c.service == nil {
 		return nil, fmt.Errorf("client is not initialized")
@@ -43,6 +45,10 @@ func (c *Client) ScanProjectResources(ctx context.Context, progress ScanProgress
 		if err := c.cache.ClearProjectZones(c.project); err != nil {
 			logger.Log.Warnf("Failed to clear existing zones for project %s: %v", c.project, err)
 		}
+		// Probe APIs previously detected as disabled again on the next search
+		if err := c.cache.ClearDisabledServices(c.project); err != nil {
+			logger.Log.Warnf("Failed to clear disabled services for project %s: %v", c.project, err)
+		}
 	}
 
 	// Scan zones
@@ -57,11 +63,11 @@ func (c *Client) ScanProjectResources(ctx context.Context, progress ScanProgress
 	}
 
 	// Scan instances
-	instances, err := c.scanInstances(ctx)
-	if err != nil {
-		logger.Log.Warnf("Failed to scan instances for project %s: %v", c.project, err)
+	instances, instanceErr := c.scanInstances(ctx)
+	if instanceErr != nil {
+		logger.Log.Warnf("Failed to scan instances for project %s: %v", c.project, instanceErr)
 	} else {
-		stats.Instances = instances
+		stats.Instances = len(instances)
 		if progress != nil {
 			progress("instances", stats.Instances)
 		}
@@ -79,16 +85,31 @@ func (c *Client) ScanProjectResources(ctx context.Context, progress ScanProgress
 	}
 
 	// Scan subnets
-	subnets, err := c.scanSubnets(ctx)
-	if err != nil {
-		logger.Log.Warnf("Failed to scan subnets for project %s: %v", c.project, err)
+	subnets, subnetErr := c.scanSubnets(ctx)
+	if subnetErr != nil {
+		logger.Log.Warnf("Failed to scan subnets for project %s: %v", c.project, subnetErr)
 	} else {
-		stats.Subnets = subnets
+		stats.Subnets = len(subnets)
 		if progress != nil {
 			progress("subnets", stats.Subnets)
 		}
 	}
 
+	// Record IP ownership, reusing the instance and subnet listings unless their scan failed
+	var listed *ipInventory
+	if instanceErr == nil && subnetErr == nil {
+		listed = &ipInventory{instances: instances, subnets: subnets}
+	}
+	addresses, err := c.scanIPAddresses(ctx, listed)
+	if err != nil {
+		logger.Log.Warnf("Failed to record IP addresses for project %s: %v", c.project, err)
+	} else {
+		stats.IPAddresses = addresses
+		if progress != nil {
+			progress("ip_addresses", stats.IPAddresses)
+		}
+	}
+
 	return stats, nil
 }
 
@@ -98,22 +119,36 @@ func (c *Client) scanZones(ctx context.Context) ([]string, error) {
 	return c.ListZones(ctx)
 }
 
-// scanInstances lists all instances and caches them.
-func (c *Client) scanInstances(ctx context.Context) (int, error) {
+// scanInstances lists all instances and caches them, returning the listed instances.
+func (c *Client) scanInstances(ctx context.Context) ([]scopedInstance, error) {
 	if c.cache == nil {
-		return 0, nil
+		return nil, nil
 	}
 
-	instances, err := c.ListInstances(ctx, "")
+	var instances []scopedInstance
+	call := c.service.Instances.AggregatedList(c.project).Context(ctx)
+	err := call.Pages(ctx, func(page *compute.InstanceAggregatedList) error {
+		for scope, scopedList := range page.Items {
+			location := locationFromScope(scope)
+			for _, inst := range scopedList.Instances {
+				if inst != nil {
+					instances = append(instances, scopedInstance{location: location, instance: inst})
+				}
+			}
+		}
+
+		return nil
+	})
 	if err != nil {
-		return 0, err
+		return nil, fmt.Errorf("failed to list instances: %w", err)
 	}
 
 	// Batch cache all instances
 	entries := make(map[string]*cache.LocationInfo, len(instances))
-	for _, inst := range instances {
-		if inst == nil {
-			continue
+	for _, item := range instances {
+		inst := c.convertInstance(item.instance)
+		if inst.Zone == "" {
+			inst.Zone = item.location
 		}
 
 		entries[inst.Name] = &cache.LocationInfo{
@@ -130,7 +165,7 @@ func (c *Client) scanInstances(ctx context.Context) (int, error) {
 		}
 	}
 
-	return len(instances), nil
+	return instances, nil
 }
 
 // scanMIGs lists all managed instance groups and caches them.
@@ -167,58 +202,34 @@ func (c *Client) scanMIGs(ctx context.Context) (int, error) {
 	return len(migs), nil
 }
 
-// scanSubnets lists all subnets and caches them.
-func (c *Client) scanSubnets(ctx context.Context) (int, error) {
+// scanSubnets lists all subnets and caches them, returning the listed subnets.
+func (c *Client) scanSubnets(ctx context.Context) ([]scopedSubnet, error) {
 	if c.cache == nil {
-		return 0, nil
+		return nil, nil
 	}
 
 	call := c.service.Subnetworks.AggregatedList(c.project).Context(ctx)
 
+	var subnets []scopedSubnet
 	var entries []*cache.SubnetEntry
 	err := call.Pages(ctx, func(page *SubnetworkAggregatedList) error {
 		if err := ctx.Err(); err != nil {
 			return err
 		}
 
-		for _, scopedList := range page.Items {
+		for scope, scopedList := range page.Items {
 			if scopedList.Subnetworks == nil {
 				continue
 			}
 
+			location := locationFromScope(scope)
 			for _, subnet := range scopedList.Subnetworks {
 				if subnet == nil {
 					continue
 				}
 
-				secondary := make([]cache.SubnetSecondaryRange, 0, len(subnet.SecondaryIpRanges))
-				for _, sr := range subnet.SecondaryIpRanges {
-					if sr == nil {
-						continue
-					}
-
-					cidr := strings.TrimSpace(sr.IpCidrRange)
-					if cidr == "" {
-						continue
-					}
-
-					secondary = append(secondary, cache.SubnetSecondaryRange{
-						Name: strings.TrimSpace(sr.RangeName),
-						CIDR: cidr,
-					})
-				}
-
-				entries = append(entries, &cache.SubnetEntry{
-					Project:         c.project,
-					Network:         lastComponent(subnet.Network),
-					Region:          lastComponent(subnet.Region),
-					Name:            subnet.Name,
-					SelfLink:        strings.TrimSpace(subnet.SelfLink),
-					PrimaryCIDR:     strings.TrimSpace(subnet.IpCidrRange),
-					SecondaryRanges: secondary,
-					IPv6CIDR:        strings.TrimSpace(subnet.Ipv6CidrRange),
-					Gateway:         strings.TrimSpace(subnet.GatewayAddress),
-				})
+				subnets = append(subnets, scopedSubnet{location: location, subnet: subnet})
+				entries = append(entries, c.subnetEntry(subnet))
 			}
 		}
 
@@ -226,7 +237,7 @@ func (c *Client) scanSubnets(ctx context.Context) (int, error) {
 	})
 
 	if err != nil {
-		return 0, fmt.Errorf("failed to list subnets: %w", err)
+		return nil, fmt.Errorf("failed to list subnets: %w", err)
 	}
 
 	if len(entries) > 0 {
@@ -235,7 +246,7 @@ func (c *Client) scanSubnets(ctx context.Context) (int, error) {
 		}
 	}
 
-	return len(entries), nil
+	return subnets, nil
 }
 
 // SubnetworkAggregatedList is an alias for the compute API type.
@@ -44,7 +44,7 @@ func (p *AddressProvider) Search(ctx context.Context, project string, query Quer
 
 	matches := make([]Result, 0, len(addresses))
 	for _, addr := range addresses {
-		if addr == nil || !query.MatchesAny(addr.Name, addr.Address, addr.Description) {
+		if addr == nil || !query.MatchesResource(addr.Labels, addr.Name, addr.Address, addr.Description) {
 			continue
 		}
 
@@ -54,6 +54,7 @@ func (p *AddressProvider) Search(ctx context.Context, project string, query Quer
 			Project:  project,
 			Location: addr.Region,
 			Details:  addressDetails(addr),
+			Labels:   addr.Labels,
 		})
 	}
 
@@ -0,0 +1,98 @@
+package search
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kedare/compass/internal/gcp"
+)
+
+// AppEngineClientFactory creates an App Engine client scoped to a project.
+type AppEngineClientFactory func(ctx context.Context, project string) (AppEngineClient, error)
+
+// AppEngineClient exposes the subset of gcp.Client used by the App Engine searcher.
+type AppEngineClient interface {
+	ListAppEngineServices(ctx context.Context) ([]*gcp.AppEngineService, error)
+}
+
+// AppEngineProvider searches App Engine services for query matches.
+type AppEngineProvider struct {
+	NewClient AppEngineClientFactory
+}
+
+// Kind returns the resource kind this provider handles.
+func (p *AppEngineProvider) Kind() ResourceKind {
+	return KindAppEngineService
+}
+
+// Search implements the Provider interface.
+func (p *AppEngineProvider) Search(ctx context.Context, project string, query Query) ([]Result, error) {
+	if p == nil || p.NewClient == nil {
+		return nil, fmt.Errorf("%s: %w", project, ErrNoProviders)
+	}
+
+	client, err := p.NewClient(ctx, project)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client for %s: %w", project, err)
+	}
+
+	services, err := client.ListAppEngineServices(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list App Engine services in %s: %w", project, err)
+	}
+
+	matches := make([]Result, 0, len(services))
+	for _, svc := range services {
+		if svc == nil || !query.MatchesResource(svc.Labels, svc.Name, svc.URL, svc.Version) {
+			continue
+		}
+
+		matches = append(matches, Result{
+			Type:     KindAppEngineService,
+			Name:     svc.Name,
+			Project:  project,
+			Location: svc.Location,
+			Details:  appEngineDetails(svc),
+			Labels:   svc.Labels,
+		})
+	}
+
+	return matches, nil
+}
+
+// appEngineDetails extracts display metadata for an App Engine service.
+func appEngineDetails(svc *gcp.AppEngineService) map[string]string {
+	details := map[string]string{
+		"trigger": "http",
+	}
+
+	if svc.Runtime != "" {
+		details["runtime"] = svc.Runtime
+	}
+
+	if svc.Environment != "" {
+		details["environment"] = svc.Environment
+	}
+
+	if svc.Version != "" {
+		details["version"] = svc.Version
+	}
+
+	if svc.VersionCount > 0 {
+		details["versions"] = fmt.Sprintf("%d", svc.VersionCount)
+	}
+
+	if svc.ServingStatus != "" {
+		details["servingStatus"] = svc.ServingStatus
+	}
+
+	if svc.IngressSettings != "" {
+		details["ingress"] = svc.IngressSettings
+	}
+
+	if svc.URL != "" {
+		details["url"] = svc.URL
+	}
+
+	return details
+}
@@ -0,0 +1,109 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kedare/compass/internal/gcp"
+)
+
+func TestAppEngineProviderReturnsMatches(t *testing.T) {
+	client := &fakeAppEngineClient{services: []*gcp.AppEngineService{
+		{Name: "default", Location: "europe-west", URL: "https://proj-a.ew.r.appspot.com", Version: "20240101t120000", Runtime: "go122", Environment: "standard", ServingStatus: "SERVING", VersionCount: 3, IngressSettings: "INGRESS_TRAFFIC_ALLOWED_ALL"},
+		{Name: "admin", Location: "europe-west", URL: "https://admin-dot-proj-a.ew.r.appspot.com", Runtime: "python312"},
+	}}
+
+	provider := &AppEngineProvider{NewClient: func(ctx context.Context, project string) (AppEngineClient, error) {
+		if project != "proj-a" {
+			t.Fatalf("unexpected project %s", project)
+		}
+		return client, nil
+	}}
+
+	results, err := provider.Search(context.Background(), "proj-a", Query{Term: "default"})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+
+	if len(results) != 1 {
+		t.Fatalf("expected 1 result, got %d", len(results))
+	}
+
+	result := results[0]
+	if result.Type != KindAppEngineService || result.Name != "default" || result.Location != "europe-west" {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+
+	expected := map[string]string{
+		"trigger":       "http",
+		"runtime":       "go122",
+		"environment":   "standard",
+		"version":       "20240101t120000",
+		"versions":      "3",
+		"servingStatus": "SERVING",
+		"ingress":       "INGRESS_TRAFFIC_ALLOWED_ALL",
+		"url":           "https://proj-a.ew.r.appspot.com",
+	}
+	for key, want := range expected {
+		if got := result.Details[key]; got != want {
+			t.Fatalf("expected %s=%q, got %q", key, want, got)
+		}
+	}
+}
+
+func TestAppEngineProviderMatchesByURL(t *testing.T) {
+	client := &fakeAppEngineClient{services: []*gcp.AppEngineService{
+		{Name: "default", URL: "https://proj-a.ew.r.appspot.com"},
+		{Name: "admin", URL: "https://admin-dot-proj-a.ew.r.appspot.com"},
+	}}
+
+	provider := &AppEngineProvider{NewClient: func(context.Context, string) (AppEngineClient, error) {
+		return client, nil
+	}}
+
+	results, err := provider.Search(context.Background(), "proj-a", Query{Term: "admin-dot"})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(results) != 1 || results[0].Name != "admin" {
+		t.Fatalf("expected admin, got %+v", results)
+	}
+}
+
+func TestAppEngineProviderPropagatesErrors(t *testing.T) {
+	provider := &AppEngineProvider{NewClient: func(context.Context, string) (AppEngineClient, error) {
+		return nil, errors.New("client boom")
+	}}
+
+	if _, err := provider.Search(context.Background(), "proj-a", Query{Term: "foo"}); err == nil {
+		t.Fatal("expected error")
+	}
+
+	provider = &AppEngineProvider{NewClient: func(context.Context, string) (AppEngineClient, error) {
+		return &fakeAppEngineClient{err: errors.New("list boom")}, nil
+	}}
+
+	if _, err := provider.Search(context.Background(), "proj-a", Query{Term: "foo"}); err == nil {
+		t.Fatal("expected list error")
+	}
+}
+
+func TestAppEngineProviderNilProvider(t *testing.T) {
+	var provider *AppEngineProvider
+	if _, err := provider.Search(context.Background(), "proj-a", Query{Term: "foo"}); err == nil {
+		t.Fatal("expected error for nil provider")
+	}
+}
+
+type fakeAppEngineClient struct {
+	services []*gcp.AppEngineService
+	err      error
+}
+
+func (f *fakeAppEngineClient) ListAppEngineServices(context.Context) ([]*gcp.AppEngineService, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.services, nil
+}
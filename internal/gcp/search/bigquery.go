@@ -0,0 +1,72 @@
+package search
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kedare/compass/internal/gcp"
+)
+
+// BigQueryClientFactory creates a BigQuery client scoped to a project.
+type BigQueryClientFactory func(ctx context.Context, project string) (BigQueryClient, error)
+
+// BigQueryClient exposes the subset of gcp.Client used by the BigQuery searcher.
+type BigQueryClient interface {
+	ListBigQueryDatasets(ctx context.Context) ([]*gcp.BigQueryDataset, error)
+}
+
+// BigQueryDatasetProvider searches BigQuery datasets for query matches.
+type BigQueryDatasetProvider struct {
+	NewClient BigQueryClientFactory
+}
+
+// Kind returns the resource kind this provider handles.
+func (p *BigQueryDatasetProvider) Kind() ResourceKind {
+	return KindBigQueryDataset
+}
+
+// Search implements the Provider interface.
+func (p *BigQueryDatasetProvider) Search(ctx context.Context, project string, query Query) ([]Result, error) {
+	if p == nil || p.NewClient == nil {
+		return nil, fmt.Errorf("%s: %w", project, ErrNoProviders)
+	}
+
+	client, err := p.NewClient(ctx, project)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client for %s: %w", project, err)
+	}
+
+	datasets, err := client.ListBigQueryDatasets(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list BigQuery datasets in %s: %w", project, err)
+	}
+
+	matches := make([]Result, 0, len(datasets))
+	for _, dataset := range datasets {
+		if dataset == nil || !query.MatchesResource(dataset.Labels, dataset.Name, dataset.FriendlyName) {
+			continue
+		}
+
+		matches = append(matches, Result{
+			Type:     KindBigQueryDataset,
+			Name:     dataset.Name,
+			Project:  project,
+			Location: dataset.Location,
+			Details:  bigQueryDatasetDetails(dataset),
+			Labels:   dataset.Labels,
+		})
+	}
+
+	return matches, nil
+}
+
+// bigQueryDatasetDetails extracts display metadata for a BigQuery dataset.
+func bigQueryDatasetDetails(dataset *gcp.BigQueryDataset) map[string]string {
+	details := make(map[string]string)
+
+	if dataset.FriendlyName != "" {
+		details["friendlyName"] = dataset.FriendlyName
+	}
+
+	return details
+}
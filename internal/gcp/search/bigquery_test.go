@@ -0,0 +1,82 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kedare/compass/internal/gcp"
+)
+
+func TestBigQueryDatasetProviderReturnsMatches(t *testing.T) {
+	client := &fakeBigQueryClient{datasets: []*gcp.BigQueryDataset{
+		{Name: "orders_raw", Location: "EU", FriendlyName: "Raw orders"},
+		{Name: "billing_export", Location: "US", FriendlyName: "Billing"},
+	}}
+
+	provider := &BigQueryDatasetProvider{NewClient: func(ctx context.Context, project string) (BigQueryClient, error) {
+		if project != "proj-a" {
+			t.Fatalf("unexpected project %s", project)
+		}
+		return client, nil
+	}}
+
+	results, err := provider.Search(context.Background(), "proj-a", Query{Term: "orders"})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+
+	if len(results) != 1 || results[0].Name != "orders_raw" {
+		t.Fatalf("expected orders_raw, got %+v", results)
+	}
+	if results[0].Type != KindBigQueryDataset || results[0].Location != "EU" {
+		t.Fatalf("unexpected result: %+v", results[0])
+	}
+	if results[0].Details["friendlyName"] != "Raw orders" {
+		t.Fatalf("unexpected friendlyName %q", results[0].Details["friendlyName"])
+	}
+
+	// Friendly names are searchable
+	results, err = provider.Search(context.Background(), "proj-a", Query{Term: "Billing"})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(results) != 1 || results[0].Name != "billing_export" {
+		t.Fatalf("expected billing_export, got %+v", results)
+	}
+}
+
+func TestBigQueryDatasetProviderPropagatesErrors(t *testing.T) {
+	provider := &BigQueryDatasetProvider{NewClient: func(context.Context, string) (BigQueryClient, error) {
+		return nil, errors.New("client boom")
+	}}
+
+	if _, err := provider.Search(context.Background(), "proj-a", Query{Term: "foo"}); err == nil {
+		t.Fatal("expected error")
+	}
+
+	provider = &BigQueryDatasetProvider{NewClient: func(context.Context, string) (BigQueryClient, error) {
+		return &fakeBigQueryClient{err: errors.New("list boom")}, nil
+	}}
+
+	if _, err := provider.Search(context.Background(), "proj-a", Query{Term: "foo"}); err == nil {
+		t.Fatal("expected list error")
+	}
+
+	var nilProvider *BigQueryDatasetProvider
+	if _, err := nilProvider.Search(context.Background(), "proj-a", Query{Term: "foo"}); err == nil {
+		t.Fatal("expected error for nil provider")
+	}
+}
+
+type fakeBigQueryClient struct {
+	datasets []*gcp.BigQueryDataset
+	err      error
+}
+
+func (f *fakeBigQueryClient) ListBigQueryDatasets(context.Context) ([]*gcp.BigQueryDataset, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.datasets, nil
+}
@@ -43,7 +43,7 @@ func (p *BucketProvider) Search(ctx context.Context, project string, query Query
 
 	matches := make([]Result, 0, len(buckets))
 	for _, bucket := range buckets {
-		if bucket == nil || !query.MatchesAny(bucket.Name, bucket.StorageClass) {
+		if bucket == nil || !query.MatchesResource(bucket.Labels, bucket.Name, bucket.StorageClass) {
 			continue
 		}
 
@@ -53,6 +53,7 @@ func (p *BucketProvider) Search(ctx context.Context, project string, query Query
 			Project:  project,
 			Location: bucket.Location,
 			Details:  bucketDetails(bucket),
+			Labels:   bucket.Labels,
 		})
 	}
 
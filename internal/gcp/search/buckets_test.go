@@ -103,3 +103,34 @@ func (f *fakeBucketClient) ListBuckets(context.Context) ([]*gcp.Bucket, error) {
 	}
 	return f.buckets, nil
 }
+
+func TestBucketProviderMatchesByLabel(t *testing.T) {
+	client := &fakeBucketClient{buckets: []*gcp.Bucket{
+		{Name: "logs", Location: "US", Labels: map[string]string{"env": "prod", "team": "sre"}},
+		{Name: "scratch", Location: "US", Labels: map[string]string{"env": "dev"}},
+		{Name: "unlabelled", Location: "US"},
+	}}
+
+	provider := &BucketProvider{NewClient: func(ctx context.Context, project string) (BucketClient, error) {
+		return client, nil
+	}}
+
+	results, err := provider.Search(context.Background(), "proj-a", Query{Term: "env=prod"})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(results) != 1 || results[0].Name != "logs" {
+		t.Fatalf("expected only logs to match env=prod, got %+v", results)
+	}
+	if results[0].Labels["team"] != "sre" {
+		t.Fatalf("expected labels to be carried on the result, got %v", results[0].Labels)
+	}
+
+	results, err = provider.Search(context.Background(), "proj-a", Query{Term: "env=*"})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results for env=*, got %d", len(results))
+	}
+}
@@ -0,0 +1,100 @@
+package search
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kedare/compass/internal/gcp"
+)
+
+// CloudFunctionClientFactory creates a Cloud Functions client scoped to a project.
+type CloudFunctionClientFactory func(ctx context.Context, project string) (CloudFunctionClient, error)
+
+// CloudFunctionClient exposes the subset of gcp.Client used by the Cloud Functions searcher.
+type CloudFunctionClient interface {
+	ListCloudFunctions(ctx context.Context) ([]*gcp.CloudFunction, error)
+}
+
+// CloudFunctionProvider searches Cloud Functions (1st and 2nd gen) for query matches.
+type CloudFunctionProvider struct {
+	NewClient CloudFunctionClientFactory
+}
+
+// Kind returns the resource kind this provider handles.
+func (p *CloudFunctionProvider) Kind() ResourceKind {
+	return KindCloudFunction
+}
+
+// Search implements the Provider interface.
+func (p *CloudFunctionProvider) Search(ctx context.Context, project string, query Query) ([]Result, error) {
+	if p == nil || p.NewClient == nil {
+		return nil, fmt.Errorf("%s: %w", project, ErrNoProviders)
+	}
+
+	client, err := p.NewClient(ctx, project)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client for %s: %w", project, err)
+	}
+
+	functions, err := client.ListCloudFunctions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list Cloud Functions in %s: %w", project, err)
+	}
+
+	matches := make([]Result, 0, len(functions))
+	for _, fn := range functions {
+		if fn == nil || !query.MatchesResource(fn.Labels, fn.Name, fn.URL, fn.EntryPoint, fn.TriggerResource, fn.ServiceAccount) {
+			continue
+		}
+
+		matches = append(matches, Result{
+			Type:     KindCloudFunction,
+			Name:     fn.Name,
+			Project:  project,
+			Location: fn.Region,
+			Details:  cloudFunctionDetails(fn),
+			Labels:   fn.Labels,
+		})
+	}
+
+	return matches, nil
+}
+
+// cloudFunctionDetails extracts display metadata for a Cloud Function.
+func cloudFunctionDetails(fn *gcp.CloudFunction) map[string]string {
+	details := make(map[string]string)
+
+	if fn.Generation != "" {
+		details["generation"] = fn.Generation
+	}
+
+	if fn.Runtime != "" {
+		details["runtime"] = fn.Runtime
+	}
+
+	if fn.State != "" {
+		details["state"] = fn.State
+	}
+
+	if fn.TriggerType != "" {
+		details["trigger"] = fn.TriggerType
+	}
+
+	if fn.EventType != "" {
+		details["eventType"] = fn.EventType
+	}
+
+	if fn.TriggerResource != "" {
+		details["triggerResource"] = fn.TriggerResource
+	}
+
+	if fn.IngressSettings != "" {
+		details["ingress"] = fn.IngressSettings
+	}
+
+	if fn.URL != "" {
+		details["url"] = fn.URL
+	}
+
+	return details
+}
@@ -0,0 +1,119 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kedare/compass/internal/gcp"
+)
+
+func TestCloudFunctionProviderReturnsMatches(t *testing.T) {
+	client := &fakeCloudFunctionClient{functions: []*gcp.CloudFunction{
+		{Name: "resize-images", Region: "us-central1", Generation: "gen2", Runtime: "python312", State: "ACTIVE", TriggerType: "event", EventType: "google.cloud.storage.object.v1.finalized", TriggerResource: "uploads-bucket", IngressSettings: "ALLOW_INTERNAL_ONLY", URL: "https://resize-images-xyz.a.run.app"},
+		{Name: "webhook", Region: "europe-west1", Generation: "gen1", Runtime: "nodejs20", TriggerType: "http", IngressSettings: "ALLOW_ALL", URL: "https://europe-west1-proj-a.cloudfunctions.net/webhook"},
+	}}
+
+	provider := &CloudFunctionProvider{NewClient: func(ctx context.Context, project string) (CloudFunctionClient, error) {
+		if project != "proj-a" {
+			t.Fatalf("unexpected project %s", project)
+		}
+		return client, nil
+	}}
+
+	results, err := provider.Search(context.Background(), "proj-a", Query{Term: "resize"})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+
+	if len(results) != 1 {
+		t.Fatalf("expected 1 result, got %d", len(results))
+	}
+
+	result := results[0]
+	if result.Type != KindCloudFunction || result.Name != "resize-images" || result.Location != "us-central1" {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+
+	expected := map[string]string{
+		"generation":      "gen2",
+		"runtime":         "python312",
+		"state":           "ACTIVE",
+		"trigger":         "event",
+		"eventType":       "google.cloud.storage.object.v1.finalized",
+		"triggerResource": "uploads-bucket",
+		"ingress":         "ALLOW_INTERNAL_ONLY",
+		"url":             "https://resize-images-xyz.a.run.app",
+	}
+	for key, want := range expected {
+		if got := result.Details[key]; got != want {
+			t.Fatalf("expected %s=%q, got %q", key, want, got)
+		}
+	}
+}
+
+func TestCloudFunctionProviderMatchesByDetailFields(t *testing.T) {
+	client := &fakeCloudFunctionClient{functions: []*gcp.CloudFunction{
+		{Name: "resize-images", Region: "us-central1", TriggerType: "event", TriggerResource: "uploads-bucket"},
+		{Name: "webhook", Region: "europe-west1", TriggerType: "http", URL: "https://europe-west1-proj-a.cloudfunctions.net/webhook"},
+	}}
+
+	provider := &CloudFunctionProvider{NewClient: func(context.Context, string) (CloudFunctionClient, error) {
+		return client, nil
+	}}
+
+	// Search by trigger resource
+	results, err := provider.Search(context.Background(), "proj-a", Query{Term: "uploads-bucket"})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(results) != 1 || results[0].Name != "resize-images" {
+		t.Fatalf("expected resize-images for trigger search, got %+v", results)
+	}
+
+	// Search by URL
+	results, err = provider.Search(context.Background(), "proj-a", Query{Term: "cloudfunctions.net"})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(results) != 1 || results[0].Name != "webhook" {
+		t.Fatalf("expected webhook for URL search, got %+v", results)
+	}
+}
+
+func TestCloudFunctionProviderPropagatesErrors(t *testing.T) {
+	provider := &CloudFunctionProvider{NewClient: func(context.Context, string) (CloudFunctionClient, error) {
+		return nil, errors.New("client boom")
+	}}
+
+	if _, err := provider.Search(context.Background(), "proj-a", Query{Term: "foo"}); err == nil {
+		t.Fatal("expected error")
+	}
+
+	provider = &CloudFunctionProvider{NewClient: func(context.Context, string) (CloudFunctionClient, error) {
+		return &fakeCloudFunctionClient{err: errors.New("list boom")}, nil
+	}}
+
+	if _, err := provider.Search(context.Background(), "proj-a", Query{Term: "foo"}); err == nil {
+		t.Fatal("expected list error")
+	}
+}
+
+func TestCloudFunctionProviderNilProvider(t *testing.T) {
+	var provider *CloudFunctionProvider
+	if _, err := provider.Search(context.Background(), "proj-a", Query{Term: "foo"}); err == nil {
+		t.Fatal("expected error for nil provider")
+	}
+}
+
+type fakeCloudFunctionClient struct {
+	functions []*gcp.CloudFunction
+	err       error
+}
+
+func (f *fakeCloudFunctionClient) ListCloudFunctions(context.Context) ([]*gcp.CloudFunction, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.functions, nil
+}
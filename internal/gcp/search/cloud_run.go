@@ -43,7 +43,7 @@ func (p *CloudRunProvider) Search(ctx context.Context, project string, query Que
 
 	matches := make([]Result, 0, len(services))
 	for _, svc := range services {
-		if svc == nil || !query.MatchesAny(svc.Name, svc.URL, svc.LatestRevision) {
+		if svc == nil || !query.MatchesResource(svc.Labels, svc.Name, svc.URL, svc.LatestRevision) {
 			continue
 		}
 
@@ -53,6 +53,7 @@ func (p *CloudRunProvider) Search(ctx context.Context, project string, query Que
 			Project:  project,
 			Location: svc.Region,
 			Details:  cloudRunDetails(svc),
+			Labels:   svc.Labels,
 		})
 	}
 
@@ -0,0 +1,96 @@
+package search
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kedare/compass/internal/gcp"
+)
+
+// CloudRunJobClientFactory creates a Cloud Run client scoped to a project.
+type CloudRunJobClientFactory func(ctx context.Context, project string) (CloudRunJobClient, error)
+
+// CloudRunJobClient exposes the subset of gcp.Client used by the Cloud Run job searcher.
+type CloudRunJobClient interface {
+	ListCloudRunJobs(ctx context.Context) ([]*gcp.CloudRunJob, error)
+}
+
+// CloudRunJobProvider searches Cloud Run jobs for query matches.
+type CloudRunJobProvider struct {
+	NewClient CloudRunJobClientFactory
+}
+
+// Kind returns the resource kind this provider handles.
+func (p *CloudRunJobProvider) Kind() ResourceKind {
+	return KindCloudRunJob
+}
+
+// Search implements the Provider interface.
+func (p *CloudRunJobProvider) Search(ctx context.Context, project string, query Query) ([]Result, error) {
+	if p == nil || p.NewClient == nil {
+		return nil, fmt.Errorf("%s: %w", project, ErrNoProviders)
+	}
+
+	client, err := p.NewClient(ctx, project)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client for %s: %w", project, err)
+	}
+
+	jobs, err := client.ListCloudRunJobs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list Cloud Run jobs in %s: %w", project, err)
+	}
+
+	matches := make([]Result, 0, len(jobs))
+	for _, job := range jobs {
+		if job == nil || !query.MatchesResource(job.Labels, job.Name, job.Image, job.ServiceAccount) {
+			continue
+		}
+
+		matches = append(matches, Result{
+			Type:     KindCloudRunJob,
+			Name:     job.Name,
+			Project:  project,
+			Location: job.Region,
+			Details:  cloudRunJobDetails(job),
+			Labels:   job.Labels,
+		})
+	}
+
+	return matches, nil
+}
+
+// cloudRunJobDetails extracts display metadata for a Cloud Run job.
+func cloudRunJobDetails(job *gcp.CloudRunJob) map[string]string {
+	details := make(map[string]string)
+
+	if job.Image != "" {
+		details["image"] = job.Image
+	}
+
+	if job.TaskCount > 0 {
+		details["tasks"] = fmt.Sprintf("%d", job.TaskCount)
+	}
+
+	if job.Parallelism > 0 {
+		details["parallelism"] = fmt.Sprintf("%d", job.Parallelism)
+	}
+
+	if job.ServiceAccount != "" {
+		details["serviceAccount"] = job.ServiceAccount
+	}
+
+	if job.ExecutionCount > 0 {
+		details["executions"] = fmt.Sprintf("%d", job.ExecutionCount)
+	}
+
+	if job.LatestExecution != "" {
+		details["latestExecution"] = job.LatestExecution
+	}
+
+	if job.LatestExecutionStatus != "" {
+		details["latestStatus"] = job.LatestExecutionStatus
+	}
+
+	return details
+}
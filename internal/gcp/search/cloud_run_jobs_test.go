@@ -0,0 +1,107 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kedare/compass/internal/gcp"
+)
+
+func TestCloudRunJobProviderReturnsMatches(t *testing.T) {
+	client := &fakeCloudRunJobClient{jobs: []*gcp.CloudRunJob{
+		{Name: "nightly-export", Region: "europe-west1", Image: "gcr.io/proj-a/export:1.2", TaskCount: 4, Parallelism: 2, ExecutionCount: 12, LatestExecution: "nightly-export-abc12", LatestExecutionStatus: "EXECUTION_SUCCEEDED"},
+		{Name: "db-migrate", Region: "us-central1", Image: "gcr.io/proj-a/migrate:3"},
+	}}
+
+	provider := &CloudRunJobProvider{NewClient: func(ctx context.Context, project string) (CloudRunJobClient, error) {
+		if project != "proj-a" {
+			t.Fatalf("unexpected project %s", project)
+		}
+		return client, nil
+	}}
+
+	results, err := provider.Search(context.Background(), "proj-a", Query{Term: "nightly"})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+
+	if len(results) != 1 {
+		t.Fatalf("expected 1 result, got %d", len(results))
+	}
+
+	result := results[0]
+	if result.Type != KindCloudRunJob || result.Name != "nightly-export" || result.Location != "europe-west1" {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+
+	expected := map[string]string{
+		"image":           "gcr.io/proj-a/export:1.2",
+		"tasks":           "4",
+		"parallelism":     "2",
+		"executions":      "12",
+		"latestExecution": "nightly-export-abc12",
+		"latestStatus":    "EXECUTION_SUCCEEDED",
+	}
+	for key, want := range expected {
+		if got := result.Details[key]; got != want {
+			t.Fatalf("expected %s=%q, got %q", key, want, got)
+		}
+	}
+}
+
+func TestCloudRunJobProviderMatchesByImage(t *testing.T) {
+	client := &fakeCloudRunJobClient{jobs: []*gcp.CloudRunJob{
+		{Name: "nightly-export", Region: "europe-west1", Image: "gcr.io/proj-a/export:1.2"},
+		{Name: "db-migrate", Region: "us-central1", Image: "gcr.io/proj-a/migrate:3"},
+	}}
+
+	provider := &CloudRunJobProvider{NewClient: func(context.Context, string) (CloudRunJobClient, error) {
+		return client, nil
+	}}
+
+	results, err := provider.Search(context.Background(), "proj-a", Query{Term: "migrate:3"})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(results) != 1 || results[0].Name != "db-migrate" {
+		t.Fatalf("expected db-migrate, got %+v", results)
+	}
+}
+
+func TestCloudRunJobProviderPropagatesErrors(t *testing.T) {
+	provider := &CloudRunJobProvider{NewClient: func(context.Context, string) (CloudRunJobClient, error) {
+		return nil, errors.New("client boom")
+	}}
+
+	if _, err := provider.Search(context.Background(), "proj-a", Query{Term: "foo"}); err == nil {
+		t.Fatal("expected error")
+	}
+
+	provider = &CloudRunJobProvider{NewClient: func(context.Context, string) (CloudRunJobClient, error) {
+		return &fakeCloudRunJobClient{err: errors.New("list boom")}, nil
+	}}
+
+	if _, err := provider.Search(context.Background(), "proj-a", Query{Term: "foo"}); err == nil {
+		t.Fatal("expected list error")
+	}
+}
+
+func TestCloudRunJobProviderNilProvider(t *testing.T) {
+	var provider *CloudRunJobProvider
+	if _, err := provider.Search(context.Background(), "proj-a", Query{Term: "foo"}); err == nil {
+		t.Fatal("expected error for nil provider")
+	}
+}
+
+type fakeCloudRunJobClient struct {
+	jobs []*gcp.CloudRunJob
+	err  error
+}
+
+func (f *fakeCloudRunJobClient) ListCloudRunJobs(context.Context) ([]*gcp.CloudRunJob, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.jobs, nil
+}
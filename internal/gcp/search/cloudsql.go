@@ -43,7 +43,7 @@ func (p *CloudSQLProvider) Search(ctx context.Context, project string, query Que
 
 	matches := make([]Result, 0, len(instances))
 	for _, inst := range instances {
-		if inst == nil || !query.MatchesAny(inst.Name, inst.DatabaseVersion, inst.Tier) {
+		if inst == nil || !query.MatchesResource(inst.Labels, inst.Name, inst.DatabaseVersion, inst.Tier) {
 			continue
 		}
 
@@ -53,6 +53,7 @@ func (p *CloudSQLProvider) Search(ctx context.Context, project string, query Que
 			Project:  project,
 			Location: inst.Region,
 			Details:  cloudSQLDetails(inst),
+			Labels:   inst.Labels,
 		})
 	}
 
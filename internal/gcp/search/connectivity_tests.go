@@ -60,7 +60,7 @@ func (p *ConnectivityTestProvider) Search(ctx context.Context, project string, q
 			searchFields = append(searchFields, test.Destination.Instance, test.Destination.IPAddress, test.Destination.Network)
 		}
 
-		if !query.MatchesAny(searchFields...) {
+		if !query.MatchesResource(test.Labels, searchFields...) {
 			continue
 		}
 
@@ -70,6 +70,7 @@ func (p *ConnectivityTestProvider) Search(ctx context.Context, project string, q
 			Project:  test.ProjectID,
 			Location: "global",
 			Details:  connectivityTestDetails(test),
+			Labels:   test.Labels,
 		})
 	}
 
@@ -0,0 +1,155 @@
+package search
+
+import (
+	"sort"
+)
+
+// ResultChange describes a resource present in both runs whose details differ.
+type ResultChange struct {
+	Before Result
+	After  Result
+	// Fields lists the detail keys that were added, removed or modified, sorted.
+	Fields []string
+}
+
+// ResultDiff is the difference between two runs of the same search.
+type ResultDiff struct {
+	Added   []Result
+	Removed []Result
+	Changed []ResultChange
+}
+
+// HasChanges reports whether anything was added, removed or changed.
+func (d ResultDiff) HasChanges() bool {
+	return len(d.Added) > 0 || len(d.Removed) > 0 || len(d.Changed) > 0
+}
+
+// DiffResults compares two runs of a search. Resources are identified by type,
+// project, location and name, and compared by their details.
+func DiffResults(previous, current []Result) ResultDiff {
+	before := make(map[string]Result, len(previous))
+	for _, result := range previous {
+		before[resultKey(result)] = result
+	}
+
+	var diff ResultDiff
+	seen := make(map[string]bool, len(current))
+	for _, result := range current {
+		key := resultKey(result)
+		seen[key] = true
+
+		old, ok := before[key]
+		if !ok {
+			diff.Added = append(diff.Added, result)
+			continue
+		}
+		if fields := changedDetails(old.Details, result.Details); len(fields) > 0 {
+			diff.Changed = append(diff.Changed, ResultChange{Before: old, After: result, Fields: fields})
+		}
+	}
+
+	for _, result := range previous {
+		if !seen[resultKey(result)] {
+			diff.Removed = append(diff.Removed, result)
+		}
+	}
+
+	SortResults(diff.Added, SortProject)
+	SortResults(diff.Removed, SortProject)
+	sort.SliceStable(diff.Changed, func(i, j int) bool {
+		return lessResult(diff.Changed[i].After, diff.Changed[j].After, SortProject)
+	})
+
+	return diff
+}
+
+// ProviderProject identifies a resource type searched in a project.
+type ProviderProject struct {
+	Project  string
+	Provider ResourceKind
+}
+
+// IncompletePairs returns the resource type/project pairs of a run that failed or were
+// skipped, whose results are therefore unknown, without duplicates and in order.
+func (o *SearchOutput) IncompletePairs() []ProviderProject {
+	if o == nil {
+		return nil
+	}
+
+	seen := make(map[ProviderProject]bool, len(o.Warnings)+len(o.Skipped))
+	var pairs []ProviderProject
+	add := func(pair ProviderProject) {
+		if !seen[pair] {
+			seen[pair] = true
+			pairs = append(pairs, pair)
+		}
+	}
+	for _, warning := range o.Warnings {
+		add(ProviderProject{Project: warning.Project, Provider: warning.Provider})
+	}
+	for _, skipped := range o.Skipped {
+		add(ProviderProject{Project: skipped.Project, Provider: skipped.Provider})
+	}
+
+	return pairs
+}
+
+// CarryOverResults returns the previous results of the incomplete pairs. Adding them to a
+// partial run keeps them from being reported as removed, and from being dropped from the
+// stored baseline only to be reported as added by the next complete run.
+func CarryOverResults(previous []Result, incomplete []ProviderProject) []Result {
+	if len(incomplete) == 0 {
+		return nil
+	}
+
+	pairs := make(map[ProviderProject]bool, len(incomplete))
+	for _, pair := range incomplete {
+		pairs[pair] = true
+	}
+
+	var carried []Result
+	for _, result := range previous {
+		if pairs[ProviderProject{Project: result.Project, Provider: result.Type}] {
+			carried = append(carried, result)
+		}
+	}
+
+	return carried
+}
+
+// resultKey identifies a resource across search runs.
+func resultKey(result Result) string {
+	return string(result.Type) + "\x00" + result.Project + "\x00" + result.Location + "\x00" + result.Name
+}
+
+// volatileDetails are detail keys derived from the time of the run, such as the age of the
+// oldest service account key. They are not compared, otherwise a resource would be reported
+// as changed on every run; the stable values they derive from (oldestKeyCreated) still are.
+var volatileDetails = map[string]bool{
+	"oldestKeyAge": true,
+}
+
+// changedDetails returns the sorted keys whose value differs between two detail maps,
+// ignoring volatile details.
+func changedDetails(before, after map[string]string) []string {
+	var fields []string
+	for key, value := range after {
+		if volatileDetails[key] {
+			continue
+		}
+		if old, ok := before[key]; !ok || old != value {
+			fields = append(fields, key)
+		}
+	}
+	for key := range before {
+		if volatileDetails[key] {
+			continue
+		}
+		if _, ok := after[key]; !ok {
+			fields = append(fields, key)
+		}
+	}
+	sort.Strings(fields)
+
+	return fields
+}
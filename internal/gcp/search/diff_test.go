@@ -0,0 +1,83 @@
+package search
+
+import "testing"
+
+func TestDiffResults(t *testing.T) {
+	previous := []Result{
+		{Type: KindAddress, Project: "p", Location: "us-east1", Name: "kept", Details: map[string]string{"status": "RESERVED"}},
+		{Type: KindAddress, Project: "p", Location: "us-east1", Name: "changed", Details: map[string]string{"status": "RESERVED", "user": ""}},
+		{Type: KindAddress, Project: "p", Location: "us-east1", Name: "gone"},
+	}
+	current := []Result{
+		{Type: KindAddress, Project: "p", Location: "us-east1", Name: "kept", Details: map[string]string{"status": "RESERVED"}, Score: 50},
+		{Type: KindAddress, Project: "p", Location: "us-east1", Name: "changed", Details: map[string]string{"status": "IN_USE", "users": "vm-1"}},
+		{Type: KindAddress, Project: "p", Location: "europe-west1", Name: "gone"},
+	}
+
+	diff := DiffResults(previous, current)
+	if !diff.HasChanges() {
+		t.Fatal("expected changes")
+	}
+
+	if len(diff.Added) != 1 || diff.Added[0].Location != "europe-west1" {
+		t.Fatalf("unexpected added results: %+v", diff.Added)
+	}
+	if len(diff.Removed) != 1 || diff.Removed[0].Location != "us-east1" || diff.Removed[0].Name != "gone" {
+		t.Fatalf("unexpected removed results: %+v", diff.Removed)
+	}
+	if len(diff.Changed) != 1 || diff.Changed[0].After.Name != "changed" {
+		t.Fatalf("unexpected changed results: %+v", diff.Changed)
+	}
+	if fields := diff.Changed[0].Fields; len(fields) != 3 || fields[0] != "status" || fields[1] != "user" || fields[2] != "users" {
+		t.Fatalf("unexpected changed fields: %v", fields)
+	}
+}
+
+func TestDiffResultsWithoutChanges(t *testing.T) {
+	results := []Result{{Type: KindDisk, Project: "p", Name: "d", Details: map[string]string{"size": "10"}}}
+	if diff := DiffResults(results, results); diff.HasChanges() {
+		t.Fatalf("expected no changes, got %+v", diff)
+	}
+	if diff := DiffResults(nil, nil); diff.HasChanges() {
+		t.Fatal("expected no changes for empty runs")
+	}
+}
+
+func TestCarryOverResults(t *testing.T) {
+	output := &SearchOutput{
+		Warnings: []SearchWarning{{Project: "p", Provider: KindDisk}, {Project: "p", Provider: KindDisk}},
+		Skipped:  []SkippedProvider{{Project: "q", Provider: KindCloudSQLInstance, Service: "sqladmin.googleapis.com"}},
+	}
+	incomplete := output.IncompletePairs()
+	if len(incomplete) != 2 || incomplete[0] != (ProviderProject{Project: "p", Provider: KindDisk}) {
+		t.Fatalf("unexpected incomplete pairs: %+v", incomplete)
+	}
+
+	previous := []Result{
+		{Type: KindDisk, Project: "p", Name: "disk"},
+		{Type: KindDisk, Project: "q", Name: "other-disk"},
+		{Type: KindCloudSQLInstance, Project: "q", Name: "db"},
+		{Type: KindAddress, Project: "p", Name: "ip"},
+	}
+	carried := CarryOverResults(previous, incomplete)
+	if len(carried) != 2 || carried[0].Name != "disk" || carried[1].Name != "db" {
+		t.Fatalf("unexpected carried results: %+v", carried)
+	}
+	if CarryOverResults(previous, nil) != nil {
+		t.Fatal("expected nothing carried over from a complete run")
+	}
+}
+
+func TestDiffResultsIgnoresVolatileDetails(t *testing.T) {
+	previous := []Result{{Type: KindServiceAccount, Project: "p", Name: "sa", Details: map[string]string{"oldestKeyAge": "30d", "oldestKeyCreated": "2024-01-01"}}}
+	current := []Result{{Type: KindServiceAccount, Project: "p", Name: "sa", Details: map[string]string{"oldestKeyAge": "31d", "oldestKeyCreated": "2024-01-01"}}}
+	if diff := DiffResults(previous, current); diff.HasChanges() {
+		t.Fatalf("expected a key getting older not to be a change, got %+v", diff)
+	}
+
+	current[0].Details = map[string]string{"oldestKeyAge": "0d", "oldestKeyCreated": "2024-02-01"}
+	diff := DiffResults(previous, current)
+	if len(diff.Changed) != 1 || len(diff.Changed[0].Fields) != 1 || diff.Changed[0].Fields[0] != "oldestKeyCreated" {
+		t.Fatalf("expected a rotated key to be a change, got %+v", diff.Changed)
+	}
+}
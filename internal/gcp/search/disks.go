@@ -43,7 +43,7 @@ func (p *DiskProvider) Search(ctx context.Context, project string, query Query)
 
 	matches := make([]Result, 0, len(disks))
 	for _, disk := range disks {
-		if disk == nil || !query.MatchesAny(disk.Name, disk.Type) {
+		if disk == nil || !query.MatchesResource(disk.Labels, disk.Name, disk.Type) {
 			continue
 		}
 
@@ -53,6 +53,7 @@ func (p *DiskProvider) Search(ctx context.Context, project string, query Query)
 			Project:  project,
 			Location: disk.Zone,
 			Details:  diskDetails(disk),
+			Labels:   disk.Labels,
 		})
 	}
 
@@ -0,0 +1,116 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/kedare/compass/internal/gcp"
+)
+
+// DNSRecordSetClientFactory creates a Cloud DNS client scoped to a project.
+type DNSRecordSetClientFactory func(ctx context.Context, project string) (DNSRecordSetClient, error)
+
+// DNSRecordSetClient exposes the subset of gcp.Client used by the DNS record set searcher.
+type DNSRecordSetClient interface {
+	ListDNSRecordSets(ctx context.Context) ([]*gcp.DNSRecordSet, error)
+}
+
+// DNSRecordSetProvider searches Cloud DNS record sets for query matches.
+// Records match on their name and on their rrdata, so searching an IP address
+// surfaces the A/AAAA records pointing at it.
+type DNSRecordSetProvider struct {
+	NewClient DNSRecordSetClientFactory
+}
+
+// Kind returns the resource kind this provider handles.
+func (p *DNSRecordSetProvider) Kind() ResourceKind {
+	return KindDNSRecordSet
+}
+
+// Search implements the Provider interface.
+func (p *DNSRecordSetProvider) Search(ctx context.Context, project string, query Query) ([]Result, error) {
+	if p == nil || p.NewClient == nil {
+		return nil, fmt.Errorf("%s: %w", project, ErrNoProviders)
+	}
+
+	client, err := p.NewClient(ctx, project)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client for %s: %w", project, err)
+	}
+
+	records, err := client.ListDNSRecordSets(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list DNS record sets in %s: %w", project, err)
+	}
+
+	matches := make([]Result, 0, len(records))
+	for _, record := range records {
+		if record == nil {
+			continue
+		}
+
+		searchFields := append([]string{record.Name}, record.RRDatas...)
+		if !query.MatchesAny(searchFields...) {
+			continue
+		}
+
+		// Record sets of different types share names, and split-horizon names exist in
+		// both a public and a private zone: the type and zone keep results distinct
+		name := record.Name
+		if record.Type != "" {
+			name = record.Name + " " + record.Type
+		}
+		location := record.Zone
+		if location == "" {
+			location = "global"
+		}
+
+		matches = append(matches, Result{
+			Type:     KindDNSRecordSet,
+			Name:     name,
+			Project:  project,
+			Location: location,
+			Details:  dnsRecordSetDetails(record),
+		})
+	}
+
+	return matches, nil
+}
+
+// dnsRecordSetDetails extracts display metadata for a Cloud DNS record set.
+func dnsRecordSetDetails(record *gcp.DNSRecordSet) map[string]string {
+	details := map[string]string{
+		"record": record.Name,
+	}
+
+	if record.Type != "" {
+		details["type"] = record.Type
+	}
+
+	if record.TTL > 0 {
+		details["ttl"] = fmt.Sprintf("%d", record.TTL)
+	}
+
+	if len(record.RRDatas) > 0 {
+		details["data"] = strings.Join(record.RRDatas, ", ")
+	}
+
+	if record.RoutingPolicy != "" {
+		details["routingPolicy"] = record.RoutingPolicy
+	}
+
+	if record.Zone != "" {
+		details["zone"] = record.Zone
+	}
+
+	if record.Visibility != "" {
+		details["visibility"] = record.Visibility
+	}
+
+	if len(record.Networks) > 0 {
+		details["networks"] = strings.Join(record.Networks, ", ")
+	}
+
+	return details
+}
@@ -0,0 +1,142 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kedare/compass/internal/gcp"
+)
+
+func TestDNSRecordSetProviderReturnsMatches(t *testing.T) {
+	client := &fakeDNSRecordSetClient{records: []*gcp.DNSRecordSet{
+		{Name: "api.corp.internal.", Type: "A", TTL: 300, RRDatas: []string{"10.20.0.15"}, Zone: "internal-corp", Visibility: "private"},
+		{Name: "www.example.com.", Type: "CNAME", TTL: 60, RRDatas: []string{"example.com."}, Zone: "public-site", Visibility: "public"},
+	}}
+
+	provider := &DNSRecordSetProvider{NewClient: func(ctx context.Context, project string) (DNSRecordSetClient, error) {
+		if project != "proj-a" {
+			t.Fatalf("unexpected project %s", project)
+		}
+		return client, nil
+	}}
+
+	results, err := provider.Search(context.Background(), "proj-a", Query{Term: "api.corp"})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+
+	if len(results) != 1 {
+		t.Fatalf("expected 1 result, got %d", len(results))
+	}
+
+	details := results[0].Details
+	if details["type"] != "A" || details["ttl"] != "300" || details["data"] != "10.20.0.15" {
+		t.Fatalf("unexpected details %#v", details)
+	}
+
+	if details["zone"] != "internal-corp" || details["visibility"] != "private" {
+		t.Fatalf("unexpected zone details %#v", details)
+	}
+}
+
+func TestDNSRecordSetProviderMatchesByRRData(t *testing.T) {
+	client := &fakeDNSRecordSetClient{records: []*gcp.DNSRecordSet{
+		{Name: "db.corp.internal.", Type: "A", RRDatas: []string{"10.20.0.30"}},
+		{Name: "lb.corp.internal.", Type: "A", RRDatas: []string{"10.20.0.40", "10.20.0.41"}, RoutingPolicy: "wrr"},
+		{Name: "corp.internal.", Type: "SOA", RRDatas: []string{"ns-cloud-a1.googledomains.com. cloud-dns-hostmaster.google.com. 1 21600 3600 259200 300"}},
+	}}
+
+	provider := &DNSRecordSetProvider{NewClient: func(ctx context.Context, project string) (DNSRecordSetClient, error) {
+		return client, nil
+	}}
+
+	results, err := provider.Search(context.Background(), "proj-a", Query{Term: "10.20.0.41"})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+
+	if len(results) != 1 || results[0].Name != "lb.corp.internal. A" {
+		t.Fatalf("expected lb.corp.internal. for IP search, got %#v", results)
+	}
+
+	if results[0].Details["routingPolicy"] != "wrr" {
+		t.Fatalf("expected routingPolicy wrr, got %q", results[0].Details["routingPolicy"])
+	}
+}
+
+func TestDNSRecordSetProviderIdentifiesRecordsByTypeAndZone(t *testing.T) {
+	client := &fakeDNSRecordSetClient{records: []*gcp.DNSRecordSet{
+		{Name: "app.example.com.", Type: "A", RRDatas: []string{"203.0.113.10"}, Zone: "public-site", Visibility: "public"},
+		{Name: "app.example.com.", Type: "TXT", RRDatas: []string{"\"v=spf1 -all\""}, Zone: "public-site", Visibility: "public"},
+		{Name: "app.example.com.", Type: "A", RRDatas: []string{"10.20.0.10"}, Zone: "private-site", Visibility: "private", Networks: []string{"shared-vpc", "dev-vpc"}},
+	}}
+
+	provider := &DNSRecordSetProvider{NewClient: func(context.Context, string) (DNSRecordSetClient, error) {
+		return client, nil
+	}}
+
+	results, err := provider.Search(context.Background(), "proj-a", Query{Term: "app.example.com"})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+
+	if len(results) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(results))
+	}
+
+	seen := make(map[string]bool, len(results))
+	for _, result := range results {
+		key := result.Name + "|" + result.Location
+		if seen[key] {
+			t.Fatalf("duplicate identity %q", key)
+		}
+		seen[key] = true
+
+		if result.Details["record"] != "app.example.com." {
+			t.Fatalf("expected record name in details, got %#v", result.Details)
+		}
+	}
+
+	if !seen["app.example.com. A|public-site"] || !seen["app.example.com. TXT|public-site"] || !seen["app.example.com. A|private-site"] {
+		t.Fatalf("unexpected identities %#v", seen)
+	}
+
+	if networks := results[2].Details["networks"]; networks != "shared-vpc, dev-vpc" {
+		t.Fatalf("expected attached networks, got %q", networks)
+	}
+
+	if _, ok := results[0].Details["networks"]; ok {
+		t.Fatalf("expected no networks for a public zone, got %#v", results[0].Details)
+	}
+}
+
+func TestDNSRecordSetProviderPropagatesErrors(t *testing.T) {
+	provider := &DNSRecordSetProvider{NewClient: func(context.Context, string) (DNSRecordSetClient, error) {
+		return nil, errors.New("client boom")
+	}}
+
+	if _, err := provider.Search(context.Background(), "proj-a", Query{Term: "foo"}); err == nil {
+		t.Fatal("expected error")
+	}
+
+	provider = &DNSRecordSetProvider{NewClient: func(context.Context, string) (DNSRecordSetClient, error) {
+		return &fakeDNSRecordSetClient{err: errors.New("list boom")}, nil
+	}}
+
+	if _, err := provider.Search(context.Background(), "proj-a", Query{Term: "foo"}); err == nil {
+		t.Fatal("expected list error")
+	}
+}
+
+type fakeDNSRecordSetClient struct {
+	records []*gcp.DNSRecordSet
+	err     error
+}
+
+func (f *fakeDNSRecordSetClient) ListDNSRecordSets(context.Context) ([]*gcp.DNSRecordSet, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.records, nil
+}
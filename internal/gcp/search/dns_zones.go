@@ -0,0 +1,109 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/kedare/compass/internal/gcp"
+)
+
+// DNSManagedZoneClientFactory creates a Cloud DNS client scoped to a project.
+type DNSManagedZoneClientFactory func(ctx context.Context, project string) (DNSManagedZoneClient, error)
+
+// DNSManagedZoneClient exposes the subset of gcp.Client used by the DNS zone searcher.
+type DNSManagedZoneClient interface {
+	ListDNSManagedZones(ctx context.Context) ([]*gcp.DNSManagedZone, error)
+}
+
+// DNSManagedZoneProvider searches Cloud DNS managed zones for query matches.
+type DNSManagedZoneProvider struct {
+	NewClient DNSManagedZoneClientFactory
+}
+
+// Kind returns the resource kind this provider handles.
+func (p *DNSManagedZoneProvider) Kind() ResourceKind {
+	return KindDNSManagedZone
+}
+
+// Search implements the Provider interface.
+func (p *DNSManagedZoneProvider) Search(ctx context.Context, project string, query Query) ([]Result, error) {
+	if p == nil || p.NewClient == nil {
+		return nil, fmt.Errorf("%s: %w", project, ErrNoProviders)
+	}
+
+	client, err := p.NewClient(ctx, project)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client for %s: %w", project, err)
+	}
+
+	zones, err := client.ListDNSManagedZones(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list DNS managed zones in %s: %w", project, err)
+	}
+
+	matches := make([]Result, 0, len(zones))
+	for _, zone := range zones {
+		if zone == nil {
+			continue
+		}
+
+		searchFields := []string{zone.Name, zone.DNSName, zone.Description, zone.PeeringNetwork}
+		searchFields = append(searchFields, zone.Networks...)
+		searchFields = append(searchFields, zone.ForwardingTargets...)
+
+		if !query.MatchesResource(zone.Labels, searchFields...) {
+			continue
+		}
+
+		matches = append(matches, Result{
+			Type:     KindDNSManagedZone,
+			Name:     zone.Name,
+			Project:  project,
+			Location: "global",
+			Details:  dnsManagedZoneDetails(zone),
+			Labels:   zone.Labels,
+		})
+	}
+
+	return matches, nil
+}
+
+// dnsManagedZoneDetails extracts display metadata for a Cloud DNS managed zone.
+func dnsManagedZoneDetails(zone *gcp.DNSManagedZone) map[string]string {
+	details := make(map[string]string)
+
+	if zone.DNSName != "" {
+		details["dnsName"] = zone.DNSName
+	}
+
+	if zone.Visibility != "" {
+		details["visibility"] = zone.Visibility
+	}
+
+	if len(zone.Networks) > 0 {
+		details["networks"] = strings.Join(zone.Networks, ", ")
+	}
+
+	if len(zone.ForwardingTargets) > 0 {
+		details["forwardingTargets"] = strings.Join(zone.ForwardingTargets, ", ")
+	}
+
+	if zone.PeeringNetwork != "" {
+		details["peeringNetwork"] = zone.PeeringNetwork
+	}
+
+	if zone.DNSSECState != "" && zone.DNSSECState != "off" {
+		details["dnssec"] = zone.DNSSECState
+	}
+
+	if len(zone.NameServers) > 0 {
+		details["nameServers"] = strings.Join(zone.NameServers, ", ")
+	}
+
+	if zone.Description != "" {
+		details["description"] = zone.Description
+	}
+
+	return details
+}
@@ -0,0 +1,105 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kedare/compass/internal/gcp"
+)
+
+func TestDNSManagedZoneProviderReturnsMatches(t *testing.T) {
+	client := &fakeDNSManagedZoneClient{zones: []*gcp.DNSManagedZone{
+		{Name: "internal-corp", DNSName: "corp.internal.", Visibility: "private", Networks: []string{"shared-vpc", "prod-vpc"}},
+		{Name: "public-site", DNSName: "example.com.", Visibility: "public", NameServers: []string{"ns-cloud-a1.googledomains.com."}},
+	}}
+
+	provider := &DNSManagedZoneProvider{NewClient: func(ctx context.Context, project string) (DNSManagedZoneClient, error) {
+		if project != "proj-a" {
+			t.Fatalf("unexpected project %s", project)
+		}
+		return client, nil
+	}}
+
+	results, err := provider.Search(context.Background(), "proj-a", Query{Term: "corp"})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+
+	if len(results) != 1 {
+		t.Fatalf("expected 1 result, got %d", len(results))
+	}
+
+	if results[0].Type != KindDNSManagedZone {
+		t.Fatalf("expected type %s, got %s", KindDNSManagedZone, results[0].Type)
+	}
+
+	if results[0].Details["visibility"] != "private" {
+		t.Fatalf("expected private visibility, got %s", results[0].Details["visibility"])
+	}
+
+	if results[0].Details["networks"] != "shared-vpc, prod-vpc" {
+		t.Fatalf("unexpected networks %q", results[0].Details["networks"])
+	}
+
+	if results[0].Details["dnsName"] != "corp.internal." {
+		t.Fatalf("unexpected dnsName %q", results[0].Details["dnsName"])
+	}
+}
+
+func TestDNSManagedZoneProviderMatchesByNetworkAndForwarding(t *testing.T) {
+	client := &fakeDNSManagedZoneClient{zones: []*gcp.DNSManagedZone{
+		{Name: "zone-a", DNSName: "a.internal.", Networks: []string{"shared-vpc"}},
+		{Name: "zone-b", DNSName: "onprem.corp.", ForwardingTargets: []string{"192.168.10.53"}},
+	}}
+
+	provider := &DNSManagedZoneProvider{NewClient: func(ctx context.Context, project string) (DNSManagedZoneClient, error) {
+		return client, nil
+	}}
+
+	results, err := provider.Search(context.Background(), "proj-a", Query{Term: "shared-vpc"})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(results) != 1 || results[0].Name != "zone-a" {
+		t.Fatalf("expected zone-a for network search, got %#v", results)
+	}
+
+	results, err = provider.Search(context.Background(), "proj-a", Query{Term: "192.168.10.53"})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(results) != 1 || results[0].Name != "zone-b" {
+		t.Fatalf("expected zone-b for forwarding target search, got %#v", results)
+	}
+}
+
+func TestDNSManagedZoneProviderPropagatesErrors(t *testing.T) {
+	provider := &DNSManagedZoneProvider{NewClient: func(context.Context, string) (DNSManagedZoneClient, error) {
+		return nil, errors.New("client boom")
+	}}
+
+	if _, err := provider.Search(context.Background(), "proj-a", Query{Term: "foo"}); err == nil {
+		t.Fatal("expected error")
+	}
+
+	provider = &DNSManagedZoneProvider{NewClient: func(context.Context, string) (DNSManagedZoneClient, error) {
+		return &fakeDNSManagedZoneClient{err: errors.New("list boom")}, nil
+	}}
+
+	if _, err := provider.Search(context.Background(), "proj-a", Query{Term: "foo"}); err == nil {
+		t.Fatal("expected list error")
+	}
+}
+
+type fakeDNSManagedZoneClient struct {
+	zones []*gcp.DNSManagedZone
+	err   error
+}
+
+func (f *fakeDNSManagedZoneClient) ListDNSManagedZones(context.Context) ([]*gcp.DNSManagedZone, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.zones, nil
+}
@@ -3,9 +3,13 @@ package search
 import (
 	"context"
 	"errors"
+	"fmt"
 	"sort"
 	"strings"
 	"sync"
+	"time"
+
+	"github.com/kedare/compass/internal/gcp"
 )
 
 // SearchProgress contains information about the current search progress.
@@ -22,7 +26,11 @@ type SearchProgress struct {
 // If the callback returns an error, the search will be stopped.
 type ResultCallback func(results []Result, progress SearchProgress) error
 
-const defaultProjectConcurrency = 8
+const (
+	defaultProjectConcurrency = 8
+	// defaultProviderTimeout bounds a single provider call for one project.
+	defaultProviderTimeout = 30 * time.Second
+)
 
 var (
 	// ErrNoProjects indicates that no projects were provided for the search.
@@ -33,10 +41,22 @@ var (
 	ErrNoProviders = errors.New("no search providers configured")
 )
 
+// ServiceState remembers which APIs are disabled in which projects.
+// It is typically backed by the cache so the knowledge survives between runs.
+type ServiceState interface {
+	IsServiceDisabled(project, service string) bool
+	MarkServiceDisabled(project, service string) error
+}
+
 // Engine coordinates running the configured providers across a set of projects.
 type Engine struct {
 	providers             []Provider
 	MaxConcurrentProjects int
+	// ProviderTimeout bounds each provider call for a single project.
+	// Zero uses the default timeout, a negative value disables it.
+	ProviderTimeout time.Duration
+	// ServiceState, when set, is used to skip and remember disabled APIs.
+	ServiceState ServiceState
 }
 
 // NewEngine creates a search engine with the supplied providers.
@@ -92,6 +112,7 @@ func (e *Engine) SearchWithWarnings(ctx context.Context, projects []string, quer
 	var mu sync.Mutex
 	var results []Result
 	var warnings []SearchWarning
+	var skipped []SkippedProvider
 
 	var wg sync.WaitGroup
 	for _, project := range trimmed {
@@ -109,7 +130,13 @@ func (e *Engine) SearchWithWarnings(ctx context.Context, projects []string, quer
 			}
 
 			for _, provider := range activeProviders {
-				providerResults, err := provider.Search(ctx, project, query)
+				providerResults, skip, err := e.runProvider(ctx, provider, project, query)
+				if skip != nil {
+					mu.Lock()
+					skipped = append(skipped, *skip)
+					mu.Unlock()
+					continue
+				}
 				if err != nil {
 					// Record warning but continue with other providers
 					mu.Lock()
@@ -135,18 +162,8 @@ func (e *Engine) SearchWithWarnings(ctx context.Context, projects []string, quer
 
 	wg.Wait()
 
-	sort.Slice(results, func(i, j int) bool {
-		if results[i].Project != results[j].Project {
-			return results[i].Project < results[j].Project
-		}
-		if results[i].Type != results[j].Type {
-			return results[i].Type < results[j].Type
-		}
-		if results[i].Name != results[j].Name {
-			return results[i].Name < results[j].Name
-		}
-		return results[i].Location < results[j].Location
-	})
+	// Most relevant results first
+	SortResults(results, SortRelevance)
 
 	// Sort warnings for consistent output
 	sort.Slice(warnings, func(i, j int) bool {
@@ -155,13 +172,75 @@ func (e *Engine) SearchWithWarnings(ctx context.Context, projects []string, quer
 		}
 		return warnings[i].Provider < warnings[j].Provider
 	})
+	sortSkipped(skipped)
 
 	return &SearchOutput{
 		Results:  results,
 		Warnings: warnings,
+		Skipped:  skipped,
 	}, nil
 }
 
+// runProvider runs one provider against one project within the provider timeout. Requests
+// are rate limited per API host by the transport of the clients, see gcp.SetAPIRateLimit. Providers whose API is disabled in the project are
+// skipped, and newly detected disabled APIs are remembered in the service state.
+func (e *Engine) runProvider(ctx context.Context, provider Provider, project string, query Query) ([]Result, *SkippedProvider, error) {
+	kind := provider.Kind()
+	service := serviceForKind(kind)
+
+	if service != "" && e.ServiceState != nil && e.ServiceState.IsServiceDisabled(project, service) {
+		return nil, &SkippedProvider{Project: project, Provider: kind, Service: service}, nil
+	}
+
+	timeout := e.ProviderTimeout
+	if timeout == 0 {
+		timeout = defaultProviderTimeout
+	}
+
+	callCtx := ctx
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		callCtx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	results, err := provider.Search(callCtx, project, query)
+	if err != nil {
+		if service != "" && gcp.IsServiceDisabledError(err) {
+			if e.ServiceState != nil {
+				_ = e.ServiceState.MarkServiceDisabled(project, service)
+			}
+			return nil, &SkippedProvider{Project: project, Provider: kind, Service: service}, nil
+		}
+		if ctx.Err() == nil && errors.Is(callCtx.Err(), context.DeadlineExceeded) {
+			return nil, nil, fmt.Errorf("timed out after %s: %w", timeout, err)
+		}
+		return nil, nil, err
+	}
+
+	ScoreResults(query, results)
+
+	return results, nil, nil
+}
+
+// serviceForKind returns the API service backing a resource kind, if registered.
+func serviceForKind(kind ResourceKind) string {
+	if d, ok := Lookup(kind); ok {
+		return d.Service
+	}
+	return ""
+}
+
+// sortSkipped orders skipped providers by project, then provider.
+func sortSkipped(skipped []SkippedProvider) {
+	sort.Slice(skipped, func(i, j int) bool {
+		if skipped[i].Project != skipped[j].Project {
+			return skipped[i].Project < skipped[j].Project
+		}
+		return skipped[i].Provider < skipped[j].Provider
+	})
+}
+
 // uniqueProjects trims, deduplicates, and preserves order of project IDs.
 func uniqueProjects(projects []string) []string {
 	seen := make(map[string]struct{}, len(projects))
@@ -238,6 +317,7 @@ func (e *Engine) SearchStreaming(ctx context.Context, projects []string, query Q
 	var mu sync.Mutex
 	var results []Result
 	var warnings []SearchWarning
+	var skipped []SkippedProvider
 
 	// Split projects into priority batches for better affinity-based ordering
 	// Search top projects first to show relevant results faster
@@ -268,7 +348,7 @@ func (e *Engine) SearchStreaming(ctx context.Context, projects []string, query Q
 						return
 					}
 
-					providerResults, err := provider.Search(ctx, project, query)
+					providerResults, skip, err := e.runProvider(ctx, provider, project, query)
 
 					// Update completed count regardless of result
 					mu.Lock()
@@ -276,14 +356,18 @@ func (e *Engine) SearchStreaming(ctx context.Context, projects []string, query Q
 					currentCompleted := completedRequests
 					mu.Unlock()
 
-					if err != nil {
-						// Record warning but continue with other providers
+					if skip != nil || err != nil {
+						// Record the skip or warning but continue with other providers
 						mu.Lock()
-						warnings = append(warnings, SearchWarning{
-							Project:  project,
-							Provider: provider.Kind(),
-							Err:      err,
-						})
+						if skip != nil {
+							skipped = append(skipped, *skip)
+						} else {
+							warnings = append(warnings, SearchWarning{
+								Project:  project,
+								Provider: provider.Kind(),
+								Err:      err,
+							})
+						}
 						mu.Unlock()
 
 						// Still send progress update even on error
@@ -337,19 +421,8 @@ func (e *Engine) SearchStreaming(ctx context.Context, projects []string, query Q
 		searchProjectBatch(remainingBatch)
 	}
 
-	// Sort results for consistent output
-	sort.Slice(results, func(i, j int) bool {
-		if results[i].Project != results[j].Project {
-			return results[i].Project < results[j].Project
-		}
-		if results[i].Type != results[j].Type {
-			return results[i].Type < results[j].Type
-		}
-		if results[i].Name != results[j].Name {
-			return results[i].Name < results[j].Name
-		}
-		return results[i].Location < results[j].Location
-	})
+	// Most relevant results first
+	SortResults(results, SortRelevance)
 
 	// Sort warnings for consistent output
 	sort.Slice(warnings, func(i, j int) bool {
@@ -358,10 +431,12 @@ func (e *Engine) SearchStreaming(ctx context.Context, projects []string, query Q
 		}
 		return warnings[i].Provider < warnings[j].Provider
 	})
+	sortSkipped(skipped)
 
 	return &SearchOutput{
 		Results:  results,
 		Warnings: warnings,
+		Skipped:  skipped,
 	}, nil
 }
 
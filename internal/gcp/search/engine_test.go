@@ -5,6 +5,7 @@ import (
 	"errors"
 	"sync"
 	"testing"
+	"time"
 )
 
 func TestEngineSearchAggregatesResults(t *testing.T) {
@@ -34,6 +35,34 @@ func TestEngineSearchAggregatesResults(t *testing.T) {
 	}
 }
 
+func TestEngineSearchRanksByRelevance(t *testing.T) {
+	provider := &stubProvider{
+		responses: map[string][]Result{
+			"a": {
+				{Project: "a", Type: KindComputeInstance, Name: "web-1-old", Location: "z"},
+				{Project: "a", Type: KindComputeInstance, Name: "api", Location: "z", Details: map[string]string{"tag": "web-1"}},
+			},
+			"b": {{Project: "b", Type: KindComputeInstance, Name: "web-1", Location: "z"}},
+		},
+	}
+
+	engine := NewEngine(provider)
+	results, err := engine.Search(context.Background(), []string{"a", "b"}, Query{Term: "web-1"})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+
+	if len(results) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(results))
+	}
+	if results[0].Name != "web-1" || results[1].Name != "web-1-old" || results[2].Name != "api" {
+		t.Fatalf("unexpected ordering: %#v", results)
+	}
+	if results[0].Score != ScoreExactName {
+		t.Fatalf("expected exact name score, got %v", results[0].Score)
+	}
+}
+
 func TestEngineSearchValidatesInputs(t *testing.T) {
 	engine := NewEngine(&stubProvider{})
 	if _, err := engine.Search(context.Background(), nil, Query{Term: "foo"}); !errors.Is(err, ErrNoProjects) {
@@ -99,9 +128,88 @@ func TestEngineSearchReturnsEmptyResultsOnAllProviderErrors(t *testing.T) {
 	}
 }
 
+func TestEngineSearchSkipsDisabledServices(t *testing.T) {
+	provider := &stubProvider{
+		kind:       KindRedisInstance,
+		errProject: "b",
+		err:        errors.New("googleapi: Error 403: Redis API has not been used in project b before or it is disabled., accessNotConfigured"),
+		responses: map[string][]Result{
+			"a": {{Project: "a", Type: KindRedisInstance, Name: "cache"}},
+		},
+	}
+	state := &stubServiceState{disabled: map[string]bool{"c/redis.googleapis.com": true}}
+
+	engine := NewEngine(provider)
+	engine.ServiceState = state
+
+	output, err := engine.SearchWithWarnings(context.Background(), []string{"a", "b", "c"}, Query{Term: "cache"})
+	if err != nil {
+		t.Fatalf("SearchWithWarnings failed: %v", err)
+	}
+
+	if len(output.Results) != 1 || len(output.Warnings) != 0 {
+		t.Fatalf("expected 1 result and no warnings, got %d results and %v", len(output.Results), output.Warnings)
+	}
+	if len(output.Skipped) != 2 || output.Skipped[0].Project != "b" || output.Skipped[1].Project != "c" {
+		t.Fatalf("unexpected skipped providers: %+v", output.Skipped)
+	}
+	if output.Skipped[0].Service != "redis.googleapis.com" {
+		t.Fatalf("unexpected skipped service: %+v", output.Skipped[0])
+	}
+	if provider.calls["c"] != 0 {
+		t.Fatal("expected provider not to be called for a known disabled service")
+	}
+	if !state.disabled["b/redis.googleapis.com"] {
+		t.Fatal("expected newly detected disabled service to be remembered")
+	}
+}
+
+func TestEngineSearchAppliesProviderTimeout(t *testing.T) {
+	engine := NewEngine(blockingProvider{})
+	engine.ProviderTimeout = 10 * time.Millisecond
+
+	output, err := engine.SearchWithWarnings(context.Background(), []string{"a"}, Query{Term: "x"})
+	if err != nil {
+		t.Fatalf("SearchWithWarnings failed: %v", err)
+	}
+
+	if len(output.Warnings) != 1 || !errors.Is(output.Warnings[0].Err, context.DeadlineExceeded) {
+		t.Fatalf("expected a timeout warning, got %+v", output.Warnings)
+	}
+}
+
+type stubServiceState struct {
+	mu       sync.Mutex
+	disabled map[string]bool
+}
+
+func (s *stubServiceState) IsServiceDisabled(project, service string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.disabled[project+"/"+service]
+}
+
+func (s *stubServiceState) MarkServiceDisabled(project, service string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.disabled[project+"/"+service] = true
+	return nil
+}
+
+// blockingProvider never answers until its context is done.
+type blockingProvider struct{}
+
+func (blockingProvider) Kind() ResourceKind { return KindComputeInstance }
+
+func (blockingProvider) Search(ctx context.Context, _ string, _ Query) ([]Result, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
 type stubProvider struct {
 	responses  map[string][]Result
 	errProject string
+	err        error
 	calls      map[string]int
 	mu         sync.Mutex
 	kind       ResourceKind
@@ -123,6 +231,9 @@ func (s *stubProvider) Search(_ context.Context, project string, query Query) ([
 	s.mu.Unlock()
 
 	if project == s.errProject {
+		if s.err != nil {
+			return nil, s.err
+		}
 		return nil, errors.New("boom")
 	}
 
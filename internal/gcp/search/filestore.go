@@ -0,0 +1,102 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/kedare/compass/internal/gcp"
+)
+
+// FilestoreClientFactory creates a Filestore client scoped to a project.
+type FilestoreClientFactory func(ctx context.Context, project string) (FilestoreClient, error)
+
+// FilestoreClient exposes the subset of gcp.Client used by the Filestore searcher.
+type FilestoreClient interface {
+	ListFilestoreInstances(ctx context.Context) ([]*gcp.FilestoreInstance, error)
+}
+
+// FilestoreProvider searches Filestore instances for query matches.
+type FilestoreProvider struct {
+	NewClient FilestoreClientFactory
+}
+
+// Kind returns the resource kind this provider handles.
+func (p *FilestoreProvider) Kind() ResourceKind {
+	return KindFilestoreInstance
+}
+
+// Search implements the Provider interface.
+func (p *FilestoreProvider) Search(ctx context.Context, project string, query Query) ([]Result, error) {
+	if p == nil || p.NewClient == nil {
+		return nil, fmt.Errorf("%s: %w", project, ErrNoProviders)
+	}
+
+	client, err := p.NewClient(ctx, project)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client for %s: %w", project, err)
+	}
+
+	instances, err := client.ListFilestoreInstances(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list Filestore instances in %s: %w", project, err)
+	}
+
+	matches := make([]Result, 0, len(instances))
+	for _, inst := range instances {
+		if inst == nil {
+			continue
+		}
+
+		fields := append([]string{inst.Name, inst.Description}, inst.IPAddresses...)
+		if !query.MatchesResource(inst.Labels, fields...) {
+			continue
+		}
+
+		matches = append(matches, Result{
+			Type:     KindFilestoreInstance,
+			Name:     inst.Name,
+			Project:  project,
+			Location: inst.Location,
+			Details:  filestoreDetails(inst),
+			Labels:   inst.Labels,
+		})
+	}
+
+	return matches, nil
+}
+
+// filestoreDetails extracts display metadata for a Filestore instance.
+func filestoreDetails(inst *gcp.FilestoreInstance) map[string]string {
+	details := make(map[string]string)
+
+	if len(inst.IPAddresses) > 0 {
+		details["ipAddresses"] = strings.Join(inst.IPAddresses, ", ")
+	}
+
+	if len(inst.FileShares) > 0 {
+		details["shares"] = strings.Join(inst.FileShares, ", ")
+	}
+
+	if inst.Tier != "" {
+		details["tier"] = inst.Tier
+	}
+
+	if inst.Protocol != "" {
+		details["protocol"] = inst.Protocol
+	}
+
+	if inst.Network != "" {
+		details["network"] = inst.Network
+	}
+
+	if inst.Description != "" {
+		details["description"] = inst.Description
+	}
+
+	if inst.State != "" {
+		details["state"] = inst.State
+	}
+
+	return details
+}
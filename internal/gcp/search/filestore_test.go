@@ -0,0 +1,86 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kedare/compass/internal/gcp"
+)
+
+func TestFilestoreProviderMatchesByIP(t *testing.T) {
+	client := &fakeFilestoreClient{instances: []*gcp.FilestoreInstance{
+		{Name: "shared-nfs", Location: "us-central1-a", Tier: "BASIC_HDD", IPAddresses: []string{"10.40.0.2"}, Network: "prod-vpc", FileShares: []string{"vol1 (1024 GB)"}, Protocol: "NFS_V3", State: "READY"},
+		{Name: "scratch", Location: "us-central1-b", IPAddresses: []string{"10.40.1.2"}},
+	}}
+
+	provider := &FilestoreProvider{NewClient: func(ctx context.Context, project string) (FilestoreClient, error) {
+		if project != "proj-a" {
+			t.Fatalf("unexpected project %s", project)
+		}
+		return client, nil
+	}}
+
+	results, err := provider.Search(context.Background(), "proj-a", Query{Term: "10.40.0.2"})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+
+	if len(results) != 1 || results[0].Name != "shared-nfs" {
+		t.Fatalf("expected shared-nfs, got %+v", results)
+	}
+
+	result := results[0]
+	if result.Type != KindFilestoreInstance || result.Location != "us-central1-a" {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+
+	expected := map[string]string{
+		"ipAddresses": "10.40.0.2",
+		"shares":      "vol1 (1024 GB)",
+		"tier":        "BASIC_HDD",
+		"protocol":    "NFS_V3",
+		"network":     "prod-vpc",
+		"state":       "READY",
+	}
+	for key, want := range expected {
+		if got := result.Details[key]; got != want {
+			t.Fatalf("expected %s=%q, got %q", key, want, got)
+		}
+	}
+}
+
+func TestFilestoreProviderPropagatesErrors(t *testing.T) {
+	provider := &FilestoreProvider{NewClient: func(context.Context, string) (FilestoreClient, error) {
+		return nil, errors.New("client boom")
+	}}
+
+	if _, err := provider.Search(context.Background(), "proj-a", Query{Term: "foo"}); err == nil {
+		t.Fatal("expected error")
+	}
+
+	provider = &FilestoreProvider{NewClient: func(context.Context, string) (FilestoreClient, error) {
+		return &fakeFilestoreClient{err: errors.New("list boom")}, nil
+	}}
+
+	if _, err := provider.Search(context.Background(), "proj-a", Query{Term: "foo"}); err == nil {
+		t.Fatal("expected list error")
+	}
+
+	var nilProvider *FilestoreProvider
+	if _, err := nilProvider.Search(context.Background(), "proj-a", Query{Term: "foo"}); err == nil {
+		t.Fatal("expected error for nil provider")
+	}
+}
+
+type fakeFilestoreClient struct {
+	instances []*gcp.FilestoreInstance
+	err       error
+}
+
+func (f *fakeFilestoreClient) ListFilestoreInstances(context.Context) ([]*gcp.FilestoreInstance, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.instances, nil
+}
@@ -43,7 +43,7 @@ func (p *GKEClusterProvider) Search(ctx context.Context, project string, query Q
 
 	matches := make([]Result, 0, len(clusters))
 	for _, cluster := range clusters {
-		if cluster == nil || !query.MatchesAny(cluster.Name, cluster.CurrentMasterVersion) {
+		if cluster == nil || !query.MatchesResource(cluster.Labels, cluster.Name, cluster.CurrentMasterVersion) {
 			continue
 		}
 
@@ -53,6 +53,7 @@ func (p *GKEClusterProvider) Search(ctx context.Context, project string, query Q
 			Project:  project,
 			Location: cluster.Location,
 			Details:  gkeClusterDetails(cluster),
+			Labels:   cluster.Labels,
 		})
 	}
 
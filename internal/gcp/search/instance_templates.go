@@ -44,7 +44,7 @@ func (p *InstanceTemplateProvider) Search(ctx context.Context, project string, q
 
 	matches := make([]Result, 0, len(templates))
 	for _, tmpl := range templates {
-		if tmpl == nil || !query.MatchesAny(tmpl.Name, tmpl.Description, tmpl.MachineType) {
+		if tmpl == nil || !query.MatchesResource(tmpl.Labels, tmpl.Name, tmpl.Description, tmpl.MachineType) {
 			continue
 		}
 
@@ -54,6 +54,7 @@ func (p *InstanceTemplateProvider) Search(ctx context.Context, project string, q
 			Project:  project,
 			Location: "global",
 			Details:  instanceTemplateDetails(tmpl),
+			Labels:   tmpl.Labels,
 		})
 	}
 
@@ -43,7 +43,7 @@ func (p *InstanceProvider) Search(ctx context.Context, project string, query Que
 
 	matches := make([]Result, 0, len(instances))
 	for _, inst := range instances {
-		if inst == nil || !query.MatchesAny(inst.Name, inst.InternalIP, inst.ExternalIP, inst.MachineType) {
+		if inst == nil || !query.MatchesResource(inst.Labels, inst.Name, inst.InternalIP, inst.ExternalIP, inst.MachineType) {
 			continue
 		}
 
@@ -53,6 +53,7 @@ func (p *InstanceProvider) Search(ctx context.Context, project string, query Que
 			Project:  inst.Project,
 			Location: inst.Zone,
 			Details:  instanceDetails(inst),
+			Labels:   inst.Labels,
 		})
 	}
 
@@ -0,0 +1,143 @@
+package search
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kedare/compass/internal/gcp"
+)
+
+// InterconnectAttachmentClientFactory creates an Interconnect client scoped to a project.
+type InterconnectAttachmentClientFactory func(ctx context.Context, project string) (InterconnectAttachmentClient, error)
+
+// InterconnectAttachmentClient exposes the subset of gcp.Client used by the attachment searcher.
+type InterconnectAttachmentClient interface {
+	ListInterconnectAttachments(ctx context.Context) ([]*gcp.InterconnectAttachment, error)
+}
+
+// InterconnectAttachmentProvider searches Interconnect VLAN attachments for query matches.
+type InterconnectAttachmentProvider struct {
+	NewClient InterconnectAttachmentClientFactory
+}
+
+// Kind returns the resource kind this provider handles.
+func (p *InterconnectAttachmentProvider) Kind() ResourceKind {
+	return KindInterconnectAttachment
+}
+
+// Search implements the Provider interface.
+func (p *InterconnectAttachmentProvider) Search(ctx context.Context, project string, query Query) ([]Result, error) {
+	if p == nil || p.NewClient == nil {
+		return nil, fmt.Errorf("%s: %w", project, ErrNoProviders)
+	}
+
+	client, err := p.NewClient(ctx, project)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client for %s: %w", project, err)
+	}
+
+	attachments, err := client.ListInterconnectAttachments(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list interconnect attachments in %s: %w", project, err)
+	}
+
+	matches := make([]Result, 0, len(attachments))
+	for _, attachment := range attachments {
+		if attachment == nil {
+			continue
+		}
+
+		fields := []string{
+			attachment.Name,
+			attachment.Description,
+			attachment.Interconnect,
+			attachment.Router,
+			attachment.PairingKey,
+			attachment.PartnerName,
+			attachment.CloudRouterIPAddress,
+			attachment.CustomerRouterIPAddress,
+		}
+		if attachment.PartnerASN > 0 {
+			fields = append(fields, fmt.Sprintf("%d", attachment.PartnerASN))
+		}
+		if !query.MatchesResource(attachment.Labels, fields...) {
+			continue
+		}
+
+		matches = append(matches, Result{
+			Type:     KindInterconnectAttachment,
+			Name:     attachment.Name,
+			Project:  project,
+			Location: attachment.Region,
+			Details:  interconnectAttachmentDetails(attachment),
+			Labels:   attachment.Labels,
+		})
+	}
+
+	return matches, nil
+}
+
+// interconnectAttachmentDetails extracts display metadata for an Interconnect attachment.
+func interconnectAttachmentDetails(attachment *gcp.InterconnectAttachment) map[string]string {
+	details := map[string]string{
+		"adminEnabled": fmt.Sprintf("%t", attachment.AdminEnabled),
+	}
+
+	if attachment.Type != "" {
+		details["type"] = attachment.Type
+	}
+
+	if attachment.Bandwidth != "" {
+		details["bandwidth"] = attachment.Bandwidth
+	}
+
+	if attachment.State != "" {
+		details["state"] = attachment.State
+	}
+
+	if attachment.OperationalStatus != "" {
+		details["operationalStatus"] = attachment.OperationalStatus
+	}
+
+	if attachment.Interconnect != "" {
+		details["interconnect"] = attachment.Interconnect
+	}
+
+	if attachment.Router != "" {
+		details["router"] = attachment.Router
+	}
+
+	if attachment.PairingKey != "" {
+		details["pairingKey"] = attachment.PairingKey
+	}
+
+	if attachment.PartnerName != "" {
+		details["partner"] = attachment.PartnerName
+	}
+
+	if attachment.PartnerASN > 0 {
+		details["partnerAsn"] = fmt.Sprintf("%d", attachment.PartnerASN)
+	}
+
+	if attachment.VLANTag > 0 {
+		details["vlan"] = fmt.Sprintf("%d", attachment.VLANTag)
+	}
+
+	if attachment.CloudRouterIPAddress != "" {
+		details["cloudRouterIP"] = attachment.CloudRouterIPAddress
+	}
+
+	if attachment.CustomerRouterIPAddress != "" {
+		details["customerRouterIP"] = attachment.CustomerRouterIPAddress
+	}
+
+	if attachment.EdgeAvailabilityDomain != "" {
+		details["edgeAvailabilityDomain"] = attachment.EdgeAvailabilityDomain
+	}
+
+	if attachment.MTU > 0 {
+		details["mtu"] = fmt.Sprintf("%d", attachment.MTU)
+	}
+
+	return details
+}
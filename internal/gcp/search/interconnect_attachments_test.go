@@ -0,0 +1,119 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kedare/compass/internal/gcp"
+)
+
+func TestInterconnectAttachmentProviderReturnsMatches(t *testing.T) {
+	client := &fakeInterconnectAttachmentClient{attachments: []*gcp.InterconnectAttachment{
+		{
+			Name: "vlan-paris-1", Region: "europe-west9", Type: "PARTNER", Router: "hybrid-router", Bandwidth: "BPS_1G",
+			State: "ACTIVE", OperationalStatus: "OS_ACTIVE", AdminEnabled: true, PairingKey: "7e51371e-72a3-40b5-b844-2e3efefaee59/europe-west9/1",
+			PartnerASN: 65020, PartnerName: "Equinix", VLANTag: 1001, CloudRouterIPAddress: "169.254.20.1/29", CustomerRouterIPAddress: "169.254.20.2/29",
+			EdgeAvailabilityDomain: "AVAILABILITY_DOMAIN_1", MTU: 1440,
+		},
+		{Name: "vlan-paris-2", Region: "europe-west9", Type: "DEDICATED", Interconnect: "par-zone1-1"},
+	}}
+
+	provider := &InterconnectAttachmentProvider{NewClient: func(ctx context.Context, project string) (InterconnectAttachmentClient, error) {
+		if project != "proj-a" {
+			t.Fatalf("unexpected project %s", project)
+		}
+		return client, nil
+	}}
+
+	results, err := provider.Search(context.Background(), "proj-a", Query{Term: "vlan-paris-1"})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+
+	if len(results) != 1 {
+		t.Fatalf("expected 1 result, got %d", len(results))
+	}
+
+	result := results[0]
+	if result.Type != KindInterconnectAttachment || result.Location != "europe-west9" {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+
+	expected := map[string]string{
+		"type":              "PARTNER",
+		"bandwidth":         "BPS_1G",
+		"state":             "ACTIVE",
+		"operationalStatus": "OS_ACTIVE",
+		"adminEnabled":      "true",
+		"router":            "hybrid-router",
+		"pairingKey":        "7e51371e-72a3-40b5-b844-2e3efefaee59/europe-west9/1",
+		"partner":           "Equinix",
+		"partnerAsn":        "65020",
+		"vlan":              "1001",
+		"cloudRouterIP":     "169.254.20.1/29",
+		"customerRouterIP":  "169.254.20.2/29",
+		"mtu":               "1440",
+	}
+	for key, want := range expected {
+		if got := result.Details[key]; got != want {
+			t.Fatalf("expected %s=%q, got %q", key, want, got)
+		}
+	}
+}
+
+func TestInterconnectAttachmentProviderMatchesByDetailFields(t *testing.T) {
+	client := &fakeInterconnectAttachmentClient{attachments: []*gcp.InterconnectAttachment{
+		{Name: "vlan-a", PairingKey: "abc-key/europe-west9/1", PartnerASN: 65020},
+		{Name: "vlan-b", Interconnect: "par-zone1-1"},
+	}}
+
+	provider := &InterconnectAttachmentProvider{NewClient: func(context.Context, string) (InterconnectAttachmentClient, error) {
+		return client, nil
+	}}
+
+	for term, want := range map[string]string{"abc-key": "vlan-a", "65020": "vlan-a", "par-zone1": "vlan-b"} {
+		results, err := provider.Search(context.Background(), "proj-a", Query{Term: term})
+		if err != nil {
+			t.Fatalf("Search failed: %v", err)
+		}
+		if len(results) != 1 || results[0].Name != want {
+			t.Fatalf("expected %s for %q, got %+v", want, term, results)
+		}
+	}
+}
+
+func TestInterconnectAttachmentProviderPropagatesErrors(t *testing.T) {
+	provider := &InterconnectAttachmentProvider{NewClient: func(context.Context, string) (InterconnectAttachmentClient, error) {
+		return nil, errors.New("client boom")
+	}}
+
+	if _, err := provider.Search(context.Background(), "proj-a", Query{Term: "foo"}); err == nil {
+		t.Fatal("expected error")
+	}
+
+	provider = &InterconnectAttachmentProvider{NewClient: func(context.Context, string) (InterconnectAttachmentClient, error) {
+		return &fakeInterconnectAttachmentClient{err: errors.New("list boom")}, nil
+	}}
+
+	if _, err := provider.Search(context.Background(), "proj-a", Query{Term: "foo"}); err == nil {
+		t.Fatal("expected list error")
+	}
+
+	var nilProvider *InterconnectAttachmentProvider
+	if _, err := nilProvider.Search(context.Background(), "proj-a", Query{Term: "foo"}); err == nil {
+		t.Fatal("expected error for nil provider")
+	}
+}
+
+type fakeInterconnectAttachmentClient struct {
+	attachments []*gcp.InterconnectAttachment
+	err         error
+}
+
+func (f *fakeInterconnectAttachmentClient) ListInterconnectAttachments(context.Context) ([]*gcp.InterconnectAttachment, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.attachments, nil
+}
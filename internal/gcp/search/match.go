@@ -0,0 +1,174 @@
+package search
+
+import (
+	"fmt"
+	"regexp"
+	"regexp/syntax"
+	"strings"
+	"sync"
+)
+
+// MatchMode selects how a query term is compared to resource values.
+type MatchMode string
+
+const (
+	// MatchSubstring matches values containing the term, ignoring case (the default).
+	MatchSubstring MatchMode = "substring"
+	// MatchFuzzy matches values containing the term characters in order, ignoring case.
+	MatchFuzzy MatchMode = "fuzzy"
+	// MatchRegex matches values against the term as a case-sensitive regular expression.
+	MatchRegex MatchMode = "regex"
+	// MatchExact matches values equal to the term, respecting case.
+	MatchExact MatchMode = "exact"
+)
+
+// minAffinityTermLength is the shortest regex literal prefix worth learning affinity for.
+const minAffinityTermLength = 3
+
+// Mode returns the matching mode selected by the query.
+// Regex takes precedence over Exact, which takes precedence over Fuzzy.
+func (q Query) Mode() MatchMode {
+	switch {
+	case q.Regex:
+		return MatchRegex
+	case q.Exact:
+		return MatchExact
+	case q.Fuzzy:
+		return MatchFuzzy
+	default:
+		return MatchSubstring
+	}
+}
+
+// WithMode returns a copy of the query using the given matching mode.
+func (q Query) WithMode(mode MatchMode) Query {
+	q.Fuzzy = mode == MatchFuzzy
+	q.Regex = mode == MatchRegex
+	q.Exact = mode == MatchExact
+
+	return q
+}
+
+// Validate reports whether the query term can be used, i.e. that a regex term compiles.
+func (q Query) Validate() error {
+	if !q.Regex {
+		return nil
+	}
+
+	if _, err := compilePattern(strings.TrimSpace(q.Term)); err != nil {
+		return fmt.Errorf("invalid regular expression %q: %w", strings.TrimSpace(q.Term), err)
+	}
+
+	return nil
+}
+
+// AffinityTerm returns the term under which search affinity is learned for the query.
+// Regular expressions are reduced to their leading literal (e.g. "^api-v[0-9]+" to
+// "api-v") so they share affinity with plain searches; an empty string means the query
+// is too generic to learn from.
+func (q Query) AffinityTerm() string {
+	if !q.Regex {
+		return q.Term
+	}
+
+	prefix := strings.ToLower(regexLiteralPrefix(strings.TrimSpace(q.Term)))
+	if len(prefix) < minAffinityTermLength {
+		return ""
+	}
+
+	return prefix
+}
+
+// matchSpan returns the position of the first match of a regex or exact query in value.
+// Substring and fuzzy queries are compared case-insensitively.
+func (q Query) matchSpan(value string) (start, end int, ok bool) {
+	switch q.Mode() {
+	case MatchRegex:
+		re, err := compilePattern(strings.TrimSpace(q.Term))
+		if err != nil || strings.TrimSpace(q.Term) == "" {
+			return 0, 0, false
+		}
+		loc := re.FindStringIndex(value)
+		if loc == nil {
+			return 0, 0, false
+		}
+		return loc[0], loc[1], true
+	case MatchExact:
+		if q.Matches(value) {
+			return 0, len(value), true
+		}
+		return 0, 0, false
+	default:
+		term := q.NormalizedTerm()
+		idx := strings.Index(strings.ToLower(value), term)
+		if term == "" || idx < 0 {
+			return 0, 0, false
+		}
+		return idx, idx + len(term), true
+	}
+}
+
+// maxCompiledPatterns bounds the compiled pattern cache, interactive sessions compile
+// a new pattern for every search.
+const maxCompiledPatterns = 128
+
+type compiledPattern struct {
+	re  *regexp.Regexp
+	err error
+}
+
+var (
+	compiledPatternsMu sync.Mutex
+	compiledPatterns   = make(map[string]compiledPattern)
+)
+
+// compilePattern compiles a regular expression once, as the same query is matched
+// against every field of every resource in every project.
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	compiledPatternsMu.Lock()
+	defer compiledPatternsMu.Unlock()
+
+	if cached, ok := compiledPatterns[pattern]; ok {
+		return cached.re, cached.err
+	}
+
+	if len(compiledPatterns) >= maxCompiledPatterns {
+		compiledPatterns = make(map[string]compiledPattern)
+	}
+
+	re, err := regexp.Compile(pattern)
+	compiledPatterns[pattern] = compiledPattern{re: re, err: err}
+
+	return re, err
+}
+
+// regexLiteralPrefix returns the literal text every match of pattern starts with,
+// skipping leading anchors.
+func regexLiteralPrefix(pattern string) string {
+	parsed, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return ""
+	}
+	parsed = parsed.Simplify()
+
+	parts := []*syntax.Regexp{parsed}
+	if parsed.Op == syntax.OpConcat {
+		parts = parsed.Sub
+	}
+
+	var prefix strings.Builder
+	for _, part := range parts {
+		switch part.Op {
+		case syntax.OpBeginText, syntax.OpBeginLine:
+			if prefix.Len() > 0 {
+				return prefix.String()
+			}
+		case syntax.OpLiteral:
+			prefix.WriteString(string(part.Rune))
+		default:
+			return prefix.String()
+		}
+	}
+
+	return prefix.String()
+}
@@ -0,0 +1,68 @@
+package search
+
+import "testing"
+
+func TestQueryMode(t *testing.T) {
+	for _, mode := range []MatchMode{MatchSubstring, MatchFuzzy, MatchRegex, MatchExact} {
+		query := Query{Term: "web", Fuzzy: true, Exact: true}.WithMode(mode)
+		if got := query.Mode(); got != mode {
+			t.Errorf("expected mode %s, got %s", mode, got)
+		}
+	}
+
+	if got := (Query{Fuzzy: true, Regex: true}).Mode(); got != MatchRegex {
+		t.Fatalf("expected regex to take precedence, got %s", got)
+	}
+}
+
+func TestQueryValidate(t *testing.T) {
+	if err := (Query{Term: "api-[", Regex: true}).Validate(); err == nil {
+		t.Fatal("expected invalid pattern to be rejected")
+	}
+	if err := (Query{Term: "^api-v[0-9]+-", Regex: true}).Validate(); err != nil {
+		t.Fatalf("expected valid pattern, got %v", err)
+	}
+	if err := (Query{Term: "api-["}).Validate(); err != nil {
+		t.Fatalf("substring terms are not patterns, got %v", err)
+	}
+}
+
+func TestQueryAffinityTerm(t *testing.T) {
+	tests := []struct {
+		query    Query
+		expected string
+	}{
+		{Query{Term: "web-1"}, "web-1"},
+		{Query{Term: "web-1", Exact: true}, "web-1"},
+		{Query{Term: "^api-v[0-9]+-", Regex: true}, "api-v"},
+		{Query{Term: "(?i)^Prod-DB", Regex: true}, "prod-db"},
+		{Query{Term: "^db", Regex: true}, ""},
+		{Query{Term: "web|api", Regex: true}, ""},
+		{Query{Term: ".*-prod$", Regex: true}, ""},
+		{Query{Term: "api-[", Regex: true}, ""},
+	}
+
+	for _, tt := range tests {
+		if got := tt.query.AffinityTerm(); got != tt.expected {
+			t.Errorf("%q: expected affinity term %q, got %q", tt.query.Term, tt.expected, got)
+		}
+	}
+}
+
+func TestCompilePatternCachesResults(t *testing.T) {
+	first, err := compilePattern("^cache-test-[a-z]+$")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	second, _ := compilePattern("^cache-test-[a-z]+$")
+	if first != second {
+		t.Fatal("expected the compiled pattern to be reused")
+	}
+
+	if _, err := compilePattern("cache-test-["); err == nil {
+		t.Fatal("expected compile error")
+	}
+	if _, err := compilePattern("cache-test-["); err == nil {
+		t.Fatal("expected cached compile error")
+	}
+}
@@ -0,0 +1,205 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/kedare/compass/internal/gcp"
+)
+
+// RedisClientFactory creates a Memorystore Redis client scoped to a project.
+type RedisClientFactory func(ctx context.Context, project string) (RedisClient, error)
+
+// RedisClient exposes the subset of gcp.Client used by the Redis searcher.
+type RedisClient interface {
+	ListRedisInstances(ctx context.Context) ([]*gcp.RedisInstance, error)
+}
+
+// RedisProvider searches Memorystore for Redis instances for query matches.
+type RedisProvider struct {
+	NewClient RedisClientFactory
+}
+
+// Kind returns the resource kind this provider handles.
+func (p *RedisProvider) Kind() ResourceKind {
+	return KindRedisInstance
+}
+
+// Search implements the Provider interface.
+func (p *RedisProvider) Search(ctx context.Context, project string, query Query) ([]Result, error) {
+	if p == nil || p.NewClient == nil {
+		return nil, fmt.Errorf("%s: %w", project, ErrNoProviders)
+	}
+
+	client, err := p.NewClient(ctx, project)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client for %s: %w", project, err)
+	}
+
+	instances, err := client.ListRedisInstances(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list Redis instances in %s: %w", project, err)
+	}
+
+	matches := make([]Result, 0, len(instances))
+	for _, inst := range instances {
+		if inst == nil || !query.MatchesResource(inst.Labels, inst.Name, inst.DisplayName, inst.Host, inst.ReadEndpoint) {
+			continue
+		}
+
+		matches = append(matches, Result{
+			Type:     KindRedisInstance,
+			Name:     inst.Name,
+			Project:  project,
+			Location: inst.Region,
+			Details:  redisDetails(inst),
+			Labels:   inst.Labels,
+		})
+	}
+
+	return matches, nil
+}
+
+// redisDetails extracts display metadata for a Redis instance.
+func redisDetails(inst *gcp.RedisInstance) map[string]string {
+	details := make(map[string]string)
+
+	if inst.Host != "" {
+		details["host"] = inst.Host
+		if inst.Port > 0 {
+			details["endpoint"] = fmt.Sprintf("%s:%d", inst.Host, inst.Port)
+		}
+	}
+
+	if inst.ReadEndpoint != "" {
+		details["readEndpoint"] = inst.ReadEndpoint
+	}
+
+	if inst.DisplayName != "" {
+		details["displayName"] = inst.DisplayName
+	}
+
+	if inst.Tier != "" {
+		details["tier"] = inst.Tier
+	}
+
+	if inst.RedisVersion != "" {
+		details["version"] = inst.RedisVersion
+	}
+
+	if inst.MemorySizeGB > 0 {
+		details["memory"] = fmt.Sprintf("%d GB", inst.MemorySizeGB)
+	}
+
+	if inst.Network != "" {
+		details["network"] = inst.Network
+	}
+
+	if inst.ReservedIPRange != "" {
+		details["reservedRange"] = inst.ReservedIPRange
+	}
+
+	if inst.ConnectMode != "" {
+		details["connectMode"] = inst.ConnectMode
+	}
+
+	if inst.State != "" {
+		details["state"] = inst.State
+	}
+
+	return details
+}
+
+// MemcacheClientFactory creates a Memorystore Memcached client scoped to a project.
+type MemcacheClientFactory func(ctx context.Context, project string) (MemcacheClient, error)
+
+// MemcacheClient exposes the subset of gcp.Client used by the Memcached searcher.
+type MemcacheClient interface {
+	ListMemcacheInstances(ctx context.Context) ([]*gcp.MemcacheInstance, error)
+}
+
+// MemcacheProvider searches Memorystore for Memcached instances for query matches.
+type MemcacheProvider struct {
+	NewClient MemcacheClientFactory
+}
+
+// Kind returns the resource kind this provider handles.
+func (p *MemcacheProvider) Kind() ResourceKind {
+	return KindMemcacheInstance
+}
+
+// Search implements the Provider interface.
+func (p *MemcacheProvider) Search(ctx context.Context, project string, query Query) ([]Result, error) {
+	if p == nil || p.NewClient == nil {
+		return nil, fmt.Errorf("%s: %w", project, ErrNoProviders)
+	}
+
+	client, err := p.NewClient(ctx, project)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client for %s: %w", project, err)
+	}
+
+	instances, err := client.ListMemcacheInstances(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list Memcached instances in %s: %w", project, err)
+	}
+
+	matches := make([]Result, 0, len(instances))
+	for _, inst := range instances {
+		if inst == nil {
+			continue
+		}
+
+		fields := append([]string{inst.Name, inst.DisplayName, inst.DiscoveryEndpoint}, inst.NodeHosts...)
+		if !query.MatchesResource(inst.Labels, fields...) {
+			continue
+		}
+
+		matches = append(matches, Result{
+			Type:     KindMemcacheInstance,
+			Name:     inst.Name,
+			Project:  project,
+			Location: inst.Region,
+			Details:  memcacheDetails(inst),
+			Labels:   inst.Labels,
+		})
+	}
+
+	return matches, nil
+}
+
+// memcacheDetails extracts display metadata for a Memcached instance.
+func memcacheDetails(inst *gcp.MemcacheInstance) map[string]string {
+	details := make(map[string]string)
+
+	if inst.DiscoveryEndpoint != "" {
+		details["discoveryEndpoint"] = inst.DiscoveryEndpoint
+	}
+
+	if len(inst.NodeHosts) > 0 {
+		details["nodeHosts"] = strings.Join(inst.NodeHosts, ", ")
+	}
+
+	if inst.NodeCount > 0 {
+		details["nodes"] = fmt.Sprintf("%d", inst.NodeCount)
+	}
+
+	if inst.DisplayName != "" {
+		details["displayName"] = inst.DisplayName
+	}
+
+	if inst.MemcacheVersion != "" {
+		details["version"] = inst.MemcacheVersion
+	}
+
+	if inst.Network != "" {
+		details["network"] = inst.Network
+	}
+
+	if inst.State != "" {
+		details["state"] = inst.State
+	}
+
+	return details
+}
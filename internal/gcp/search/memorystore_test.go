@@ -0,0 +1,155 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kedare/compass/internal/gcp"
+)
+
+func TestRedisProviderMatchesByHostIP(t *testing.T) {
+	client := &fakeRedisClient{instances: []*gcp.RedisInstance{
+		{Name: "orders-cache", Region: "europe-west1", Host: "10.20.0.4", Port: 6379, ReadEndpoint: "10.20.0.5", Tier: "STANDARD_HA", RedisVersion: "REDIS_7_0", MemorySizeGB: 5, Network: "prod-vpc", State: "READY"},
+		{Name: "sessions", Region: "europe-west1", Host: "10.20.0.12", Port: 6379},
+	}}
+
+	provider := &RedisProvider{NewClient: func(ctx context.Context, project string) (RedisClient, error) {
+		if project != "proj-a" {
+			t.Fatalf("unexpected project %s", project)
+		}
+		return client, nil
+	}}
+
+	results, err := provider.Search(context.Background(), "proj-a", Query{Term: "10.20.0.4"})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+
+	if len(results) != 1 || results[0].Name != "orders-cache" {
+		t.Fatalf("expected orders-cache, got %+v", results)
+	}
+
+	result := results[0]
+	if result.Type != KindRedisInstance || result.Location != "europe-west1" {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+
+	expected := map[string]string{
+		"host":         "10.20.0.4",
+		"endpoint":     "10.20.0.4:6379",
+		"readEndpoint": "10.20.0.5",
+		"tier":         "STANDARD_HA",
+		"version":      "REDIS_7_0",
+		"memory":       "5 GB",
+		"network":      "prod-vpc",
+		"state":        "READY",
+	}
+	for key, want := range expected {
+		if got := result.Details[key]; got != want {
+			t.Fatalf("expected %s=%q, got %q", key, want, got)
+		}
+	}
+
+	// The read endpoint is searchable as well
+	results, err = provider.Search(context.Background(), "proj-a", Query{Term: "10.20.0.5"})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(results) != 1 || results[0].Name != "orders-cache" {
+		t.Fatalf("expected orders-cache for read endpoint, got %+v", results)
+	}
+}
+
+func TestRedisProviderPropagatesErrors(t *testing.T) {
+	provider := &RedisProvider{NewClient: func(context.Context, string) (RedisClient, error) {
+		return nil, errors.New("client boom")
+	}}
+
+	if _, err := provider.Search(context.Background(), "proj-a", Query{Term: "foo"}); err == nil {
+		t.Fatal("expected error")
+	}
+
+	provider = &RedisProvider{NewClient: func(context.Context, string) (RedisClient, error) {
+		return &fakeRedisClient{err: errors.New("list boom")}, nil
+	}}
+
+	if _, err := provider.Search(context.Background(), "proj-a", Query{Term: "foo"}); err == nil {
+		t.Fatal("expected list error")
+	}
+
+	var nilProvider *RedisProvider
+	if _, err := nilProvider.Search(context.Background(), "proj-a", Query{Term: "foo"}); err == nil {
+		t.Fatal("expected error for nil provider")
+	}
+}
+
+func TestMemcacheProviderMatchesByNodeHost(t *testing.T) {
+	client := &fakeMemcacheClient{instances: []*gcp.MemcacheInstance{
+		{Name: "catalog-memcache", Region: "us-east1", DiscoveryEndpoint: "10.30.0.2:11211", NodeHosts: []string{"10.30.0.3", "10.30.0.4"}, NodeCount: 2, MemcacheVersion: "MEMCACHE_1_6_15", State: "READY"},
+		{Name: "other", Region: "us-east1", NodeHosts: []string{"10.30.1.3"}},
+	}}
+
+	provider := &MemcacheProvider{NewClient: func(context.Context, string) (MemcacheClient, error) {
+		return client, nil
+	}}
+
+	results, err := provider.Search(context.Background(), "proj-a", Query{Term: "10.30.0.4"})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+
+	if len(results) != 1 || results[0].Name != "catalog-memcache" {
+		t.Fatalf("expected catalog-memcache, got %+v", results)
+	}
+
+	result := results[0]
+	if result.Type != KindMemcacheInstance {
+		t.Fatalf("expected type %s, got %s", KindMemcacheInstance, result.Type)
+	}
+	if result.Details["nodeHosts"] != "10.30.0.3, 10.30.0.4" {
+		t.Fatalf("unexpected nodeHosts %q", result.Details["nodeHosts"])
+	}
+	if result.Details["discoveryEndpoint"] != "10.30.0.2:11211" || result.Details["nodes"] != "2" {
+		t.Fatalf("unexpected details %+v", result.Details)
+	}
+}
+
+func TestMemcacheProviderPropagatesErrors(t *testing.T) {
+	provider := &MemcacheProvider{NewClient: func(context.Context, string) (MemcacheClient, error) {
+		return &fakeMemcacheClient{err: errors.New("list boom")}, nil
+	}}
+
+	if _, err := provider.Search(context.Background(), "proj-a", Query{Term: "foo"}); err == nil {
+		t.Fatal("expected list error")
+	}
+
+	var nilProvider *MemcacheProvider
+	if _, err := nilProvider.Search(context.Background(), "proj-a", Query{Term: "foo"}); err == nil {
+		t.Fatal("expected error for nil provider")
+	}
+}
+
+type fakeRedisClient struct {
+	instances []*gcp.RedisInstance
+	err       error
+}
+
+func (f *fakeRedisClient) ListRedisInstances(context.Context) ([]*gcp.RedisInstance, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.instances, nil
+}
+
+type fakeMemcacheClient struct {
+	instances []*gcp.MemcacheInstance
+	err       error
+}
+
+func (f *fakeMemcacheClient) ListMemcacheInstances(context.Context) ([]*gcp.MemcacheInstance, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.instances, nil
+}
@@ -0,0 +1,376 @@
+package search
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// PluginPrefix is the file name prefix of exec-plugin providers looked up on PATH.
+	PluginPrefix = "compass-provider-"
+	// PluginPathsEnv lists additional plugin executables, separated like PATH entries.
+	PluginPathsEnv = "COMPASS_PROVIDERS"
+
+	defaultPluginTimeout  = 30 * time.Second
+	pluginDescribeTimeout = 5 * time.Second
+	// pluginStderrLimit caps how much of a failing plugin's stderr is reported.
+	pluginStderrLimit = 512
+	// pluginURLDetail is the detail key a plugin can set to give the browser action a URL.
+	pluginURLDetail = "url"
+)
+
+// Plugin is an external executable providing search results for one resource kind.
+//
+// Protocol: for every project searched, the executable is run without arguments and
+// receives the project and query on stdin:
+//
+//	{"project": "...", "query": {"term": "...", "fuzzy": false, "regex": false, "exact": false, "types": [...]}}
+//
+// It must print a JSON array of results on stdout, each result being
+// {"name": "...", "project": "...", "location": "...", "details": {...}, "labels": {...}}
+// where only name is required. A non-zero exit status is reported as a search warning
+// along with the end of stderr.
+//
+// When run with the single argument "describe", a plugin may print
+// {"kind": "...", "displayName": "..."} to choose its resource kind, which otherwise
+// defaults to "plugin.<name>".
+type Plugin struct {
+	Name        string // Executable name without PluginPrefix, e.g. "cmdb"
+	Path        string
+	Kind        ResourceKind
+	DisplayName string
+}
+
+type pluginRequest struct {
+	Project string      `json:"project"`
+	Query   pluginQuery `json:"query"`
+}
+
+type pluginQuery struct {
+	Term  string         `json:"term"`
+	Fuzzy bool           `json:"fuzzy"`
+	Regex bool           `json:"regex"`
+	Exact bool           `json:"exact"`
+	Types []ResourceKind `json:"types,omitempty"`
+}
+
+type pluginResult struct {
+	Name     string            `json:"name"`
+	Project  string            `json:"project"`
+	Location string            `json:"location"`
+	Details  map[string]string `json:"details"`
+	Labels   map[string]string `json:"labels"`
+}
+
+type pluginDescription struct {
+	Kind        ResourceKind `json:"kind"`
+	DisplayName string       `json:"displayName"`
+}
+
+// ExecProvider adapts a Plugin to the Provider interface.
+type ExecProvider struct {
+	Plugin  Plugin
+	Timeout time.Duration // Maximum run time of one invocation (defaults to 30s)
+}
+
+// NewExecProvider creates a provider running the plugin executable.
+func NewExecProvider(plugin Plugin) *ExecProvider {
+	return &ExecProvider{Plugin: plugin, Timeout: defaultPluginTimeout}
+}
+
+// Kind returns the resource kind served by the plugin.
+func (p *ExecProvider) Kind() ResourceKind {
+	return p.Plugin.Kind
+}
+
+// Search runs the plugin for the project and decodes the results it prints.
+func (p *ExecProvider) Search(ctx context.Context, project string, query Query) ([]Result, error) {
+	payload, err := json.Marshal(pluginRequest{
+		Project: project,
+		Query: pluginQuery{
+			Term:  query.Term,
+			Fuzzy: query.Fuzzy,
+			Regex: query.Regex,
+			Exact: query.Exact,
+			Types: query.Types,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode plugin request: %w", err)
+	}
+
+	stdout, err := p.run(ctx, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	var decoded []pluginResult
+	if len(bytes.TrimSpace(stdout)) > 0 {
+		if err := json.Unmarshal(stdout, &decoded); err != nil {
+			return nil, fmt.Errorf("plugin %s returned invalid JSON: %w", p.Plugin.Name, err)
+		}
+	}
+
+	results := make([]Result, 0, len(decoded))
+	for _, r := range decoded {
+		if r.Name == "" {
+			continue
+		}
+		if r.Project == "" {
+			r.Project = project
+		}
+		results = append(results, Result{
+			Type:     p.Plugin.Kind,
+			Name:     r.Name,
+			Project:  r.Project,
+			Location: r.Location,
+			Details:  r.Details,
+			Labels:   r.Labels,
+		})
+	}
+
+	return results, nil
+}
+
+// run executes the plugin with payload on stdin and returns its stdout.
+func (p *ExecProvider) run(ctx context.Context, payload []byte, args ...string) ([]byte, error) {
+	timeout := p.Timeout
+	if timeout == 0 {
+		timeout = defaultPluginTimeout
+	}
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, p.Plugin.Path, args...)
+	cmd.Stdin = bytes.NewReader(payload)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	// Do not wait forever on children that inherited the output pipes
+	cmd.WaitDelay = time.Second
+
+	if err := cmd.Run(); err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, fmt.Errorf("plugin %s: %w", p.Plugin.Name, ctxErr)
+		}
+		if msg := lastBytes(strings.TrimSpace(stderr.String()), pluginStderrLimit); msg != "" {
+			return nil, fmt.Errorf("plugin %s failed: %w: %s", p.Plugin.Name, err, msg)
+		}
+		return nil, fmt.Errorf("plugin %s failed: %w", p.Plugin.Name, err)
+	}
+
+	return stdout.Bytes(), nil
+}
+
+// lastBytes returns at most the last n bytes of s.
+func lastBytes(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return "..." + s[len(s)-n:]
+}
+
+// DiscoverPlugins returns the plugins found in the directories of pathList (a PATH value)
+// and the executables listed in configured. Configured plugins take precedence over PATH
+// ones with the same name, and earlier PATH directories over later ones.
+// Kinds are not resolved, see DescribePlugin.
+func DiscoverPlugins(pathList string, configured []string) []Plugin {
+	seen := make(map[string]struct{})
+	var plugins []Plugin
+
+	add := func(path string) {
+		name := pluginName(filepath.Base(path))
+		if name == "" {
+			return
+		}
+		if _, ok := seen[name]; ok {
+			return
+		}
+		if !isExecutableFile(path) {
+			return
+		}
+		seen[name] = struct{}{}
+		plugins = append(plugins, Plugin{Name: name, Path: path})
+	}
+
+	for _, path := range configured {
+		if path = strings.TrimSpace(path); path != "" {
+			add(path)
+		}
+	}
+
+	for _, dir := range filepath.SplitList(pathList) {
+		if dir == "" {
+			continue
+		}
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			if strings.HasPrefix(entry.Name(), PluginPrefix) {
+				add(filepath.Join(dir, entry.Name()))
+			}
+		}
+	}
+
+	sort.Slice(plugins, func(i, j int) bool { return plugins[i].Name < plugins[j].Name })
+
+	return plugins
+}
+
+// pluginName derives the plugin name from its executable file name.
+func pluginName(base string) string {
+	base = strings.TrimSuffix(base, ".exe")
+	return strings.TrimPrefix(base, PluginPrefix)
+}
+
+func isExecutableFile(path string) bool {
+	info, err := os.Stat(path)
+	if err != nil || info.IsDir() {
+		return false
+	}
+	return info.Mode().Perm()&0o111 != 0
+}
+
+// InstalledPlugins returns the plugins found on PATH and in COMPASS_PROVIDERS with their
+// default kind "plugin.<name>". The plugins are not run, which keeps it fast enough for
+// shell completion.
+func InstalledPlugins() []Plugin {
+	plugins := discoverInstalledPlugins()
+	for i := range plugins {
+		plugins[i] = withDefaultKind(plugins[i])
+	}
+
+	return plugins
+}
+
+func discoverInstalledPlugins() []Plugin {
+	return DiscoverPlugins(os.Getenv("PATH"), filepath.SplitList(os.Getenv(PluginPathsEnv)))
+}
+
+// withDefaultKind sets the kind and display name used by plugins not implementing describe.
+func withDefaultKind(plugin Plugin) Plugin {
+	plugin.Kind = ResourceKind("plugin." + plugin.Name)
+	plugin.DisplayName = plugin.Name + " (plugin)"
+
+	return plugin
+}
+
+// DescribePlugin asks the plugin for its kind and display name, falling back to
+// "plugin.<name>" when it does not implement the describe command.
+func DescribePlugin(ctx context.Context, plugin Plugin) Plugin {
+	plugin = withDefaultKind(plugin)
+
+	provider := &ExecProvider{Plugin: plugin, Timeout: pluginDescribeTimeout}
+	stdout, err := provider.run(ctx, nil, "describe")
+	if err != nil {
+		return plugin
+	}
+
+	var desc pluginDescription
+	if err := json.Unmarshal(stdout, &desc); err != nil {
+		return plugin
+	}
+	if kind := strings.TrimSpace(string(desc.Kind)); kind != "" && !strings.ContainsAny(kind, " \t,") {
+		plugin.Kind = ResourceKind(kind)
+	}
+	if desc.DisplayName != "" {
+		plugin.DisplayName = desc.DisplayName
+	}
+
+	return plugin
+}
+
+// RegisterPlugin registers the plugin as an additional resource kind.
+func RegisterPlugin(plugin Plugin) error {
+	return Register(Descriptor{
+		Kind:        plugin.Kind,
+		DisplayName: plugin.DisplayName,
+		Service:     "plugin:" + plugin.Name,
+		Scope:       ScopeMixed,
+		ConsoleURLFunc: func(_, project, _ string, details map[string]string) string {
+			if url := details[pluginURLDetail]; url != "" {
+				return url
+			}
+			return BuildConsoleURL("home/dashboard", project, nil)
+		},
+		NewProvider: func() Provider { return NewExecProvider(plugin) },
+	})
+}
+
+var (
+	pluginsOnce       sync.Once
+	pluginsRegistered []Plugin
+	pluginsErr        error
+)
+
+// RegisterPlugins discovers the plugins on PATH and in COMPASS_PROVIDERS and registers
+// them as additional resource kinds. Discovery only happens once per process, later calls
+// return the outcome of the first one. Plugins are described concurrently, so that slow
+// plugins only delay the search by one describe timeout. Plugins that could not be
+// registered (e.g. because their kind is already taken) are reported in the returned error.
+func RegisterPlugins(ctx context.Context) ([]Plugin, error) {
+	pluginsOnce.Do(func() {
+		plugins := describePlugins(ctx, discoverInstalledPlugins())
+
+		var errs []error
+		for _, plugin := range plugins {
+			if err := RegisterPlugin(plugin); err != nil {
+				errs = append(errs, fmt.Errorf("plugin %s (%s): %w", plugin.Name, plugin.Path, err))
+				continue
+			}
+			pluginsRegistered = append(pluginsRegistered, plugin)
+		}
+		pluginsErr = errors.Join(errs...)
+	})
+
+	return pluginsRegistered, pluginsErr
+}
+
+// ResolvePluginKind returns the kind a registered plugin described for itself when kind is
+// the plugin's default "plugin.<name>" kind, as offered by shell completion, and kind otherwise.
+func ResolvePluginKind(kind string) string {
+	name, ok := strings.CutPrefix(kind, "plugin.")
+	if !ok {
+		return kind
+	}
+
+	for _, plugin := range pluginsRegistered {
+		if plugin.Name == name {
+			return string(plugin.Kind)
+		}
+	}
+
+	return kind
+}
+
+// describePlugins runs DescribePlugin on all the plugins at once, keeping their order.
+func describePlugins(ctx context.Context, plugins []Plugin) []Plugin {
+	described := make([]Plugin, len(plugins))
+
+	var wg sync.WaitGroup
+	for i, plugin := range plugins {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			described[i] = DescribePlugin(ctx, plugin)
+		}()
+	}
+	wg.Wait()
+
+	return described
+}
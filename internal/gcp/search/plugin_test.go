@@ -0,0 +1,182 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// writePlugin creates an executable shell script plugin in dir.
+func writePlugin(t *testing.T, dir, name, script string) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("shell script plugins are not supported on windows")
+	}
+
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, []byte("#!/bin/sh\n"+script+"\n"), 0o755))
+
+	return path
+}
+
+// restoreRegistry removes the kinds registered during the test.
+func restoreRegistry(t *testing.T) {
+	t.Helper()
+
+	registryMu.RLock()
+	saved := append([]Descriptor(nil), descriptors...)
+	registryMu.RUnlock()
+
+	t.Cleanup(func() {
+		registryMu.Lock()
+		descriptors = saved
+		registryMu.Unlock()
+	})
+}
+
+func TestDiscoverPlugins(t *testing.T) {
+	first, second, other := t.TempDir(), t.TempDir(), t.TempDir()
+
+	cmdb := writePlugin(t, first, PluginPrefix+"cmdb", "echo '[]'")
+	writePlugin(t, second, PluginPrefix+"cmdb", "echo '[]'")
+	netbox := writePlugin(t, second, PluginPrefix+"netbox", "echo '[]'")
+	writePlugin(t, second, "unrelated-tool", "echo '[]'")
+	require.NoError(t, os.WriteFile(filepath.Join(second, PluginPrefix+"notexec"), []byte("#!/bin/sh\n"), 0o644))
+	configured := writePlugin(t, other, PluginPrefix+"netbox", "echo '[]'")
+
+	plugins := DiscoverPlugins(strings.Join([]string{first, second}, string(os.PathListSeparator)), []string{configured})
+
+	require.Equal(t, []Plugin{
+		{Name: "cmdb", Path: cmdb},
+		{Name: "netbox", Path: configured},
+	}, plugins)
+	require.NotEqual(t, netbox, plugins[1].Path, "configured plugins take precedence over PATH")
+}
+
+func TestExecProviderSearch(t *testing.T) {
+	dir := t.TempDir()
+	path := writePlugin(t, dir, PluginPrefix+"cmdb", `
+request=$(cat)
+case "$request" in
+  *'"project":"proj-a"'*'"term":"web"'*) ;;
+  *) echo "unexpected request: $request" >&2; exit 1 ;;
+esac
+echo '[{"name":"web-1","location":"dc1","details":{"owner":"ops"}},{"name":"web-2","project":"other"},{"location":"ignored"}]'`)
+
+	provider := NewExecProvider(Plugin{Name: "cmdb", Path: path, Kind: "cmdb.host"})
+	require.Equal(t, ResourceKind("cmdb.host"), provider.Kind())
+
+	results, err := provider.Search(context.Background(), "proj-a", Query{Term: "web"})
+	require.NoError(t, err)
+	require.Equal(t, []Result{
+		{Type: "cmdb.host", Name: "web-1", Project: "proj-a", Location: "dc1", Details: map[string]string{"owner": "ops"}},
+		{Type: "cmdb.host", Name: "web-2", Project: "other"},
+	}, results)
+}
+
+func TestExecProviderReportsFailures(t *testing.T) {
+	dir := t.TempDir()
+
+	failing := writePlugin(t, dir, PluginPrefix+"failing", "echo 'token expired' >&2; exit 3")
+	_, err := NewExecProvider(Plugin{Name: "failing", Path: failing}).Search(context.Background(), "proj", Query{Term: "x"})
+	require.ErrorContains(t, err, "plugin failing failed")
+	require.ErrorContains(t, err, "token expired")
+
+	invalid := writePlugin(t, dir, PluginPrefix+"invalid", "echo 'not json'")
+	_, err = NewExecProvider(Plugin{Name: "invalid", Path: invalid}).Search(context.Background(), "proj", Query{Term: "x"})
+	require.ErrorContains(t, err, "returned invalid JSON")
+
+	slow := writePlugin(t, dir, PluginPrefix+"slow", "exec sleep 5")
+	provider := &ExecProvider{Plugin: Plugin{Name: "slow", Path: slow}, Timeout: 100 * time.Millisecond}
+	start := time.Now()
+	_, err = provider.Search(context.Background(), "proj", Query{Term: "x"})
+	require.True(t, errors.Is(err, context.DeadlineExceeded), "expected deadline error, got %v", err)
+	require.Less(t, time.Since(start), 3*time.Second)
+}
+
+func TestDescribePlugin(t *testing.T) {
+	dir := t.TempDir()
+
+	described := writePlugin(t, dir, PluginPrefix+"cmdb", `
+if [ "$1" = "describe" ]; then
+  echo '{"kind":"cmdb.host","displayName":"CMDB hosts"}'
+  exit 0
+fi
+echo '[]'`)
+	plugin := DescribePlugin(context.Background(), Plugin{Name: "cmdb", Path: described})
+	require.Equal(t, ResourceKind("cmdb.host"), plugin.Kind)
+	require.Equal(t, "CMDB hosts", plugin.DisplayName)
+
+	plain := writePlugin(t, dir, PluginPrefix+"plain", "exit 1")
+	plugin = DescribePlugin(context.Background(), Plugin{Name: "plain", Path: plain})
+	require.Equal(t, ResourceKind("plugin.plain"), plugin.Kind)
+	require.Equal(t, "plain (plugin)", plugin.DisplayName)
+}
+
+func TestInstalledPluginsDoesNotRunPlugins(t *testing.T) {
+	dir := t.TempDir()
+	marker := filepath.Join(dir, "ran")
+	writePlugin(t, dir, PluginPrefix+"cmdb", "touch "+marker+"\necho '{\"kind\":\"cmdb.host\"}'")
+	t.Setenv("PATH", dir)
+	t.Setenv(PluginPathsEnv, "")
+
+	plugins := InstalledPlugins()
+	require.Len(t, plugins, 1)
+	require.Equal(t, ResourceKind("plugin.cmdb"), plugins[0].Kind)
+	require.Equal(t, "cmdb (plugin)", plugins[0].DisplayName)
+	require.NoFileExists(t, marker)
+}
+
+func TestDescribePluginsRunsConcurrently(t *testing.T) {
+	dir := t.TempDir()
+	var plugins []Plugin
+	for _, name := range []string{"a", "b", "c"} {
+		path := writePlugin(t, dir, PluginPrefix+name, "sleep 1\necho '{\"kind\":\""+name+".item\"}'")
+		plugins = append(plugins, Plugin{Name: name, Path: path})
+	}
+
+	start := time.Now()
+	described := describePlugins(context.Background(), plugins)
+	require.Less(t, time.Since(start), 2500*time.Millisecond, "plugins are described at once")
+
+	kinds := make([]ResourceKind, 0, len(described))
+	for _, plugin := range described {
+		kinds = append(kinds, plugin.Kind)
+	}
+	require.Equal(t, []ResourceKind{"a.item", "b.item", "c.item"}, kinds)
+}
+
+func TestResolvePluginKind(t *testing.T) {
+	previous := pluginsRegistered
+	pluginsRegistered = []Plugin{{Name: "cmdb", Kind: "cmdb.host"}}
+	t.Cleanup(func() { pluginsRegistered = previous })
+
+	require.Equal(t, "cmdb.host", ResolvePluginKind("plugin.cmdb"))
+	require.Equal(t, "plugin.other", ResolvePluginKind("plugin.other"))
+	require.Equal(t, "compute.instance", ResolvePluginKind("compute.instance"))
+}
+
+func TestRegisterPlugin(t *testing.T) {
+	restoreRegistry(t)
+
+	plugin := Plugin{Name: "cmdb", Path: "/bin/true", Kind: "cmdb.host", DisplayName: "CMDB hosts"}
+	require.NoError(t, RegisterPlugin(plugin))
+	require.Error(t, RegisterPlugin(plugin), "duplicate kinds are rejected")
+	require.True(t, IsValidResourceKind("cmdb.host"))
+
+	d, ok := Lookup("cmdb.host")
+	require.True(t, ok)
+	require.Equal(t, "plugin:cmdb", d.Service)
+	require.Equal(t, ResourceKind("cmdb.host"), d.NewProvider().Kind())
+
+	require.Equal(t, "https://cmdb.example.com/hosts/1",
+		ConsoleURL("cmdb.host", "web-1", "proj", "", map[string]string{"url": "https://cmdb.example.com/hosts/1"}))
+	require.Contains(t, ConsoleURL("cmdb.host", "web-1", "proj", "", nil), "home/dashboard")
+}
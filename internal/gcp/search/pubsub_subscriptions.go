@@ -0,0 +1,141 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/kedare/compass/internal/gcp"
+)
+
+// PubSubSubscriptionClientFactory creates a Pub/Sub client scoped to a project.
+type PubSubSubscriptionClientFactory func(ctx context.Context, project string) (PubSubSubscriptionClient, error)
+
+// PubSubSubscriptionClient exposes the subset of gcp.Client used by the Pub/Sub subscription searcher.
+type PubSubSubscriptionClient interface {
+	ListPubSubSubscriptions(ctx context.Context) ([]*gcp.PubSubSubscription, error)
+}
+
+// PubSubSubscriptionProvider searches Pub/Sub subscriptions for query matches.
+type PubSubSubscriptionProvider struct {
+	NewClient PubSubSubscriptionClientFactory
+}
+
+// Kind returns the resource kind this provider handles.
+func (p *PubSubSubscriptionProvider) Kind() ResourceKind {
+	return KindPubSubSubscription
+}
+
+// Search implements the Provider interface.
+func (p *PubSubSubscriptionProvider) Search(ctx context.Context, project string, query Query) ([]Result, error) {
+	if p == nil || p.NewClient == nil {
+		return nil, fmt.Errorf("%s: %w", project, ErrNoProviders)
+	}
+
+	client, err := p.NewClient(ctx, project)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client for %s: %w", project, err)
+	}
+
+	subscriptions, err := client.ListPubSubSubscriptions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list Pub/Sub subscriptions in %s: %w", project, err)
+	}
+
+	matches := make([]Result, 0, len(subscriptions))
+	for _, sub := range subscriptions {
+		if sub == nil || !query.MatchesResource(sub.Labels, sub.Name, sub.Topic, sub.PushEndpoint, sub.DeadLetterTopic, sub.Filter, sub.BigQueryTable, sub.CloudStorageBucket) {
+			continue
+		}
+
+		matches = append(matches, Result{
+			Type:     KindPubSubSubscription,
+			Name:     sub.Name,
+			Project:  project,
+			Location: "global",
+			Details:  pubSubSubscriptionDetails(project, sub),
+			Labels:   sub.Labels,
+		})
+	}
+
+	return matches, nil
+}
+
+// pubSubSubscriptionDetails extracts display metadata for a Pub/Sub subscription.
+func pubSubSubscriptionDetails(project string, sub *gcp.PubSubSubscription) map[string]string {
+	details := map[string]string{
+		"delivery": sub.DeliveryType(),
+	}
+
+	if sub.Topic != "" {
+		details["topic"] = shortTopicName(project, sub.Topic)
+	}
+
+	if sub.AckDeadlineSeconds > 0 {
+		details["ackDeadline"] = fmt.Sprintf("%ds", sub.AckDeadlineSeconds)
+	}
+
+	if sub.MessageRetentionDuration != "" {
+		details["retention"] = sub.MessageRetentionDuration
+	}
+
+	if sub.PushEndpoint != "" {
+		details["pushEndpoint"] = sub.PushEndpoint
+	}
+
+	if sub.BigQueryTable != "" {
+		details["bigqueryTable"] = sub.BigQueryTable
+	}
+
+	if sub.CloudStorageBucket != "" {
+		details["bucket"] = sub.CloudStorageBucket
+	}
+
+	if sub.DeadLetterTopic != "" {
+		details["deadLetterTopic"] = shortTopicName(project, sub.DeadLetterTopic)
+		if sub.MaxDeliveryAttempts > 0 {
+			details["maxDeliveryAttempts"] = fmt.Sprintf("%d", sub.MaxDeliveryAttempts)
+		}
+	}
+
+	if sub.Filter != "" {
+		details["filter"] = sub.Filter
+	}
+
+	if sub.EnableMessageOrdering {
+		details["ordering"] = "true"
+	}
+
+	if sub.ExactlyOnceDelivery {
+		details["exactlyOnce"] = "true"
+	}
+
+	if sub.RetainAckedMessages {
+		details["retainAcked"] = "true"
+	}
+
+	if sub.Detached {
+		details["detached"] = "true"
+	}
+
+	if sub.State != "" && sub.State != "ACTIVE" {
+		details["state"] = sub.State
+	}
+
+	return details
+}
+
+// shortTopicName converts "projects/<p>/topics/<t>" into "<t>" when the topic lives in the
+// given project, or "<p>/<t>" when it belongs to another project.
+func shortTopicName(project, topic string) string {
+	parts := strings.Split(topic, "/")
+	if len(parts) != 4 || parts[0] != "projects" || parts[2] != "topics" {
+		return topic
+	}
+
+	if parts[1] == project {
+		return parts[3]
+	}
+
+	return parts[1] + "/" + parts[3]
+}
@@ -0,0 +1,134 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kedare/compass/internal/gcp"
+)
+
+func TestPubSubSubscriptionProviderReturnsMatches(t *testing.T) {
+	client := &fakePubSubSubscriptionClient{subscriptions: []*gcp.PubSubSubscription{
+		{
+			Name:                "orders-worker",
+			Topic:               "projects/proj-a/topics/orders-events",
+			AckDeadlineSeconds:  60,
+			PushEndpoint:        "https://orders.example.com/push",
+			DeadLetterTopic:     "projects/shared/topics/dead-letters",
+			MaxDeliveryAttempts: 5,
+			Filter:              `attributes.type = "order"`,
+		},
+		{Name: "billing-worker", Topic: "projects/proj-a/topics/billing-events"},
+	}}
+
+	provider := &PubSubSubscriptionProvider{NewClient: func(ctx context.Context, project string) (PubSubSubscriptionClient, error) {
+		if project != "proj-a" {
+			t.Fatalf("unexpected project %s", project)
+		}
+		return client, nil
+	}}
+
+	results, err := provider.Search(context.Background(), "proj-a", Query{Term: "orders-worker"})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+
+	if len(results) != 1 {
+		t.Fatalf("expected 1 result, got %d", len(results))
+	}
+
+	details := results[0].Details
+	expected := map[string]string{
+		"topic":               "orders-events",
+		"delivery":            "push",
+		"ackDeadline":         "60s",
+		"pushEndpoint":        "https://orders.example.com/push",
+		"deadLetterTopic":     "shared/dead-letters",
+		"maxDeliveryAttempts": "5",
+		"filter":              `attributes.type = "order"`,
+	}
+	for key, want := range expected {
+		if details[key] != want {
+			t.Fatalf("expected %s=%q, got %q", key, want, details[key])
+		}
+	}
+}
+
+func TestPubSubSubscriptionProviderMatchesByDetailFields(t *testing.T) {
+	client := &fakePubSubSubscriptionClient{subscriptions: []*gcp.PubSubSubscription{
+		{Name: "sub-a", Topic: "projects/proj-a/topics/payments", PushEndpoint: "https://payments.run.app/"},
+		{Name: "sub-b", Topic: "projects/proj-a/topics/audit", DeadLetterTopic: "projects/proj-a/topics/audit-dlq"},
+		{Name: "sub-c", Topic: "projects/proj-a/topics/exports", BigQueryTable: "proj-a.analytics.events"},
+	}}
+
+	provider := &PubSubSubscriptionProvider{NewClient: func(ctx context.Context, project string) (PubSubSubscriptionClient, error) {
+		return client, nil
+	}}
+
+	tests := []struct {
+		term string
+		want string
+	}{
+		{"payments.run.app", "sub-a"},
+		{"audit-dlq", "sub-b"},
+		{"analytics.events", "sub-c"},
+	}
+
+	for _, tt := range tests {
+		results, err := provider.Search(context.Background(), "proj-a", Query{Term: tt.term})
+		if err != nil {
+			t.Fatalf("Search failed: %v", err)
+		}
+		if len(results) != 1 || results[0].Name != tt.want {
+			t.Fatalf("expected %s for %q, got %#v", tt.want, tt.term, results)
+		}
+	}
+}
+
+func TestPubSubSubscriptionProviderPropagatesErrors(t *testing.T) {
+	provider := &PubSubSubscriptionProvider{NewClient: func(context.Context, string) (PubSubSubscriptionClient, error) {
+		return nil, errors.New("client boom")
+	}}
+
+	if _, err := provider.Search(context.Background(), "proj-a", Query{Term: "foo"}); err == nil {
+		t.Fatal("expected error")
+	}
+
+	provider = &PubSubSubscriptionProvider{NewClient: func(context.Context, string) (PubSubSubscriptionClient, error) {
+		return &fakePubSubSubscriptionClient{err: errors.New("list boom")}, nil
+	}}
+
+	if _, err := provider.Search(context.Background(), "proj-a", Query{Term: "foo"}); err == nil {
+		t.Fatal("expected list error")
+	}
+}
+
+func TestShortTopicName(t *testing.T) {
+	tests := []struct {
+		topic string
+		want  string
+	}{
+		{"projects/proj-a/topics/orders", "orders"},
+		{"projects/other/topics/orders", "other/orders"},
+		{"_deleted-topic_", "_deleted-topic_"},
+	}
+
+	for _, tt := range tests {
+		if got := shortTopicName("proj-a", tt.topic); got != tt.want {
+			t.Errorf("shortTopicName(%q) = %q, want %q", tt.topic, got, tt.want)
+		}
+	}
+}
+
+type fakePubSubSubscriptionClient struct {
+	subscriptions []*gcp.PubSubSubscription
+	err           error
+}
+
+func (f *fakePubSubSubscriptionClient) ListPubSubSubscriptions(context.Context) ([]*gcp.PubSubSubscription, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.subscriptions, nil
+}
@@ -0,0 +1,93 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/kedare/compass/internal/gcp"
+)
+
+// PubSubTopicClientFactory creates a Pub/Sub client scoped to a project.
+type PubSubTopicClientFactory func(ctx context.Context, project string) (PubSubTopicClient, error)
+
+// PubSubTopicClient exposes the subset of gcp.Client used by the Pub/Sub topic searcher.
+type PubSubTopicClient interface {
+	ListPubSubTopics(ctx context.Context) ([]*gcp.PubSubTopic, error)
+}
+
+// PubSubTopicProvider searches Pub/Sub topics for query matches.
+type PubSubTopicProvider struct {
+	NewClient PubSubTopicClientFactory
+}
+
+// Kind returns the resource kind this provider handles.
+func (p *PubSubTopicProvider) Kind() ResourceKind {
+	return KindPubSubTopic
+}
+
+// Search implements the Provider interface.
+func (p *PubSubTopicProvider) Search(ctx context.Context, project string, query Query) ([]Result, error) {
+	if p == nil || p.NewClient == nil {
+		return nil, fmt.Errorf("%s: %w", project, ErrNoProviders)
+	}
+
+	client, err := p.NewClient(ctx, project)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client for %s: %w", project, err)
+	}
+
+	topics, err := client.ListPubSubTopics(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list Pub/Sub topics in %s: %w", project, err)
+	}
+
+	matches := make([]Result, 0, len(topics))
+	for _, topic := range topics {
+		if topic == nil || !query.MatchesResource(topic.Labels, topic.Name, topic.KMSKeyName, topic.SchemaName) {
+			continue
+		}
+
+		matches = append(matches, Result{
+			Type:     KindPubSubTopic,
+			Name:     topic.Name,
+			Project:  project,
+			Location: "global",
+			Details:  pubSubTopicDetails(topic),
+			Labels:   topic.Labels,
+		})
+	}
+
+	return matches, nil
+}
+
+// pubSubTopicDetails extracts display metadata for a Pub/Sub topic.
+func pubSubTopicDetails(topic *gcp.PubSubTopic) map[string]string {
+	details := make(map[string]string)
+
+	if topic.MessageRetentionDuration != "" {
+		details["retention"] = topic.MessageRetentionDuration
+	}
+
+	if topic.KMSKeyName != "" {
+		details["kmsKey"] = topic.KMSKeyName
+	}
+
+	if topic.SchemaName != "" {
+		details["schema"] = topic.SchemaName
+	}
+
+	if topic.SchemaEncoding != "" {
+		details["schemaEncoding"] = topic.SchemaEncoding
+	}
+
+	if len(topic.AllowedRegions) > 0 {
+		details["allowedRegions"] = strings.Join(topic.AllowedRegions, ", ")
+	}
+
+	if topic.State != "" && topic.State != "ACTIVE" {
+		details["state"] = topic.State
+	}
+
+	return details
+}
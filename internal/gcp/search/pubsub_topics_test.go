@@ -0,0 +1,109 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kedare/compass/internal/gcp"
+)
+
+func TestPubSubTopicProviderReturnsMatches(t *testing.T) {
+	client := &fakePubSubTopicClient{topics: []*gcp.PubSubTopic{
+		{Name: "orders-events", MessageRetentionDuration: "604800s", SchemaName: "order-schema", SchemaEncoding: "JSON", AllowedRegions: []string{"europe-west1", "europe-west4"}},
+		{Name: "billing-events"},
+	}}
+
+	provider := &PubSubTopicProvider{NewClient: func(ctx context.Context, project string) (PubSubTopicClient, error) {
+		if project != "proj-a" {
+			t.Fatalf("unexpected project %s", project)
+		}
+		return client, nil
+	}}
+
+	results, err := provider.Search(context.Background(), "proj-a", Query{Term: "orders"})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+
+	if len(results) != 1 {
+		t.Fatalf("expected 1 result, got %d", len(results))
+	}
+
+	if results[0].Type != KindPubSubTopic {
+		t.Fatalf("expected type %s, got %s", KindPubSubTopic, results[0].Type)
+	}
+
+	if results[0].Location != "global" {
+		t.Fatalf("expected global location, got %s", results[0].Location)
+	}
+
+	if results[0].Details["retention"] != "604800s" {
+		t.Fatalf("expected retention 604800s, got %s", results[0].Details["retention"])
+	}
+
+	if results[0].Details["schema"] != "order-schema" {
+		t.Fatalf("expected schema order-schema, got %s", results[0].Details["schema"])
+	}
+
+	if results[0].Details["allowedRegions"] != "europe-west1, europe-west4" {
+		t.Fatalf("unexpected allowedRegions %q", results[0].Details["allowedRegions"])
+	}
+}
+
+func TestPubSubTopicProviderMatchesByKMSKey(t *testing.T) {
+	client := &fakePubSubTopicClient{topics: []*gcp.PubSubTopic{
+		{Name: "secure-topic", KMSKeyName: "projects/kms/locations/global/keyRings/ring/cryptoKeys/pubsub-key"},
+		{Name: "plain-topic"},
+	}}
+
+	provider := &PubSubTopicProvider{NewClient: func(ctx context.Context, project string) (PubSubTopicClient, error) {
+		return client, nil
+	}}
+
+	results, err := provider.Search(context.Background(), "proj-a", Query{Term: "pubsub-key"})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+
+	if len(results) != 1 || results[0].Name != "secure-topic" {
+		t.Fatalf("expected secure-topic, got %#v", results)
+	}
+}
+
+func TestPubSubTopicProviderPropagatesErrors(t *testing.T) {
+	provider := &PubSubTopicProvider{NewClient: func(context.Context, string) (PubSubTopicClient, error) {
+		return nil, errors.New("client boom")
+	}}
+
+	if _, err := provider.Search(context.Background(), "proj-a", Query{Term: "foo"}); err == nil {
+		t.Fatal("expected error")
+	}
+
+	provider = &PubSubTopicProvider{NewClient: func(context.Context, string) (PubSubTopicClient, error) {
+		return &fakePubSubTopicClient{err: errors.New("list boom")}, nil
+	}}
+
+	if _, err := provider.Search(context.Background(), "proj-a", Query{Term: "foo"}); err == nil {
+		t.Fatal("expected list error")
+	}
+}
+
+func TestPubSubTopicProviderNilProvider(t *testing.T) {
+	var provider *PubSubTopicProvider
+	if _, err := provider.Search(context.Background(), "proj-a", Query{Term: "foo"}); err == nil {
+		t.Fatal("expected error for nil provider")
+	}
+}
+
+type fakePubSubTopicClient struct {
+	topics []*gcp.PubSubTopic
+	err    error
+}
+
+func (f *fakePubSubTopicClient) ListPubSubTopics(context.Context) ([]*gcp.PubSubTopic, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.topics, nil
+}
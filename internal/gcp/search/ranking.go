@@ -0,0 +1,183 @@
+package search
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/lithammer/fuzzysearch/fuzzy"
+)
+
+// Relevance tiers assigned to a result depending on how it matched the query.
+// Tiers are spaced further apart than the maximum affinity boost so that the
+// boost only reorders results within a tier.
+const (
+	ScoreExactName     = 100.0
+	ScoreNamePrefix    = 75.0
+	ScoreNameSubstring = 50.0
+	ScoreDetailsMatch  = 25.0
+	ScoreFuzzyMatch    = 10.0
+
+	// maxAffinityBoost is the upper bound of the boost derived from project affinity.
+	maxAffinityBoost = 10.0
+)
+
+// SortOrder selects how search results are ordered.
+type SortOrder string
+
+const (
+	// SortRelevance orders results by descending score (the default).
+	SortRelevance SortOrder = "relevance"
+	// SortProject orders results by project, type, name and location.
+	SortProject SortOrder = "project"
+	// SortName orders results by name, then project.
+	SortName SortOrder = "name"
+	// SortType orders results by type, then name.
+	SortType SortOrder = "type"
+)
+
+// AllSortOrders returns the supported sort orders for validation and completion.
+func AllSortOrders() []SortOrder {
+	return []SortOrder{SortRelevance, SortProject, SortName, SortType}
+}
+
+// ParseSortOrder converts a user supplied value into a SortOrder.
+// An empty value selects relevance ordering.
+func ParseSortOrder(value string) (SortOrder, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if value == "" {
+		return SortRelevance, nil
+	}
+
+	for _, order := range AllSortOrders() {
+		if string(order) == value {
+			return order, nil
+		}
+	}
+
+	return "", fmt.Errorf("invalid sort order %q (valid: relevance, project, name, type)", value)
+}
+
+// RelevanceScore ranks how well result matches the query:
+// exact name > name prefix > name substring > details or label match > fuzzy match,
+// plus a boost of up to maxAffinityBoost from the query's project affinity.
+func RelevanceScore(query Query, result Result) float64 {
+	return matchScore(query, result) + affinityBoost(query.Affinity[result.Project])
+}
+
+// matchScore returns the relevance tier of a result without any affinity boost.
+func matchScore(query Query, result Result) float64 {
+	term := query.NormalizedTerm()
+	if term == "" {
+		return 0
+	}
+
+	if query.Regex || query.Exact {
+		return patternMatchScore(query, result)
+	}
+
+	name := strings.ToLower(result.Name)
+	switch {
+	case name == term:
+		return ScoreExactName
+	case strings.HasPrefix(name, term):
+		return ScoreNamePrefix
+	case strings.Contains(name, term):
+		return ScoreNameSubstring
+	}
+
+	if query.MatchesLabels(result.Labels) {
+		return ScoreDetailsMatch
+	}
+	for _, value := range result.Details {
+		if strings.Contains(strings.ToLower(value), term) {
+			return ScoreDetailsMatch
+		}
+	}
+
+	if query.Fuzzy && fuzzy.MatchFold(term, result.Name) {
+		return ScoreFuzzyMatch
+	}
+
+	// Providers also match on fields they do not expose in details (e.g. peer ASNs),
+	// so a result that reached us without a visible match still counts as a details match
+	// unless the query is fuzzy, where a hidden match is most likely a fuzzy one.
+	if query.Fuzzy {
+		return ScoreFuzzyMatch
+	}
+
+	return ScoreDetailsMatch
+}
+
+// patternMatchScore ranks regex and exact queries by where they match the name,
+// the whole name counting as an exact match.
+func patternMatchScore(query Query, result Result) float64 {
+	start, end, ok := query.matchSpan(result.Name)
+	switch {
+	case ok && start == 0 && end == len(result.Name):
+		return ScoreExactName
+	case ok && start == 0:
+		return ScoreNamePrefix
+	case ok:
+		return ScoreNameSubstring
+	}
+
+	return ScoreDetailsMatch
+}
+
+// affinityBoost maps an unbounded affinity score to [0, maxAffinityBoost).
+func affinityBoost(affinity float64) float64 {
+	if affinity <= 0 {
+		return 0
+	}
+
+	return maxAffinityBoost * affinity / (1 + affinity)
+}
+
+// ScoreResults sets the relevance score of every result in place.
+func ScoreResults(query Query, results []Result) {
+	for i := range results {
+		results[i].Score = RelevanceScore(query, results[i])
+	}
+}
+
+// SortResults orders results in place according to order.
+func SortResults(results []Result, order SortOrder) {
+	sort.SliceStable(results, func(i, j int) bool {
+		return lessResult(results[i], results[j], order)
+	})
+}
+
+// lessResult reports whether a sorts before b for the given order.
+func lessResult(a, b Result, order SortOrder) bool {
+	switch order {
+	case SortName:
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+	case SortType:
+		if a.Type != b.Type {
+			return a.Type < b.Type
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+	case SortProject:
+		// Falls through to the project ordering below.
+	default:
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+	}
+
+	if a.Project != b.Project {
+		return a.Project < b.Project
+	}
+	if a.Type != b.Type {
+		return a.Type < b.Type
+	}
+	if a.Name != b.Name {
+		return a.Name < b.Name
+	}
+	return a.Location < b.Location
+}
@@ -0,0 +1,95 @@
+package search
+
+import "testing"
+
+func TestRelevanceScoreTiers(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    Query
+		result   Result
+		expected float64
+	}{
+		{"exact name", Query{Term: "Web-1"}, Result{Name: "web-1"}, ScoreExactName},
+		{"name prefix", Query{Term: "web"}, Result{Name: "web-1"}, ScoreNamePrefix},
+		{"name substring", Query{Term: "eb-1"}, Result{Name: "web-1"}, ScoreNameSubstring},
+		{"details match", Query{Term: "10.0.0.5"}, Result{Name: "web-1", Details: map[string]string{"internalIP": "10.0.0.5"}}, ScoreDetailsMatch},
+		{"label match", Query{Term: "env=prod"}, Result{Name: "web-1", Labels: map[string]string{"env": "prod"}}, ScoreDetailsMatch},
+		{"fuzzy match", Query{Term: "wb1", Fuzzy: true}, Result{Name: "web-1"}, ScoreFuzzyMatch},
+		{"hidden field match", Query{Term: "64512"}, Result{Name: "router-1"}, ScoreDetailsMatch},
+		{"empty term", Query{Term: " "}, Result{Name: "web-1"}, 0},
+		{"regex whole name", Query{Term: "^web-[0-9]$", Regex: true}, Result{Name: "web-1"}, ScoreExactName},
+		{"regex name prefix", Query{Term: "^web", Regex: true}, Result{Name: "web-1"}, ScoreNamePrefix},
+		{"regex name substring", Query{Term: "[0-9]$", Regex: true}, Result{Name: "web-1"}, ScoreNameSubstring},
+		{"regex details match", Query{Term: `^10\.0\.`, Regex: true}, Result{Name: "web-1"}, ScoreDetailsMatch},
+		{"exact name", Query{Term: "web-1", Exact: true}, Result{Name: "web-1"}, ScoreExactName},
+		{"exact details match", Query{Term: "10.0.0.5", Exact: true}, Result{Name: "web-1"}, ScoreDetailsMatch},
+	}
+
+	for _, tt := range tests {
+		if got := RelevanceScore(tt.query, tt.result); got != tt.expected {
+			t.Errorf("%s: expected %v got %v", tt.name, tt.expected, got)
+		}
+	}
+}
+
+func TestRelevanceScoreAffinityBoost(t *testing.T) {
+	query := Query{Term: "web", Affinity: map[string]float64{"favourite": 50}}
+
+	boosted := RelevanceScore(query, Result{Name: "web-1", Project: "favourite"})
+	plain := RelevanceScore(query, Result{Name: "web-1", Project: "other"})
+	exact := RelevanceScore(query, Result{Name: "web", Project: "other"})
+
+	if boosted <= plain {
+		t.Fatalf("expected affinity to boost the score: boosted=%v plain=%v", boosted, plain)
+	}
+	if boosted >= exact {
+		t.Fatalf("affinity must not lift a prefix match above an exact match: boosted=%v exact=%v", boosted, exact)
+	}
+}
+
+func TestSortResults(t *testing.T) {
+	results := func() []Result {
+		return []Result{
+			{Project: "b", Type: KindDisk, Name: "alpha", Score: ScoreNamePrefix},
+			{Project: "a", Type: KindComputeInstance, Name: "zulu", Score: ScoreDetailsMatch},
+			{Project: "c", Type: KindBucket, Name: "mike", Score: ScoreExactName},
+		}
+	}
+	names := func(rs []Result) string {
+		out := ""
+		for _, r := range rs {
+			out += r.Name + ","
+		}
+		return out
+	}
+
+	tests := []struct {
+		order    SortOrder
+		expected string
+	}{
+		{SortRelevance, "mike,alpha,zulu,"},
+		{SortProject, "zulu,alpha,mike,"},
+		{SortName, "alpha,mike,zulu,"},
+		{SortType, "alpha,zulu,mike,"},
+	}
+
+	for _, tt := range tests {
+		rs := results()
+		SortResults(rs, tt.order)
+		if got := names(rs); got != tt.expected {
+			t.Errorf("%s: expected %s got %s", tt.order, tt.expected, got)
+		}
+	}
+}
+
+func TestParseSortOrder(t *testing.T) {
+	if order, err := ParseSortOrder(""); err != nil || order != SortRelevance {
+		t.Fatalf("expected relevance default, got %v %v", order, err)
+	}
+	if order, err := ParseSortOrder(" Project "); err != nil || order != SortProject {
+		t.Fatalf("expected project, got %v %v", order, err)
+	}
+	if _, err := ParseSortOrder("size"); err == nil {
+		t.Fatal("expected error for unknown sort order")
+	}
+}
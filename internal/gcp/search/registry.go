@@ -0,0 +1,753 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/kedare/compass/internal/gcp"
+)
+
+// cloudConsoleBaseURL is the base URL for Google Cloud Console.
+const cloudConsoleBaseURL = "https://console.cloud.google.com"
+
+// Scope describes where resources of a kind live.
+type Scope string
+
+const (
+	// ScopeGlobal marks resources that are not tied to a region or zone.
+	ScopeGlobal Scope = "global"
+	// ScopeRegional marks resources that live in a region (or multi-region).
+	ScopeRegional Scope = "regional"
+	// ScopeZonal marks resources that live in a zone.
+	ScopeZonal Scope = "zonal"
+	// ScopeMixed marks kinds whose resources can be global, regional or zonal.
+	ScopeMixed Scope = "mixed"
+)
+
+// Action identifies an interactive action that consumers can offer on a result.
+type Action string
+
+const (
+	// ActionSSH opens an SSH session to the resource (or one of its instances).
+	ActionSSH Action = "ssh"
+	// ActionDetails shows the resource details.
+	ActionDetails Action = "details"
+	// ActionBrowser opens the resource in the Cloud Console.
+	ActionBrowser Action = "browser"
+	// ActionOpen opens the resource content itself (e.g. a bucket browser).
+	ActionOpen Action = "open"
+	// ActionRelated lists the resources related to the resource.
+	ActionRelated Action = "related"
+)
+
+// defaultActions are offered for every kind that does not declare its own.
+var defaultActions = []Action{ActionDetails, ActionBrowser}
+
+// Descriptor describes a searchable resource kind and everything consumers need to handle it.
+type Descriptor struct {
+	Kind        ResourceKind
+	DisplayName string // Human readable name, e.g. "Compute Engine instances"
+	Service     string // API service that must be enabled, e.g. "compute.googleapis.com"
+	Scope       Scope
+
+	// ConsoleURL is a Cloud Console path template, optionally followed by "?query".
+	// Supported placeholders are {name}, {project}, {location} and {details.<key>}.
+	// Query parameters that expand to an empty value are dropped.
+	ConsoleURL string
+	// ConsoleURLFunc overrides ConsoleURL for kinds whose URL depends on the result.
+	ConsoleURLFunc func(name, project, location string, details map[string]string) string
+
+	// Actions lists the interactive actions available for the kind (defaults to details and browser).
+	Actions []Action
+
+	// NewProvider creates the search provider for the kind.
+	NewProvider func() Provider
+}
+
+var (
+	registryMu  sync.RWMutex
+	descriptors []Descriptor
+)
+
+// Register adds a resource kind to the registry.
+// It returns an error if the kind is empty, has no provider or is already registered.
+func Register(d Descriptor) error {
+	if d.Kind == "" {
+		return fmt.Errorf("cannot register descriptor without a kind")
+	}
+	if d.NewProvider == nil {
+		return fmt.Errorf("cannot register %s without a provider", d.Kind)
+	}
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	for _, existing := range descriptors {
+		if existing.Kind == d.Kind {
+			return fmt.Errorf("resource kind %s is already registered", d.Kind)
+		}
+	}
+
+	descriptors = append(descriptors, d)
+
+	return nil
+}
+
+// Descriptors returns every registered resource kind in registration order.
+func Descriptors() []Descriptor {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	result := make([]Descriptor, len(descriptors))
+	copy(result, descriptors)
+
+	return result
+}
+
+// Lookup returns the descriptor registered for kind.
+func Lookup(kind ResourceKind) (Descriptor, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	for _, d := range descriptors {
+		if d.Kind == kind {
+			return d, true
+		}
+	}
+
+	return Descriptor{}, false
+}
+
+// NewProviders instantiates the provider of every registered kind.
+func NewProviders() []Provider {
+	all := Descriptors()
+	providers := make([]Provider, 0, len(all))
+	for _, d := range all {
+		providers = append(providers, d.NewProvider())
+	}
+
+	return providers
+}
+
+// ActionsFor returns the actions available for kind. Kinds covered by the
+// relationship graph also offer ActionRelated.
+func ActionsFor(kind ResourceKind) []Action {
+	actions := defaultActions
+	if d, ok := Lookup(kind); ok && len(d.Actions) > 0 {
+		actions = d.Actions
+	}
+
+	if IsRelatedKind(kind) {
+		return append(append([]Action(nil), actions...), ActionRelated)
+	}
+
+	return actions
+}
+
+// ConsoleURL returns the Cloud Console URL for a result of the given kind.
+// Unknown kinds fall back to the project dashboard.
+func ConsoleURL(kind ResourceKind, name, project, location string, details map[string]string) string {
+	d, ok := Lookup(kind)
+	switch {
+	case ok && d.ConsoleURLFunc != nil:
+		return d.ConsoleURLFunc(name, project, location, details)
+	case ok && d.ConsoleURL != "":
+		return expandConsoleURL(d.ConsoleURL, name, project, location, details)
+	default:
+		return BuildConsoleURL("home/dashboard", project, nil)
+	}
+}
+
+// BuildConsoleURL constructs a Cloud Console URL for urlPath scoped to project with extra query parameters.
+func BuildConsoleURL(urlPath, project string, params url.Values) string {
+	u, _ := url.Parse(cloudConsoleBaseURL)
+	u.Path = urlPath
+	q := u.Query()
+	for key, values := range params {
+		for _, value := range values {
+			q.Add(key, value)
+		}
+	}
+	q.Set("project", project)
+	u.RawQuery = q.Encode()
+
+	return u.String()
+}
+
+// expandConsoleURL fills the placeholders of a console URL template and builds the final URL.
+func expandConsoleURL(template, name, project, location string, details map[string]string) string {
+	expand := func(s string) string {
+		var b strings.Builder
+		for {
+			start := strings.IndexByte(s, '{')
+			if start < 0 {
+				break
+			}
+			end := strings.IndexByte(s[start:], '}')
+			if end < 0 {
+				break
+			}
+			b.WriteString(s[:start])
+
+			key := s[start+1 : start+end]
+			switch {
+			case key == "name":
+				b.WriteString(name)
+			case key == "project":
+				b.WriteString(project)
+			case key == "location":
+				b.WriteString(location)
+			case strings.HasPrefix(key, "details."):
+				b.WriteString(details[strings.TrimPrefix(key, "details.")])
+			}
+			s = s[start+end+1:]
+		}
+		b.WriteString(s)
+
+		return b.String()
+	}
+
+	urlPath, rawQuery, _ := strings.Cut(template, "?")
+
+	params := url.Values{}
+	if rawQuery != "" {
+		for _, pair := range strings.Split(rawQuery, "&") {
+			key, value, _ := strings.Cut(pair, "=")
+			if value = expand(value); value != "" {
+				params.Add(key, value)
+			}
+		}
+	}
+
+	return BuildConsoleURL(path.Clean(expand(urlPath)), project, params)
+}
+
+func init() {
+	for _, d := range builtinDescriptors() {
+		if err := Register(d); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// builtinDescriptors returns the descriptors of the resource kinds shipped with compass.
+func builtinDescriptors() []Descriptor {
+	return []Descriptor{
+		{
+			Kind:        KindComputeInstance,
+			DisplayName: "Compute Engine instances",
+			Service:     "compute.googleapis.com",
+			Scope:       ScopeZonal,
+			ConsoleURL:  "compute/instancesDetail/zones/{location}/instances/{name}",
+			Actions:     []Action{ActionSSH, ActionDetails, ActionBrowser},
+			NewProvider: func() Provider {
+				return &InstanceProvider{NewClient: func(ctx context.Context, project string) (InstanceClient, error) {
+					return gcp.NewClient(ctx, project)
+				}}
+			},
+		},
+		{
+			Kind:        KindManagedInstanceGroup,
+			DisplayName: "Managed instance groups",
+			Service:     "compute.googleapis.com",
+			Scope:       ScopeMixed,
+			ConsoleURL:  "compute/instanceGroups/details/{location}/{name}",
+			Actions:     []Action{ActionSSH, ActionDetails, ActionBrowser},
+			NewProvider: func() Provider {
+				return &MIGProvider{NewClient: func(ctx context.Context, project string) (MIGClient, error) {
+					return gcp.NewClient(ctx, project)
+				}}
+			},
+		},
+		{
+			Kind:        KindInstanceTemplate,
+			DisplayName: "Instance templates",
+			Service:     "compute.googleapis.com",
+			Scope:       ScopeMixed,
+			ConsoleURL:  "compute/instanceTemplates/details/{name}",
+			NewProvider: func() Provider {
+				return &InstanceTemplateProvider{NewClient: func(ctx context.Context, project string) (InstanceTemplateClient, error) {
+					return gcp.NewClient(ctx, project)
+				}}
+			},
+		},
+		{
+			Kind:        KindAddress,
+			DisplayName: "IP address reservations",
+			Service:     "compute.googleapis.com",
+			Scope:       ScopeMixed,
+			ConsoleURL:  "networking/addresses/list",
+			NewProvider: func() Provider {
+				return &AddressProvider{NewClient: func(ctx context.Context, project string) (AddressClient, error) {
+					return gcp.NewClient(ctx, project)
+				}}
+			},
+		},
+		{
+			Kind:        KindDisk,
+			DisplayName: "Persistent disks",
+			Service:     "compute.googleapis.com",
+			Scope:       ScopeZonal,
+			ConsoleURL:  "compute/disksDetail/zones/{location}/disks/{name}",
+			NewProvider: func() Provider {
+				return &DiskProvider{NewClient: func(ctx context.Context, project string) (DiskClient, error) {
+					return gcp.NewClient(ctx, project)
+				}}
+			},
+		},
+		{
+			Kind:        KindSnapshot,
+			DisplayName: "Disk snapshots",
+			Service:     "compute.googleapis.com",
+			Scope:       ScopeGlobal,
+			ConsoleURL:  "compute/snapshotsDetail/projects/{project}/global/snapshots/{name}",
+			NewProvider: func() Provider {
+				return &SnapshotProvider{NewClient: func(ctx context.Context, project string) (SnapshotClient, error) {
+					return gcp.NewClient(ctx, project)
+				}}
+			},
+		},
+		{
+			Kind:        KindBucket,
+			DisplayName: "Cloud Storage buckets",
+			Service:     "storage.googleapis.com",
+			Scope:       ScopeRegional,
+			ConsoleURL:  "storage/browser/{name}",
+			Actions:     []Action{ActionDetails, ActionBrowser, ActionOpen},
+			NewProvider: func() Provider {
+				return &BucketProvider{NewClient: func(ctx context.Context, project string) (BucketClient, error) {
+					return gcp.NewClient(ctx, project)
+				}}
+			},
+		},
+		{
+			Kind:        KindForwardingRule,
+			DisplayName: "Forwarding rules",
+			Service:     "compute.googleapis.com",
+			Scope:       ScopeMixed,
+			ConsoleURLFunc: func(name, project, location string, _ map[string]string) string {
+				if location == "global" {
+					return BuildConsoleURL(path.Join("net-services/loadbalancing/advanced/globalForwardingRules/details", name), project, nil)
+				}
+				return BuildConsoleURL(path.Join("net-services/loadbalancing/advanced/forwardingRules/details/regions", location, "forwardingRules", name), project, nil)
+			},
+			NewProvider: func() Provider {
+				return &ForwardingRuleProvider{NewClient: func(ctx context.Context, project string) (ForwardingRuleClient, error) {
+					return gcp.NewClient(ctx, project)
+				}}
+			},
+		},
+		{
+			Kind:        KindBackendService,
+			DisplayName: "Backend services",
+			Service:     "compute.googleapis.com",
+			Scope:       ScopeMixed,
+			ConsoleURL:  "net-services/loadbalancing/advanced/backendServices/details/{name}",
+			NewProvider: func() Provider {
+				return &BackendServiceProvider{NewClient: func(ctx context.Context, project string) (BackendServiceClient, error) {
+					return gcp.NewClient(ctx, project)
+				}}
+			},
+		},
+		{
+			Kind:        KindTargetPool,
+			DisplayName: "Target pools",
+			Service:     "compute.googleapis.com",
+			Scope:       ScopeRegional,
+			NewProvider: func() Provider {
+				return &TargetPoolProvider{NewClient: func(ctx context.Context, project string) (TargetPoolClient, error) {
+					return gcp.NewClient(ctx, project)
+				}}
+			},
+		},
+		{
+			Kind:        KindHealthCheck,
+			DisplayName: "Health checks",
+			Service:     "compute.googleapis.com",
+			Scope:       ScopeMixed,
+			ConsoleURLFunc: func(name, project, location string, _ map[string]string) string {
+				if location == "" || location == "global" {
+					return BuildConsoleURL(path.Join("compute/healthChecks/details", name), project, nil)
+				}
+				return BuildConsoleURL(path.Join("compute/healthChecks/details/regions", location, name), project, nil)
+			},
+			NewProvider: func() Provider {
+				return &HealthCheckProvider{NewClient: func(ctx context.Context, project string) (HealthCheckClient, error) {
+					return gcp.NewClient(ctx, project)
+				}}
+			},
+		},
+		{
+			Kind:        KindURLMap,
+			DisplayName: "URL maps",
+			Service:     "compute.googleapis.com",
+			Scope:       ScopeGlobal,
+			ConsoleURL:  "net-services/loadbalancing/advanced/urlMaps/details/{name}",
+			NewProvider: func() Provider {
+				return &URLMapProvider{NewClient: func(ctx context.Context, project string) (URLMapClient, error) {
+					return gcp.NewClient(ctx, project)
+				}}
+			},
+		},
+		{
+			Kind:        KindCloudSQLInstance,
+			DisplayName: "Cloud SQL instances",
+			Service:     "sqladmin.googleapis.com",
+			Scope:       ScopeRegional,
+			ConsoleURL:  "sql/instances/{name}/overview",
+			NewProvider: func() Provider {
+				return &CloudSQLProvider{NewClient: func(ctx context.Context, project string) (CloudSQLClient, error) {
+					return gcp.NewClient(ctx, project)
+				}}
+			},
+		},
+		{
+			Kind:        KindGKECluster,
+			DisplayName: "GKE clusters",
+			Service:     "container.googleapis.com",
+			Scope:       ScopeMixed,
+			ConsoleURL:  "kubernetes/clusters/details/{location}/{name}/details",
+			NewProvider: func() Provider {
+				return &GKEClusterProvider{NewClient: func(ctx context.Context, project string) (GKEClusterClient, error) {
+					return gcp.NewClient(ctx, project)
+				}}
+			},
+		},
+		{
+			Kind:        KindGKENodePool,
+			DisplayName: "GKE node pools",
+			Service:     "container.googleapis.com",
+			Scope:       ScopeMixed,
+			ConsoleURLFunc: func(name, project, location string, details map[string]string) string {
+				cluster := details["cluster"]
+				if cluster == "" {
+					cluster = "unknown"
+				}
+				return BuildConsoleURL(path.Join("kubernetes/nodepool", location, cluster, name), project, nil)
+			},
+			NewProvider: func() Provider {
+				return &GKENodePoolProvider{NewClient: func(ctx context.Context, project string) (GKENodePoolClient, error) {
+					return gcp.NewClient(ctx, project)
+				}}
+			},
+		},
+		{
+			Kind:        KindVPCNetwork,
+			DisplayName: "VPC networks",
+			Service:     "compute.googleapis.com",
+			Scope:       ScopeGlobal,
+			ConsoleURL:  "networking/networks/details/{name}",
+			NewProvider: func() Provider {
+				return &VPCNetworkProvider{NewClient: func(ctx context.Context, project string) (VPCNetworkClient, error) {
+					return gcp.NewClient(ctx, project)
+				}}
+			},
+		},
+		{
+			Kind:        KindSubnet,
+			DisplayName: "VPC subnets",
+			Service:     "compute.googleapis.com",
+			Scope:       ScopeRegional,
+			ConsoleURL:  "networking/subnetworks/details/{location}/{name}",
+			NewProvider: func() Provider {
+				return &SubnetProvider{NewClient: func(ctx context.Context, project string) (SubnetClient, error) {
+					return gcp.NewClient(ctx, project)
+				}}
+			},
+		},
+		{
+			Kind:        KindCloudRunService,
+			DisplayName: "Cloud Run services",
+			Service:     "run.googleapis.com",
+			Scope:       ScopeRegional,
+			ConsoleURL:  "run/detail/{location}/{name}/metrics",
+			NewProvider: func() Provider {
+				return &CloudRunProvider{NewClient: func(ctx context.Context, project string) (CloudRunClient, error) {
+					return gcp.NewClient(ctx, project)
+				}}
+			},
+		},
+		{
+			Kind:        KindCloudRunJob,
+			DisplayName: "Cloud Run jobs",
+			Service:     "run.googleapis.com",
+			Scope:       ScopeRegional,
+			ConsoleURL:  "run/jobs/details/{location}/{name}/executions",
+			NewProvider: func() Provider {
+				return &CloudRunJobProvider{NewClient: func(ctx context.Context, project string) (CloudRunJobClient, error) {
+					return gcp.NewClient(ctx, project)
+				}}
+			},
+		},
+		{
+			Kind:        KindCloudFunction,
+			DisplayName: "Cloud Functions",
+			Service:     "cloudfunctions.googleapis.com",
+			Scope:       ScopeRegional,
+			ConsoleURL:  "functions/details/{location}/{name}?env={details.generation}",
+			NewProvider: func() Provider {
+				return &CloudFunctionProvider{NewClient: func(ctx context.Context, project string) (CloudFunctionClient, error) {
+					return gcp.NewClient(ctx, project)
+				}}
+			},
+		},
+		{
+			Kind:        KindAppEngineService,
+			DisplayName: "App Engine services",
+			Service:     "appengine.googleapis.com",
+			Scope:       ScopeRegional,
+			ConsoleURL:  "appengine/versions?serviceId={name}",
+			NewProvider: func() Provider {
+				return &AppEngineProvider{NewClient: func(ctx context.Context, project string) (AppEngineClient, error) {
+					return gcp.NewClient(ctx, project)
+				}}
+			},
+		},
+		{
+			Kind:        KindFirewallRule,
+			DisplayName: "Firewall rules",
+			Service:     "compute.googleapis.com",
+			Scope:       ScopeGlobal,
+			ConsoleURL:  "networking/firewalls/details/{name}",
+			NewProvider: func() Provider {
+				return &FirewallRuleProvider{NewClient: func(ctx context.Context, project string) (FirewallRuleClient, error) {
+					return gcp.NewClient(ctx, project)
+				}}
+			},
+		},
+		{
+			Kind:        KindSecret,
+			DisplayName: "Secret Manager secrets",
+			Service:     "secretmanager.googleapis.com",
+			Scope:       ScopeGlobal,
+			ConsoleURL:  "security/secret-manager/secret/{name}",
+			NewProvider: func() Provider {
+				return &SecretProvider{NewClient: func(ctx context.Context, project string) (SecretClient, error) {
+					return gcp.NewClient(ctx, project)
+				}}
+			},
+		},
+		{
+			Kind:        KindVPNGateway,
+			DisplayName: "HA VPN gateways",
+			Service:     "compute.googleapis.com",
+			Scope:       ScopeRegional,
+			ConsoleURL:  "hybrid/vpn/gateways/details/{location}/{name}?isHA=true",
+			NewProvider: func() Provider {
+				return &VPNGatewayProvider{NewClient: func(ctx context.Context, project string) (VPNGatewayClient, error) {
+					return gcp.NewClient(ctx, project)
+				}}
+			},
+		},
+		{
+			Kind:        KindVPNTunnel,
+			DisplayName: "VPN tunnels",
+			Service:     "compute.googleapis.com",
+			Scope:       ScopeRegional,
+			// isHA is only set for HA VPN tunnels, Classic VPN tunnels omit it
+			ConsoleURL: "hybrid/vpn/tunnels/details/{location}/{name}?isHA={details.isHA}",
+			NewProvider: func() Provider {
+				return &VPNTunnelProvider{NewClient: func(ctx context.Context, project string) (VPNTunnelClient, error) {
+					return gcp.NewClient(ctx, project)
+				}}
+			},
+		},
+		{
+			Kind:        KindRoute,
+			DisplayName: "VPC routes",
+			Service:     "compute.googleapis.com",
+			Scope:       ScopeGlobal,
+			ConsoleURL:  "networking/routes/details/{name}",
+			NewProvider: func() Provider {
+				return &RouteProvider{NewClient: func(ctx context.Context, project string) (RouteClient, error) {
+					return gcp.NewClient(ctx, project)
+				}}
+			},
+		},
+		{
+			Kind:        KindRouter,
+			DisplayName: "Cloud Routers",
+			Service:     "compute.googleapis.com",
+			Scope:       ScopeRegional,
+			ConsoleURL:  "hybrid/routers/details/{location}/{name}",
+			NewProvider: func() Provider {
+				return &RouterProvider{NewClient: func(ctx context.Context, project string) (RouterClient, error) {
+					return gcp.NewClient(ctx, project)
+				}}
+			},
+		},
+		{
+			Kind:        KindRouterNAT,
+			DisplayName: "Cloud NAT gateways",
+			Service:     "compute.googleapis.com",
+			Scope:       ScopeRegional,
+			ConsoleURL:  "net-services/nat/details/{location}/{details.router}/{name}",
+			NewProvider: func() Provider {
+				return &RouterNATProvider{NewClient: func(ctx context.Context, project string) (RouterNATClient, error) {
+					return gcp.NewClient(ctx, project)
+				}}
+			},
+		},
+		{
+			Kind:        KindInterconnectAttachment,
+			DisplayName: "Interconnect attachments",
+			Service:     "compute.googleapis.com",
+			Scope:       ScopeRegional,
+			ConsoleURL:  "hybrid/attachments/details/{location}/{name}",
+			NewProvider: func() Provider {
+				return &InterconnectAttachmentProvider{NewClient: func(ctx context.Context, project string) (InterconnectAttachmentClient, error) {
+					return gcp.NewClient(ctx, project)
+				}}
+			},
+		},
+		{
+			Kind:        KindConnectivityTest,
+			DisplayName: "Connectivity tests",
+			Service:     "networkmanagement.googleapis.com",
+			Scope:       ScopeGlobal,
+			ConsoleURL:  "net-intelligence/connectivity/tests/details/{name}",
+			NewProvider: func() Provider {
+				return &ConnectivityTestProvider{NewClient: func(ctx context.Context, project string) (ConnectivityTestClient, error) {
+					return gcp.NewConnectivityClient(ctx, project)
+				}}
+			},
+		},
+		{
+			Kind:        KindPubSubTopic,
+			DisplayName: "Pub/Sub topics",
+			Service:     "pubsub.googleapis.com",
+			Scope:       ScopeGlobal,
+			ConsoleURL:  "cloudpubsub/topic/detail/{name}",
+			NewProvider: func() Provider {
+				return &PubSubTopicProvider{NewClient: func(ctx context.Context, project string) (PubSubTopicClient, error) {
+					return gcp.NewClient(ctx, project)
+				}}
+			},
+		},
+		{
+			Kind:        KindPubSubSubscription,
+			DisplayName: "Pub/Sub subscriptions",
+			Service:     "pubsub.googleapis.com",
+			Scope:       ScopeGlobal,
+			ConsoleURL:  "cloudpubsub/subscription/detail/{name}",
+			NewProvider: func() Provider {
+				return &PubSubSubscriptionProvider{NewClient: func(ctx context.Context, project string) (PubSubSubscriptionClient, error) {
+					return gcp.NewClient(ctx, project)
+				}}
+			},
+		},
+		{
+			Kind:        KindDNSManagedZone,
+			DisplayName: "Cloud DNS zones",
+			Service:     "dns.googleapis.com",
+			Scope:       ScopeGlobal,
+			ConsoleURL:  "net-services/dns/zones/{name}/details",
+			NewProvider: func() Provider {
+				return &DNSManagedZoneProvider{NewClient: func(ctx context.Context, project string) (DNSManagedZoneClient, error) {
+					return gcp.NewClient(ctx, project)
+				}}
+			},
+		},
+		{
+			Kind:        KindDNSRecordSet,
+			DisplayName: "Cloud DNS record sets",
+			Service:     "dns.googleapis.com",
+			Scope:       ScopeGlobal,
+			ConsoleURLFunc: func(_, project, _ string, details map[string]string) string {
+				zone := details["zone"]
+				record := details["record"]
+				recordType := details["type"]
+				if zone == "" || record == "" || recordType == "" {
+					return BuildConsoleURL("net-services/dns/zones", project, nil)
+				}
+				return BuildConsoleURL(path.Join("net-services/dns/zones", zone, "rrsets", record, recordType, "view"), project, nil)
+			},
+			NewProvider: func() Provider {
+				return &DNSRecordSetProvider{NewClient: func(ctx context.Context, project string) (DNSRecordSetClient, error) {
+					return gcp.NewClient(ctx, project)
+				}}
+			},
+		},
+		{
+			Kind:        KindServiceAccount,
+			DisplayName: "IAM service accounts",
+			Service:     "iam.googleapis.com",
+			Scope:       ScopeGlobal,
+			ConsoleURLFunc: func(_, project, _ string, details map[string]string) string {
+				if uniqueID := details["uniqueId"]; uniqueID != "" {
+					return BuildConsoleURL(path.Join("iam-admin/serviceaccounts/details", uniqueID), project, nil)
+				}
+				return BuildConsoleURL("iam-admin/serviceaccounts", project, nil)
+			},
+			NewProvider: func() Provider {
+				return &ServiceAccountProvider{NewClient: func(ctx context.Context, project string) (ServiceAccountClient, error) {
+					return gcp.NewClient(ctx, project)
+				}}
+			},
+		},
+		{
+			Kind:        KindRedisInstance,
+			DisplayName: "Memorystore for Redis instances",
+			Service:     "redis.googleapis.com",
+			Scope:       ScopeRegional,
+			ConsoleURL:  "memorystore/redis/locations/{location}/instances/{name}/details/overview",
+			NewProvider: func() Provider {
+				return &RedisProvider{NewClient: func(ctx context.Context, project string) (RedisClient, error) {
+					return gcp.NewClient(ctx, project)
+				}}
+			},
+		},
+		{
+			Kind:        KindMemcacheInstance,
+			DisplayName: "Memorystore for Memcached instances",
+			Service:     "memcache.googleapis.com",
+			Scope:       ScopeRegional,
+			ConsoleURL:  "memorystore/memcached/locations/{location}/instances/{name}/details",
+			NewProvider: func() Provider {
+				return &MemcacheProvider{NewClient: func(ctx context.Context, project string) (MemcacheClient, error) {
+					return gcp.NewClient(ctx, project)
+				}}
+			},
+		},
+		{
+			Kind:        KindFilestoreInstance,
+			DisplayName: "Filestore instances",
+			Service:     "file.googleapis.com",
+			Scope:       ScopeMixed,
+			ConsoleURL:  "filestore/instances/locations/{location}/id/{name}",
+			NewProvider: func() Provider {
+				return &FilestoreProvider{NewClient: func(ctx context.Context, project string) (FilestoreClient, error) {
+					return gcp.NewClient(ctx, project)
+				}}
+			},
+		},
+		{
+			Kind:        KindSpannerInstance,
+			DisplayName: "Spanner instances",
+			Service:     "spanner.googleapis.com",
+			Scope:       ScopeRegional,
+			ConsoleURL:  "spanner/instances/{name}/details/databases",
+			NewProvider: func() Provider {
+				return &SpannerProvider{NewClient: func(ctx context.Context, project string) (SpannerClient, error) {
+					return gcp.NewClient(ctx, project)
+				}}
+			},
+		},
+		{
+			Kind:        KindBigQueryDataset,
+			DisplayName: "BigQuery datasets",
+			Service:     "bigquery.googleapis.com",
+			Scope:       ScopeRegional,
+			ConsoleURL:  "bigquery?ws=!1m4!1m3!3m2!1s{project}!2s{name}",
+			NewProvider: func() Provider {
+				return &BigQueryDatasetProvider{NewClient: func(ctx context.Context, project string) (BigQueryClient, error) {
+					return gcp.NewClient(ctx, project)
+				}}
+			},
+		},
+	}
+}
@@ -0,0 +1,145 @@
+package search
+
+import "testing"
+
+func TestBuiltinDescriptorsAreConsistent(t *testing.T) {
+	descriptors := Descriptors()
+	if len(descriptors) == 0 {
+		t.Fatal("expected registered descriptors")
+	}
+
+	seen := make(map[ResourceKind]bool)
+	for _, d := range descriptors {
+		if seen[d.Kind] {
+			t.Fatalf("kind %s registered twice", d.Kind)
+		}
+		seen[d.Kind] = true
+
+		if d.DisplayName == "" || d.Service == "" || d.Scope == "" {
+			t.Errorf("%s: missing display name, service or scope: %+v", d.Kind, d)
+		}
+
+		provider := d.NewProvider()
+		if provider == nil {
+			t.Fatalf("%s: NewProvider returned nil", d.Kind)
+		}
+		if provider.Kind() != d.Kind {
+			t.Errorf("%s: provider reports kind %s", d.Kind, provider.Kind())
+		}
+	}
+
+	if got := len(NewProviders()); got != len(descriptors) {
+		t.Fatalf("expected %d providers, got %d", len(descriptors), got)
+	}
+}
+
+func TestRegisterRejectsInvalidDescriptors(t *testing.T) {
+	if err := Register(Descriptor{NewProvider: func() Provider { return nil }}); err == nil {
+		t.Error("expected error for descriptor without kind")
+	}
+	if err := Register(Descriptor{Kind: "test.kind"}); err == nil {
+		t.Error("expected error for descriptor without provider")
+	}
+	if err := Register(Descriptor{Kind: KindComputeInstance, NewProvider: func() Provider { return &InstanceProvider{} }}); err == nil {
+		t.Error("expected error for duplicate kind")
+	}
+}
+
+func TestLookup(t *testing.T) {
+	d, ok := Lookup(KindCloudSQLInstance)
+	if !ok {
+		t.Fatal("expected Cloud SQL descriptor")
+	}
+	if d.Service != "sqladmin.googleapis.com" || d.Scope != ScopeRegional {
+		t.Fatalf("unexpected descriptor: %+v", d)
+	}
+
+	if _, ok := Lookup("unknown.kind"); ok {
+		t.Fatal("expected unknown kind to be missing")
+	}
+}
+
+func TestActionsFor(t *testing.T) {
+	if got := ActionsFor(KindComputeInstance); len(got) != 4 || got[0] != ActionSSH || got[3] != ActionRelated {
+		t.Fatalf("unexpected instance actions: %v", got)
+	}
+	if got := ActionsFor(KindBucket); len(got) != 3 || got[2] != ActionOpen {
+		t.Fatalf("unexpected bucket actions: %v", got)
+	}
+	if got := ActionsFor(KindSecret); len(got) != 2 || got[0] != ActionDetails || got[1] != ActionBrowser {
+		t.Fatalf("unexpected default actions: %v", got)
+	}
+	if got := ActionsFor(KindSubnet); len(got) != 3 || got[2] != ActionRelated {
+		t.Fatalf("unexpected subnet actions: %v", got)
+	}
+	if got := ActionsFor("unknown.kind"); len(got) != 2 {
+		t.Fatalf("unexpected actions for unknown kind: %v", got)
+	}
+}
+
+func TestConsoleURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		kind     ResourceKind
+		resource string
+		location string
+		details  map[string]string
+		expected string
+	}{
+		{
+			"instance", KindComputeInstance, "web-1", "us-central1-a", nil,
+			"https://console.cloud.google.com/compute/instancesDetail/zones/us-central1-a/instances/web-1?project=proj",
+		},
+		{
+			"snapshot uses project placeholder", KindSnapshot, "snap", "global", nil,
+			"https://console.cloud.google.com/compute/snapshotsDetail/projects/proj/global/snapshots/snap?project=proj",
+		},
+		{
+			"function with generation", KindCloudFunction, "fn", "europe-west1", map[string]string{"generation": "gen2"},
+			"https://console.cloud.google.com/functions/details/europe-west1/fn?env=gen2&project=proj",
+		},
+		{
+			"function without generation drops empty query", KindCloudFunction, "fn", "europe-west1", nil,
+			"https://console.cloud.google.com/functions/details/europe-west1/fn?project=proj",
+		},
+		{
+			"classic VPN tunnel", KindVPNTunnel, "tun", "us-east1", nil,
+			"https://console.cloud.google.com/hybrid/vpn/tunnels/details/us-east1/tun?project=proj",
+		},
+		{
+			"HA VPN tunnel", KindVPNTunnel, "tun", "us-east1", map[string]string{"isHA": "true"},
+			"https://console.cloud.google.com/hybrid/vpn/tunnels/details/us-east1/tun?isHA=true&project=proj",
+		},
+		{
+			"router NAT uses details", KindRouterNAT, "nat", "us-east1", map[string]string{"router": "rtr"},
+			"https://console.cloud.google.com/net-services/nat/details/us-east1/rtr/nat?project=proj",
+		},
+		{
+			"regional forwarding rule", KindForwardingRule, "fr", "us-east1", nil,
+			"https://console.cloud.google.com/net-services/loadbalancing/advanced/forwardingRules/details/regions/us-east1/forwardingRules/fr?project=proj",
+		},
+		{
+			"record set without zone", KindDNSRecordSet, "www.example.com.", "global", nil,
+			"https://console.cloud.google.com/net-services/dns/zones?project=proj",
+		},
+		{
+			"record set", KindDNSRecordSet, "www.example.com. A", "public-site",
+			map[string]string{"zone": "public-site", "record": "www.example.com.", "type": "A"},
+			"https://console.cloud.google.com/net-services/dns/zones/public-site/rrsets/www.example.com./A/view?project=proj",
+		},
+		{
+			"kind without console page", KindTargetPool, "pool", "us-east1", nil,
+			"https://console.cloud.google.com/home/dashboard?project=proj",
+		},
+		{
+			"unknown kind", "unknown.kind", "x", "", nil,
+			"https://console.cloud.google.com/home/dashboard?project=proj",
+		},
+	}
+
+	for _, tt := range tests {
+		if got := ConsoleURL(tt.kind, tt.resource, "proj", tt.location, tt.details); got != tt.expected {
+			t.Errorf("%s: ConsoleURL() = %q, want %q", tt.name, got, tt.expected)
+		}
+	}
+}
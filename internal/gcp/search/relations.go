@@ -0,0 +1,462 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/kedare/compass/internal/gcp"
+)
+
+// DefaultRelatedDepth is the default number of hops shown around a resource.
+const DefaultRelatedDepth = 2
+
+// ResourceRef identifies a resource within a project.
+type ResourceRef struct {
+	Kind     ResourceKind
+	Name     string
+	Location string
+}
+
+// String renders the reference as "kind name (location)".
+func (r ResourceRef) String() string {
+	if r.Location == "" {
+		return fmt.Sprintf("%s %s", r.Kind, r.Name)
+	}
+	return fmt.Sprintf("%s %s (%s)", r.Kind, r.Name, r.Location)
+}
+
+// Relation is a directed edge between two resources. Label describes To as seen from From.
+type Relation struct {
+	From  ResourceRef
+	To    ResourceRef
+	Label string
+}
+
+// RelatedNode is a resource in the neighbourhood tree returned by Graph.Tree.
+type RelatedNode struct {
+	Resource ResourceRef
+	// Relation describes how the resource relates to its parent, empty for the root.
+	Relation string
+	// Missing is set for resources that are referenced but were not found in the project,
+	// for example subnets of a Shared VPC host project.
+	Missing  bool
+	Children []*RelatedNode
+}
+
+// Inventory holds the resources of a project used to build the relationship graph.
+type Inventory struct {
+	Instances       []*gcp.Instance
+	MIGs            []gcp.ManagedInstanceGroup
+	Templates       []*gcp.InstanceTemplate
+	Disks           []*gcp.Disk
+	Snapshots       []*gcp.Snapshot
+	ForwardingRules []*gcp.ForwardingRule
+	BackendServices []*gcp.BackendService
+	TargetPools     []*gcp.TargetPool
+	HealthChecks    []*gcp.HealthCheck
+	URLMaps         []*gcp.URLMap
+	Networks        []*gcp.VPCNetwork
+	Subnets         []*gcp.Subnet
+	FirewallRules   []*gcp.FirewallRule
+}
+
+// InventoryClient exposes the subset of gcp.Client used to load an Inventory.
+type InventoryClient interface {
+	ListInstances(ctx context.Context, zone string) ([]*gcp.Instance, error)
+	ListManagedInstanceGroups(ctx context.Context, location string) ([]gcp.ManagedInstanceGroup, error)
+	ListInstanceTemplates(ctx context.Context) ([]*gcp.InstanceTemplate, error)
+	ListDisks(ctx context.Context) ([]*gcp.Disk, error)
+	ListSnapshots(ctx context.Context) ([]*gcp.Snapshot, error)
+	ListForwardingRules(ctx context.Context) ([]*gcp.ForwardingRule, error)
+	ListBackendServices(ctx context.Context) ([]*gcp.BackendService, error)
+	ListTargetPools(ctx context.Context) ([]*gcp.TargetPool, error)
+	ListHealthChecks(ctx context.Context) ([]*gcp.HealthCheck, error)
+	ListURLMaps(ctx context.Context) ([]*gcp.URLMap, error)
+	ListVPCNetworks(ctx context.Context) ([]*gcp.VPCNetwork, error)
+	ListSubnets(ctx context.Context) ([]*gcp.Subnet, error)
+	ListFirewallRules(ctx context.Context) ([]*gcp.FirewallRule, error)
+}
+
+// RelatedKinds returns the resource kinds covered by the relationship graph.
+func RelatedKinds() []ResourceKind {
+	return []ResourceKind{
+		KindComputeInstance,
+		KindManagedInstanceGroup,
+		KindInstanceTemplate,
+		KindDisk,
+		KindSnapshot,
+		KindForwardingRule,
+		KindBackendService,
+		KindTargetPool,
+		KindHealthCheck,
+		KindURLMap,
+		KindVPCNetwork,
+		KindSubnet,
+		KindFirewallRule,
+	}
+}
+
+// IsRelatedKind reports whether the relationship graph covers the kind.
+func IsRelatedKind(kind ResourceKind) bool {
+	for _, k := range RelatedKinds() {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// LoadInventory lists the resources needed for the relationship graph concurrently.
+// Resource types that fail to load are left empty and their errors are joined in the
+// returned error, so callers can still use the partial inventory.
+func LoadInventory(ctx context.Context, client InventoryClient) (*Inventory, error) {
+	inv := &Inventory{}
+
+	var mu sync.Mutex
+	var errs []error
+	var wg sync.WaitGroup
+
+	load := func(name string, fn func() error) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := fn(); err != nil {
+				mu.Lock()
+				errs = append(errs, fmt.Errorf("failed to list %s: %w", name, err))
+				mu.Unlock()
+			}
+		}()
+	}
+
+	load("instances", func() (err error) { inv.Instances, err = client.ListInstances(ctx, ""); return })
+	load("managed instance groups", func() (err error) { inv.MIGs, err = client.ListManagedInstanceGroups(ctx, ""); return })
+	load("instance templates", func() (err error) { inv.Templates, err = client.ListInstanceTemplates(ctx); return })
+	load("disks", func() (err error) { inv.Disks, err = client.ListDisks(ctx); return })
+	load("snapshots", func() (err error) { inv.Snapshots, err = client.ListSnapshots(ctx); return })
+	load("forwarding rules", func() (err error) { inv.ForwardingRules, err = client.ListForwardingRules(ctx); return })
+	load("backend services", func() (err error) { inv.BackendServices, err = client.ListBackendServices(ctx); return })
+	load("target pools", func() (err error) { inv.TargetPools, err = client.ListTargetPools(ctx); return })
+	load("health checks", func() (err error) { inv.HealthChecks, err = client.ListHealthChecks(ctx); return })
+	load("URL maps", func() (err error) { inv.URLMaps, err = client.ListURLMaps(ctx); return })
+	load("networks", func() (err error) { inv.Networks, err = client.ListVPCNetworks(ctx); return })
+	load("subnets", func() (err error) { inv.Subnets, err = client.ListSubnets(ctx); return })
+	load("firewall rules", func() (err error) { inv.FirewallRules, err = client.ListFirewallRules(ctx); return })
+
+	wg.Wait()
+
+	sort.Slice(errs, func(i, j int) bool { return errs[i].Error() < errs[j].Error() })
+
+	return inv, errors.Join(errs...)
+}
+
+// Graph is an undirected view of the relations between the resources of a project.
+type Graph struct {
+	known  map[ResourceRef]bool
+	byName map[string][]ResourceRef
+	edges  map[ResourceRef][]Relation
+	seen   map[Relation]bool
+}
+
+// BuildGraph extracts the relations between the resources of an inventory from the
+// references they hold to each other (attached disks, MIG membership, templates,
+// load balancer targets, networks and firewall target tags).
+func BuildGraph(inv *Inventory) *Graph {
+	g := &Graph{
+		known:  make(map[ResourceRef]bool),
+		byName: make(map[string][]ResourceRef),
+		edges:  make(map[ResourceRef][]Relation),
+		seen:   make(map[Relation]bool),
+	}
+	if inv == nil {
+		return g
+	}
+
+	// Register every listed resource first so references can be resolved to them.
+	for _, inst := range inv.Instances {
+		g.add(ResourceRef{KindComputeInstance, inst.Name, inst.Zone})
+	}
+	for _, mig := range inv.MIGs {
+		g.add(ResourceRef{KindManagedInstanceGroup, mig.Name, mig.Location})
+	}
+	for _, tmpl := range inv.Templates {
+		g.add(ResourceRef{KindInstanceTemplate, tmpl.Name, "global"})
+	}
+	for _, disk := range inv.Disks {
+		g.add(ResourceRef{KindDisk, disk.Name, disk.Zone})
+	}
+	for _, snap := range inv.Snapshots {
+		g.add(ResourceRef{KindSnapshot, snap.Name, "global"})
+	}
+	for _, fr := range inv.ForwardingRules {
+		g.add(ResourceRef{KindForwardingRule, fr.Name, fr.Region})
+	}
+	for _, bs := range inv.BackendServices {
+		g.add(ResourceRef{KindBackendService, bs.Name, bs.Region})
+	}
+	for _, pool := range inv.TargetPools {
+		g.add(ResourceRef{KindTargetPool, pool.Name, pool.Region})
+	}
+	for _, hc := range inv.HealthChecks {
+		g.add(ResourceRef{KindHealthCheck, hc.Name, "global"})
+	}
+	for _, urlMap := range inv.URLMaps {
+		g.add(ResourceRef{KindURLMap, urlMap.Name, "global"})
+	}
+	for _, network := range inv.Networks {
+		g.add(ResourceRef{KindVPCNetwork, network.Name, "global"})
+	}
+	for _, subnet := range inv.Subnets {
+		g.add(ResourceRef{KindSubnet, subnet.Name, subnet.Region})
+	}
+	for _, rule := range inv.FirewallRules {
+		g.add(ResourceRef{KindFirewallRule, rule.Name, "global"})
+	}
+
+	for _, inst := range inv.Instances {
+		ref := ResourceRef{KindComputeInstance, inst.Name, inst.Zone}
+		for _, disk := range inst.Disks {
+			if disk.Name == "" {
+				continue
+			}
+			label := "disk"
+			if disk.Boot {
+				label = "boot disk"
+			}
+			g.link(ref, g.resolve(KindDisk, disk.Name, inst.Zone), label, "attached to")
+		}
+		if inst.MIGName != "" {
+			g.link(ref, g.resolve(KindManagedInstanceGroup, inst.MIGName, inst.Zone), "member of", "instance")
+		}
+		if inst.Subnetwork != "" {
+			g.link(ref, g.resolve(KindSubnet, inst.Subnetwork, regionOf(inst.Zone)), "subnet", "instance")
+		}
+	}
+
+	for _, mig := range inv.MIGs {
+		if mig.InstanceTemplate != "" {
+			g.link(ResourceRef{KindManagedInstanceGroup, mig.Name, mig.Location},
+				g.resolve(KindInstanceTemplate, mig.InstanceTemplate, "global"), "template", "used by")
+		}
+	}
+
+	for _, snap := range inv.Snapshots {
+		if snap.SourceDisk != "" {
+			g.link(g.resolve(KindDisk, snap.SourceDisk, snap.SourceDiskLocation), ResourceRef{KindSnapshot, snap.Name, "global"}, "snapshot", "snapshot of")
+		}
+	}
+
+	for _, fr := range inv.ForwardingRules {
+		ref := ResourceRef{KindForwardingRule, fr.Name, fr.Region}
+		if fr.BackendService != "" {
+			g.link(ref, g.resolve(KindBackendService, fr.BackendService, fr.Region), "backend service", "forwarding rule")
+		}
+		if fr.TargetType == "targetPools" && fr.Target != "" {
+			g.link(ref, g.resolve(KindTargetPool, fr.Target, fr.Region), "target pool", "forwarding rule")
+		}
+	}
+
+	for _, bs := range inv.BackendServices {
+		ref := ResourceRef{KindBackendService, bs.Name, bs.Region}
+		for _, hc := range bs.HealthChecks {
+			g.link(ref, g.resolve(KindHealthCheck, hc, "global"), "health check", "used by")
+		}
+		// Backends can also be unmanaged groups or NEGs, so only MIGs we know about are linked.
+		for _, group := range bs.Backends {
+			if mig, ok := g.lookup(KindManagedInstanceGroup, group.Name, group.Location); ok {
+				g.link(ref, mig, "backend", "backend of")
+			}
+		}
+	}
+
+	for _, pool := range inv.TargetPools {
+		ref := ResourceRef{KindTargetPool, pool.Name, pool.Region}
+		for _, instance := range pool.Instances {
+			g.link(ref, g.resolve(KindComputeInstance, instance, pool.Region), "instance", "target pool")
+		}
+	}
+
+	for _, urlMap := range inv.URLMaps {
+		if urlMap.DefaultService != "" {
+			g.link(ResourceRef{KindURLMap, urlMap.Name, "global"},
+				g.resolve(KindBackendService, urlMap.DefaultService, "global"), "default service", "URL map")
+		}
+	}
+
+	for _, subnet := range inv.Subnets {
+		if subnet.Network != "" {
+			g.link(ResourceRef{KindSubnet, subnet.Name, subnet.Region},
+				g.resolve(KindVPCNetwork, subnet.Network, "global"), "network", "subnet")
+		}
+	}
+
+	for _, rule := range inv.FirewallRules {
+		ruleRef := ResourceRef{KindFirewallRule, rule.Name, "global"}
+		if rule.Network != "" {
+			g.link(g.resolve(KindVPCNetwork, rule.Network, "global"), ruleRef, "firewall rule", "network")
+		}
+		if len(rule.TargetTags) == 0 {
+			continue
+		}
+
+		// Rules with target tags apply to the instances carrying one of the tags,
+		// and through them to the subnets those instances live in.
+		for _, inst := range inv.Instances {
+			if inst.Network != rule.Network || !sharesTag(inst.NetworkTags, rule.TargetTags) {
+				continue
+			}
+			g.link(ResourceRef{KindComputeInstance, inst.Name, inst.Zone}, ruleRef, "firewall rule", "applies to")
+			if inst.Subnetwork != "" {
+				g.link(g.resolve(KindSubnet, inst.Subnetwork, regionOf(inst.Zone)), ruleRef, "firewall rule (via tags)", "applies to subnet")
+			}
+		}
+	}
+
+	return g
+}
+
+// Find returns the known resources of the given kind and name.
+func (g *Graph) Find(kind ResourceKind, name string) []ResourceRef {
+	var found []ResourceRef
+	for _, ref := range g.byName[nameKey(kind, name)] {
+		if g.known[ref] {
+			found = append(found, ref)
+		}
+	}
+	return found
+}
+
+// Relations returns the relations of a resource, ordered by label, kind and name.
+func (g *Graph) Relations(ref ResourceRef) []Relation {
+	relations := append([]Relation(nil), g.edges[ref]...)
+	sort.SliceStable(relations, func(i, j int) bool {
+		a, b := relations[i], relations[j]
+		if a.Label != b.Label {
+			return a.Label < b.Label
+		}
+		if a.To.Kind != b.To.Kind {
+			return a.To.Kind < b.To.Kind
+		}
+		if a.To.Name != b.To.Name {
+			return a.To.Name < b.To.Name
+		}
+		return a.To.Location < b.To.Location
+	})
+	return relations
+}
+
+// Tree returns the neighbourhood of root up to depth hops. Each resource appears once,
+// at its shortest distance from the root.
+func (g *Graph) Tree(root ResourceRef, depth int) *RelatedNode {
+	if depth <= 0 {
+		depth = DefaultRelatedDepth
+	}
+
+	rootNode := &RelatedNode{Resource: root, Missing: !g.known[root]}
+	visited := map[ResourceRef]bool{root: true}
+
+	level := []*RelatedNode{rootNode}
+	for d := 0; d < depth && len(level) > 0; d++ {
+		var next []*RelatedNode
+		for _, node := range level {
+			for _, rel := range g.Relations(node.Resource) {
+				if visited[rel.To] {
+					continue
+				}
+				visited[rel.To] = true
+
+				child := &RelatedNode{Resource: rel.To, Relation: rel.Label, Missing: !g.known[rel.To]}
+				node.Children = append(node.Children, child)
+				next = append(next, child)
+			}
+		}
+		level = next
+	}
+
+	return rootNode
+}
+
+// add registers a resource listed in the inventory.
+func (g *Graph) add(ref ResourceRef) {
+	if g.known[ref] {
+		return
+	}
+	g.known[ref] = true
+	key := nameKey(ref.Kind, ref.Name)
+	g.byName[key] = append(g.byName[key], ref)
+}
+
+// link records a relation in both directions.
+func (g *Graph) link(from, to ResourceRef, label, reverseLabel string) {
+	if from == to {
+		return
+	}
+	forward := Relation{From: from, To: to, Label: label}
+	if g.seen[forward] {
+		return
+	}
+	g.seen[forward] = true
+	g.edges[from] = append(g.edges[from], forward)
+
+	backward := Relation{From: to, To: from, Label: reverseLabel}
+	if !g.seen[backward] {
+		g.seen[backward] = true
+		g.edges[to] = append(g.edges[to], backward)
+	}
+}
+
+// lookup finds a known resource by kind and name, preferring the one in location
+// (or in the region of a zonal location) when several share the name.
+func (g *Graph) lookup(kind ResourceKind, name, location string) (ResourceRef, bool) {
+	candidates := g.byName[nameKey(kind, name)]
+	if len(candidates) == 0 {
+		return ResourceRef{}, false
+	}
+	for _, ref := range candidates {
+		if ref.Location == location {
+			return ref, true
+		}
+	}
+	for _, ref := range candidates {
+		if location != "" && ref.Location == regionOf(location) {
+			return ref, true
+		}
+	}
+	return candidates[0], true
+}
+
+// resolve returns the known resource a reference points to, or a reference to a
+// resource missing from the inventory.
+func (g *Graph) resolve(kind ResourceKind, name, location string) ResourceRef {
+	if ref, ok := g.lookup(kind, name, location); ok {
+		return ref
+	}
+	return ResourceRef{Kind: kind, Name: name, Location: location}
+}
+
+func nameKey(kind ResourceKind, name string) string {
+	return string(kind) + "/" + name
+}
+
+// regionOf returns the region of a zone name (e.g. "us-central1-a" -> "us-central1").
+func regionOf(location string) string {
+	if idx := strings.LastIndex(location, "-"); idx > 0 && strings.Count(location, "-") >= 2 {
+		return location[:idx]
+	}
+	return location
+}
+
+// sharesTag reports whether both tag lists have at least one tag in common.
+func sharesTag(tags, targets []string) bool {
+	for _, tag := range tags {
+		for _, target := range targets {
+			if tag == target {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,252 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/kedare/compass/internal/gcp"
+)
+
+func testInventory() *Inventory {
+	return &Inventory{
+		Instances: []*gcp.Instance{{
+			Name:        "web-1",
+			Zone:        "us-central1-a",
+			Network:     "prod",
+			Subnetwork:  "default",
+			NetworkTags: []string{"web"},
+			MIGName:     "web-mig",
+			Disks:       []gcp.InstanceDisk{{Name: "web-1", Boot: true}},
+		}},
+		MIGs:      []gcp.ManagedInstanceGroup{{Name: "web-mig", Location: "us-central1", InstanceTemplate: "web-tmpl"}},
+		Templates: []*gcp.InstanceTemplate{{Name: "web-tmpl"}},
+		Disks:     []*gcp.Disk{{Name: "web-1", Zone: "us-central1-a"}},
+		Snapshots: []*gcp.Snapshot{{Name: "web-1-daily", SourceDisk: "web-1", SourceDiskLocation: "us-central1-a"}},
+		ForwardingRules: []*gcp.ForwardingRule{
+			{Name: "web-ilb", Region: "us-central1", BackendService: "web-bs"},
+			{Name: "legacy", Region: "us-central1", Target: "pool", TargetType: "targetPools"},
+		},
+		BackendServices: []*gcp.BackendService{{Name: "web-bs", Region: "us-central1", HealthChecks: []string{"web-hc"}, Backends: []gcp.BackendGroup{{Name: "web-mig", Location: "us-central1"}, {Name: "some-neg", Location: "us-central1-a"}}}},
+		HealthChecks:    []*gcp.HealthCheck{{Name: "web-hc"}},
+		Networks:        []*gcp.VPCNetwork{{Name: "prod"}},
+		Subnets: []*gcp.Subnet{
+			{Name: "default", Region: "us-central1", Network: "prod"},
+			{Name: "default", Region: "europe-west1", Network: "prod"},
+		},
+		FirewallRules: []*gcp.FirewallRule{
+			{Name: "allow-web", Network: "prod", TargetTags: []string{"web"}},
+			{Name: "allow-db", Network: "prod", TargetTags: []string{"db"}},
+		},
+	}
+}
+
+func relationLabels(g *Graph, ref ResourceRef) map[string]string {
+	labels := make(map[string]string)
+	for _, rel := range g.Relations(ref) {
+		labels[rel.To.String()] = rel.Label
+	}
+	return labels
+}
+
+func TestBuildGraphInstanceRelations(t *testing.T) {
+	g := BuildGraph(testInventory())
+
+	instance := ResourceRef{KindComputeInstance, "web-1", "us-central1-a"}
+	labels := relationLabels(g, instance)
+
+	expected := map[string]string{
+		"compute.disk web-1 (us-central1-a)":   "boot disk",
+		"compute.mig web-mig (us-central1)":    "member of",
+		"compute.subnet default (us-central1)": "subnet",
+		"compute.firewall allow-web (global)":  "firewall rule",
+	}
+	for target, label := range expected {
+		if labels[target] != label {
+			t.Errorf("expected %s to be %q, got %q (all: %v)", target, label, labels[target], labels)
+		}
+	}
+	if len(labels) != len(expected) {
+		t.Errorf("unexpected relations: %v", labels)
+	}
+}
+
+func TestBuildGraphLoadBalancerRelations(t *testing.T) {
+	g := BuildGraph(testInventory())
+
+	labels := relationLabels(g, ResourceRef{KindBackendService, "web-bs", "us-central1"})
+	if labels["compute.healthCheck web-hc (global)"] != "health check" {
+		t.Errorf("expected health check relation, got %v", labels)
+	}
+	if labels["compute.mig web-mig (us-central1)"] != "backend" {
+		t.Errorf("expected MIG backend relation, got %v", labels)
+	}
+	if labels["compute.forwardingRule web-ilb (us-central1)"] != "forwarding rule" {
+		t.Errorf("expected reverse forwarding rule relation, got %v", labels)
+	}
+	for target := range labels {
+		if strings.Contains(target, "some-neg") {
+			t.Errorf("expected unknown backend group to be ignored, got %v", labels)
+		}
+	}
+
+	tree := g.Tree(ResourceRef{KindForwardingRule, "legacy", "us-central1"}, 1)
+	if len(tree.Children) != 1 || !tree.Children[0].Missing || tree.Children[0].Resource.Kind != KindTargetPool {
+		t.Fatalf("expected a missing target pool child, got %+v", tree.Children)
+	}
+}
+
+func TestBuildGraphResolvesSameNameResourcesByLocation(t *testing.T) {
+	g := BuildGraph(&Inventory{
+		Disks: []*gcp.Disk{{Name: "data", Zone: "us-central1-a"}, {Name: "data", Zone: "europe-west1-b"}},
+		Snapshots: []*gcp.Snapshot{
+			{Name: "data-eu", SourceDisk: "data", SourceDiskLocation: "europe-west1-b"},
+		},
+		MIGs: []gcp.ManagedInstanceGroup{{Name: "web-mig", Location: "us-central1"}, {Name: "web-mig", Location: "europe-west1"}},
+		BackendServices: []*gcp.BackendService{
+			{Name: "web-bs", Region: "global", Backends: []gcp.BackendGroup{{Name: "web-mig", Location: "europe-west1"}}},
+		},
+	})
+
+	labels := relationLabels(g, ResourceRef{KindSnapshot, "data-eu", "global"})
+	if labels["compute.disk data (europe-west1-b)"] != "snapshot of" || len(labels) != 1 {
+		t.Errorf("expected snapshot of the europe-west1-b disk only, got %v", labels)
+	}
+
+	labels = relationLabels(g, ResourceRef{KindBackendService, "web-bs", "global"})
+	if labels["compute.mig web-mig (europe-west1)"] != "backend" || len(labels) != 1 {
+		t.Errorf("expected the europe-west1 MIG backend only, got %v", labels)
+	}
+}
+
+func TestBuildGraphSubnetFirewallViaTags(t *testing.T) {
+	g := BuildGraph(testInventory())
+
+	labels := relationLabels(g, ResourceRef{KindSubnet, "default", "us-central1"})
+	if labels["compute.firewall allow-web (global)"] != "firewall rule (via tags)" {
+		t.Errorf("expected tag based firewall relation, got %v", labels)
+	}
+	if _, ok := labels["compute.firewall allow-db (global)"]; ok {
+		t.Errorf("expected unrelated tag rule to be excluded, got %v", labels)
+	}
+
+	other := relationLabels(g, ResourceRef{KindSubnet, "default", "europe-west1"})
+	if _, ok := other["compute.firewall allow-web (global)"]; ok {
+		t.Errorf("expected subnet without tagged instances to have no tag rules, got %v", other)
+	}
+}
+
+func TestGraphTree(t *testing.T) {
+	g := BuildGraph(testInventory())
+
+	roots := g.Find(KindComputeInstance, "web-1")
+	if len(roots) != 1 {
+		t.Fatalf("expected one instance, got %v", roots)
+	}
+	if len(g.Find(KindSubnet, "default")) != 2 {
+		t.Fatal("expected subnets sharing a name to both be found")
+	}
+
+	tree := g.Tree(roots[0], 2)
+	var disk *RelatedNode
+	for _, child := range tree.Children {
+		if child.Resource.Kind == KindDisk {
+			disk = child
+		}
+	}
+	if disk == nil {
+		t.Fatalf("expected disk child, got %+v", tree.Children)
+	}
+	if len(disk.Children) != 1 || disk.Children[0].Resource.Name != "web-1-daily" || disk.Children[0].Relation != "snapshot" {
+		t.Fatalf("expected snapshot below the disk, got %+v", disk.Children)
+	}
+
+	// Resources appear once, so the firewall rule reached directly is not repeated below the subnet.
+	count := 0
+	var walk func(node *RelatedNode)
+	walk = func(node *RelatedNode) {
+		if node.Resource.Name == "allow-web" {
+			count++
+		}
+		for _, child := range node.Children {
+			walk(child)
+		}
+	}
+	walk(tree)
+	if count != 1 {
+		t.Fatalf("expected allow-web to appear once, got %d", count)
+	}
+}
+
+func TestLoadInventoryKeepsPartialResults(t *testing.T) {
+	client := &fakeInventoryClient{
+		instances: []*gcp.Instance{{Name: "web-1"}},
+		diskErr:   errors.New("boom"),
+	}
+
+	inv, err := LoadInventory(context.Background(), client)
+	if err == nil || !strings.Contains(err.Error(), "failed to list disks") {
+		t.Fatalf("expected disk listing error, got %v", err)
+	}
+	if inv == nil || len(inv.Instances) != 1 {
+		t.Fatalf("expected partial inventory, got %+v", inv)
+	}
+}
+
+type fakeInventoryClient struct {
+	instances []*gcp.Instance
+	diskErr   error
+}
+
+func (f *fakeInventoryClient) ListInstances(context.Context, string) ([]*gcp.Instance, error) {
+	return f.instances, nil
+}
+
+func (f *fakeInventoryClient) ListManagedInstanceGroups(context.Context, string) ([]gcp.ManagedInstanceGroup, error) {
+	return nil, nil
+}
+
+func (f *fakeInventoryClient) ListInstanceTemplates(context.Context) ([]*gcp.InstanceTemplate, error) {
+	return nil, nil
+}
+
+func (f *fakeInventoryClient) ListDisks(context.Context) ([]*gcp.Disk, error) {
+	return nil, f.diskErr
+}
+
+func (f *fakeInventoryClient) ListSnapshots(context.Context) ([]*gcp.Snapshot, error) {
+	return nil, nil
+}
+
+func (f *fakeInventoryClient) ListForwardingRules(context.Context) ([]*gcp.ForwardingRule, error) {
+	return nil, nil
+}
+
+func (f *fakeInventoryClient) ListBackendServices(context.Context) ([]*gcp.BackendService, error) {
+	return nil, nil
+}
+
+func (f *fakeInventoryClient) ListTargetPools(context.Context) ([]*gcp.TargetPool, error) {
+	return nil, nil
+}
+
+func (f *fakeInventoryClient) ListHealthChecks(context.Context) ([]*gcp.HealthCheck, error) {
+	return nil, nil
+}
+
+func (f *fakeInventoryClient) ListURLMaps(context.Context) ([]*gcp.URLMap, error) {
+	return nil, nil
+}
+
+func (f *fakeInventoryClient) ListVPCNetworks(context.Context) ([]*gcp.VPCNetwork, error) {
+	return nil, nil
+}
+
+func (f *fakeInventoryClient) ListSubnets(context.Context) ([]*gcp.Subnet, error) {
+	return nil, nil
+}
+
+func (f *fakeInventoryClient) ListFirewallRules(context.Context) ([]*gcp.FirewallRule, error) {
+	return nil, nil
+}
@@ -0,0 +1,225 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/kedare/compass/internal/gcp"
+)
+
+// RouterClientFactory creates a Cloud Router client scoped to a project.
+type RouterClientFactory func(ctx context.Context, project string) (RouterClient, error)
+
+// RouterClient exposes the subset of gcp.Client used by the Cloud Router searcher.
+type RouterClient interface {
+	ListRouters(ctx context.Context) ([]*gcp.Router, error)
+}
+
+// RouterProvider searches Cloud Routers for query matches.
+type RouterProvider struct {
+	NewClient RouterClientFactory
+}
+
+// Kind returns the resource kind this provider handles.
+func (p *RouterProvider) Kind() ResourceKind {
+	return KindRouter
+}
+
+// Search implements the Provider interface.
+func (p *RouterProvider) Search(ctx context.Context, project string, query Query) ([]Result, error) {
+	if p == nil || p.NewClient == nil {
+		return nil, fmt.Errorf("%s: %w", project, ErrNoProviders)
+	}
+
+	client, err := p.NewClient(ctx, project)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client for %s: %w", project, err)
+	}
+
+	routers, err := client.ListRouters(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list routers in %s: %w", project, err)
+	}
+
+	matches := make([]Result, 0, len(routers))
+	for _, router := range routers {
+		if router == nil || !query.MatchesAny(routerSearchFields(router)...) {
+			continue
+		}
+
+		matches = append(matches, Result{
+			Type:     KindRouter,
+			Name:     router.Name,
+			Project:  project,
+			Location: router.Region,
+			Details:  routerDetails(router),
+		})
+	}
+
+	return matches, nil
+}
+
+// routerSearchFields returns the values a router can be matched on, including its ASN
+// and the ASNs and addresses of its BGP peers.
+func routerSearchFields(router *gcp.Router) []string {
+	fields := []string{router.Name, router.Network, router.Description}
+	if router.ASN > 0 {
+		fields = append(fields, fmt.Sprintf("%d", router.ASN))
+	}
+
+	for _, peer := range router.BGPPeers {
+		fields = append(fields, peer.Name, peer.PeerIPAddress, peer.IPAddress)
+		if peer.PeerASN > 0 {
+			fields = append(fields, fmt.Sprintf("%d", peer.PeerASN))
+		}
+	}
+
+	return fields
+}
+
+// routerDetails extracts display metadata for a Cloud Router.
+func routerDetails(router *gcp.Router) map[string]string {
+	details := make(map[string]string)
+
+	if router.Network != "" {
+		details["network"] = router.Network
+	}
+
+	if router.ASN > 0 {
+		details["asn"] = fmt.Sprintf("%d", router.ASN)
+	}
+
+	if router.AdvertiseMode != "" {
+		details["advertiseMode"] = router.AdvertiseMode
+	}
+
+	if len(router.AdvertisedGroups) > 0 {
+		details["advertisedGroups"] = strings.Join(router.AdvertisedGroups, ", ")
+	}
+
+	if len(router.AdvertisedRanges) > 0 {
+		details["advertisedRanges"] = strings.Join(router.AdvertisedRanges, ", ")
+	}
+
+	if router.Interfaces > 0 {
+		details["interfaces"] = fmt.Sprintf("%d", router.Interfaces)
+	}
+
+	if len(router.BGPPeers) > 0 {
+		peers := make([]string, 0, len(router.BGPPeers))
+		for _, peer := range router.BGPPeers {
+			peers = append(peers, fmt.Sprintf("%s (AS%d %s)", peer.Name, peer.PeerASN, peer.PeerIPAddress))
+		}
+		details["bgpPeers"] = strings.Join(peers, ", ")
+	}
+
+	if len(router.NATs) > 0 {
+		details["nats"] = strings.Join(router.NATs, ", ")
+	}
+
+	if router.Description != "" {
+		details["description"] = router.Description
+	}
+
+	return details
+}
+
+// RouterNATClientFactory creates a Cloud NAT client scoped to a project.
+type RouterNATClientFactory func(ctx context.Context, project string) (RouterNATClient, error)
+
+// RouterNATClient exposes the subset of gcp.Client used by the Cloud NAT searcher.
+type RouterNATClient interface {
+	ListRouterNATs(ctx context.Context) ([]*gcp.RouterNAT, error)
+}
+
+// RouterNATProvider searches Cloud NAT gateways for query matches.
+type RouterNATProvider struct {
+	NewClient RouterNATClientFactory
+}
+
+// Kind returns the resource kind this provider handles.
+func (p *RouterNATProvider) Kind() ResourceKind {
+	return KindRouterNAT
+}
+
+// Search implements the Provider interface.
+func (p *RouterNATProvider) Search(ctx context.Context, project string, query Query) ([]Result, error) {
+	if p == nil || p.NewClient == nil {
+		return nil, fmt.Errorf("%s: %w", project, ErrNoProviders)
+	}
+
+	client, err := p.NewClient(ctx, project)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client for %s: %w", project, err)
+	}
+
+	nats, err := client.ListRouterNATs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list Cloud NAT gateways in %s: %w", project, err)
+	}
+
+	matches := make([]Result, 0, len(nats))
+	for _, nat := range nats {
+		if nat == nil {
+			continue
+		}
+
+		fields := append([]string{nat.Name, nat.Router, nat.Network}, nat.NATIPs...)
+		fields = append(fields, nat.NATIPResources...)
+		if !query.MatchesAny(fields...) {
+			continue
+		}
+
+		matches = append(matches, Result{
+			Type:     KindRouterNAT,
+			Name:     nat.Name,
+			Project:  project,
+			Location: nat.Region,
+			Details:  routerNATDetails(nat),
+		})
+	}
+
+	return matches, nil
+}
+
+// routerNATDetails extracts display metadata for a Cloud NAT gateway.
+func routerNATDetails(nat *gcp.RouterNAT) map[string]string {
+	details := map[string]string{
+		"router": nat.Router,
+	}
+
+	if nat.Network != "" {
+		details["network"] = nat.Network
+	}
+
+	if nat.IPAllocateOption != "" {
+		details["allocation"] = nat.IPAllocateOption
+	}
+
+	if len(nat.NATIPs) > 0 {
+		details["natIPs"] = strings.Join(nat.NATIPs, ", ")
+	}
+
+	if len(nat.NATIPResources) > 0 {
+		details["natIPAddresses"] = strings.Join(nat.NATIPResources, ", ")
+	}
+
+	if nat.SourceSubnetworkRanges != "" {
+		details["sourceRanges"] = nat.SourceSubnetworkRanges
+	}
+
+	if nat.MinPortsPerVM > 0 {
+		details["minPortsPerVM"] = fmt.Sprintf("%d", nat.MinPortsPerVM)
+	}
+
+	if nat.EndpointIndependent {
+		details["endpointIndependentMapping"] = "true"
+	}
+
+	if nat.LogFilter != "" {
+		details["logging"] = nat.LogFilter
+	}
+
+	return details
+}
@@ -0,0 +1,180 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kedare/compass/internal/gcp"
+)
+
+func TestRouterProviderMatchesByASN(t *testing.T) {
+	client := &fakeRouterClient{routers: []*gcp.Router{
+		{
+			Name: "hybrid-router", Region: "europe-west1", Network: "prod-vpc", ASN: 64512, AdvertiseMode: "CUSTOM",
+			AdvertisedGroups: []string{"ALL_SUBNETS"}, AdvertisedRanges: []string{"10.0.0.0/8"}, Interfaces: 2,
+			BGPPeers: []gcp.RouterBGPPeer{{Name: "onprem-peer", PeerASN: 65010, PeerIPAddress: "169.254.10.2", IPAddress: "169.254.10.1"}},
+			NATs:     []string{"egress-nat"},
+		},
+		{Name: "nat-router", Region: "us-central1", Network: "dev-vpc", ASN: 64513},
+	}}
+
+	provider := &RouterProvider{NewClient: func(ctx context.Context, project string) (RouterClient, error) {
+		if project != "proj-a" {
+			t.Fatalf("unexpected project %s", project)
+		}
+		return client, nil
+	}}
+
+	results, err := provider.Search(context.Background(), "proj-a", Query{Term: "64512"})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+
+	if len(results) != 1 || results[0].Name != "hybrid-router" {
+		t.Fatalf("expected hybrid-router, got %+v", results)
+	}
+
+	result := results[0]
+	if result.Type != KindRouter || result.Location != "europe-west1" {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+
+	expected := map[string]string{
+		"network":          "prod-vpc",
+		"asn":              "64512",
+		"advertiseMode":    "CUSTOM",
+		"advertisedGroups": "ALL_SUBNETS",
+		"advertisedRanges": "10.0.0.0/8",
+		"interfaces":       "2",
+		"bgpPeers":         "onprem-peer (AS65010 169.254.10.2)",
+		"nats":             "egress-nat",
+	}
+	for key, want := range expected {
+		if got := result.Details[key]; got != want {
+			t.Fatalf("expected %s=%q, got %q", key, want, got)
+		}
+	}
+
+	// Peer ASNs are searchable as well
+	results, err = provider.Search(context.Background(), "proj-a", Query{Term: "65010"})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(results) != 1 || results[0].Name != "hybrid-router" {
+		t.Fatalf("expected hybrid-router for peer ASN, got %+v", results)
+	}
+}
+
+func TestRouterProviderPropagatesErrors(t *testing.T) {
+	provider := &RouterProvider{NewClient: func(context.Context, string) (RouterClient, error) {
+		return nil, errors.New("client boom")
+	}}
+
+	if _, err := provider.Search(context.Background(), "proj-a", Query{Term: "foo"}); err == nil {
+		t.Fatal("expected error")
+	}
+
+	provider = &RouterProvider{NewClient: func(context.Context, string) (RouterClient, error) {
+		return &fakeRouterClient{err: errors.New("list boom")}, nil
+	}}
+
+	if _, err := provider.Search(context.Background(), "proj-a", Query{Term: "foo"}); err == nil {
+		t.Fatal("expected list error")
+	}
+
+	var nilProvider *RouterProvider
+	if _, err := nilProvider.Search(context.Background(), "proj-a", Query{Term: "foo"}); err == nil {
+		t.Fatal("expected error for nil provider")
+	}
+}
+
+func TestRouterNATProviderMatchesByNATIP(t *testing.T) {
+	client := &fakeRouterNATClient{nats: []*gcp.RouterNAT{
+		{Name: "egress-nat", Router: "hybrid-router", Region: "europe-west1", Network: "prod-vpc", IPAllocateOption: "MANUAL_ONLY", NATIPs: []string{"34.1.2.3", "34.1.2.4"}, NATIPResources: []string{"nat-ip-1", "nat-ip-2"}, SourceSubnetworkRanges: "ALL_SUBNETWORKS_ALL_IP_RANGES", MinPortsPerVM: 64, EndpointIndependent: true, LogFilter: "ERRORS_ONLY"},
+		{Name: "auto-nat", Router: "nat-router", Region: "us-central1", IPAllocateOption: "AUTO_ONLY", NATIPs: []string{"35.9.9.9"}},
+	}}
+
+	provider := &RouterNATProvider{NewClient: func(context.Context, string) (RouterNATClient, error) {
+		return client, nil
+	}}
+
+	results, err := provider.Search(context.Background(), "proj-a", Query{Term: "34.1.2.4"})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+
+	if len(results) != 1 || results[0].Name != "egress-nat" {
+		t.Fatalf("expected egress-nat, got %+v", results)
+	}
+
+	result := results[0]
+	if result.Type != KindRouterNAT || result.Location != "europe-west1" {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+
+	expected := map[string]string{
+		"router":                     "hybrid-router",
+		"network":                    "prod-vpc",
+		"allocation":                 "MANUAL_ONLY",
+		"natIPs":                     "34.1.2.3, 34.1.2.4",
+		"natIPAddresses":             "nat-ip-1, nat-ip-2",
+		"sourceRanges":               "ALL_SUBNETWORKS_ALL_IP_RANGES",
+		"minPortsPerVM":              "64",
+		"endpointIndependentMapping": "true",
+		"logging":                    "ERRORS_ONLY",
+	}
+	for key, want := range expected {
+		if got := result.Details[key]; got != want {
+			t.Fatalf("expected %s=%q, got %q", key, want, got)
+		}
+	}
+
+	// Auto-allocated IPs come from the router status and are searchable too
+	results, err = provider.Search(context.Background(), "proj-a", Query{Term: "35.9.9.9"})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(results) != 1 || results[0].Name != "auto-nat" {
+		t.Fatalf("expected auto-nat, got %+v", results)
+	}
+}
+
+func TestRouterNATProviderPropagatesErrors(t *testing.T) {
+	provider := &RouterNATProvider{NewClient: func(context.Context, string) (RouterNATClient, error) {
+		return &fakeRouterNATClient{err: errors.New("list boom")}, nil
+	}}
+
+	if _, err := provider.Search(context.Background(), "proj-a", Query{Term: "foo"}); err == nil {
+		t.Fatal("expected list error")
+	}
+
+	var nilProvider *RouterNATProvider
+	if _, err := nilProvider.Search(context.Background(), "proj-a", Query{Term: "foo"}); err == nil {
+		t.Fatal("expected error for nil provider")
+	}
+}
+
+type fakeRouterClient struct {
+	routers []*gcp.Router
+	err     error
+}
+
+func (f *fakeRouterClient) ListRouters(context.Context) ([]*gcp.Router, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.routers, nil
+}
+
+type fakeRouterNATClient struct {
+	nats []*gcp.RouterNAT
+	err  error
+}
+
+func (f *fakeRouterNATClient) ListRouterNATs(context.Context) ([]*gcp.RouterNAT, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.nats, nil
+}
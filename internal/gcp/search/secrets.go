@@ -43,7 +43,7 @@ func (p *SecretProvider) Search(ctx context.Context, project string, query Query
 
 	matches := make([]Result, 0, len(secrets))
 	for _, secret := range secrets {
-		if secret == nil || !query.MatchesAny(secret.Name, secret.Replication) {
+		if secret == nil || !query.MatchesResource(secret.Labels, secret.Name, secret.Replication) {
 			continue
 		}
 
@@ -53,6 +53,7 @@ func (p *SecretProvider) Search(ctx context.Context, project string, query Query
 			Project:  project,
 			Location: "global",
 			Details:  secretDetails(secret),
+			Labels:   secret.Labels,
 		})
 	}
 
@@ -0,0 +1,113 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kedare/compass/internal/gcp"
+)
+
+// ServiceAccountClientFactory creates an IAM client scoped to a project.
+type ServiceAccountClientFactory func(ctx context.Context, project string) (ServiceAccountClient, error)
+
+// ServiceAccountClient exposes the subset of gcp.Client used by the service account searcher.
+type ServiceAccountClient interface {
+	ListServiceAccounts(ctx context.Context) ([]*gcp.ServiceAccount, error)
+	ServiceAccountKeySummary(ctx context.Context, accounts []*gcp.ServiceAccount) error
+}
+
+// ServiceAccountProvider searches IAM service accounts for query matches.
+type ServiceAccountProvider struct {
+	NewClient ServiceAccountClientFactory
+	// Now returns the reference time used to compute key ages (defaults to time.Now).
+	Now func() time.Time
+}
+
+// Kind returns the resource kind this provider handles.
+func (p *ServiceAccountProvider) Kind() ResourceKind {
+	return KindServiceAccount
+}
+
+// Search implements the Provider interface.
+func (p *ServiceAccountProvider) Search(ctx context.Context, project string, query Query) ([]Result, error) {
+	if p == nil || p.NewClient == nil {
+		return nil, fmt.Errorf("%s: %w", project, ErrNoProviders)
+	}
+
+	client, err := p.NewClient(ctx, project)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client for %s: %w", project, err)
+	}
+
+	accounts, err := client.ListServiceAccounts(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list service accounts in %s: %w", project, err)
+	}
+
+	now := time.Now()
+	if p.Now != nil {
+		now = p.Now()
+	}
+
+	matched := make([]*gcp.ServiceAccount, 0, len(accounts))
+	for _, account := range accounts {
+		if account == nil || !query.MatchesAny(account.Email, account.DisplayName, account.Description, account.UniqueID) {
+			continue
+		}
+		matched = append(matched, account)
+	}
+
+	// Keys take one call per account, only list them for the matches
+	if err := client.ServiceAccountKeySummary(ctx, matched); err != nil {
+		return nil, fmt.Errorf("failed to list service account keys in %s: %w", project, err)
+	}
+
+	matches := make([]Result, 0, len(matched))
+	for _, account := range matched {
+		matches = append(matches, Result{
+			Type:     KindServiceAccount,
+			Name:     account.Email,
+			Project:  project,
+			Location: "global",
+			Details:  serviceAccountDetails(account, now),
+		})
+	}
+
+	return matches, nil
+}
+
+// serviceAccountDetails extracts display metadata for a service account. Key details are
+// left out when the keys could not be listed, rather than showing the account without keys.
+func serviceAccountDetails(account *gcp.ServiceAccount, now time.Time) map[string]string {
+	details := map[string]string{
+		"disabled": fmt.Sprintf("%t", account.Disabled),
+	}
+
+	if account.KeysKnown {
+		details["keys"] = fmt.Sprintf("%d", account.KeyCount)
+	}
+
+	if account.DisplayName != "" {
+		details["displayName"] = account.DisplayName
+	}
+
+	if account.Description != "" {
+		details["description"] = account.Description
+	}
+
+	if account.UniqueID != "" {
+		details["uniqueId"] = account.UniqueID
+	}
+
+	if account.KeysKnown && !account.OldestKeyCreated.IsZero() {
+		days := int(now.Sub(account.OldestKeyCreated).Hours() / 24)
+		if days < 0 {
+			days = 0
+		}
+		details["oldestKeyAge"] = fmt.Sprintf("%dd", days)
+		details["oldestKeyCreated"] = account.OldestKeyCreated.UTC().Format("2006-01-02")
+	}
+
+	return details
+}
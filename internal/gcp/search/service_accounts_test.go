@@ -0,0 +1,163 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kedare/compass/internal/gcp"
+)
+
+func TestServiceAccountProviderReturnsMatches(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	client := &fakeServiceAccountClient{accounts: []*gcp.ServiceAccount{
+		{
+			Email:            "svc-foo@proj-a.iam.gserviceaccount.com",
+			DisplayName:      "Foo worker",
+			UniqueID:         "1122334455",
+			KeyCount:         2,
+			OldestKeyCreated: now.AddDate(0, 0, -400),
+		},
+		{Email: "svc-bar@proj-a.iam.gserviceaccount.com", Disabled: true},
+	}}
+
+	provider := &ServiceAccountProvider{
+		NewClient: func(ctx context.Context, project string) (ServiceAccountClient, error) {
+			if project != "proj-a" {
+				t.Fatalf("unexpected project %s", project)
+			}
+			return client, nil
+		},
+		Now: func() time.Time { return now },
+	}
+
+	results, err := provider.Search(context.Background(), "proj-a", Query{Term: "svc-foo"})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+
+	if len(results) != 1 {
+		t.Fatalf("expected 1 result, got %d", len(results))
+	}
+
+	if results[0].Type != KindServiceAccount {
+		t.Fatalf("expected type %s, got %s", KindServiceAccount, results[0].Type)
+	}
+
+	if results[0].Name != "svc-foo@proj-a.iam.gserviceaccount.com" {
+		t.Fatalf("unexpected name %s", results[0].Name)
+	}
+
+	if len(client.summarized) != 1 || client.summarized[0] != "svc-foo@proj-a.iam.gserviceaccount.com" {
+		t.Fatalf("expected keys to be listed for the match only, got %v", client.summarized)
+	}
+
+	expected := map[string]string{
+		"displayName":      "Foo worker",
+		"disabled":         "false",
+		"keys":             "2",
+		"oldestKeyAge":     "400d",
+		"oldestKeyCreated": "2024-04-27",
+		"uniqueId":         "1122334455",
+	}
+	for key, want := range expected {
+		if results[0].Details[key] != want {
+			t.Fatalf("expected %s=%q, got %q", key, want, results[0].Details[key])
+		}
+	}
+}
+
+func TestServiceAccountProviderWithoutKeys(t *testing.T) {
+	client := &fakeServiceAccountClient{accounts: []*gcp.ServiceAccount{
+		{Email: "svc-bar@proj-a.iam.gserviceaccount.com", DisplayName: "Legacy batch", Disabled: true},
+	}}
+
+	provider := &ServiceAccountProvider{NewClient: func(ctx context.Context, project string) (ServiceAccountClient, error) {
+		return client, nil
+	}}
+
+	results, err := provider.Search(context.Background(), "proj-a", Query{Term: "legacy"})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+
+	if len(results) != 1 {
+		t.Fatalf("expected match on display name, got %d results", len(results))
+	}
+
+	if results[0].Details["disabled"] != "true" || results[0].Details["keys"] != "0" {
+		t.Fatalf("unexpected details %#v", results[0].Details)
+	}
+
+	if _, ok := results[0].Details["oldestKeyAge"]; ok {
+		t.Fatal("expected no oldestKeyAge without keys")
+	}
+}
+
+func TestServiceAccountProviderWithUnknownKeys(t *testing.T) {
+	client := &fakeServiceAccountClient{
+		accounts: []*gcp.ServiceAccount{{Email: "svc-foo@proj-a.iam.gserviceaccount.com"}},
+		keysErr:  map[string]bool{"svc-foo@proj-a.iam.gserviceaccount.com": true},
+	}
+
+	provider := &ServiceAccountProvider{NewClient: func(context.Context, string) (ServiceAccountClient, error) {
+		return client, nil
+	}}
+
+	results, err := provider.Search(context.Background(), "proj-a", Query{Term: "svc-foo"})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+
+	if len(results) != 1 {
+		t.Fatalf("expected 1 result, got %d", len(results))
+	}
+
+	for _, key := range []string{"keys", "oldestKeyAge", "oldestKeyCreated"} {
+		if _, ok := results[0].Details[key]; ok {
+			t.Fatalf("expected no %s when keys could not be listed, got %#v", key, results[0].Details)
+		}
+	}
+}
+
+func TestServiceAccountProviderPropagatesErrors(t *testing.T) {
+	provider := &ServiceAccountProvider{NewClient: func(context.Context, string) (ServiceAccountClient, error) {
+		return nil, errors.New("client boom")
+	}}
+
+	if _, err := provider.Search(context.Background(), "proj-a", Query{Term: "foo"}); err == nil {
+		t.Fatal("expected error")
+	}
+
+	provider = &ServiceAccountProvider{NewClient: func(context.Context, string) (ServiceAccountClient, error) {
+		return &fakeServiceAccountClient{err: errors.New("list boom")}, nil
+	}}
+
+	if _, err := provider.Search(context.Background(), "proj-a", Query{Term: "foo"}); err == nil {
+		t.Fatal("expected list error")
+	}
+}
+
+type fakeServiceAccountClient struct {
+	accounts []*gcp.ServiceAccount
+	err      error
+	// keysErr lists the accounts whose keys cannot be listed.
+	keysErr    map[string]bool
+	summarized []string
+}
+
+func (f *fakeServiceAccountClient) ServiceAccountKeySummary(_ context.Context, accounts []*gcp.ServiceAccount) error {
+	for _, account := range accounts {
+		f.summarized = append(f.summarized, account.Email)
+		account.KeysKnown = !f.keysErr[account.Email]
+	}
+	return nil
+}
+
+func (f *fakeServiceAccountClient) ListServiceAccounts(context.Context) ([]*gcp.ServiceAccount, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.accounts, nil
+}
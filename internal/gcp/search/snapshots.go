@@ -43,7 +43,7 @@ func (p *SnapshotProvider) Search(ctx context.Context, project string, query Que
 
 	matches := make([]Result, 0, len(snapshots))
 	for _, snapshot := range snapshots {
-		if snapshot == nil || !query.MatchesAny(snapshot.Name, snapshot.SourceDisk) {
+		if snapshot == nil || !query.MatchesResource(snapshot.Labels, snapshot.Name, snapshot.SourceDisk) {
 			continue
 		}
 
@@ -53,6 +53,7 @@ func (p *SnapshotProvider) Search(ctx context.Context, project string, query Que
 			Project:  project,
 			Location: "global",
 			Details:  snapshotDetails(snapshot),
+			Labels:   snapshot.Labels,
 		})
 	}
 
@@ -0,0 +1,108 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/kedare/compass/internal/gcp"
+)
+
+// SpannerClientFactory creates a Spanner client scoped to a project.
+type SpannerClientFactory func(ctx context.Context, project string) (SpannerClient, error)
+
+// SpannerClient exposes the subset of gcp.Client used by the Spanner searcher.
+type SpannerClient interface {
+	ListSpannerInstances(ctx context.Context) ([]*gcp.SpannerInstance, error)
+}
+
+// SpannerProvider searches Cloud Spanner instances for query matches.
+type SpannerProvider struct {
+	NewClient SpannerClientFactory
+}
+
+// Kind returns the resource kind this provider handles.
+func (p *SpannerProvider) Kind() ResourceKind {
+	return KindSpannerInstance
+}
+
+// Search implements the Provider interface.
+func (p *SpannerProvider) Search(ctx context.Context, project string, query Query) ([]Result, error) {
+	if p == nil || p.NewClient == nil {
+		return nil, fmt.Errorf("%s: %w", project, ErrNoProviders)
+	}
+
+	client, err := p.NewClient(ctx, project)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client for %s: %w", project, err)
+	}
+
+	instances, err := client.ListSpannerInstances(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list Spanner instances in %s: %w", project, err)
+	}
+
+	matches := make([]Result, 0, len(instances))
+	for _, inst := range instances {
+		if inst == nil {
+			continue
+		}
+
+		fields := append([]string{inst.Name, inst.DisplayName, inst.Config}, inst.EndpointURIs...)
+		if !query.MatchesResource(inst.Labels, fields...) {
+			continue
+		}
+
+		matches = append(matches, Result{
+			Type:     KindSpannerInstance,
+			Name:     inst.Name,
+			Project:  project,
+			Location: spannerLocation(inst.Config),
+			Details:  spannerDetails(inst),
+			Labels:   inst.Labels,
+		})
+	}
+
+	return matches, nil
+}
+
+// spannerLocation derives a location from an instance config name
+// (e.g. "regional-us-central1" -> "us-central1", "nam6" stays "nam6").
+func spannerLocation(config string) string {
+	return strings.TrimPrefix(config, "regional-")
+}
+
+// spannerDetails extracts display metadata for a Spanner instance.
+func spannerDetails(inst *gcp.SpannerInstance) map[string]string {
+	details := make(map[string]string)
+
+	if inst.DisplayName != "" {
+		details["displayName"] = inst.DisplayName
+	}
+
+	if inst.Config != "" {
+		details["config"] = inst.Config
+	}
+
+	if inst.Edition != "" {
+		details["edition"] = inst.Edition
+	}
+
+	if inst.NodeCount > 0 {
+		details["nodes"] = fmt.Sprintf("%d", inst.NodeCount)
+	}
+
+	if inst.ProcessingUnits > 0 {
+		details["processingUnits"] = fmt.Sprintf("%d", inst.ProcessingUnits)
+	}
+
+	if len(inst.EndpointURIs) > 0 {
+		details["endpoints"] = strings.Join(inst.EndpointURIs, ", ")
+	}
+
+	if inst.State != "" {
+		details["state"] = inst.State
+	}
+
+	return details
+}
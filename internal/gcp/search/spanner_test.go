@@ -0,0 +1,84 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kedare/compass/internal/gcp"
+)
+
+func TestSpannerProviderReturnsMatches(t *testing.T) {
+	client := &fakeSpannerClient{instances: []*gcp.SpannerInstance{
+		{Name: "orders-spanner", DisplayName: "Orders", Config: "regional-europe-west1", Edition: "ENTERPRISE", ProcessingUnits: 1000, NodeCount: 1, State: "READY"},
+		{Name: "analytics", DisplayName: "Analytics", Config: "nam6"},
+	}}
+
+	provider := &SpannerProvider{NewClient: func(ctx context.Context, project string) (SpannerClient, error) {
+		if project != "proj-a" {
+			t.Fatalf("unexpected project %s", project)
+		}
+		return client, nil
+	}}
+
+	results, err := provider.Search(context.Background(), "proj-a", Query{Term: "orders"})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+
+	if len(results) != 1 || results[0].Name != "orders-spanner" {
+		t.Fatalf("expected orders-spanner, got %+v", results)
+	}
+
+	result := results[0]
+	if result.Type != KindSpannerInstance || result.Location != "europe-west1" {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	if result.Details["config"] != "regional-europe-west1" || result.Details["processingUnits"] != "1000" || result.Details["nodes"] != "1" {
+		t.Fatalf("unexpected details %+v", result.Details)
+	}
+
+	// Multi-region configs are kept as-is
+	results, err = provider.Search(context.Background(), "proj-a", Query{Term: "analytics"})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(results) != 1 || results[0].Location != "nam6" {
+		t.Fatalf("expected nam6 location, got %+v", results)
+	}
+}
+
+func TestSpannerProviderPropagatesErrors(t *testing.T) {
+	provider := &SpannerProvider{NewClient: func(context.Context, string) (SpannerClient, error) {
+		return nil, errors.New("client boom")
+	}}
+
+	if _, err := provider.Search(context.Background(), "proj-a", Query{Term: "foo"}); err == nil {
+		t.Fatal("expected error")
+	}
+
+	provider = &SpannerProvider{NewClient: func(context.Context, string) (SpannerClient, error) {
+		return &fakeSpannerClient{err: errors.New("list boom")}, nil
+	}}
+
+	if _, err := provider.Search(context.Background(), "proj-a", Query{Term: "foo"}); err == nil {
+		t.Fatal("expected list error")
+	}
+
+	var nilProvider *SpannerProvider
+	if _, err := nilProvider.Search(context.Background(), "proj-a", Query{Term: "foo"}); err == nil {
+		t.Fatal("expected error for nil provider")
+	}
+}
+
+type fakeSpannerClient struct {
+	instances []*gcp.SpannerInstance
+	err       error
+}
+
+func (f *fakeSpannerClient) ListSpannerInstances(context.Context) ([]*gcp.SpannerInstance, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.instances, nil
+}
@@ -3,6 +3,7 @@ package search
 
 import (
 	"context"
+	"path"
 	"strings"
 
 	"github.com/lithammer/fuzzysearch/fuzzy"
@@ -48,6 +49,12 @@ const (
 	KindSubnet ResourceKind = "compute.subnet"
 	// KindCloudRunService represents a Cloud Run service.
 	KindCloudRunService ResourceKind = "run.service"
+	// KindCloudRunJob represents a Cloud Run job.
+	KindCloudRunJob ResourceKind = "run.job"
+	// KindCloudFunction represents a Cloud Functions function (1st or 2nd gen).
+	KindCloudFunction ResourceKind = "functions.function"
+	// KindAppEngineService represents an App Engine service.
+	KindAppEngineService ResourceKind = "appengine.service"
 	// KindFirewallRule represents a VPC firewall rule.
 	KindFirewallRule ResourceKind = "compute.firewall"
 	// KindSecret represents a Secret Manager secret.
@@ -58,38 +65,45 @@ const (
 	KindVPNTunnel ResourceKind = "compute.vpnTunnel"
 	// KindRoute represents a VPC route.
 	KindRoute ResourceKind = "compute.route"
+	// KindRouter represents a Cloud Router.
+	KindRouter ResourceKind = "compute.router"
+	// KindRouterNAT represents a Cloud NAT gateway configured on a Cloud Router.
+	KindRouterNAT ResourceKind = "compute.routerNat"
+	// KindInterconnectAttachment represents an Interconnect VLAN attachment.
+	KindInterconnectAttachment ResourceKind = "compute.interconnectAttachment"
 	// KindConnectivityTest represents a Network Connectivity Test.
 	KindConnectivityTest ResourceKind = "networkmanagement.connectivityTest"
+	// KindPubSubTopic represents a Pub/Sub topic.
+	KindPubSubTopic ResourceKind = "pubsub.topic"
+	// KindPubSubSubscription represents a Pub/Sub subscription.
+	KindPubSubSubscription ResourceKind = "pubsub.subscription"
+	// KindDNSManagedZone represents a Cloud DNS managed zone.
+	KindDNSManagedZone ResourceKind = "dns.managedZone"
+	// KindDNSRecordSet represents a Cloud DNS resource record set.
+	KindDNSRecordSet ResourceKind = "dns.recordSet"
+	// KindServiceAccount represents an IAM service account.
+	KindServiceAccount ResourceKind = "iam.serviceAccount"
+	// KindRedisInstance represents a Memorystore for Redis instance.
+	KindRedisInstance ResourceKind = "redis.instance"
+	// KindMemcacheInstance represents a Memorystore for Memcached instance.
+	KindMemcacheInstance ResourceKind = "memcache.instance"
+	// KindFilestoreInstance represents a Filestore instance.
+	KindFilestoreInstance ResourceKind = "file.instance"
+	// KindSpannerInstance represents a Cloud Spanner instance.
+	KindSpannerInstance ResourceKind = "spanner.instance"
+	// KindBigQueryDataset represents a BigQuery dataset.
+	KindBigQueryDataset ResourceKind = "bigquery.dataset"
 )
 
-// AllResourceKinds returns all available resource kind values for use in validation and completion.
+// AllResourceKinds returns all registered resource kind values for use in validation and completion.
 func AllResourceKinds() []ResourceKind {
-	return []ResourceKind{
-		KindComputeInstance,
-		KindManagedInstanceGroup,
-		KindInstanceTemplate,
-		KindAddress,
-		KindDisk,
-		KindSnapshot,
-		KindBucket,
-		KindForwardingRule,
-		KindBackendService,
-		KindTargetPool,
-		KindHealthCheck,
-		KindURLMap,
-		KindCloudSQLInstance,
-		KindGKECluster,
-		KindGKENodePool,
-		KindVPCNetwork,
-		KindSubnet,
-		KindCloudRunService,
-		KindFirewallRule,
-		KindSecret,
-		KindVPNGateway,
-		KindVPNTunnel,
-		KindRoute,
-		KindConnectivityTest,
+	all := Descriptors()
+	kinds := make([]ResourceKind, 0, len(all))
+	for _, d := range all {
+		kinds = append(kinds, d.Kind)
 	}
+
+	return kinds
 }
 
 // IsValidResourceKind checks if the provided string is a valid resource kind.
@@ -103,10 +117,15 @@ func IsValidResourceKind(kind string) bool {
 }
 
 // Query captures the user supplied search term and matching behaviour.
+// At most one of Fuzzy, Regex and Exact should be set, see Mode.
 type Query struct {
 	Term  string
 	Fuzzy bool           // Use fuzzy matching instead of substring matching
+	Regex bool           // Match Term as a case-sensitive regular expression
+	Exact bool           // Match values equal to Term (case-sensitive)
 	Types []ResourceKind // Filter results to these types (empty means all types)
+	// Affinity holds per-project search affinity scores used to boost result relevance.
+	Affinity map[string]float64
 }
 
 // NormalizedTerm returns the lowercase trimmed representation of the query.
@@ -116,6 +135,15 @@ func (q Query) NormalizedTerm() string {
 
 // Matches reports whether the provided value satisfies the query.
 func (q Query) Matches(value string) bool {
+	switch {
+	case q.Regex:
+		_, _, ok := q.matchSpan(value)
+		return ok
+	case q.Exact:
+		term := strings.TrimSpace(q.Term)
+		return term != "" && value == term
+	}
+
 	normalized := q.NormalizedTerm()
 	if normalized == "" {
 		return false
@@ -130,29 +158,68 @@ func (q Query) Matches(value string) bool {
 
 // MatchesAny reports whether any of the provided values satisfies the query.
 func (q Query) MatchesAny(values ...string) bool {
-	normalized := q.NormalizedTerm()
-	if normalized == "" {
+	if q.NormalizedTerm() == "" {
 		return false
 	}
 
 	for _, v := range values {
-		if v == "" {
+		if v != "" && q.Matches(v) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// LabelPattern splits a "key=value" query term into its key and value parts.
+// The value may be "*" to match any value for the key, or contain glob wildcards.
+// ok is false when the term is not a label pattern.
+// Regular expressions are never label patterns.
+func (q Query) LabelPattern() (key, value string, ok bool) {
+	if q.Regex {
+		return "", "", false
+	}
+
+	normalized := q.NormalizedTerm()
+	key, value, found := strings.Cut(normalized, "=")
+	key = strings.TrimSpace(key)
+	if !found || key == "" {
+		return "", "", false
+	}
+
+	return key, strings.TrimSpace(value), true
+}
+
+// MatchesLabels reports whether the provided labels satisfy a "key=value" query term.
+// Keys are compared case-insensitively; values are matched as glob patterns so that
+// "env=*" matches any resource carrying the env label and "env=prod*" matches prefixes.
+func (q Query) MatchesLabels(labels map[string]string) bool {
+	key, pattern, ok := q.LabelPattern()
+	if !ok || len(labels) == 0 {
+		return false
+	}
+
+	for k, v := range labels {
+		if strings.ToLower(k) != key {
 			continue
 		}
-		if q.Fuzzy {
-			if fuzzy.MatchFold(normalized, v) {
-				return true
-			}
-		} else {
-			if strings.Contains(strings.ToLower(v), normalized) {
-				return true
-			}
+		if pattern == "*" {
+			return true
+		}
+		if matched, err := path.Match(pattern, strings.ToLower(v)); err == nil && matched {
+			return true
 		}
 	}
 
 	return false
 }
 
+// MatchesResource reports whether a resource matches the query, either through a
+// "key=value" label pattern against its labels or through any of the provided values.
+func (q Query) MatchesResource(labels map[string]string, values ...string) bool {
+	return q.MatchesLabels(labels) || q.MatchesAny(values...)
+}
+
 // MatchesType reports whether the provided resource kind is included in the query's type filter.
 // Returns true if no type filter is set (empty Types slice).
 func (q Query) MatchesType(kind ResourceKind) bool {
@@ -176,6 +243,8 @@ type Result struct {
 	Project  string
 	Location string
 	Details  map[string]string
+	Labels   map[string]string
+	Score    float64 // Relevance score, see RelevanceScore
 }
 
 // SearchWarning captures a non-fatal error that occurred during search.
@@ -191,10 +260,19 @@ func (w SearchWarning) Error() string {
 	return w.Err.Error()
 }
 
+// SkippedProvider records a provider that was not queried for a project because
+// its API is disabled there.
+type SkippedProvider struct {
+	Project  string
+	Provider ResourceKind
+	Service  string
+}
+
 // SearchOutput contains the results and any warnings from a search operation.
 type SearchOutput struct {
 	Results  []Result
 	Warnings []SearchWarning
+	Skipped  []SkippedProvider
 }
 
 // Provider defines a search backend for a specific resource type.
@@ -20,6 +20,17 @@ func TestQueryMatches(t *testing.T) {
 		{"fuzzy substring still works", Query{Term: "prod", Fuzzy: true}, "my-production-vm", true},
 		{"fuzzy no match", Query{Term: "xyz", Fuzzy: true}, "production", false},
 		{"fuzzy empty term", Query{Term: "  ", Fuzzy: true}, "production", false},
+		// Regex matching
+		{"regex anchored", Query{Term: "^api-v[0-9]+-", Regex: true}, "api-v2-backend", true},
+		{"regex anchored no match", Query{Term: "^api-v[0-9]+-", Regex: true}, "old-api-v2-backend", false},
+		{"regex case sensitive", Query{Term: "^API", Regex: true}, "api-v2", false},
+		{"regex case insensitive flag", Query{Term: "(?i)^API", Regex: true}, "api-v2", true},
+		{"regex invalid pattern", Query{Term: "api-[", Regex: true}, "api-[", false},
+		// Exact matching
+		{"exact match", Query{Term: "web-1", Exact: true}, "web-1", true},
+		{"exact trims term", Query{Term: " web-1 ", Exact: true}, "web-1", true},
+		{"exact rejects substring", Query{Term: "web-1", Exact: true}, "web-10", false},
+		{"exact case sensitive", Query{Term: "Web-1", Exact: true}, "web-1", false},
 	}
 
 	for _, tt := range tests {
@@ -59,3 +70,75 @@ func TestQueryMatchesAny(t *testing.T) {
 		}
 	}
 }
+
+func TestQueryLabelPattern(t *testing.T) {
+	tests := []struct {
+		term  string
+		key   string
+		value string
+		ok    bool
+	}{
+		{"env=prod", "env", "prod", true},
+		{" Team = Payments ", "team", "payments", true},
+		{"env=*", "env", "*", true},
+		{"env=", "env", "", true},
+		{"=prod", "", "", false},
+		{"prod", "", "", false},
+	}
+
+	for _, tt := range tests {
+		key, value, ok := Query{Term: tt.term}.LabelPattern()
+		if key != tt.key || value != tt.value || ok != tt.ok {
+			t.Errorf("%q: expected (%q, %q, %v) got (%q, %q, %v)", tt.term, tt.key, tt.value, tt.ok, key, value, ok)
+		}
+	}
+}
+
+func TestQueryMatchesLabels(t *testing.T) {
+	labels := map[string]string{"env": "Production", "team": "payments", "cost-center": ""}
+
+	tests := []struct {
+		name   string
+		term   string
+		labels map[string]string
+		result bool
+	}{
+		{"exact value", "env=production", labels, true},
+		{"case insensitive key", "ENV=production", labels, true},
+		{"wrong value", "env=staging", labels, false},
+		{"substring value does not match", "env=prod", labels, false},
+		{"glob value", "env=prod*", labels, true},
+		{"any value", "team=*", labels, true},
+		{"any value on empty label", "cost-center=*", labels, true},
+		{"empty value", "cost-center=", labels, true},
+		{"missing key", "owner=*", labels, false},
+		{"not a label pattern", "payments", labels, false},
+		{"no labels", "env=*", nil, false},
+	}
+
+	for _, tt := range tests {
+		if got := (Query{Term: tt.term}).MatchesLabels(tt.labels); got != tt.result {
+			t.Errorf("%s: expected %v got %v", tt.name, tt.result, got)
+		}
+	}
+}
+
+func TestQueryMatchesResource(t *testing.T) {
+	labels := map[string]string{"env": "prod"}
+
+	if !(Query{Term: "env=prod"}).MatchesResource(labels, "web-1") {
+		t.Error("expected label pattern to match")
+	}
+	if !(Query{Term: "web"}).MatchesResource(labels, "web-1") {
+		t.Error("expected value to match")
+	}
+	if (Query{Term: "env=dev"}).MatchesResource(labels, "web-1") {
+		t.Error("expected no match")
+	}
+}
+
+func TestQueryLabelPatternIgnoresRegex(t *testing.T) {
+	if _, _, ok := (Query{Term: "env=prod", Regex: true}).LabelPattern(); ok {
+		t.Fatal("regex terms must not be treated as label patterns")
+	}
+}
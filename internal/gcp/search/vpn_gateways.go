@@ -41,7 +41,7 @@ func (p *VPNGatewayProvider) Search(ctx context.Context, project string, query Q
 
 	matches := make([]Result, 0, len(gateways))
 	for _, gw := range gateways {
-		if gw == nil || !query.MatchesAny(gw.Name, gw.Network) {
+		if gw == nil || !query.MatchesResource(gw.Labels, gw.Name, gw.Network) {
 			continue
 		}
 
@@ -51,6 +51,7 @@ func (p *VPNGatewayProvider) Search(ctx context.Context, project string, query Q
 			Project:  project,
 			Location: gw.Region,
 			Details:  vpnGatewayDetails(gw),
+			Labels:   gw.Labels,
 		})
 	}
 
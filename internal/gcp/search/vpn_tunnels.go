@@ -41,7 +41,7 @@ func (p *VPNTunnelProvider) Search(ctx context.Context, project string, query Qu
 
 	matches := make([]Result, 0, len(tunnels))
 	for _, tunnel := range tunnels {
-		if tunnel == nil || !query.MatchesAny(tunnel.Name, tunnel.PeerIP, tunnel.LocalGatewayIP) {
+		if tunnel == nil || !query.MatchesResource(tunnel.Labels, tunnel.Name, tunnel.PeerIP, tunnel.LocalGatewayIP) {
 			continue
 		}
 
@@ -51,6 +51,7 @@ func (p *VPNTunnelProvider) Search(ctx context.Context, project string, query Qu
 			Project:  project,
 			Location: tunnel.Region,
 			Details:  vpnTunnelDetails(tunnel),
+			Labels:   tunnel.Labels,
 		})
 	}
 
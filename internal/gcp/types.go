@@ -1,6 +1,9 @@
 package gcp
 
-import "errors"
+import (
+	"errors"
+	"time"
+)
 
 const (
 	InstanceStatusRunning    = "RUNNING"
@@ -167,6 +170,7 @@ type Address struct {
 	Description string
 	Subnetwork  string
 	Users       []string
+	Labels      map[string]string
 }
 
 // Disk represents a Compute Engine persistent disk.
@@ -176,6 +180,7 @@ type Disk struct {
 	SizeGb int64
 	Type   string
 	Status string
+	Labels map[string]string
 }
 
 // Snapshot represents a Compute Engine disk snapshot.
@@ -185,6 +190,9 @@ type Snapshot struct {
 	StorageBytes int64
 	Status       string
 	SourceDisk   string
+	// SourceDiskLocation is the zone, or region for regional disks, of the source disk.
+	SourceDiskLocation string
+	Labels             map[string]string
 }
 
 // Bucket represents a Cloud Storage bucket.
@@ -193,6 +201,7 @@ type Bucket struct {
 	Location     string
 	StorageClass string
 	LocationType string
+	Labels       map[string]string
 }
 
 // ForwardingRule represents a forwarding rule (load balancer frontend).
@@ -203,6 +212,13 @@ type ForwardingRule struct {
 	IPProtocol          string
 	PortRange           string
 	LoadBalancingScheme string
+	Labels              map[string]string
+	// Target is the name of the target proxy, pool or instance receiving the traffic.
+	Target string
+	// TargetType is the collection of the target, e.g. "targetPools" or "targetHttpsProxies".
+	TargetType string
+	// BackendService is set for internal and network passthrough load balancers.
+	BackendService string
 }
 
 // BackendService represents a backend service.
@@ -213,6 +229,16 @@ type BackendService struct {
 	LoadBalancingScheme string
 	HealthCheckCount    int
 	BackendCount        int
+	// HealthChecks holds the names of the health checks.
+	HealthChecks []string
+	Backends     []BackendGroup
+}
+
+// BackendGroup is an instance group or network endpoint group serving a backend service.
+type BackendGroup struct {
+	Name string
+	// Location is the zone or region of the group.
+	Location string
 }
 
 // TargetPool represents a target pool (legacy load balancing).
@@ -221,6 +247,7 @@ type TargetPool struct {
 	Region          string
 	SessionAffinity string
 	InstanceCount   int
+	Instances       []string
 }
 
 // HealthCheck represents a health check.
@@ -245,6 +272,74 @@ type CloudSQLInstance struct {
 	DatabaseVersion string
 	Tier            string
 	State           string
+	Labels          map[string]string
+}
+
+// RedisInstance represents a Memorystore for Redis instance.
+type RedisInstance struct {
+	Name            string
+	Region          string
+	DisplayName     string
+	Tier            string
+	RedisVersion    string
+	MemorySizeGB    int64
+	Host            string
+	Port            int64
+	ReadEndpoint    string
+	Network         string
+	ReservedIPRange string
+	ConnectMode     string
+	State           string
+	Labels          map[string]string
+}
+
+// MemcacheInstance represents a Memorystore for Memcached instance.
+type MemcacheInstance struct {
+	Name              string
+	Region            string
+	DisplayName       string
+	MemcacheVersion   string
+	NodeCount         int64
+	DiscoveryEndpoint string
+	NodeHosts         []string
+	Network           string
+	State             string
+	Labels            map[string]string
+}
+
+// FilestoreInstance represents a Filestore instance.
+type FilestoreInstance struct {
+	Name        string
+	Location    string
+	Tier        string
+	Description string
+	IPAddresses []string
+	Network     string
+	FileShares  []string // Share names with their capacity, e.g. "vol1 (1024 GB)"
+	Protocol    string
+	State       string
+	Labels      map[string]string
+}
+
+// SpannerInstance represents a Cloud Spanner instance.
+type SpannerInstance struct {
+	Name            string
+	DisplayName     string
+	Config          string
+	Edition         string
+	NodeCount       int64
+	ProcessingUnits int64
+	EndpointURIs    []string
+	State           string
+	Labels          map[string]string
+}
+
+// BigQueryDataset represents a BigQuery dataset.
+type BigQueryDataset struct {
+	Name         string
+	Location     string
+	FriendlyName string
+	Labels       map[string]string
 }
 
 // GKECluster represents a GKE Kubernetes cluster.
@@ -254,6 +349,7 @@ type GKECluster struct {
 	Status               string
 	CurrentMasterVersion string
 	NodeCount            int
+	Labels               map[string]string
 }
 
 // GKENodePool represents a GKE node pool.
@@ -288,19 +384,69 @@ type CloudRunService struct {
 	Region         string
 	URL            string
 	LatestRevision string
+	Labels         map[string]string
+}
+
+// CloudRunJob represents a Cloud Run job.
+type CloudRunJob struct {
+	Name                  string
+	Region                string
+	Image                 string
+	TaskCount             int64
+	Parallelism           int64
+	MaxRetries            int64
+	ServiceAccount        string
+	ExecutionCount        int64
+	LatestExecution       string
+	LatestExecutionStatus string
+	LatestExecutionTime   string
+	Labels                map[string]string
+}
+
+// CloudFunction represents a Cloud Functions function (1st or 2nd generation).
+type CloudFunction struct {
+	Name            string
+	Region          string
+	Generation      string // "gen1" or "gen2"
+	Runtime         string
+	EntryPoint      string
+	State           string
+	TriggerType     string // "http" or "event"
+	EventType       string
+	TriggerResource string
+	IngressSettings string
+	URL             string
+	ServiceAccount  string
+	Labels          map[string]string
+}
+
+// AppEngineService represents an App Engine service and its main serving version.
+type AppEngineService struct {
+	Name            string
+	Location        string
+	URL             string
+	IngressSettings string
+	Version         string // Version receiving the largest traffic share
+	Runtime         string
+	Environment     string // "standard" or "flexible"
+	ServingStatus   string
+	VersionCount    int
+	Labels          map[string]string
 }
 
 // FirewallRule represents a VPC firewall rule.
 type FirewallRule struct {
-	Name         string
-	Network      string
-	Direction    string
-	Priority     int64
-	Disabled     bool
-	Description  string
-	SourceRanges []string
-	TargetTags   []string
-	Allowed      []string
+	Name              string
+	Network           string
+	Direction         string
+	Priority          int64
+	Disabled          bool
+	Description       string
+	SourceRanges      []string
+	DestinationRanges []string
+	TargetTags        []string
+	Allowed           []string
+	Denied            []string
 }
 
 // Secret represents a Secret Manager secret.
@@ -308,6 +454,7 @@ type Secret struct {
 	Name         string
 	Replication  string
 	VersionCount int
+	Labels       map[string]string
 }
 
 // Route represents a VPC route.
@@ -321,3 +468,155 @@ type Route struct {
 	RouteType   string
 	Tags        []string
 }
+
+// Router represents a Cloud Router.
+type Router struct {
+	Name             string
+	Region           string
+	Network          string
+	Description      string
+	ASN              int64
+	AdvertiseMode    string
+	AdvertisedGroups []string
+	AdvertisedRanges []string
+	Interfaces       int
+	BGPPeers         []RouterBGPPeer
+	NATs             []string
+}
+
+// RouterBGPPeer summarizes a BGP peer configured on a Cloud Router.
+type RouterBGPPeer struct {
+	Name          string
+	PeerASN       int64
+	PeerIPAddress string
+	IPAddress     string
+}
+
+// RouterNAT represents a Cloud NAT gateway configured on a Cloud Router.
+type RouterNAT struct {
+	Name                   string
+	Router                 string
+	Region                 string
+	Network                string
+	IPAllocateOption       string   // AUTO_ONLY or MANUAL_ONLY
+	NATIPs                 []string // External IPs in use, from the router status when available
+	NATIPResources         []string // Reserved address names for MANUAL_ONLY allocation
+	SourceSubnetworkRanges string
+	MinPortsPerVM          int64
+	EndpointIndependent    bool
+	LogFilter              string
+}
+
+// InterconnectAttachment represents an Interconnect VLAN attachment.
+type InterconnectAttachment struct {
+	Name                    string
+	Region                  string
+	Description             string
+	Type                    string
+	Interconnect            string
+	Router                  string
+	Bandwidth               string
+	State                   string
+	OperationalStatus       string
+	AdminEnabled            bool
+	PairingKey              string
+	PartnerASN              int64
+	PartnerName             string
+	VLANTag                 int64
+	CloudRouterIPAddress    string
+	CustomerRouterIPAddress string
+	EdgeAvailabilityDomain  string
+	MTU                     int64
+	Labels                  map[string]string
+}
+
+// PubSubTopic represents a Pub/Sub topic.
+type PubSubTopic struct {
+	Name                     string
+	KMSKeyName               string
+	MessageRetentionDuration string
+	SchemaName               string
+	SchemaEncoding           string
+	AllowedRegions           []string
+	State                    string
+	Labels                   map[string]string
+}
+
+// PubSubSubscription represents a Pub/Sub subscription.
+type PubSubSubscription struct {
+	Name                     string
+	Topic                    string
+	AckDeadlineSeconds       int64
+	MessageRetentionDuration string
+	RetainAckedMessages      bool
+	EnableMessageOrdering    bool
+	ExactlyOnceDelivery      bool
+	Filter                   string
+	PushEndpoint             string
+	BigQueryTable            string
+	CloudStorageBucket       string
+	DeadLetterTopic          string
+	MaxDeliveryAttempts      int64
+	Detached                 bool
+	State                    string
+	Labels                   map[string]string
+}
+
+// DeliveryType returns how messages are delivered to the subscriber (pull, push, bigquery or cloudstorage).
+func (s *PubSubSubscription) DeliveryType() string {
+	switch {
+	case s.PushEndpoint != "":
+		return "push"
+	case s.BigQueryTable != "":
+		return "bigquery"
+	case s.CloudStorageBucket != "":
+		return "cloudstorage"
+	default:
+		return "pull"
+	}
+}
+
+// DNSManagedZone represents a Cloud DNS managed zone.
+type DNSManagedZone struct {
+	Name        string
+	DNSName     string
+	Description string
+	Visibility  string
+	// Networks lists the VPC networks a private zone is attached to.
+	Networks          []string
+	DNSSECState       string
+	ForwardingTargets []string
+	PeeringNetwork    string
+	NameServers       []string
+	Labels            map[string]string
+}
+
+// DNSRecordSet represents a Cloud DNS resource record set.
+type DNSRecordSet struct {
+	Name    string
+	Type    string
+	TTL     int64
+	RRDatas []string
+	// RoutingPolicy is "geo" or "wrr" when the record uses a routing policy instead of static rrdatas.
+	RoutingPolicy string
+	Zone          string
+	ZoneDNSName   string
+	Visibility    string
+	// Networks lists the VPC networks a private zone is attached to.
+	Networks []string
+}
+
+// ServiceAccount represents an IAM service account.
+type ServiceAccount struct {
+	Email       string
+	DisplayName string
+	Description string
+	UniqueID    string
+	Disabled    bool
+	// KeyCount is the number of user-managed keys attached to the account.
+	KeyCount int
+	// OldestKeyCreated is the creation time of the oldest user-managed key (zero when there are none).
+	OldestKeyCreated time.Time
+	// KeysKnown reports whether KeyCount and OldestKeyCreated were filled by ServiceAccountKeySummary.
+	KeysKnown bool
+}
@@ -58,6 +58,7 @@ type VPNTunnelInfo struct {
 	RouterSelfLink    string
 	SharedSecretHash  string
 	LabelFingerprint  string
+	Labels            map[string]string
 	RouterName        string
 	RouterRegion      string
 	Name              string
@@ -467,98 +468,89 @@ func (c *Client) listRouterBgpPeers(ctx context.Context, progress func(string))
 		progress("Calling compute.v1 Routers.AggregatedList")
 	}
 
-	call := c.service.Routers.AggregatedList(c.project).Context(ctx)
-
-	err := call.Pages(ctx, func(page *compute.RouterAggregatedList) error {
-		for scope, scopedList := range page.Items {
-			if len(scopedList.Routers) == 0 {
-				continue
-			}
+	routers, err := c.listRouters(ctx)
+	if err != nil {
+		logger.Log.Errorf("Failed to list Cloud Router BGP peers: %v", err)
 
-			region := scopeSuffix(scope)
-			if progress != nil {
-				progress(fmt.Sprintf("Fetched Cloud Routers in %s", region))
-			}
+		return nil, fmt.Errorf("failed to list Cloud Router BGP peers: %w", err)
+	}
 
-			for _, router := range scopedList.Routers {
-				if len(router.BgpPeers) == 0 {
-					continue
-				}
+	if progress != nil {
+		progress(fmt.Sprintf("Fetched %d Cloud Routers", len(routers)))
+	}
 
-				interfaceToTunnel := map[string]string{}
+	for _, router := range routers {
+		if len(router.BgpPeers) == 0 {
+			continue
+		}
 
-				for _, iface := range router.Interfaces {
-					if iface == nil || strings.TrimSpace(iface.Name) == "" {
-						continue
-					}
+		region := extractResourceName(router.Region)
 
-					if iface.LinkedVpnTunnel != "" {
-						interfaceToTunnel[iface.Name] = iface.LinkedVpnTunnel
-					}
-				}
+		interfaceToTunnel := map[string]string{}
 
-				statusMap := map[string]*compute.RouterStatusBgpPeerStatus{}
+		for _, iface := range router.Interfaces {
+			if iface == nil || strings.TrimSpace(iface.Name) == "" {
+				continue
+			}
 
-				if progress != nil {
-					progress(fmt.Sprintf("Fetching router status for %s", router.Name))
-				}
+			if iface.LinkedVpnTunnel != "" {
+				interfaceToTunnel[iface.Name] = iface.LinkedVpnTunnel
+			}
+		}
 
-				statusResp, err := c.service.Routers.GetRouterStatus(c.project, region, router.Name).Context(ctx).Do()
-				if err != nil {
-					logger.Log.Warnf("Failed to fetch router status for %s: %v", router.Name, err)
-				} else if statusResp != nil && statusResp.Result != nil {
-					for _, peerStatus := range statusResp.Result.BgpPeerStatus {
-						statusMap[peerStatus.Name] = peerStatus
-					}
-				}
+		statusMap := map[string]*compute.RouterStatusBgpPeerStatus{}
 
-				for _, peer := range router.BgpPeers {
-					link := ""
-					if candidate, ok := interfaceToTunnel[peer.InterfaceName]; ok {
-						link = normalizeLink(candidate)
-					}
+		if progress != nil {
+			progress(fmt.Sprintf("Fetching router status for %s", router.Name))
+		}
 
-					localASN := int64(0)
-					if router.Bgp != nil {
-						localASN = router.Bgp.Asn
-					}
+		statusResp, err := c.service.Routers.GetRouterStatus(c.project, region, router.Name).Context(ctx).Do()
+		if err != nil {
+			logger.Log.Warnf("Failed to fetch router status for %s: %v", router.Name, err)
+		} else if statusResp != nil && statusResp.Result != nil {
+			for _, peerStatus := range statusResp.Result.BgpPeerStatus {
+				statusMap[peerStatus.Name] = peerStatus
+			}
+		}
 
-					peerInfo := &BGPSessionInfo{
-						Name:               peer.Name,
-						RouterName:         router.Name,
-						RouterSelfLink:     router.SelfLink,
-						Region:             region,
-						VpnTunnelLink:      link,
-						Interface:          peer.InterfaceName,
-						PeerIP:             firstNonEmpty(peer.PeerIpAddress, peer.PeerIpv4NexthopAddress, peer.PeerIpv6NexthopAddress),
-						LocalIP:            firstNonEmpty(peer.IpAddress, peer.Ipv4NexthopAddress, peer.Ipv6NexthopAddress),
-						LocalASN:           localASN,
-						PeerASN:            peer.PeerAsn,
-						AdvertisedMode:     peer.AdvertiseMode,
-						RoutePriority:      peer.AdvertisedRoutePriority,
-						Enabled:            !strings.EqualFold(peer.Enable, "FALSE"),
-						AdvertisedGroups:   append([]string{}, peer.AdvertisedGroups...),
-						AdvertisedIPRanges: append([]*compute.RouterAdvertisedIpRange{}, peer.AdvertisedIpRanges...),
-					}
+		for _, peer := range router.BgpPeers {
+			link := ""
+			if candidate, ok := interfaceToTunnel[peer.InterfaceName]; ok {
+				link = normalizeLink(candidate)
+			}
 
-					if status := statusMap[peer.Name]; status != nil {
-						peerInfo.SessionStatus = status.Status
-						peerInfo.SessionState = status.State
-						peerInfo.LearnedRoutes = status.NumLearnedRoutes
-						peerInfo.AdvertisedCount = len(status.AdvertisedRoutes)
-					}
+			localASN := int64(0)
+			if router.Bgp != nil {
+				localASN = router.Bgp.Asn
+			}
 
-					peers = append(peers, peerInfo)
-				}
+			peerInfo := &BGPSessionInfo{
+				Name:               peer.Name,
+				RouterName:         router.Name,
+				RouterSelfLink:     router.SelfLink,
+				Region:             region,
+				VpnTunnelLink:      link,
+				Interface:          peer.InterfaceName,
+				PeerIP:             firstNonEmpty(peer.PeerIpAddress, peer.PeerIpv4NexthopAddress, peer.PeerIpv6NexthopAddress),
+				LocalIP:            firstNonEmpty(peer.IpAddress, peer.Ipv4NexthopAddress, peer.Ipv6NexthopAddress),
+				LocalASN:           localASN,
+				PeerASN:            peer.PeerAsn,
+				AdvertisedMode:     peer.AdvertiseMode,
+				RoutePriority:      peer.AdvertisedRoutePriority,
+				Enabled:            !strings.EqualFold(peer.Enable, "FALSE"),
+				AdvertisedGroups:   append([]string{}, peer.AdvertisedGroups...),
+				AdvertisedIPRanges: append([]*compute.RouterAdvertisedIpRange{}, peer.AdvertisedIpRanges...),
 			}
-		}
 
-		return nil
-	})
-	if err != nil {
-		logger.Log.Errorf("Failed to list Cloud Router BGP peers: %v", err)
+			if status := statusMap[peer.Name]; status != nil {
+				peerInfo.SessionStatus = status.Status
+				peerInfo.SessionState = status.State
+				peerInfo.LearnedRoutes = status.NumLearnedRoutes
+				peerInfo.AdvertisedCount = len(status.AdvertisedRoutes)
+			}
 
-		return nil, fmt.Errorf("failed to list Cloud Router BGP peers: %w", err)
+			peers = append(peers, peerInfo)
+		}
 	}
 
 	return peers, nil
@@ -943,6 +935,7 @@ func convertVpnTunnel(region string, t *compute.VpnTunnel) *VPNTunnelInfo {
 		GatewayInterface:  t.VpnGatewayInterface,
 		LabelFingerprint:  t.LabelFingerprint,
 		SharedSecretHash:  t.SharedSecretHash,
+		Labels:            copyLabels(t.Labels),
 		BgpSessions:       []*BGPSessionInfo{},
 	}
 
@@ -0,0 +1,422 @@
+// Package ipranges classifies IP addresses against the Google IP ranges published at
+// https://www.gstatic.com/ipranges/ (goog.json and cloud.json), the Google ranges with a
+// documented purpose and the IANA special-purpose ranges.
+package ipranges
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kedare/compass/internal/logger"
+)
+
+//go:generate curl -sSfo data/goog.json https://www.gstatic.com/ipranges/goog.json
+//go:generate curl -sSfo data/cloud.json https://www.gstatic.com/ipranges/cloud.json
+
+//go:embed data/goog.json data/cloud.json
+var embedded embed.FS
+
+const (
+	googFileName  = "goog.json"
+	cloudFileName = "cloud.json"
+	// Dir is the directory under $HOME where updated range lists are stored.
+	Dir = ".cache/compass/ipranges"
+)
+
+// Published range lists, overridden in tests.
+var (
+	googURL  = "https://www.gstatic.com/ipranges/goog.json"
+	cloudURL = "https://www.gstatic.com/ipranges/cloud.json"
+)
+
+// Categories of classified addresses.
+const (
+	CategoryGoogleCloud   = "google_cloud"
+	CategoryGoogle        = "google"
+	CategoryPrivate       = "private"
+	CategorySharedAddress = "shared_address_space"
+	CategoryLinkLocal     = "link_local"
+	CategoryLoopback      = "loopback"
+	CategoryMulticast     = "multicast"
+	CategoryDocumentation = "documentation"
+	CategoryUniqueLocal   = "unique_local"
+	CategoryReserved      = "reserved"
+)
+
+// Classification describes what is known about an address without looking up any resource.
+type Classification struct {
+	// Category is one of the Category constants.
+	Category string `json:"category"`
+	// Prefix is the most specific published or special-purpose range containing the address.
+	Prefix string `json:"prefix"`
+	// Scope is the Google Cloud region of the range, or "global", as published in cloud.json.
+	Scope string `json:"scope,omitempty"`
+	// Service is the Google service of the range as published in cloud.json, e.g. "Google Cloud".
+	Service string `json:"service,omitempty"`
+	// Purpose is the documented use of the range, e.g. "Identity-Aware Proxy TCP forwarding".
+	Purpose string `json:"purpose,omitempty"`
+}
+
+// IsGoogle reports whether the address belongs to Google.
+func (c *Classification) IsGoogle() bool {
+	return c != nil && (c.Category == CategoryGoogleCloud || c.Category == CategoryGoogle)
+}
+
+// Metadata describes a loaded range list.
+type Metadata struct {
+	Source       string `json:"source"`
+	SyncToken    string `json:"sync_token,omitempty"`
+	CreationTime string `json:"creation_time,omitempty"`
+	Prefixes     int    `json:"prefixes"`
+}
+
+// Set holds the parsed range lists.
+type Set struct {
+	Google Metadata `json:"google"`
+	Cloud  Metadata `json:"cloud"`
+
+	google []publishedRange
+	cloud  []publishedRange
+}
+
+type publishedRange struct {
+	network *net.IPNet
+	scope   string
+	service string
+}
+
+// rangeFile is the format of goog.json and cloud.json.
+type rangeFile struct {
+	SyncToken    string `json:"syncToken"`
+	CreationTime string `json:"creationTime"`
+	Prefixes     []struct {
+		IPv4Prefix string `json:"ipv4Prefix"`
+		IPv6Prefix string `json:"ipv6Prefix"`
+		Service    string `json:"service"`
+		Scope      string `json:"scope"`
+	} `json:"prefixes"`
+}
+
+type wellKnownRange struct {
+	cidr     string
+	category string
+	purpose  string
+}
+
+// googlePurposes lists Google ranges with a documented use, most specific first.
+var googlePurposes = []wellKnownRange{
+	{"8.8.8.8/32", CategoryGoogle, "Google Public DNS"},
+	{"8.8.4.4/32", CategoryGoogle, "Google Public DNS"},
+	{"2001:4860:4860::8888/128", CategoryGoogle, "Google Public DNS"},
+	{"2001:4860:4860::8844/128", CategoryGoogle, "Google Public DNS"},
+	{"199.36.153.8/30", CategoryGoogle, "Private Google Access (private.googleapis.com)"},
+	{"199.36.153.4/30", CategoryGoogle, "VPC Service Controls (restricted.googleapis.com)"},
+	{"35.235.240.0/20", CategoryGoogleCloud, "Identity-Aware Proxy TCP forwarding"},
+	{"130.211.0.0/22", CategoryGoogleCloud, "Load balancer health checks and proxies"},
+	{"35.191.0.0/16", CategoryGoogleCloud, "Load balancer health checks and proxies"},
+	{"209.85.152.0/22", CategoryGoogleCloud, "Legacy network load balancer health checks"},
+	{"209.85.204.0/22", CategoryGoogleCloud, "Legacy network load balancer health checks"},
+	{"2600:2d00:1:b029::/64", CategoryGoogleCloud, "Load balancer health checks"},
+	{"35.199.192.0/19", CategoryGoogleCloud, "Cloud DNS forwarding and inbound server policies"},
+	{"169.254.169.254/32", CategoryLinkLocal, "Compute Engine metadata server"},
+}
+
+// specialPurposes lists the IANA special-purpose ranges, most specific first.
+var specialPurposes = []wellKnownRange{
+	{"192.0.2.0/24", CategoryDocumentation, "Documentation (TEST-NET-1, RFC 5737)"},
+	{"198.51.100.0/24", CategoryDocumentation, "Documentation (TEST-NET-2, RFC 5737)"},
+	{"203.0.113.0/24", CategoryDocumentation, "Documentation (TEST-NET-3, RFC 5737)"},
+	{"2001:db8::/32", CategoryDocumentation, "Documentation (RFC 3849)"},
+	{"10.0.0.0/8", CategoryPrivate, "Private network (RFC 1918)"},
+	{"172.16.0.0/12", CategoryPrivate, "Private network (RFC 1918)"},
+	{"192.168.0.0/16", CategoryPrivate, "Private network (RFC 1918)"},
+	{"100.64.0.0/10", CategorySharedAddress, "Shared address space / carrier-grade NAT (RFC 6598)"},
+	{"169.254.0.0/16", CategoryLinkLocal, "Link-local (RFC 3927)"},
+	{"fe80::/10", CategoryLinkLocal, "Link-local (RFC 4291)"},
+	{"127.0.0.0/8", CategoryLoopback, "Loopback (RFC 1122)"},
+	{"::1/128", CategoryLoopback, "Loopback (RFC 4291)"},
+	{"fc00::/7", CategoryUniqueLocal, "Unique local address (RFC 4193)"},
+	{"224.0.0.0/4", CategoryMulticast, "Multicast (RFC 5771)"},
+	{"ff00::/8", CategoryMulticast, "Multicast (RFC 4291)"},
+	{"198.18.0.0/15", CategoryReserved, "Benchmarking (RFC 2544)"},
+	{"0.0.0.0/8", CategoryReserved, "\"This network\" (RFC 791)"},
+	{"240.0.0.0/4", CategoryReserved, "Reserved (RFC 1112)"},
+}
+
+var (
+	defaultOnce sync.Once
+	defaultSet  *Set
+)
+
+// Default returns the range lists updated with Update when available, or the embedded ones.
+// The lists are loaded once per process.
+func Default() *Set {
+	defaultOnce.Do(func() {
+		dir := ""
+		if home, err := os.UserHomeDir(); err == nil {
+			dir = filepath.Join(home, Dir)
+		}
+
+		set, err := Load(dir)
+		if err != nil {
+			logger.Log.Debugf("Failed to load Google IP ranges: %v", err)
+			set = &Set{}
+		}
+		defaultSet = set
+	})
+
+	return defaultSet
+}
+
+// Load reads the range lists from dir, falling back to the embedded copy for each list that is
+// missing or invalid there. An empty dir only loads the embedded lists.
+func Load(dir string) (*Set, error) {
+	set := &Set{}
+
+	google, meta, err := loadList(dir, googFileName)
+	if err != nil {
+		return nil, err
+	}
+	set.google, set.Google = google, meta
+
+	cloud, meta, err := loadList(dir, cloudFileName)
+	if err != nil {
+		return nil, err
+	}
+	set.cloud, set.Cloud = cloud, meta
+
+	return set, nil
+}
+
+func loadList(dir, name string) ([]publishedRange, Metadata, error) {
+	if dir != "" {
+		path := filepath.Join(dir, name)
+		data, err := os.ReadFile(path)
+		switch {
+		case err == nil:
+			ranges, meta, parseErr := parseList(data)
+			if parseErr == nil {
+				meta.Source = path
+
+				return ranges, meta, nil
+			}
+			logger.Log.Warnf("Ignoring invalid IP range list %s: %v", path, parseErr)
+		case !errors.Is(err, os.ErrNotExist):
+			logger.Log.Debugf("Failed to read IP range list %s: %v", path, err)
+		}
+	}
+
+	data, err := embedded.ReadFile("data/" + name)
+	if err != nil {
+		return nil, Metadata{}, fmt.Errorf("failed to read embedded %s: %w", name, err)
+	}
+
+	ranges, meta, err := parseList(data)
+	if err != nil {
+		return nil, Metadata{}, fmt.Errorf("failed to parse embedded %s: %w", name, err)
+	}
+	meta.Source = "embedded"
+
+	return ranges, meta, nil
+}
+
+// parseList parses a goog.json or cloud.json document.
+func parseList(data []byte) ([]publishedRange, Metadata, error) {
+	var file rangeFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, Metadata{}, err
+	}
+
+	ranges := make([]publishedRange, 0, len(file.Prefixes))
+	for _, prefix := range file.Prefixes {
+		cidr := strings.TrimSpace(prefix.IPv4Prefix)
+		if cidr == "" {
+			cidr = strings.TrimSpace(prefix.IPv6Prefix)
+		}
+
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, Metadata{}, fmt.Errorf("invalid prefix %q", cidr)
+		}
+
+		ranges = append(ranges, publishedRange{network: network, scope: prefix.Scope, service: prefix.Service})
+	}
+
+	return ranges, Metadata{SyncToken: file.SyncToken, CreationTime: file.CreationTime, Prefixes: len(ranges)}, nil
+}
+
+// Classify returns what is known about the address, or nil for a public address that does not
+// belong to Google.
+func (s *Set) Classify(ip net.IP) *Classification {
+	if ip == nil {
+		return nil
+	}
+
+	if s == nil {
+		s = &Set{}
+	}
+
+	var result *Classification
+
+	if network, scope, service := mostSpecific(s.cloud, ip); network != nil {
+		result = &Classification{Category: CategoryGoogleCloud, Prefix: network.String(), Scope: scope, Service: service}
+	} else if network, _, _ := mostSpecific(s.google, ip); network != nil {
+		result = &Classification{Category: CategoryGoogle, Prefix: network.String()}
+	}
+
+	if known, network := matchWellKnown(googlePurposes, ip); known != nil {
+		if result == nil {
+			result = &Classification{Category: known.category, Prefix: network.String()}
+		}
+		result.Purpose = known.purpose
+
+		return result
+	}
+
+	if result != nil {
+		return result
+	}
+
+	if known, network := matchWellKnown(specialPurposes, ip); known != nil {
+		return &Classification{Category: known.category, Prefix: network.String(), Purpose: known.purpose}
+	}
+
+	return nil
+}
+
+// mostSpecific returns the longest published range containing ip.
+func mostSpecific(ranges []publishedRange, ip net.IP) (*net.IPNet, string, string) {
+	var (
+		best    *publishedRange
+		bestLen = -1
+	)
+
+	for i := range ranges {
+		if !ranges[i].network.Contains(ip) {
+			continue
+		}
+
+		if ones, _ := ranges[i].network.Mask.Size(); ones > bestLen {
+			best, bestLen = &ranges[i], ones
+		}
+	}
+
+	if best == nil {
+		return nil, "", ""
+	}
+
+	return best.network, best.scope, best.service
+}
+
+func matchWellKnown(ranges []wellKnownRange, ip net.IP) (*wellKnownRange, *net.IPNet) {
+	for i := range ranges {
+		_, network, err := net.ParseCIDR(ranges[i].cidr)
+		if err == nil && network.Contains(ip) {
+			return &ranges[i], network
+		}
+	}
+
+	return nil, nil
+}
+
+// Update downloads the published range lists into dir and returns them. The current files
+// are only replaced once both lists are downloaded and valid.
+func Update(ctx context.Context, client *http.Client, dir string) (*Set, error) {
+	if client == nil {
+		client = &http.Client{Timeout: 30 * time.Second}
+	}
+
+	documents := make(map[string][]byte, 2)
+	for name, url := range map[string]string{googFileName: googURL, cloudFileName: cloudURL} {
+		data, err := download(ctx, client, url)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, meta, err := parseList(data); err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", url, err)
+		} else if meta.Prefixes == 0 {
+			return nil, fmt.Errorf("invalid %s: no prefix", url)
+		}
+
+		documents[name] = data
+	}
+
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+
+	for name, data := range documents {
+		if err := writeFileAtomic(filepath.Join(dir, name), data); err != nil {
+			return nil, err
+		}
+	}
+
+	return Load(dir)
+}
+
+func download(ctx context.Context, client *http.Client, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create request for %s: %w", url, err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch %s: %w", url, err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status fetching %s: %s", url, resp.Status)
+	}
+
+	var buf bytes.Buffer
+	if _, err := io.Copy(&buf, resp.Body); err != nil {
+		return nil, fmt.Errorf("read %s: %w", url, err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		_ = os.Remove(tmp.Name())
+
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+
+	return nil
+}
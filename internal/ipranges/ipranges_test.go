@@ -0,0 +1,144 @@
+package ipranges
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testCloudJSON = `{
+  "syncToken": "1700000000000",
+  "creationTime": "2026-10-01T00:00:00.000000",
+  "prefixes": [
+    {"ipv4Prefix": "34.76.0.0/14", "service": "Google Cloud", "scope": "europe-west1"},
+    {"ipv4Prefix": "35.235.240.0/20", "service": "Google Cloud", "scope": "us-west2"},
+    {"ipv6Prefix": "2600:1900:4010::/44", "service": "Google Cloud", "scope": "europe-west1"}
+  ]
+}`
+
+const testGoogJSON = `{
+  "syncToken": "1700000000001",
+  "creationTime": "2026-10-01T00:00:00.000000",
+  "prefixes": [
+    {"ipv4Prefix": "8.8.4.0/24"},
+    {"ipv4Prefix": "8.8.8.0/24"},
+    {"ipv4Prefix": "34.64.0.0/10"},
+    {"ipv4Prefix": "35.190.0.0/15"}
+  ]
+}`
+
+func testSet(t *testing.T) *Set {
+	t.Helper()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, googFileName), []byte(testGoogJSON), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, cloudFileName), []byte(testCloudJSON), 0o600))
+
+	set, err := Load(dir)
+	require.NoError(t, err)
+
+	return set
+}
+
+func TestLoad(t *testing.T) {
+	set := testSet(t)
+	require.Equal(t, 4, set.Google.Prefixes)
+	require.Equal(t, 3, set.Cloud.Prefixes)
+	require.Equal(t, "2026-10-01T00:00:00.000000", set.Cloud.CreationTime)
+
+	embedded, err := Load("")
+	require.NoError(t, err)
+	require.Equal(t, "embedded", embedded.Google.Source)
+	require.Equal(t, "embedded", embedded.Cloud.Source)
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, cloudFileName), []byte("not json"), 0o600))
+	fallback, err := Load(dir)
+	require.NoError(t, err)
+	require.Equal(t, "embedded", fallback.Cloud.Source)
+}
+
+func TestClassify(t *testing.T) {
+	set := testSet(t)
+
+	tests := []struct {
+		ip       string
+		category string
+		prefix   string
+		scope    string
+		purpose  string
+	}{
+		{"34.77.1.2", CategoryGoogleCloud, "34.76.0.0/14", "europe-west1", ""},
+		{"35.235.241.10", CategoryGoogleCloud, "35.235.240.0/20", "us-west2", "Identity-Aware Proxy TCP forwarding"},
+		{"35.191.10.1", CategoryGoogle, "35.190.0.0/15", "", "Load balancer health checks and proxies"},
+		{"34.120.0.1", CategoryGoogle, "34.64.0.0/10", "", ""},
+		{"8.8.8.8", CategoryGoogle, "8.8.8.0/24", "", "Google Public DNS"},
+		{"2600:1900:4010::1", CategoryGoogleCloud, "2600:1900:4010::/44", "europe-west1", ""},
+		{"130.211.1.1", CategoryGoogleCloud, "130.211.0.0/22", "", "Load balancer health checks and proxies"},
+		{"199.36.153.9", CategoryGoogle, "199.36.153.8/30", "", "Private Google Access (private.googleapis.com)"},
+		{"169.254.169.254", CategoryLinkLocal, "169.254.169.254/32", "", "Compute Engine metadata server"},
+		{"169.254.10.1", CategoryLinkLocal, "169.254.0.0/16", "", "Link-local (RFC 3927)"},
+		{"10.1.2.3", CategoryPrivate, "10.0.0.0/8", "", "Private network (RFC 1918)"},
+		{"100.64.1.1", CategorySharedAddress, "100.64.0.0/10", "", "Shared address space / carrier-grade NAT (RFC 6598)"},
+		{"fd00::1", CategoryUniqueLocal, "fc00::/7", "", "Unique local address (RFC 4193)"},
+		{"192.0.2.10", CategoryDocumentation, "192.0.2.0/24", "", "Documentation (TEST-NET-1, RFC 5737)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ip, func(t *testing.T) {
+			class := set.Classify(net.ParseIP(tt.ip))
+			require.NotNil(t, class)
+			require.Equal(t, tt.category, class.Category)
+			require.Equal(t, tt.prefix, class.Prefix)
+			require.Equal(t, tt.scope, class.Scope)
+			require.Equal(t, tt.purpose, class.Purpose)
+		})
+	}
+
+	require.Nil(t, set.Classify(net.ParseIP("1.1.1.1")))
+	require.Nil(t, set.Classify(nil))
+	require.True(t, set.Classify(net.ParseIP("34.77.1.2")).IsGoogle())
+	require.False(t, set.Classify(net.ParseIP("10.1.2.3")).IsGoogle())
+}
+
+func TestUpdate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/goog.json":
+			_, _ = w.Write([]byte(testGoogJSON))
+		case "/cloud.json":
+			_, _ = w.Write([]byte(testCloudJSON))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	originalGoog, originalCloud := googURL, cloudURL
+	t.Cleanup(func() {
+		googURL, cloudURL = originalGoog, originalCloud
+	})
+	googURL, cloudURL = server.URL+"/goog.json", server.URL+"/cloud.json"
+
+	dir := filepath.Join(t.TempDir(), "ipranges")
+	set, err := Update(context.Background(), server.Client(), dir)
+	require.NoError(t, err)
+	require.Equal(t, 4, set.Google.Prefixes)
+	require.Equal(t, filepath.Join(dir, cloudFileName), set.Cloud.Source)
+	require.FileExists(t, filepath.Join(dir, googFileName))
+
+	// A broken list leaves the current files untouched
+	cloudURL = server.URL + "/missing.json"
+	_, err = Update(context.Background(), server.Client(), dir)
+	require.Error(t, err)
+
+	data, err := os.ReadFile(filepath.Join(dir, cloudFileName))
+	require.NoError(t, err)
+	require.JSONEq(t, testCloudJSON, string(data))
+}
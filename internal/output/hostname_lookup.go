@@ -0,0 +1,77 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kedare/compass/internal/gcp"
+	"github.com/pterm/pterm"
+)
+
+// DisplayHostnameResolutions renders the addresses looked up hostnames resolved to, ahead of
+// their IP lookup results. JSON and CSV include them in DisplayIPLookupDocument instead.
+// Supported formats:
+//   - "table": one row per address with its source, zone and record
+//   - "text" (default): one line per address
+func DisplayHostnameResolutions(resolutions []gcp.HostnameAddress, format string) error {
+	switch strings.ToLower(format) {
+	case "json", "csv":
+		return nil
+	case "table":
+		tableData := pterm.TableData{{"Hostname", "Address", "Source", "Project", "Zone", "Record"}}
+		for _, resolution := range resolutions {
+			tableData = append(tableData, []string{
+				resolution.Hostname,
+				resolution.Address,
+				describeHostnameSource(resolution.Source),
+				resolution.Project,
+				resolution.Zone,
+				describeHostnameRecord(resolution),
+			})
+		}
+
+		if err := pterm.DefaultTable.WithHasHeader().WithData(tableData).Render(); err != nil {
+			return err
+		}
+		fmt.Println()
+
+		return nil
+	default:
+		for _, resolution := range resolutions {
+			fmt.Printf("%s → %s (%s)\n", resolution.Hostname, resolution.Address, describeHostnameResolution(resolution))
+		}
+		fmt.Println()
+
+		return nil
+	}
+}
+
+// describeHostnameResolution tells where an address comes from, e.g.
+// "Cloud DNS zone internal in my-project, record db.internal.example.com. A".
+func describeHostnameResolution(resolution gcp.HostnameAddress) string {
+	if resolution.Source != gcp.HostnameSourceCloudDNS {
+		return describeHostnameSource(resolution.Source)
+	}
+
+	return fmt.Sprintf("Cloud DNS zone %s in %s, record %s", resolution.Zone, resolution.Project, describeHostnameRecord(resolution))
+}
+
+func describeHostnameSource(source string) string {
+	switch source {
+	case gcp.HostnameSourceSystem:
+		return "system resolver"
+	case gcp.HostnameSourceCloudDNS:
+		return "Cloud DNS"
+	default:
+		return source
+	}
+}
+
+// describeHostnameRecord returns the record of an address and the CNAME records leading to it.
+func describeHostnameRecord(resolution gcp.HostnameAddress) string {
+	if len(resolution.CNAMEs) == 0 {
+		return resolution.Record
+	}
+
+	return fmt.Sprintf("%s via CNAME %s", resolution.Record, strings.Join(resolution.CNAMEs, " → "))
+}
@@ -0,0 +1,34 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/kedare/compass/internal/gcp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDisplayHostnameResolutions(t *testing.T) {
+	resolutions := []gcp.HostnameAddress{
+		{Hostname: "www.example.com", Address: "203.0.113.10", Source: gcp.HostnameSourceSystem},
+		{
+			Hostname: "db.internal.example.com",
+			Address:  "10.0.0.5",
+			Source:   gcp.HostnameSourceCloudDNS,
+			Project:  "proj",
+			Zone:     "internal",
+			Record:   "primary.internal.example.com. A",
+			CNAMEs:   []string{"primary.internal.example.com."},
+		},
+	}
+
+	out := captureStdout(t, func() {
+		require.NoError(t, DisplayHostnameResolutions(resolutions, "text"))
+	})
+	require.Contains(t, out, "www.example.com → 203.0.113.10 (system resolver)\n")
+	require.Contains(t, out, "db.internal.example.com → 10.0.0.5 (Cloud DNS zone internal in proj, record primary.internal.example.com. A via CNAME primary.internal.example.com.)\n")
+
+	out = captureStdout(t, func() {
+		require.NoError(t, DisplayHostnameResolutions(resolutions, "json"))
+	})
+	require.Empty(t, out)
+}
@@ -0,0 +1,100 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kedare/compass/internal/cache"
+	"github.com/kedare/compass/internal/gcp"
+	"github.com/pterm/pterm"
+)
+
+// ipHistoryTimeLayout is used for first and last sightings in table and text output.
+const ipHistoryTimeLayout = "2006-01-02 15:04"
+
+// ipHistoryEntry is the JSON form of a recorded IP owner.
+type ipHistoryEntry struct {
+	Project      string    `json:"project"`
+	Kind         string    `json:"kind"`
+	Resource     string    `json:"resource"`
+	Location     string    `json:"location,omitempty"`
+	Details      string    `json:"details,omitempty"`
+	ResourceLink string    `json:"resource_link,omitempty"`
+	FirstSeen    time.Time `json:"first_seen"`
+	LastSeen     time.Time `json:"last_seen"`
+}
+
+// DisplayIPHistory renders the recorded owners of an IP address, oldest first.
+// Supported formats:
+//   - "json": object with the address and its owners
+//   - "table": one row per owner with first and last sightings
+//   - "text" (default): timeline, one line per owner
+func DisplayIPHistory(ip string, history []cache.IPSighting, format string) error {
+	switch strings.ToLower(format) {
+	case "json":
+		entries := make([]ipHistoryEntry, 0, len(history))
+		for _, sighting := range history {
+			entries = append(entries, ipHistoryEntry{
+				Project:      sighting.Project,
+				Kind:         sighting.Kind,
+				Resource:     sighting.Resource,
+				Location:     sighting.Location,
+				Details:      sighting.Details,
+				ResourceLink: sighting.ResourceLink,
+				FirstSeen:    sighting.FirstSeen.UTC(),
+				LastSeen:     sighting.LastSeen.UTC(),
+			})
+		}
+
+		return displayJSON(struct {
+			IP      string           `json:"ip"`
+			History []ipHistoryEntry `json:"history"`
+		}{IP: ip, History: entries})
+	case "table":
+		if len(history) == 0 {
+			fmt.Printf("No recorded owner for %s\n", ip)
+
+			return nil
+		}
+
+		tableData := pterm.TableData{{"First seen", "Last seen", "Project", "Type", "Resource", "Location"}}
+		for _, sighting := range history {
+			tableData = append(tableData, []string{
+				sighting.FirstSeen.Format(ipHistoryTimeLayout),
+				sighting.LastSeen.Format(ipHistoryTimeLayout),
+				sighting.Project,
+				describeAssociationKind(gcp.IPAssociationKind(sighting.Kind)),
+				sighting.Resource,
+				sighting.Location,
+			})
+		}
+
+		return pterm.DefaultTable.WithHasHeader().WithData(tableData).Render()
+	default:
+		if len(history) == 0 {
+			fmt.Printf("No recorded owner for %s\n", ip)
+
+			return nil
+		}
+
+		fmt.Printf("%s: %d recorded owner(s)\n\n", ip, len(history))
+		for _, sighting := range history {
+			fmt.Printf("%s → %s  %s\n", sighting.FirstSeen.Format(ipHistoryTimeLayout),
+				sighting.LastSeen.Format(ipHistoryTimeLayout), describeSighting(sighting))
+		}
+
+		return nil
+	}
+}
+
+// describeSighting returns e.g. "Instance (internal) prod/vm-1 (europe-west1-b)".
+func describeSighting(sighting cache.IPSighting) string {
+	owner := fmt.Sprintf("%s %s/%s", describeAssociationKind(gcp.IPAssociationKind(sighting.Kind)),
+		sighting.Project, sighting.Resource)
+	if sighting.Location != "" {
+		owner = fmt.Sprintf("%s (%s)", owner, sighting.Location)
+	}
+
+	return owner
+}
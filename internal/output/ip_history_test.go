@@ -0,0 +1,47 @@
+package output
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/kedare/compass/internal/cache"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDisplayIPHistory(t *testing.T) {
+	first := time.Date(2026, 10, 13, 9, 12, 0, 0, time.Local)
+	history := []cache.IPSighting{
+		{IP: "10.1.2.3", Project: "prod", Kind: "instance_internal", Resource: "vm-1", Location: "europe-west1-b",
+			FirstSeen: first, LastSeen: first.Add(8 * time.Hour)},
+		{IP: "10.1.2.3", Project: "prod", Kind: "forwarding_rule", Resource: "ilb",
+			FirstSeen: first.Add(24 * time.Hour), LastSeen: first.Add(48 * time.Hour)},
+	}
+
+	out := captureStdout(t, func() {
+		require.NoError(t, DisplayIPHistory("10.1.2.3", history, "text"))
+	})
+	require.Contains(t, out, "10.1.2.3: 2 recorded owner(s)")
+	require.Contains(t, out, "2026-10-13 09:12 → 2026-10-13 17:12  Instance (internal) prod/vm-1 (europe-west1-b)\n")
+	require.Contains(t, out, "prod/ilb\n")
+
+	out = captureStdout(t, func() {
+		require.NoError(t, DisplayIPHistory("10.1.2.3", history, "json"))
+	})
+	var decoded struct {
+		IP      string `json:"ip"`
+		History []struct {
+			Resource  string    `json:"resource"`
+			FirstSeen time.Time `json:"first_seen"`
+		} `json:"history"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(out), &decoded))
+	require.Equal(t, "10.1.2.3", decoded.IP)
+	require.Len(t, decoded.History, 2)
+	require.True(t, first.Equal(decoded.History[0].FirstSeen))
+
+	out = captureStdout(t, func() {
+		require.NoError(t, DisplayIPHistory("10.9.9.9", nil, "text"))
+	})
+	require.Equal(t, "No recorded owner for 10.9.9.9\n", out)
+}
@@ -75,9 +75,16 @@ func displayIPText(results []gcp.IPAssociation) error {
 		return nil
 	}
 
+	fmt.Printf("Found %d association(s):\n\n", len(results))
+	printIPAssociations(results)
+
+	return nil
+}
+
+// printIPAssociations prints one block per association with its contextual details.
+func printIPAssociations(results []gcp.IPAssociation) {
 	subnetCIDRs := buildSubnetCIDRMap(results)
 
-	fmt.Printf("Found %d association(s):\n\n", len(results))
 	for _, assoc := range results {
 		fmt.Printf("- %s • %s\n", assoc.Project, describeAssociationKind(assoc.Kind))
 
@@ -113,8 +120,6 @@ func displayIPText(results []gcp.IPAssociation) error {
 
 		fmt.Println()
 	}
-
-	return nil
 }
 
 func describeAssociationKind(kind gcp.IPAssociationKind) string {
@@ -129,6 +134,26 @@ func describeAssociationKind(kind gcp.IPAssociationKind) string {
 		return "Reserved address"
 	case gcp.IPAssociationSubnet:
 		return "Subnet range"
+	case gcp.IPAssociationAliasRange:
+		return "Alias IP range"
+	case gcp.IPAssociationPSCEndpoint:
+		return "Private Service Connect endpoint"
+	case gcp.IPAssociationGKEPodRange:
+		return "GKE pod range"
+	case gcp.IPAssociationGKEServiceRange:
+		return "GKE service range"
+	case gcp.IPAssociationGKEEndpoint:
+		return "GKE control plane"
+	case gcp.IPAssociationCloudSQL:
+		return "Cloud SQL instance"
+	case gcp.IPAssociationCloudNAT:
+		return "Cloud NAT"
+	case gcp.IPAssociationVPNGateway:
+		return "HA VPN gateway"
+	case gcp.IPAssociationVPNPeer:
+		return "VPN peer"
+	case gcp.IPAssociationRouterInterface:
+		return "Cloud Router interface"
 	default:
 		return string(kind)
 	}
@@ -183,6 +208,8 @@ func formatIPWithMask(assoc gcp.IPAssociation, subnetCIDRs map[string]string) st
 			return assoc.Resource
 		}
 		return strings.TrimSpace(assoc.IPAddress)
+	case gcp.IPAssociationAliasRange:
+		return strings.TrimSpace(assoc.IPAddress)
 	default:
 		ip := strings.TrimSpace(assoc.IPAddress)
 		if ip == "" {
@@ -291,8 +318,10 @@ func formatDetailNote(assoc gcp.IPAssociation, subnetCIDRs map[string]string) (s
 	default:
 		clean := stripDetailKeys(detail, []string{"network", "subnet", "cidr", "subnet_link", "region"})
 		clean = strings.ReplaceAll(clean, "gateway=true", "Google Cloud default gateway for this subnet")
-		extra := classifySpecialIP(assoc, subnetCIDRs)
-		if extra != "" {
+		for _, extra := range []string{classifySpecialIP(assoc, subnetCIDRs), classifyGoogleRange(assoc)} {
+			if extra == "" {
+				continue
+			}
 			if clean != "" {
 				clean = clean + "; " + extra
 			} else {
@@ -778,6 +807,10 @@ func humanizeRange(value string) string {
 		return fmt.Sprintf("secondary (%s)", strings.TrimPrefix(value, "secondary:"))
 	}
 
+	if strings.EqualFold(value, "external_ipv6") {
+		return "external IPv6"
+	}
+
 	return value
 }
 
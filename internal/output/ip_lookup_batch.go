@@ -0,0 +1,189 @@
+package output
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/kedare/compass/internal/gcp"
+	"github.com/pterm/pterm"
+)
+
+// noAssociation is shown in place of the resource of addresses nothing references.
+const noAssociation = "no association"
+
+// DisplayBatchIPLookupResults renders the associations of several looked up addresses, in the
+// order of targets, using the requested format. Addresses without any association are kept and,
+// except in JSON, annotated with their Google or special-purpose range.
+// Supported formats:
+//   - "json": object keyed by address, with an empty array when nothing references it
+//   - "table": one row per association, prefixed by the address
+//   - "csv": same columns as the table, for spreadsheets and scripts
+//   - "text" (default): one section per address
+func DisplayBatchIPLookupResults(targets []string, results map[string][]gcp.IPAssociation, format string) error {
+	switch strings.ToLower(format) {
+	case "json":
+		return displayJSON(keyedIPLookupResults(targets, results))
+	case "table":
+		rows := batchIPLookupRows(targets, results)
+		tableData := append(pterm.TableData{batchIPLookupHeader()}, rows...)
+
+		return pterm.DefaultTable.WithHasHeader().WithData(tableData).Render()
+	case "csv":
+		writer := csv.NewWriter(os.Stdout)
+		if err := writer.Write(batchIPLookupHeader()); err != nil {
+			return err
+		}
+		if err := writer.WriteAll(batchIPLookupRows(targets, results)); err != nil {
+			return err
+		}
+
+		return writer.Error()
+	default:
+		return displayBatchIPLookupText(targets, results)
+	}
+}
+
+// DisplayIPLookupDocument renders the associations of the looked up addresses along with the
+// resolutions of the looked up hostnames and the firewall rules and routes referencing each
+// address, as a single document. Resolutions are nil when no hostname was looked up, and
+// policies are nil when they were not requested.
+// Supported formats:
+//   - "json": object with the associations, the resolutions and the policies keyed by address
+//   - "csv": the batch lookup columns, preceded by the hostnames each address comes from, with
+//     one extra row per firewall rule and route
+func DisplayIPLookupDocument(resolutions []gcp.HostnameAddress, targets []string, results map[string][]gcp.IPAssociation, policies map[string]gcp.IPPolicy, format string) error {
+	if strings.EqualFold(format, "csv") {
+		var hostnames map[string][]string
+		header := batchIPLookupHeader()
+		if resolutions != nil {
+			hostnames = make(map[string][]string, len(resolutions))
+			seen := make(map[string]struct{}, len(resolutions))
+			for _, resolution := range resolutions {
+				key := resolution.Address + "|" + resolution.Hostname
+				if _, ok := seen[key]; ok {
+					continue
+				}
+				seen[key] = struct{}{}
+				hostnames[resolution.Address] = append(hostnames[resolution.Address], resolution.Hostname)
+			}
+			header = append([]string{"Hostname"}, header...)
+		}
+
+		writer := csv.NewWriter(os.Stdout)
+		if err := writer.Write(header); err != nil {
+			return err
+		}
+
+		for _, target := range targets {
+			rows := batchIPLookupRows([]string{target}, results)
+			if policies != nil {
+				rows = append(rows, ipPolicyRows(target, policies[target])...)
+			}
+
+			for _, row := range rows {
+				if hostnames != nil {
+					row = append([]string{strings.Join(hostnames[target], " ")}, row...)
+				}
+				if err := writer.Write(row); err != nil {
+					return err
+				}
+			}
+		}
+		writer.Flush()
+
+		return writer.Error()
+	}
+
+	document := map[string]interface{}{"results": keyedIPLookupResults(targets, results)}
+	if resolutions != nil {
+		document["resolutions"] = resolutions
+	}
+	if policies != nil {
+		keyed := make(map[string]gcp.IPPolicy, len(targets))
+		for _, target := range targets {
+			policy := policies[target]
+			if policy.FirewallRules == nil {
+				policy.FirewallRules = []gcp.FirewallRuleMatch{}
+			}
+			if policy.Routes == nil {
+				policy.Routes = []gcp.RouteMatch{}
+			}
+			keyed[target] = policy
+		}
+		document["policy"] = keyed
+	}
+
+	return displayJSON(document)
+}
+
+// keyedIPLookupResults returns the associations of each target, with an empty array when nothing references it.
+func keyedIPLookupResults(targets []string, results map[string][]gcp.IPAssociation) map[string][]gcp.IPAssociation {
+	keyed := make(map[string][]gcp.IPAssociation, len(targets))
+	for _, target := range targets {
+		associations := results[target]
+		if associations == nil {
+			associations = []gcp.IPAssociation{}
+		}
+		keyed[target] = associations
+	}
+
+	return keyed
+}
+
+func batchIPLookupHeader() []string {
+	return []string{"IP", "Project", "Type", "Resource", "Location", "Details"}
+}
+
+// batchIPLookupRows returns one row per association, or a single "no association" row per address.
+func batchIPLookupRows(targets []string, results map[string][]gcp.IPAssociation) [][]string {
+	rows := make([][]string, 0, len(targets))
+	for _, target := range targets {
+		associations := results[target]
+		if len(associations) == 0 {
+			rows = append(rows, []string{target, "", "", noAssociation, "", classifyTarget(target)})
+
+			continue
+		}
+
+		for _, assoc := range associations {
+			address := target
+			if assoc.IPAddress != "" && assoc.IPAddress != target {
+				// Prefix lookups report the address of each resource inside the range
+				address = fmt.Sprintf("%s (%s)", target, assoc.IPAddress)
+			}
+
+			rows = append(rows, []string{
+				address,
+				assoc.Project,
+				describeAssociationKind(assoc.Kind),
+				assoc.Resource,
+				assoc.Location,
+				detailForDisplay(assoc),
+			})
+		}
+	}
+
+	return rows
+}
+
+func displayBatchIPLookupText(targets []string, results map[string][]gcp.IPAssociation) error {
+	for _, target := range targets {
+		associations := results[target]
+		if len(associations) == 0 {
+			if description := classifyTarget(target); description != "" {
+				fmt.Printf("%s: %s (%s)\n\n", target, noAssociation, description)
+			} else {
+				fmt.Printf("%s: %s\n\n", target, noAssociation)
+			}
+
+			continue
+		}
+
+		fmt.Printf("%s: %d association(s)\n\n", target, len(associations))
+		printIPAssociations(associations)
+	}
+
+	return nil
+}
@@ -0,0 +1,102 @@
+package output
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/kedare/compass/internal/gcp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDisplayBatchIPLookupResults(t *testing.T) {
+	targets := []string{"10.0.0.5", "203.0.113.1"}
+	results := map[string][]gcp.IPAssociation{
+		"10.0.0.5": {{
+			Project:   "proj",
+			Kind:      gcp.IPAssociationInstanceInternal,
+			Resource:  "vm-1",
+			Location:  "us-central1-a",
+			IPAddress: "10.0.0.5",
+		}},
+	}
+
+	out := captureStdout(t, func() {
+		require.NoError(t, DisplayBatchIPLookupResults(targets, results, "csv"))
+	})
+	require.Contains(t, out, "IP,Project,Type,Resource,Location,Details\n")
+	require.Contains(t, out, "10.0.0.5,proj,")
+	require.Contains(t, out, "203.0.113.1,,,no association,,\"Documentation (TEST-NET-3, RFC 5737), range 203.0.113.0/24\"\n")
+
+	out = captureStdout(t, func() {
+		require.NoError(t, DisplayBatchIPLookupResults(targets, results, "json"))
+	})
+	var decoded map[string][]gcp.IPAssociation
+	require.NoError(t, json.Unmarshal([]byte(out), &decoded))
+	require.Len(t, decoded["10.0.0.5"], 1)
+	require.NotNil(t, decoded["203.0.113.1"])
+	require.Empty(t, decoded["203.0.113.1"])
+
+	out = captureStdout(t, func() {
+		require.NoError(t, DisplayBatchIPLookupResults(targets, results, "text"))
+	})
+	require.Contains(t, out, "10.0.0.5: 1 association(s)")
+	require.Contains(t, out, "203.0.113.1: no association")
+}
+
+func TestDisplayIPLookupDocument(t *testing.T) {
+	resolutions := []gcp.HostnameAddress{
+		{Hostname: "db.internal.example.com", Address: "10.0.0.5", Source: gcp.HostnameSourceCloudDNS, Project: "proj", Zone: "internal"},
+	}
+	targets := []string{"10.0.0.5", "10.0.0.9"}
+	results := map[string][]gcp.IPAssociation{
+		"10.0.0.5": {{Project: "proj", Kind: gcp.IPAssociationInstanceInternal, Resource: "db-1", IPAddress: "10.0.0.5"}},
+	}
+	policies := map[string]gcp.IPPolicy{
+		"10.0.0.5": {
+			FirewallRules: []gcp.FirewallRuleMatch{{
+				Project: "proj", Name: "allow-ssh", Network: "vpc", Direction: "ingress", Action: gcp.FirewallActionAllow,
+				Protocols: []string{"tcp:22"}, Priority: 1000, Field: gcp.FirewallRangeSource, Range: "10.0.0.0/8",
+			}},
+			Routes: []gcp.RouteMatch{{Project: "proj", Name: "to-onprem", Network: "vpc", DestRange: "10.0.0.0/16", NextHop: "tunnel-1", RouteType: "static", Priority: 100}},
+		},
+	}
+
+	out := captureStdout(t, func() {
+		require.NoError(t, DisplayIPLookupDocument(resolutions, targets, results, nil, "json"))
+	})
+	var decoded struct {
+		Resolutions []gcp.HostnameAddress          `json:"resolutions"`
+		Results     map[string][]gcp.IPAssociation `json:"results"`
+		Policy      map[string]gcp.IPPolicy        `json:"policy"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(out), &decoded))
+	require.Len(t, decoded.Resolutions, 1)
+	require.Len(t, decoded.Results["10.0.0.5"], 1)
+	require.NotNil(t, decoded.Results["10.0.0.9"])
+	require.Nil(t, decoded.Policy, "policies are left out unless requested")
+
+	out = captureStdout(t, func() {
+		require.NoError(t, DisplayIPLookupDocument(nil, targets, results, policies, "json"))
+	})
+	decoded.Resolutions, decoded.Policy = nil, nil
+	require.NoError(t, json.Unmarshal([]byte(out), &decoded))
+	require.Nil(t, decoded.Resolutions, "resolutions are left out when no hostname was looked up")
+	require.Len(t, decoded.Policy["10.0.0.5"].FirewallRules, 1)
+	require.Len(t, decoded.Policy["10.0.0.5"].Routes, 1)
+	require.NotNil(t, decoded.Policy["10.0.0.9"].FirewallRules)
+	require.Contains(t, out, `"routes": []`)
+
+	out = captureStdout(t, func() {
+		require.NoError(t, DisplayIPLookupDocument(resolutions, targets, results, nil, "csv"))
+	})
+	require.Contains(t, out, "Hostname,IP,Project,Type,Resource,Location,Details\n")
+	require.Contains(t, out, "db.internal.example.com,10.0.0.5,proj,")
+	require.Contains(t, out, ",10.0.0.9,,,no association,,")
+
+	out = captureStdout(t, func() {
+		require.NoError(t, DisplayIPLookupDocument(nil, targets, results, policies, "csv"))
+	})
+	require.Contains(t, out, "IP,Project,Type,Resource,Location,Details\n")
+	require.Contains(t, out, "10.0.0.5,proj,Firewall rule,allow-ssh,vpc,\"ingress allow tcp:22, source 10.0.0.0/8, priority 1000\"\n")
+	require.Contains(t, out, "10.0.0.5,proj,Route,to-onprem,vpc,\"10.0.0.0/16 via tunnel-1, priority 100, static\"\n")
+}
@@ -56,6 +56,15 @@ func TestFormatIPWithMask(t *testing.T) {
 	}
 
 	require.Equal(t, "10.0.0.2/29", formatIPWithMask(instanceAssoc, subnetCIDRs))
+
+	aliasAssoc := gcp.IPAssociation{
+		Project:   "proj-1",
+		Kind:      gcp.IPAssociationAliasRange,
+		IPAddress: "10.0.0.4/30",
+		Details:   "Alias IP range on interface nic0, network=vpc-1, subnet=subnet-a, range=primary",
+	}
+
+	require.Equal(t, "10.0.0.4/30", formatIPWithMask(aliasAssoc, subnetCIDRs))
 }
 
 func TestFormatAssociationPath(t *testing.T) {
@@ -180,3 +189,22 @@ func TestDisplayIPLookupResultsText(t *testing.T) {
 	require.Contains(t, output, "Subnet range")
 	require.Contains(t, output, "subnet-a (10.0.0.0/24)")
 }
+
+func TestServiceAssociationDisplay(t *testing.T) {
+	t.Parallel()
+
+	assoc := gcp.IPAssociation{
+		Project:   "proj-1",
+		Kind:      gcp.IPAssociationRouterInterface,
+		Resource:  "edge",
+		Location:  "europe-west1",
+		IPAddress: "169.254.10.1",
+		Details:   "Interface if-tunnel-1, cidr=169.254.10.0/30, tunnel=tunnel-1, network=vpc",
+	}
+
+	require.Equal(t, "Cloud Router interface", describeAssociationKind(assoc.Kind))
+	require.Equal(t, "169.254.10.1/30", formatIPWithMask(assoc, map[string]string{}))
+	require.Equal(t, "proj-1 > vpc > europe-west1", formatAssociationPath(assoc))
+	require.Equal(t, "GKE pod range", describeAssociationKind(gcp.IPAssociationGKEPodRange))
+	require.Equal(t, "Private Service Connect endpoint", describeAssociationKind(gcp.IPAssociationPSCEndpoint))
+}
@@ -0,0 +1,125 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kedare/compass/internal/gcp"
+	"github.com/pterm/pterm"
+)
+
+// DisplayPrefixOverlaps renders overlapping prefix pairs using the requested format.
+// Supported formats:
+//   - "json": raw JSON array of {a, b} prefix pairs
+//   - "table": one row per pair
+//   - "text" (default): pairs grouped by the two networks involved
+func DisplayPrefixOverlaps(overlaps []gcp.PrefixOverlap, format string) error {
+	switch strings.ToLower(format) {
+	case "json":
+		return displayJSON(overlaps)
+	case "table":
+		if len(overlaps) == 0 {
+			fmt.Println("No overlapping ranges found.")
+
+			return nil
+		}
+
+		tableData := pterm.TableData{
+			{"Network", "CIDR", "Resource", "Overlaps network", "CIDR", "Resource"},
+		}
+		for _, overlap := range overlaps {
+			tableData = append(tableData, []string{
+				overlap.A.Key(), overlap.A.CIDR, describePrefixResource(overlap.A),
+				overlap.B.Key(), overlap.B.CIDR, describePrefixResource(overlap.B),
+			})
+		}
+
+		return pterm.DefaultTable.WithHasHeader().WithData(tableData).Render()
+	default:
+		return displayPrefixOverlapsText(overlaps)
+	}
+}
+
+// DisplayPrefixConflicts renders the prefixes a candidate CIDR conflicts with.
+func DisplayPrefixConflicts(candidate string, conflicts []gcp.NetworkPrefix, format string) error {
+	switch strings.ToLower(format) {
+	case "json":
+		return displayJSON(struct {
+			Candidate string              `json:"candidate"`
+			Conflicts []gcp.NetworkPrefix `json:"conflicts"`
+		}{Candidate: candidate, Conflicts: conflicts})
+	case "table":
+		if len(conflicts) == 0 {
+			fmt.Printf("No conflict found for %s.\n", candidate)
+
+			return nil
+		}
+
+		tableData := pterm.TableData{
+			{"Network", "CIDR", "Resource", "Region"},
+		}
+		for _, conflict := range conflicts {
+			tableData = append(tableData, []string{conflict.Key(), conflict.CIDR, describePrefixResource(conflict), conflict.Region})
+		}
+
+		return pterm.DefaultTable.WithHasHeader().WithData(tableData).Render()
+	default:
+		if len(conflicts) == 0 {
+			fmt.Printf("No conflict found for %s.\n", candidate)
+
+			return nil
+		}
+
+		fmt.Printf("%s conflicts with %d range(s):\n\n", candidate, len(conflicts))
+		for _, conflict := range conflicts {
+			fmt.Printf("- %s • %s\n", conflict.Key(), formatPrefix(conflict))
+		}
+
+		return nil
+	}
+}
+
+func displayPrefixOverlapsText(overlaps []gcp.PrefixOverlap) error {
+	if len(overlaps) == 0 {
+		fmt.Println("No overlapping ranges found.")
+
+		return nil
+	}
+
+	fmt.Printf("Found %d overlap(s):\n", len(overlaps))
+
+	group := ""
+	for _, overlap := range overlaps {
+		header := overlap.A.Key()
+		if overlap.B.Key() != header {
+			header = fmt.Sprintf("%s ↔ %s", header, overlap.B.Key())
+		}
+
+		if header != group {
+			group = header
+			fmt.Printf("\n- %s\n", header)
+		}
+
+		fmt.Printf("  %s overlaps %s\n", formatPrefix(overlap.A), formatPrefix(overlap.B))
+	}
+
+	return nil
+}
+
+// formatPrefix renders a prefix with its origin, e.g. "10.0.0.0/24 (subnet-a, primary, us-central1)".
+func formatPrefix(prefix gcp.NetworkPrefix) string {
+	parts := filterSegments([]string{describePrefixResource(prefix), prefix.Region})
+	if len(parts) == 0 {
+		return prefix.CIDR
+	}
+
+	return fmt.Sprintf("%s (%s)", prefix.CIDR, strings.Join(parts, ", "))
+}
+
+func describePrefixResource(prefix gcp.NetworkPrefix) string {
+	if prefix.Source == gcp.PrefixSourceBGP {
+		return fmt.Sprintf("BGP %s", prefix.Resource)
+	}
+
+	return fmt.Sprintf("%s, %s", prefix.Resource, humanizeRange(prefix.Range))
+}
@@ -0,0 +1,18 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/kedare/compass/internal/gcp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatPrefix(t *testing.T) {
+	t.Parallel()
+
+	subnet := gcp.NetworkPrefix{Source: gcp.PrefixSourceSubnet, Resource: "subnet-a", Range: "secondary:pods", Region: "us-central1", CIDR: "10.4.0.0/14"}
+	require.Equal(t, "10.4.0.0/14 (subnet-a, secondary (pods), us-central1)", formatPrefix(subnet))
+
+	learned := gcp.NetworkPrefix{Source: gcp.PrefixSourceBGP, Resource: "tunnel-1/peer-1", Range: "learned", CIDR: "172.16.0.0/12"}
+	require.Equal(t, "172.16.0.0/12 (BGP tunnel-1/peer-1)", formatPrefix(learned))
+}
@@ -0,0 +1,161 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kedare/compass/internal/gcp"
+	"github.com/pterm/pterm"
+)
+
+// DisplayIPPolicies renders the firewall rules and routes referencing each looked up address,
+// after its associations. JSON and CSV include them in DisplayIPLookupDocument instead.
+// Supported formats:
+//   - "table": a firewall rule table and a route table per address
+//   - "text" (default): one section per address, one line per rule and route
+func DisplayIPPolicies(targets []string, policies map[string]gcp.IPPolicy, format string) error {
+	switch strings.ToLower(format) {
+	case "json", "csv":
+		return nil
+	case "table":
+		for _, target := range targets {
+			policy := policies[target]
+
+			fmt.Println()
+			if len(policy.FirewallRules) == 0 && len(policy.Routes) == 0 {
+				fmt.Printf("No firewall rule or route references %s\n", target)
+
+				continue
+			}
+
+			if len(policy.FirewallRules) > 0 {
+				fmt.Printf("Firewall rules referencing %s:\n", target)
+				tableData := pterm.TableData{{"Project", "Rule", "Network", "Direction", "Action", "Protocols", "Priority", "Range"}}
+				for _, rule := range policy.FirewallRules {
+					tableData = append(tableData, []string{
+						rule.Project,
+						describeFirewallRuleName(rule),
+						rule.Network,
+						rule.Direction,
+						rule.Action,
+						strings.Join(rule.Protocols, ", "),
+						fmt.Sprintf("%d", rule.Priority),
+						fmt.Sprintf("%s %s", rule.Field, rule.Range),
+					})
+				}
+
+				if err := pterm.DefaultTable.WithHasHeader().WithData(tableData).Render(); err != nil {
+					return err
+				}
+			}
+
+			if len(policy.Routes) > 0 {
+				fmt.Printf("Routes to %s (longest prefix first):\n", target)
+				tableData := pterm.TableData{{"Project", "Route", "Network", "Destination", "Next hop", "Type", "Priority"}}
+				for _, route := range policy.Routes {
+					tableData = append(tableData, []string{
+						route.Project,
+						route.Name,
+						route.Network,
+						route.DestRange,
+						route.NextHop,
+						route.RouteType,
+						fmt.Sprintf("%d", route.Priority),
+					})
+				}
+
+				if err := pterm.DefaultTable.WithHasHeader().WithData(tableData).Render(); err != nil {
+					return err
+				}
+			}
+		}
+
+		return nil
+	default:
+		for _, target := range targets {
+			policy := policies[target]
+
+			fmt.Println()
+			if len(policy.FirewallRules) == 0 && len(policy.Routes) == 0 {
+				fmt.Printf("No firewall rule or route references %s\n", target)
+
+				continue
+			}
+
+			if len(policy.FirewallRules) > 0 {
+				fmt.Printf("Firewall rules referencing %s:\n", target)
+				for _, rule := range policy.FirewallRules {
+					fmt.Printf("- %s • %s (%s): %s\n", rule.Project, describeFirewallRuleName(rule), rule.Network, describeFirewallRuleMatch(rule))
+				}
+			}
+
+			if len(policy.Routes) > 0 {
+				fmt.Printf("Routes to %s (longest prefix first):\n", target)
+				for _, route := range policy.Routes {
+					fmt.Printf("- %s • %s (%s): %s\n", route.Project, route.Name, route.Network, describeRouteMatch(route))
+				}
+			}
+		}
+
+		return nil
+	}
+}
+
+// ipPolicyRows returns the firewall rules and routes of an address in the batch lookup columns.
+func ipPolicyRows(target string, policy gcp.IPPolicy) [][]string {
+	rows := make([][]string, 0, len(policy.FirewallRules)+len(policy.Routes))
+	for _, rule := range policy.FirewallRules {
+		rows = append(rows, []string{target, rule.Project, "Firewall rule", describeFirewallRuleName(rule), rule.Network, describeFirewallRuleMatch(rule)})
+	}
+
+	for _, route := range policy.Routes {
+		rows = append(rows, []string{target, route.Project, "Route", route.Name, route.Network, describeRouteMatch(route)})
+	}
+
+	return rows
+}
+
+func describeFirewallRuleName(rule gcp.FirewallRuleMatch) string {
+	if rule.Disabled {
+		return rule.Name + " (disabled)"
+	}
+
+	return rule.Name
+}
+
+// describeFirewallRuleMatch summarizes a firewall rule, e.g.
+// "ingress allow tcp:22, source 10.0.0.0/8, priority 1000".
+func describeFirewallRuleMatch(rule gcp.FirewallRuleMatch) string {
+	action := rule.Action
+	if len(rule.Protocols) > 0 {
+		action += " " + strings.Join(rule.Protocols, ", ")
+	}
+
+	parts := []string{
+		strings.TrimSpace(rule.Direction + " " + action),
+		fmt.Sprintf("%s %s", rule.Field, rule.Range),
+		fmt.Sprintf("priority %d", rule.Priority),
+	}
+	if len(rule.TargetTags) > 0 {
+		parts = append(parts, "tags "+strings.Join(rule.TargetTags, ", "))
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// describeRouteMatch summarizes a route, e.g. "10.128.0.0/16 via vpn-tunnel-1, priority 100, static".
+func describeRouteMatch(route gcp.RouteMatch) string {
+	parts := []string{route.DestRange}
+	if route.NextHop != "" {
+		parts[0] += " via " + route.NextHop
+	}
+	parts = append(parts, fmt.Sprintf("priority %d", route.Priority))
+	if route.RouteType != "" {
+		parts = append(parts, route.RouteType)
+	}
+	if len(route.Tags) > 0 {
+		parts = append(parts, "tags "+strings.Join(route.Tags, ", "))
+	}
+
+	return strings.Join(parts, ", ")
+}
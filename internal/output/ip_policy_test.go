@@ -0,0 +1,34 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/kedare/compass/internal/gcp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDisplayIPPolicies(t *testing.T) {
+	targets := []string{"10.0.0.5", "192.0.2.1"}
+	policies := map[string]gcp.IPPolicy{
+		"10.0.0.5": {
+			FirewallRules: []gcp.FirewallRuleMatch{{
+				Project: "proj", Name: "deny-db", Network: "vpc", Direction: "ingress", Action: gcp.FirewallActionDeny,
+				Protocols: []string{"tcp:5432"}, Priority: 900, Disabled: true, Field: gcp.FirewallRangeSource, Range: "10.0.0.5",
+				TargetTags: []string{"db"},
+			}},
+			Routes: []gcp.RouteMatch{{Project: "proj", Name: "default-internet", Network: "vpc", DestRange: "0.0.0.0/0", NextHop: "default-internet-gateway", Priority: 1000}},
+		},
+	}
+
+	out := captureStdout(t, func() {
+		require.NoError(t, DisplayIPPolicies(targets, policies, "text"))
+	})
+	require.Contains(t, out, "Firewall rules referencing 10.0.0.5:\n- proj • deny-db (disabled) (vpc): ingress deny tcp:5432, source 10.0.0.5, priority 900, tags db\n")
+	require.Contains(t, out, "Routes to 10.0.0.5 (longest prefix first):\n- proj • default-internet (vpc): 0.0.0.0/0 via default-internet-gateway, priority 1000\n")
+	require.Contains(t, out, "No firewall rule or route references 192.0.2.1\n")
+
+	out = captureStdout(t, func() {
+		require.NoError(t, DisplayIPPolicies(targets, policies, "csv"))
+	})
+	require.Empty(t, out, "CSV and JSON render policies with the associations")
+}
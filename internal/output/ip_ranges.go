@@ -0,0 +1,124 @@
+package output
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/kedare/compass/internal/gcp"
+	"github.com/kedare/compass/internal/ipranges"
+	"github.com/pterm/pterm"
+)
+
+// classifyAddress is the classification used by the renderers, overridden in tests.
+var classifyAddress = func(ip net.IP) *ipranges.Classification {
+	return ipranges.Default().Classify(ip)
+}
+
+// DisplayIPClassification renders what is known about an address no resource references.
+// Supported formats:
+//   - "json": object with the address and its classification (null when unknown)
+//   - "table"/"text" (default): one sentence, nothing for an unknown public address
+func DisplayIPClassification(ip string, class *ipranges.Classification, format string) error {
+	if strings.EqualFold(format, "json") {
+		return displayJSON(struct {
+			IP             string                   `json:"ip"`
+			Classification *ipranges.Classification `json:"classification"`
+		}{IP: ip, Classification: class})
+	}
+
+	if description := describeClassification(class); description != "" {
+		fmt.Printf("%s: %s\n", ip, description)
+	}
+
+	return nil
+}
+
+// DisplayIPRanges renders the metadata of the loaded Google IP range lists.
+func DisplayIPRanges(set *ipranges.Set, format string) error {
+	switch strings.ToLower(format) {
+	case "json":
+		return displayJSON(set)
+	case "table":
+		tableData := pterm.TableData{{"List", "Prefixes", "Created", "Source"}}
+		for _, meta := range []struct {
+			name string
+			data ipranges.Metadata
+		}{{"goog.json", set.Google}, {"cloud.json", set.Cloud}} {
+			tableData = append(tableData, []string{
+				meta.name,
+				fmt.Sprintf("%d", meta.data.Prefixes),
+				meta.data.CreationTime,
+				meta.data.Source,
+			})
+		}
+
+		return pterm.DefaultTable.WithHasHeader().WithData(tableData).Render()
+	default:
+		fmt.Printf("goog.json: %d prefixes (created %s)\n", set.Google.Prefixes, valueOrDash(set.Google.CreationTime))
+		fmt.Printf("cloud.json: %d prefixes (created %s)\n", set.Cloud.Prefixes, valueOrDash(set.Cloud.CreationTime))
+
+		return nil
+	}
+}
+
+// describeClassification returns a short sentence such as
+// "Google Cloud address (europe-west1, range 34.76.0.0/16)".
+func describeClassification(class *ipranges.Classification) string {
+	if class == nil {
+		return ""
+	}
+
+	var label string
+	switch class.Category {
+	case ipranges.CategoryGoogleCloud:
+		label = "Google Cloud address"
+	case ipranges.CategoryGoogle:
+		label = "Google address"
+	default:
+		if class.Purpose != "" {
+			return fmt.Sprintf("%s, range %s", class.Purpose, class.Prefix)
+		}
+
+		return fmt.Sprintf("Special-purpose address, range %s", class.Prefix)
+	}
+
+	parts := filterSegments([]string{class.Purpose, class.Scope, "range " + class.Prefix})
+
+	return fmt.Sprintf("%s (%s)", label, strings.Join(parts, ", "))
+}
+
+// classifyGoogleRange describes the Google range of the address of an association, if any.
+// Other special-purpose ranges are left out as the association already tells where it belongs.
+func classifyGoogleRange(assoc gcp.IPAssociation) string {
+	ip := net.ParseIP(strings.TrimSpace(assoc.IPAddress))
+	if ip == nil {
+		return ""
+	}
+
+	class := classifyAddress(ip)
+	if !class.IsGoogle() {
+		return ""
+	}
+
+	return describeClassification(class)
+}
+
+// classifyTarget describes a looked up address nothing references, or "" for a range or an
+// unknown public address.
+func classifyTarget(target string) string {
+	ip := net.ParseIP(target)
+	if ip == nil {
+		return ""
+	}
+
+	return describeClassification(classifyAddress(ip))
+}
+
+func valueOrDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+
+	return value
+}
@@ -0,0 +1,57 @@
+package output
+
+import (
+	"net"
+	"testing"
+
+	"github.com/kedare/compass/internal/gcp"
+	"github.com/kedare/compass/internal/ipranges"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDescribeClassification(t *testing.T) {
+	require.Empty(t, describeClassification(nil))
+	require.Equal(t, "Google Cloud address (Identity-Aware Proxy TCP forwarding, us-west2, range 35.235.240.0/20)",
+		describeClassification(&ipranges.Classification{
+			Category: ipranges.CategoryGoogleCloud,
+			Prefix:   "35.235.240.0/20",
+			Scope:    "us-west2",
+			Purpose:  "Identity-Aware Proxy TCP forwarding",
+		}))
+	require.Equal(t, "Google address (range 34.64.0.0/10)",
+		describeClassification(&ipranges.Classification{Category: ipranges.CategoryGoogle, Prefix: "34.64.0.0/10"}))
+	require.Equal(t, "Private network (RFC 1918), range 10.0.0.0/8",
+		describeClassification(&ipranges.Classification{
+			Category: ipranges.CategoryPrivate,
+			Prefix:   "10.0.0.0/8",
+			Purpose:  "Private network (RFC 1918)",
+		}))
+}
+
+func TestClassifyGoogleRange(t *testing.T) {
+	original := classifyAddress
+	t.Cleanup(func() { classifyAddress = original })
+	classifyAddress = func(ip net.IP) *ipranges.Classification {
+		if ip.Equal(net.ParseIP("34.77.1.2")) {
+			return &ipranges.Classification{Category: ipranges.CategoryGoogleCloud, Prefix: "34.76.0.0/14", Scope: "europe-west1"}
+		}
+
+		return &ipranges.Classification{Category: ipranges.CategoryPrivate, Prefix: "10.0.0.0/8"}
+	}
+
+	require.Equal(t, "Google Cloud address (europe-west1, range 34.76.0.0/14)",
+		classifyGoogleRange(gcp.IPAssociation{IPAddress: "34.77.1.2"}))
+	require.Empty(t, classifyGoogleRange(gcp.IPAssociation{IPAddress: "10.0.0.5"}))
+
+	note, _ := formatDetailNote(gcp.IPAssociation{
+		Kind:      gcp.IPAssociationForwardingRule,
+		IPAddress: "34.77.1.2",
+		Details:   "scheme=EXTERNAL",
+	}, nil)
+	require.Contains(t, note, "Google Cloud address (europe-west1, range 34.76.0.0/14)")
+
+	out := captureStdout(t, func() {
+		require.NoError(t, DisplayIPClassification("34.77.1.2", classifyAddress(net.ParseIP("34.77.1.2")), "text"))
+	})
+	require.Equal(t, "34.77.1.2: Google Cloud address (europe-west1, range 34.76.0.0/14)\n", out)
+}
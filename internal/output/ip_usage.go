@@ -0,0 +1,119 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kedare/compass/internal/gcp"
+	"github.com/pterm/pterm"
+)
+
+// DisplaySubnetUsage renders subnet range utilisation using the requested format.
+// Supported formats:
+//   - "json": raw JSON array including the allocations of every range
+//   - "table": one row per range, suitable for terminals
+//   - "text" (default): one block per range grouped by subnet
+func DisplaySubnetUsage(usages []*gcp.SubnetUsage, format string) error {
+	switch strings.ToLower(format) {
+	case "json":
+		return displayJSON(usages)
+	case "table":
+		return displaySubnetUsageTable(usages)
+	default:
+		return displaySubnetUsageText(usages)
+	}
+}
+
+func displaySubnetUsageTable(usages []*gcp.SubnetUsage) error {
+	tableData := pterm.TableData{
+		{"Project", "Network", "Region", "Subnet", "Range", "CIDR", "Used", "Reserved", "Free", "Usage", "Next free", "Largest free"},
+	}
+
+	for _, usage := range usages {
+		for _, r := range usage.Ranges {
+			tableData = append(tableData, []string{
+				usage.Project,
+				usage.Network,
+				usage.Region,
+				usage.Subnet,
+				rangeLabel(r),
+				r.CIDR,
+				fmt.Sprintf("%d", r.Used),
+				fmt.Sprintf("%d", r.Reserved),
+				fmt.Sprintf("%d", r.Free),
+				formatUtilization(r.Utilization),
+				r.NextFree,
+				firstOrEmpty(r.LargestFreeBlocks),
+			})
+		}
+	}
+
+	return pterm.DefaultTable.WithHasHeader().WithData(tableData).Render()
+}
+
+func displaySubnetUsageText(usages []*gcp.SubnetUsage) error {
+	for _, usage := range usages {
+		fmt.Printf("- %s • %s\n", usage.Subnet, strings.Join(filterSegments([]string{usage.Project, usage.Network, usage.Region}), " > "))
+
+		if len(usage.Ranges) == 0 {
+			fmt.Println("  No range to report")
+			fmt.Println()
+
+			continue
+		}
+
+		for _, r := range usage.Ranges {
+			pairs := []labelValue{
+				{Label: "Range", Value: fmt.Sprintf("%s (%s)", r.CIDR, rangeLabel(r))},
+				{Label: "Usage", Value: fmt.Sprintf("%s, %d used, %d free, %d reserved of %d%s", formatUtilization(r.Utilization), r.Used, r.Free, r.Reserved, r.Total, unitSuffix(r))},
+				{Label: "Next free", Value: r.NextFree},
+				{Label: "Free blocks", Value: strings.Join(r.LargestFreeBlocks, ", ")},
+			}
+
+			if block := renderAssociationPanels(pairs); block != "" {
+				fmt.Print(block)
+			}
+		}
+
+		fmt.Println()
+	}
+
+	return nil
+}
+
+// rangeLabel names a range for display, e.g. "primary", "secondary: pods" or "external IPv6".
+func rangeLabel(r gcp.RangeUsage) string {
+	switch r.Kind {
+	case gcp.RangeSecondary:
+		if r.Name != "" {
+			return fmt.Sprintf("secondary: %s", r.Name)
+		}
+	case gcp.RangeIPv6:
+		return "IPv6"
+	case gcp.RangeExternalIPv6:
+		return "external IPv6"
+	}
+
+	return r.Kind
+}
+
+// unitSuffix tells what the counts of a range are when they are not addresses, e.g. " /96 blocks".
+func unitSuffix(r gcp.RangeUsage) string {
+	if r.UnitPrefixLength == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf(" /%d blocks", r.UnitPrefixLength)
+}
+
+func formatUtilization(percent float64) string {
+	return fmt.Sprintf("%.1f%%", percent)
+}
+
+func firstOrEmpty(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+
+	return values[0]
+}
@@ -3,10 +3,8 @@ package tui
 import (
 	"context"
 	"fmt"
-	"net/url"
 	"os"
 	"os/exec"
-	"path"
 	"strings"
 
 	"github.com/gdamore/tcell/v2"
@@ -16,11 +14,6 @@ import (
 	"github.com/rivo/tview"
 )
 
-const (
-	// cloudConsoleBaseURL is the base URL for Google Cloud Console
-	cloudConsoleBaseURL = "https://console.cloud.google.com"
-)
-
 // ResourceAction represents an action that can be performed on a resource
 type ResourceAction struct {
 	Key         rune   // Key to trigger the action
@@ -46,34 +39,25 @@ type ResourceActionHandler interface {
 	Execute(key rune, ctx *ActionContext) bool
 }
 
+// resourceActions maps registry actions to their key bindings
+var resourceActions = map[search.Action]ResourceAction{
+	search.ActionSSH:     {Key: 's', Name: "SSH", Description: "SSH to instance"},
+	search.ActionDetails: {Key: 'd', Name: "Details", Description: "Show details"},
+	search.ActionBrowser: {Key: 'b', Name: "Browser", Description: "Open in Cloud Console"},
+	search.ActionOpen:    {Key: 'o', Name: "Open", Description: "Open in browser"},
+	search.ActionRelated: {Key: 'r', Name: "Related", Description: "Show related resources"},
+}
+
 // GetActionsForResourceType returns the available actions for a resource type
 func GetActionsForResourceType(resourceType string) []ResourceAction {
-	switch resourceType {
-	case string(search.KindComputeInstance):
-		return []ResourceAction{
-			{Key: 's', Name: "SSH", Description: "SSH to instance"},
-			{Key: 'd', Name: "Details", Description: "Show details"},
-			{Key: 'b', Name: "Browser", Description: "Open in Cloud Console"},
-		}
-	case string(search.KindManagedInstanceGroup):
-		return []ResourceAction{
-			{Key: 's', Name: "SSH", Description: "SSH to MIG instance"},
-			{Key: 'd', Name: "Details", Description: "Show details"},
-			{Key: 'b', Name: "Browser", Description: "Open in Cloud Console"},
-		}
-	case string(search.KindBucket):
-		return []ResourceAction{
-			{Key: 'd', Name: "Details", Description: "Show details"},
-			{Key: 'b', Name: "Browser", Description: "Open in Cloud Console"},
-			{Key: 'o', Name: "Open", Description: "Open bucket in browser"},
-		}
-	default:
-		// Most resource types support details and browser
-		return []ResourceAction{
-			{Key: 'd', Name: "Details", Description: "Show details"},
-			{Key: 'b', Name: "Browser", Description: "Open in Cloud Console"},
+	kindActions := search.ActionsFor(search.ResourceKind(resourceType))
+	actions := make([]ResourceAction, 0, len(kindActions))
+	for _, action := range kindActions {
+		if binding, ok := resourceActions[action]; ok {
+			actions = append(actions, binding)
 		}
 	}
+	return actions
 }
 
 // FormatActionsStatusBar formats actions for display in the status bar
@@ -521,9 +505,11 @@ func ShowMIGInstanceSelectionModal(app *tview.Application, migName string, insta
 }
 
 // ExecuteDetails fetches and displays instance details.
+// The fetched instance is passed along with the formatted details so callers can offer
+// follow-up navigation (e.g. to attached service accounts).
 // Note: The caller should set any "loading" status message before calling this method,
 // as this method spawns a goroutine and returns immediately.
-func (e *InstanceActionExecutor) ExecuteDetails(ctx *ActionContext, showDetailFunc func(details string)) {
+func (e *InstanceActionExecutor) ExecuteDetails(ctx *ActionContext, showDetailFunc func(details string, instance *gcp.Instance)) {
 	go func() {
 		client, err := gcp.NewClient(ctx.Ctx, e.Project)
 		if err != nil {
@@ -561,7 +547,7 @@ func (e *InstanceActionExecutor) ExecuteDetails(ctx *ActionContext, showDetailFu
 		details := FormatInstanceDetails(instance, e.Project)
 
 		ctx.App.QueueUpdateDraw(func() {
-			showDetailFunc(details)
+			showDetailFunc(details, instance)
 		})
 	}()
 }
@@ -934,6 +920,594 @@ func FormatMIGDetails(name, project, location string, detailsMap map[string]stri
 	return details.String()
 }
 
+// FormatPubSubTopicDetails formats Pub/Sub topic details from search result metadata
+func FormatPubSubTopicDetails(name, project string, detailsMap map[string]string) string {
+	var details strings.Builder
+
+	details.WriteString("[yellow::b]Pub/Sub Topic[-:-:-]\n\n")
+
+	details.WriteString(fmt.Sprintf("[white::b]Name:[-:-:-]             %s\n", name))
+	details.WriteString(fmt.Sprintf("[white::b]Project:[-:-:-]          %s\n", project))
+	if state := detailsMap["state"]; state != "" {
+		details.WriteString(fmt.Sprintf("[white::b]State:[-:-:-]            %s\n", state))
+	}
+
+	details.WriteString("\n")
+
+	details.WriteString("[cyan::b]Storage[-:-:-]\n")
+	retention := detailsMap["retention"]
+	if retention == "" {
+		retention = "not retained after delivery"
+	}
+	details.WriteString(fmt.Sprintf("  [white::b]Retention:[-:-:-]        %s\n", retention))
+	if regions := detailsMap["allowedRegions"]; regions != "" {
+		details.WriteString(fmt.Sprintf("  [white::b]Allowed Regions:[-:-:-]  %s\n", regions))
+	}
+	if kmsKey := detailsMap["kmsKey"]; kmsKey != "" {
+		details.WriteString(fmt.Sprintf("  [white::b]KMS Key:[-:-:-]          %s\n", kmsKey))
+	}
+
+	if schema := detailsMap["schema"]; schema != "" {
+		details.WriteString("\n")
+		details.WriteString("[cyan::b]Schema[-:-:-]\n")
+		details.WriteString(fmt.Sprintf("  [white::b]Schema:[-:-:-]           %s\n", schema))
+		if encoding := detailsMap["schemaEncoding"]; encoding != "" {
+			details.WriteString(fmt.Sprintf("  [white::b]Encoding:[-:-:-]         %s\n", encoding))
+		}
+	}
+
+	details.WriteString("\n[darkgray]Press Esc to close[-]")
+	return details.String()
+}
+
+// FormatPubSubSubscriptionDetails formats Pub/Sub subscription details from search result metadata
+func FormatPubSubSubscriptionDetails(name, project string, detailsMap map[string]string) string {
+	var details strings.Builder
+
+	details.WriteString("[yellow::b]Pub/Sub Subscription[-:-:-]\n\n")
+
+	details.WriteString(fmt.Sprintf("[white::b]Name:[-:-:-]             %s\n", name))
+	details.WriteString(fmt.Sprintf("[white::b]Project:[-:-:-]          %s\n", project))
+	if topic := detailsMap["topic"]; topic != "" {
+		details.WriteString(fmt.Sprintf("[white::b]Topic:[-:-:-]            %s\n", topic))
+	}
+	if state := detailsMap["state"]; state != "" {
+		details.WriteString(fmt.Sprintf("[white::b]State:[-:-:-]            %s\n", state))
+	}
+	if detailsMap["detached"] == "true" {
+		details.WriteString("[white::b]Detached:[-:-:-]         [red]Yes[-]\n")
+	}
+
+	details.WriteString("\n")
+
+	// Delivery
+	details.WriteString("[cyan::b]Delivery[-:-:-]\n")
+	if delivery := detailsMap["delivery"]; delivery != "" {
+		details.WriteString(fmt.Sprintf("  [white::b]Type:[-:-:-]             %s\n", delivery))
+	}
+	if endpoint := detailsMap["pushEndpoint"]; endpoint != "" {
+		details.WriteString(fmt.Sprintf("  [white::b]Push Endpoint:[-:-:-]    %s\n", endpoint))
+	}
+	if table := detailsMap["bigqueryTable"]; table != "" {
+		details.WriteString(fmt.Sprintf("  [white::b]BigQuery Table:[-:-:-]   %s\n", table))
+	}
+	if bucket := detailsMap["bucket"]; bucket != "" {
+		details.WriteString(fmt.Sprintf("  [white::b]Bucket:[-:-:-]           %s\n", bucket))
+	}
+	if ackDeadline := detailsMap["ackDeadline"]; ackDeadline != "" {
+		details.WriteString(fmt.Sprintf("  [white::b]Ack Deadline:[-:-:-]     %s\n", ackDeadline))
+	}
+	if retention := detailsMap["retention"]; retention != "" {
+		details.WriteString(fmt.Sprintf("  [white::b]Retention:[-:-:-]        %s\n", retention))
+	}
+	if detailsMap["ordering"] == "true" {
+		details.WriteString("  [white::b]Message Ordering:[-:-:-] [green]Enabled[-]\n")
+	}
+	if detailsMap["exactlyOnce"] == "true" {
+		details.WriteString("  [white::b]Exactly Once:[-:-:-]     [green]Enabled[-]\n")
+	}
+
+	if filter := detailsMap["filter"]; filter != "" {
+		details.WriteString("\n")
+		details.WriteString("[cyan::b]Filter[-:-:-]\n")
+		details.WriteString(fmt.Sprintf("  %s\n", tview.Escape(filter)))
+	}
+
+	if dlq := detailsMap["deadLetterTopic"]; dlq != "" {
+		details.WriteString("\n")
+		details.WriteString("[cyan::b]Dead Letter Policy[-:-:-]\n")
+		details.WriteString(fmt.Sprintf("  [white::b]Topic:[-:-:-]            %s\n", dlq))
+		if attempts := detailsMap["maxDeliveryAttempts"]; attempts != "" {
+			details.WriteString(fmt.Sprintf("  [white::b]Max Attempts:[-:-:-]     %s\n", attempts))
+		}
+	}
+
+	details.WriteString("\n[darkgray]Press Esc to close[-]")
+	return details.String()
+}
+
+// FormatDNSManagedZoneDetails formats Cloud DNS managed zone details from search result metadata
+func FormatDNSManagedZoneDetails(name, project string, detailsMap map[string]string) string {
+	var details strings.Builder
+
+	details.WriteString("[yellow::b]Cloud DNS Zone[-:-:-]\n\n")
+
+	details.WriteString(fmt.Sprintf("[white::b]Name:[-:-:-]             %s\n", name))
+	details.WriteString(fmt.Sprintf("[white::b]Project:[-:-:-]          %s\n", project))
+	if dnsName := detailsMap["dnsName"]; dnsName != "" {
+		details.WriteString(fmt.Sprintf("[white::b]DNS Name:[-:-:-]         %s\n", dnsName))
+	}
+	if visibility := detailsMap["visibility"]; visibility != "" {
+		details.WriteString(fmt.Sprintf("[white::b]Visibility:[-:-:-]       %s\n", visibility))
+	}
+	if desc := detailsMap["description"]; desc != "" {
+		details.WriteString(fmt.Sprintf("[white::b]Description:[-:-:-]      %s\n", desc))
+	}
+	if dnssec := detailsMap["dnssec"]; dnssec != "" {
+		details.WriteString(fmt.Sprintf("[white::b]DNSSEC:[-:-:-]           %s\n", dnssec))
+	}
+
+	if networks := detailsMap["networks"]; networks != "" {
+		details.WriteString("\n")
+		details.WriteString("[cyan::b]Attached Networks[-:-:-]\n")
+		for _, network := range strings.Split(networks, ", ") {
+			details.WriteString(fmt.Sprintf("  %s\n", network))
+		}
+	}
+
+	if targets := detailsMap["forwardingTargets"]; targets != "" {
+		details.WriteString("\n")
+		details.WriteString("[cyan::b]Forwarding Targets[-:-:-]\n")
+		for _, target := range strings.Split(targets, ", ") {
+			details.WriteString(fmt.Sprintf("  %s\n", target))
+		}
+	}
+
+	if peering := detailsMap["peeringNetwork"]; peering != "" {
+		details.WriteString("\n")
+		details.WriteString("[cyan::b]Peering[-:-:-]\n")
+		details.WriteString(fmt.Sprintf("  [white::b]Target Network:[-:-:-]   %s\n", peering))
+	}
+
+	if nameServers := detailsMap["nameServers"]; nameServers != "" {
+		details.WriteString("\n")
+		details.WriteString("[cyan::b]Name Servers[-:-:-]\n")
+		for _, ns := range strings.Split(nameServers, ", ") {
+			details.WriteString(fmt.Sprintf("  %s\n", ns))
+		}
+	}
+
+	details.WriteString("\n[darkgray]Press Esc to close[-]")
+	return details.String()
+}
+
+// FormatDNSRecordSetDetails formats Cloud DNS record set details from search result metadata
+func FormatDNSRecordSetDetails(name, project string, detailsMap map[string]string) string {
+	var details strings.Builder
+
+	details.WriteString("[yellow::b]Cloud DNS Record Set[-:-:-]\n\n")
+
+	if record := detailsMap["record"]; record != "" {
+		name = record
+	}
+	details.WriteString(fmt.Sprintf("[white::b]Name:[-:-:-]             %s\n", name))
+	details.WriteString(fmt.Sprintf("[white::b]Project:[-:-:-]          %s\n", project))
+	if zone := detailsMap["zone"]; zone != "" {
+		details.WriteString(fmt.Sprintf("[white::b]Zone:[-:-:-]             %s\n", zone))
+	}
+	if visibility := detailsMap["visibility"]; visibility != "" {
+		details.WriteString(fmt.Sprintf("[white::b]Visibility:[-:-:-]       %s\n", visibility))
+	}
+	if networks := detailsMap["networks"]; networks != "" {
+		details.WriteString(fmt.Sprintf("[white::b]Networks:[-:-:-]         %s\n", networks))
+	}
+
+	details.WriteString("\n")
+
+	details.WriteString("[cyan::b]Record[-:-:-]\n")
+	if recordType := detailsMap["type"]; recordType != "" {
+		details.WriteString(fmt.Sprintf("  [white::b]Type:[-:-:-]             %s\n", recordType))
+	}
+	if ttl := detailsMap["ttl"]; ttl != "" {
+		details.WriteString(fmt.Sprintf("  [white::b]TTL:[-:-:-]              %ss\n", ttl))
+	}
+	if policy := detailsMap["routingPolicy"]; policy != "" {
+		details.WriteString(fmt.Sprintf("  [white::b]Routing Policy:[-:-:-]   %s\n", policy))
+	}
+
+	if data := detailsMap["data"]; data != "" {
+		details.WriteString("\n")
+		details.WriteString("[cyan::b]Data[-:-:-]\n")
+		for _, rrdata := range strings.Split(data, ", ") {
+			details.WriteString(fmt.Sprintf("  %s\n", tview.Escape(rrdata)))
+		}
+	}
+
+	details.WriteString("\n[darkgray]Press Esc to close[-]")
+	return details.String()
+}
+
+// FormatServiceAccountDetails formats IAM service account details from search result metadata
+func FormatServiceAccountDetails(email, project string, detailsMap map[string]string) string {
+	var details strings.Builder
+
+	details.WriteString("[yellow::b]Service Account[-:-:-]\n\n")
+
+	details.WriteString(fmt.Sprintf("[white::b]Email:[-:-:-]            %s\n", email))
+	details.WriteString(fmt.Sprintf("[white::b]Project:[-:-:-]          %s\n", project))
+	if displayName := detailsMap["displayName"]; displayName != "" {
+		details.WriteString(fmt.Sprintf("[white::b]Display Name:[-:-:-]     %s\n", displayName))
+	}
+	if desc := detailsMap["description"]; desc != "" {
+		details.WriteString(fmt.Sprintf("[white::b]Description:[-:-:-]      %s\n", desc))
+	}
+	if uniqueID := detailsMap["uniqueId"]; uniqueID != "" {
+		details.WriteString(fmt.Sprintf("[white::b]Unique ID:[-:-:-]        %s\n", uniqueID))
+	}
+	statusStr := "[green]Enabled[-]"
+	if detailsMap["disabled"] == "true" {
+		statusStr = "[red]Disabled[-]"
+	}
+	details.WriteString(fmt.Sprintf("[white::b]Status:[-:-:-]           %s\n", statusStr))
+
+	details.WriteString("\n")
+
+	details.WriteString("[cyan::b]User-Managed Keys[-:-:-]\n")
+	keys := detailsMap["keys"]
+	if keys == "" {
+		// Absent when the keys could not be listed
+		keys = "unknown"
+	}
+	details.WriteString(fmt.Sprintf("  [white::b]Key Count:[-:-:-]        %s\n", keys))
+	if age := detailsMap["oldestKeyAge"]; age != "" {
+		details.WriteString(fmt.Sprintf("  [white::b]Oldest Key:[-:-:-]       %s old (created %s)\n", age, detailsMap["oldestKeyCreated"]))
+	}
+
+	details.WriteString("\n[darkgray]Press Esc to close[-]")
+	return details.String()
+}
+
+// FormatRedisInstanceDetails formats Memorystore Redis instance details from search result metadata
+func FormatRedisInstanceDetails(name, project, location string, detailsMap map[string]string) string {
+	var details strings.Builder
+
+	details.WriteString("[yellow::b]Memorystore for Redis Instance[-:-:-]\n\n")
+
+	details.WriteString(fmt.Sprintf("[white::b]Name:[-:-:-]             %s\n", name))
+	details.WriteString(fmt.Sprintf("[white::b]Project:[-:-:-]          %s\n", project))
+	details.WriteString(fmt.Sprintf("[white::b]Region:[-:-:-]           %s\n", location))
+	if displayName := detailsMap["displayName"]; displayName != "" {
+		details.WriteString(fmt.Sprintf("[white::b]Display Name:[-:-:-]     %s\n", displayName))
+	}
+	if state := detailsMap["state"]; state != "" {
+		details.WriteString(fmt.Sprintf("[white::b]State:[-:-:-]            %s\n", state))
+	}
+
+	details.WriteString("\n")
+
+	details.WriteString("[cyan::b]Configuration[-:-:-]\n")
+	if tier := detailsMap["tier"]; tier != "" {
+		details.WriteString(fmt.Sprintf("  [white::b]Tier:[-:-:-]             %s\n", tier))
+	}
+	if version := detailsMap["version"]; version != "" {
+		details.WriteString(fmt.Sprintf("  [white::b]Version:[-:-:-]          %s\n", version))
+	}
+	if memory := detailsMap["memory"]; memory != "" {
+		details.WriteString(fmt.Sprintf("  [white::b]Memory:[-:-:-]           %s\n", memory))
+	}
+
+	details.WriteString("\n")
+
+	details.WriteString("[cyan::b]Network[-:-:-]\n")
+	if endpoint := detailsMap["endpoint"]; endpoint != "" {
+		details.WriteString(fmt.Sprintf("  [white::b]Endpoint:[-:-:-]         %s\n", endpoint))
+	} else if host := detailsMap["host"]; host != "" {
+		details.WriteString(fmt.Sprintf("  [white::b]Host:[-:-:-]             %s\n", host))
+	}
+	if readEndpoint := detailsMap["readEndpoint"]; readEndpoint != "" {
+		details.WriteString(fmt.Sprintf("  [white::b]Read Endpoint:[-:-:-]    %s\n", readEndpoint))
+	}
+	if network := detailsMap["network"]; network != "" {
+		details.WriteString(fmt.Sprintf("  [white::b]Network:[-:-:-]          %s\n", network))
+	}
+	if reservedRange := detailsMap["reservedRange"]; reservedRange != "" {
+		details.WriteString(fmt.Sprintf("  [white::b]Reserved Range:[-:-:-]   %s\n", reservedRange))
+	}
+	if connectMode := detailsMap["connectMode"]; connectMode != "" {
+		details.WriteString(fmt.Sprintf("  [white::b]Connect Mode:[-:-:-]     %s\n", connectMode))
+	}
+
+	details.WriteString("\n[darkgray]Press Esc to close[-]")
+	return details.String()
+}
+
+// FormatMemcacheInstanceDetails formats Memorystore Memcached instance details from search result metadata
+func FormatMemcacheInstanceDetails(name, project, location string, detailsMap map[string]string) string {
+	var details strings.Builder
+
+	details.WriteString("[yellow::b]Memorystore for Memcached Instance[-:-:-]\n\n")
+
+	details.WriteString(fmt.Sprintf("[white::b]Name:[-:-:-]             %s\n", name))
+	details.WriteString(fmt.Sprintf("[white::b]Project:[-:-:-]          %s\n", project))
+	details.WriteString(fmt.Sprintf("[white::b]Region:[-:-:-]           %s\n", location))
+	if displayName := detailsMap["displayName"]; displayName != "" {
+		details.WriteString(fmt.Sprintf("[white::b]Display Name:[-:-:-]     %s\n", displayName))
+	}
+	if state := detailsMap["state"]; state != "" {
+		details.WriteString(fmt.Sprintf("[white::b]State:[-:-:-]            %s\n", state))
+	}
+	if version := detailsMap["version"]; version != "" {
+		details.WriteString(fmt.Sprintf("[white::b]Version:[-:-:-]          %s\n", version))
+	}
+
+	details.WriteString("\n")
+
+	details.WriteString("[cyan::b]Network[-:-:-]\n")
+	if endpoint := detailsMap["discoveryEndpoint"]; endpoint != "" {
+		details.WriteString(fmt.Sprintf("  [white::b]Discovery:[-:-:-]        %s\n", endpoint))
+	}
+	if network := detailsMap["network"]; network != "" {
+		details.WriteString(fmt.Sprintf("  [white::b]Network:[-:-:-]          %s\n", network))
+	}
+
+	if hosts := detailsMap["nodeHosts"]; hosts != "" {
+		details.WriteString("\n")
+		details.WriteString(fmt.Sprintf("[cyan::b]Nodes (%s)[-:-:-]\n", detailsMap["nodes"]))
+		for _, host := range strings.Split(hosts, ", ") {
+			details.WriteString(fmt.Sprintf("  %s\n", host))
+		}
+	}
+
+	details.WriteString("\n[darkgray]Press Esc to close[-]")
+	return details.String()
+}
+
+// FormatFilestoreInstanceDetails formats Filestore instance details from search result metadata
+func FormatFilestoreInstanceDetails(name, project, location string, detailsMap map[string]string) string {
+	var details strings.Builder
+
+	details.WriteString("[yellow::b]Filestore Instance[-:-:-]\n\n")
+
+	details.WriteString(fmt.Sprintf("[white::b]Name:[-:-:-]             %s\n", name))
+	details.WriteString(fmt.Sprintf("[white::b]Project:[-:-:-]          %s\n", project))
+	details.WriteString(fmt.Sprintf("[white::b]Location:[-:-:-]         %s\n", location))
+	if desc := detailsMap["description"]; desc != "" {
+		details.WriteString(fmt.Sprintf("[white::b]Description:[-:-:-]      %s\n", desc))
+	}
+	if state := detailsMap["state"]; state != "" {
+		details.WriteString(fmt.Sprintf("[white::b]State:[-:-:-]            %s\n", state))
+	}
+
+	details.WriteString("\n")
+
+	details.WriteString("[cyan::b]Configuration[-:-:-]\n")
+	if tier := detailsMap["tier"]; tier != "" {
+		details.WriteString(fmt.Sprintf("  [white::b]Tier:[-:-:-]             %s\n", tier))
+	}
+	if protocol := detailsMap["protocol"]; protocol != "" {
+		details.WriteString(fmt.Sprintf("  [white::b]Protocol:[-:-:-]         %s\n", protocol))
+	}
+	if shares := detailsMap["shares"]; shares != "" {
+		details.WriteString(fmt.Sprintf("  [white::b]File Shares:[-:-:-]      %s\n", shares))
+	}
+
+	details.WriteString("\n")
+
+	details.WriteString("[cyan::b]Network[-:-:-]\n")
+	if ips := detailsMap["ipAddresses"]; ips != "" {
+		details.WriteString(fmt.Sprintf("  [white::b]IP Addresses:[-:-:-]     %s\n", ips))
+	}
+	if network := detailsMap["network"]; network != "" {
+		details.WriteString(fmt.Sprintf("  [white::b]Network:[-:-:-]          %s\n", network))
+	}
+
+	details.WriteString("\n[darkgray]Press Esc to close[-]")
+	return details.String()
+}
+
+// FormatSpannerInstanceDetails formats Cloud Spanner instance details from search result metadata
+func FormatSpannerInstanceDetails(name, project string, detailsMap map[string]string) string {
+	var details strings.Builder
+
+	details.WriteString("[yellow::b]Spanner Instance[-:-:-]\n\n")
+
+	details.WriteString(fmt.Sprintf("[white::b]Name:[-:-:-]             %s\n", name))
+	details.WriteString(fmt.Sprintf("[white::b]Project:[-:-:-]          %s\n", project))
+	if displayName := detailsMap["displayName"]; displayName != "" {
+		details.WriteString(fmt.Sprintf("[white::b]Display Name:[-:-:-]     %s\n", displayName))
+	}
+	if state := detailsMap["state"]; state != "" {
+		details.WriteString(fmt.Sprintf("[white::b]State:[-:-:-]            %s\n", state))
+	}
+
+	details.WriteString("\n")
+
+	details.WriteString("[cyan::b]Configuration[-:-:-]\n")
+	if config := detailsMap["config"]; config != "" {
+		details.WriteString(fmt.Sprintf("  [white::b]Config:[-:-:-]           %s\n", config))
+	}
+	if edition := detailsMap["edition"]; edition != "" {
+		details.WriteString(fmt.Sprintf("  [white::b]Edition:[-:-:-]          %s\n", edition))
+	}
+	if nodes := detailsMap["nodes"]; nodes != "" {
+		details.WriteString(fmt.Sprintf("  [white::b]Nodes:[-:-:-]            %s\n", nodes))
+	}
+	if units := detailsMap["processingUnits"]; units != "" {
+		details.WriteString(fmt.Sprintf("  [white::b]Processing Units:[-:-:-] %s\n", units))
+	}
+	if endpoints := detailsMap["endpoints"]; endpoints != "" {
+		details.WriteString(fmt.Sprintf("  [white::b]Endpoints:[-:-:-]        %s\n", endpoints))
+	}
+
+	details.WriteString("\n[darkgray]Press Esc to close[-]")
+	return details.String()
+}
+
+// FormatBigQueryDatasetDetails formats BigQuery dataset details from search result metadata
+func FormatBigQueryDatasetDetails(name, project, location string, detailsMap map[string]string) string {
+	var details strings.Builder
+
+	details.WriteString("[yellow::b]BigQuery Dataset[-:-:-]\n\n")
+
+	details.WriteString(fmt.Sprintf("[white::b]Dataset:[-:-:-]          %s\n", name))
+	details.WriteString(fmt.Sprintf("[white::b]Project:[-:-:-]          %s\n", project))
+	details.WriteString(fmt.Sprintf("[white::b]Location:[-:-:-]         %s\n", location))
+	if friendlyName := detailsMap["friendlyName"]; friendlyName != "" {
+		details.WriteString(fmt.Sprintf("[white::b]Friendly Name:[-:-:-]    %s\n", friendlyName))
+	}
+	details.WriteString(fmt.Sprintf("[white::b]Reference:[-:-:-]        %s.%s\n", project, name))
+
+	details.WriteString("\n[darkgray]Press Esc to close[-]")
+	return details.String()
+}
+
+// FormatRouterDetails formats Cloud Router details from search result metadata
+func FormatRouterDetails(name, project, location string, detailsMap map[string]string) string {
+	var details strings.Builder
+
+	details.WriteString("[yellow::b]Cloud Router[-:-:-]\n\n")
+
+	details.WriteString(fmt.Sprintf("[white::b]Name:[-:-:-]             %s\n", name))
+	details.WriteString(fmt.Sprintf("[white::b]Project:[-:-:-]          %s\n", project))
+	details.WriteString(fmt.Sprintf("[white::b]Region:[-:-:-]           %s\n", location))
+	if network := detailsMap["network"]; network != "" {
+		details.WriteString(fmt.Sprintf("[white::b]Network:[-:-:-]          %s\n", network))
+	}
+	if desc := detailsMap["description"]; desc != "" {
+		details.WriteString(fmt.Sprintf("[white::b]Description:[-:-:-]      %s\n", desc))
+	}
+
+	details.WriteString("\n")
+
+	details.WriteString("[cyan::b]BGP[-:-:-]\n")
+	if asn := detailsMap["asn"]; asn != "" {
+		details.WriteString(fmt.Sprintf("  [white::b]ASN:[-:-:-]              %s\n", asn))
+	}
+	if mode := detailsMap["advertiseMode"]; mode != "" {
+		details.WriteString(fmt.Sprintf("  [white::b]Advertise Mode:[-:-:-]   %s\n", mode))
+	}
+	if groups := detailsMap["advertisedGroups"]; groups != "" {
+		details.WriteString(fmt.Sprintf("  [white::b]Groups:[-:-:-]           %s\n", groups))
+	}
+	if ranges := detailsMap["advertisedRanges"]; ranges != "" {
+		details.WriteString(fmt.Sprintf("  [white::b]Ranges:[-:-:-]           %s\n", ranges))
+	}
+	if interfaces := detailsMap["interfaces"]; interfaces != "" {
+		details.WriteString(fmt.Sprintf("  [white::b]Interfaces:[-:-:-]       %s\n", interfaces))
+	}
+
+	if peers := detailsMap["bgpPeers"]; peers != "" {
+		details.WriteString("\n")
+		details.WriteString("[cyan::b]BGP Peers[-:-:-]\n")
+		for _, peer := range strings.Split(peers, ", ") {
+			details.WriteString(fmt.Sprintf("  %s\n", peer))
+		}
+	}
+
+	if nats := detailsMap["nats"]; nats != "" {
+		details.WriteString("\n")
+		details.WriteString(fmt.Sprintf("[cyan::b]Cloud NAT:[-:-:-]        %s\n", nats))
+	}
+
+	details.WriteString("\n[darkgray]Press Esc to close[-]")
+	return details.String()
+}
+
+// FormatRouterNATDetails formats Cloud NAT gateway details from search result metadata
+func FormatRouterNATDetails(name, project, location string, detailsMap map[string]string) string {
+	var details strings.Builder
+
+	details.WriteString("[yellow::b]Cloud NAT Gateway[-:-:-]\n\n")
+
+	details.WriteString(fmt.Sprintf("[white::b]Name:[-:-:-]             %s\n", name))
+	details.WriteString(fmt.Sprintf("[white::b]Project:[-:-:-]          %s\n", project))
+	details.WriteString(fmt.Sprintf("[white::b]Region:[-:-:-]           %s\n", location))
+	details.WriteString(fmt.Sprintf("[white::b]Router:[-:-:-]           %s\n", detailsMap["router"]))
+	if network := detailsMap["network"]; network != "" {
+		details.WriteString(fmt.Sprintf("[white::b]Network:[-:-:-]          %s\n", network))
+	}
+
+	details.WriteString("\n")
+
+	details.WriteString("[cyan::b]NAT IPs[-:-:-]\n")
+	if allocation := detailsMap["allocation"]; allocation != "" {
+		details.WriteString(fmt.Sprintf("  [white::b]Allocation:[-:-:-]       %s\n", allocation))
+	}
+	if ips := detailsMap["natIPs"]; ips != "" {
+		details.WriteString(fmt.Sprintf("  [white::b]In Use:[-:-:-]           %s\n", ips))
+	}
+	if addresses := detailsMap["natIPAddresses"]; addresses != "" {
+		details.WriteString(fmt.Sprintf("  [white::b]Reserved:[-:-:-]         %s\n", addresses))
+	}
+
+	details.WriteString("\n")
+
+	details.WriteString("[cyan::b]Configuration[-:-:-]\n")
+	if ranges := detailsMap["sourceRanges"]; ranges != "" {
+		details.WriteString(fmt.Sprintf("  [white::b]Source Ranges:[-:-:-]    %s\n", ranges))
+	}
+	if ports := detailsMap["minPortsPerVM"]; ports != "" {
+		details.WriteString(fmt.Sprintf("  [white::b]Min Ports/VM:[-:-:-]     %s\n", ports))
+	}
+	if detailsMap["endpointIndependentMapping"] == "true" {
+		details.WriteString("  [white::b]EIM:[-:-:-]              enabled\n")
+	}
+	logging := detailsMap["logging"]
+	if logging == "" {
+		logging = "disabled"
+	}
+	details.WriteString(fmt.Sprintf("  [white::b]Logging:[-:-:-]          %s\n", logging))
+
+	details.WriteString("\n[darkgray]Press Esc to close[-]")
+	return details.String()
+}
+
+// FormatInterconnectAttachmentDetails formats Interconnect VLAN attachment details from search result metadata
+func FormatInterconnectAttachmentDetails(name, project, location string, detailsMap map[string]string) string {
+	var details strings.Builder
+
+	details.WriteString("[yellow::b]Interconnect Attachment[-:-:-]\n\n")
+
+	details.WriteString(fmt.Sprintf("[white::b]Name:[-:-:-]             %s\n", name))
+	details.WriteString(fmt.Sprintf("[white::b]Project:[-:-:-]          %s\n", project))
+	details.WriteString(fmt.Sprintf("[white::b]Region:[-:-:-]           %s\n", location))
+	if attachmentType := detailsMap["type"]; attachmentType != "" {
+		details.WriteString(fmt.Sprintf("[white::b]Type:[-:-:-]             %s\n", attachmentType))
+	}
+	stateStr := detailsMap["state"]
+	if detailsMap["adminEnabled"] == "false" {
+		stateStr += " [red](admin disabled)[-]"
+	}
+	details.WriteString(fmt.Sprintf("[white::b]State:[-:-:-]            %s\n", stateStr))
+	if opStatus := detailsMap["operationalStatus"]; opStatus != "" {
+		details.WriteString(fmt.Sprintf("[white::b]Operational:[-:-:-]      %s\n", opStatus))
+	}
+
+	details.WriteString("\n")
+
+	details.WriteString("[cyan::b]Connection[-:-:-]\n")
+	for _, field := range []struct{ label, key string }{
+		{"Bandwidth:       ", "bandwidth"},
+		{"Interconnect:    ", "interconnect"},
+		{"Partner:         ", "partner"},
+		{"Partner ASN:     ", "partnerAsn"},
+		{"Pairing Key:     ", "pairingKey"},
+		{"Router:          ", "router"},
+		{"VLAN:            ", "vlan"},
+		{"Cloud Router IP: ", "cloudRouterIP"},
+		{"Customer IP:     ", "customerRouterIP"},
+		{"Availability:    ", "edgeAvailabilityDomain"},
+		{"MTU:             ", "mtu"},
+	} {
+		if value := detailsMap[field.key]; value != "" {
+			details.WriteString(fmt.Sprintf("  [white::b]%s[-:-:-]%s\n", field.label, value))
+		}
+	}
+
+	details.WriteString("\n[darkgray]Press Esc to close[-]")
+	return details.String()
+}
+
 // BucketActionExecutor handles actions for storage buckets
 type BucketActionExecutor struct {
 	Name    string
@@ -985,104 +1559,12 @@ func isCommandAvailable(name string) bool {
 
 // buildCloudConsoleURL constructs a Google Cloud Console URL with the given path and project
 func buildCloudConsoleURL(urlPath, project string) string {
-	u, _ := url.Parse(cloudConsoleBaseURL)
-	u.Path = urlPath
-	q := u.Query()
-	q.Set("project", project)
-	u.RawQuery = q.Encode()
-	return u.String()
+	return search.BuildConsoleURL(urlPath, project, nil)
 }
 
 // GetCloudConsoleURL returns the Google Cloud Console URL for a resource
 func GetCloudConsoleURL(resourceType, name, project, location string, details map[string]string) string {
-	switch resourceType {
-	case string(search.KindComputeInstance):
-		return buildCloudConsoleURL(path.Join("compute/instancesDetail/zones", location, "instances", name), project)
-
-	case string(search.KindInstanceTemplate):
-		return buildCloudConsoleURL(path.Join("compute/instanceTemplates/details", name), project)
-
-	case string(search.KindManagedInstanceGroup):
-		return buildCloudConsoleURL(path.Join("compute/instanceGroups/details", location, name), project)
-
-	case string(search.KindBucket):
-		return buildCloudConsoleURL(path.Join("storage/browser", name), project)
-
-	case string(search.KindGKECluster):
-		return buildCloudConsoleURL(path.Join("kubernetes/clusters/details", location, name, "details"), project)
-
-	case string(search.KindGKENodePool):
-		cluster := details["cluster"]
-		if cluster == "" {
-			cluster = "unknown"
-		}
-		return buildCloudConsoleURL(path.Join("kubernetes/nodepool", location, cluster, name), project)
-
-	case string(search.KindCloudSQLInstance):
-		return buildCloudConsoleURL(path.Join("sql/instances", name, "overview"), project)
-
-	case string(search.KindCloudRunService):
-		return buildCloudConsoleURL(path.Join("run/detail", location, name, "metrics"), project)
-
-	case string(search.KindSecret):
-		return buildCloudConsoleURL(path.Join("security/secret-manager/secret", name), project)
-
-	case string(search.KindAddress):
-		return buildCloudConsoleURL("networking/addresses/list", project)
-
-	case string(search.KindDisk):
-		return buildCloudConsoleURL(path.Join("compute/disksDetail/zones", location, "disks", name), project)
-
-	case string(search.KindSnapshot):
-		return buildCloudConsoleURL(path.Join("compute/snapshotsDetail/projects", project, "global/snapshots", name), project)
-
-	case string(search.KindForwardingRule):
-		if location == "global" {
-			return buildCloudConsoleURL(path.Join("net-services/loadbalancing/advanced/globalForwardingRules/details", name), project)
-		}
-		return buildCloudConsoleURL(path.Join("net-services/loadbalancing/advanced/forwardingRules/details/regions", location, "forwardingRules", name), project)
-
-	case string(search.KindBackendService):
-		return buildCloudConsoleURL(path.Join("net-services/loadbalancing/advanced/backendServices/details", name), project)
-
-	case string(search.KindHealthCheck):
-		if location == "" || location == "global" {
-			return buildCloudConsoleURL(path.Join("compute/healthChecks/details", name), project)
-		}
-		return buildCloudConsoleURL(path.Join("compute/healthChecks/details/regions", location, name), project)
-
-	case string(search.KindURLMap):
-		return buildCloudConsoleURL(path.Join("net-services/loadbalancing/advanced/urlMaps/details", name), project)
-
-	case string(search.KindVPCNetwork):
-		return buildCloudConsoleURL(path.Join("networking/networks/details", name), project)
-
-	case string(search.KindSubnet):
-		return buildCloudConsoleURL(path.Join("networking/subnetworks/details", location, name), project)
-
-	case string(search.KindFirewallRule):
-		return buildCloudConsoleURL(path.Join("networking/firewalls/details", name), project)
-
-	case string(search.KindVPNGateway):
-		return buildCloudConsoleURL(path.Join("hybrid/vpn/gateways/details", location, name), project) + "&isHA=true"
-
-	case string(search.KindVPNTunnel):
-		baseURL := buildCloudConsoleURL(path.Join("hybrid/vpn/tunnels/details", location, name), project)
-		// Add isHA=true for HA VPN tunnels (not Classic VPN)
-		if details["isHA"] == "true" {
-			return baseURL + "&isHA=true"
-		}
-		return baseURL
-
-	case string(search.KindConnectivityTest):
-		return buildCloudConsoleURL(path.Join("net-intelligence/connectivity/tests/details", name), project)
-
-	case string(search.KindRoute):
-		return buildCloudConsoleURL(path.Join("networking/routes/details", name), project)
-
-	default:
-		return buildCloudConsoleURL("home/dashboard", project)
-	}
+	return search.ConsoleURL(search.ResourceKind(resourceType), name, project, location, details)
 }
 
 // OpenInBrowser opens a URL in the default browser
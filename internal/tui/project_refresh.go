@@ -303,6 +303,8 @@ func performProjectRefresh(app *App, projects []string, onComplete func()) {
 						totalStats.MIGs += count
 					case "subnets":
 						totalStats.Subnets += count
+					case "ip_addresses":
+						totalStats.IPAddresses += count
 					}
 					statsMu.Unlock()
 				})
@@ -314,8 +316,8 @@ func performProjectRefresh(app *App, projects []string, onComplete func()) {
 				}
 
 				if stats != nil {
-					logger.Log.Debugf("Refreshed project %s: %d zones, %d instances, %d MIGs, %d subnets",
-						proj, stats.Zones, stats.Instances, stats.MIGs, stats.Subnets)
+					logger.Log.Debugf("Refreshed project %s: %d zones, %d instances, %d MIGs, %d subnets, %d IP addresses",
+						proj, stats.Zones, stats.Instances, stats.MIGs, stats.Subnets, stats.IPAddresses)
 				}
 			}(project, i)
 		}
@@ -338,8 +340,8 @@ func performProjectRefresh(app *App, projects []string, onComplete func()) {
 
 			// Show result
 			statsMu.Lock()
-			resultMsg := fmt.Sprintf("Refreshed: %d zones, %d instances, %d MIGs, %d subnets",
-				totalStats.Zones, totalStats.Instances, totalStats.MIGs, totalStats.Subnets)
+			resultMsg := fmt.Sprintf("Refreshed: %d zones, %d instances, %d MIGs, %d subnets, %d IPs",
+				totalStats.Zones, totalStats.Instances, totalStats.MIGs, totalStats.Subnets, totalStats.IPAddresses)
 			statsMu.Unlock()
 
 			errorsMu.Lock()
@@ -2,9 +2,11 @@ package tui
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
+	"github.com/kedare/compass/internal/gcp/search"
 	"github.com/rivo/tview"
 )
 
@@ -66,14 +68,23 @@ func getTypeColor(resourceType string) string {
 		return "green"
 	case strings.HasPrefix(resourceType, "container."):
 		return "cyan"
-	case strings.HasPrefix(resourceType, "sqladmin."):
+	case strings.HasPrefix(resourceType, "sqladmin."), strings.HasPrefix(resourceType, "redis."),
+		strings.HasPrefix(resourceType, "memcache."), strings.HasPrefix(resourceType, "file."),
+		strings.HasPrefix(resourceType, "spanner."), strings.HasPrefix(resourceType, "bigquery."):
 		return "magenta"
-	case strings.HasPrefix(resourceType, "run."):
+	case strings.HasPrefix(resourceType, "run."), strings.HasPrefix(resourceType, "functions."),
+		strings.HasPrefix(resourceType, "appengine."):
 		return "yellow"
 	case strings.HasPrefix(resourceType, "secretmanager."):
 		return "red"
 	case strings.HasPrefix(resourceType, "networkmanagement."):
 		return "purple"
+	case strings.HasPrefix(resourceType, "pubsub."):
+		return "orange"
+	case strings.HasPrefix(resourceType, "dns."):
+		return "teal"
+	case strings.HasPrefix(resourceType, "iam."):
+		return "gold"
 	default:
 		return "white"
 	}
@@ -102,3 +113,86 @@ func highlightMatch(text, term string) string {
 
 	return before + "[yellow::b]" + matched + "[-:-:-]" + after
 }
+
+// matchModeCycle is the order in which Tab cycles through the match modes.
+var matchModeCycle = []search.MatchMode{search.MatchSubstring, search.MatchFuzzy, search.MatchRegex, search.MatchExact}
+
+// nextMatchMode returns the match mode following mode in the Tab cycle.
+func nextMatchMode(mode search.MatchMode) search.MatchMode {
+	for i, m := range matchModeCycle {
+		if m == mode {
+			return matchModeCycle[(i+1)%len(matchModeCycle)]
+		}
+	}
+	return search.MatchSubstring
+}
+
+// formatLabels renders resource labels as sorted "key=value" pairs for table display.
+func formatLabels(labels map[string]string) string {
+	if len(labels) == 0 {
+		return ""
+	}
+
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		parts = append(parts, k+"="+labels[k])
+	}
+	return strings.Join(parts, ", ")
+}
+
+// sortEntriesByRelevance orders entries by descending relevance score.
+// Entries with equal scores keep their arrival order.
+func sortEntriesByRelevance(entries []searchEntry) {
+	score := func(e searchEntry) float64 {
+		if e.Result == nil {
+			return 0
+		}
+		return e.Result.Score
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return score(entries[i]) > score(entries[j])
+	})
+}
+
+// prioritizeProject moves the given project to the front of the list when it is present.
+// The relative order of the other projects is preserved.
+func prioritizeProject(projects []string, project string) []string {
+	for i, p := range projects {
+		if p != project {
+			continue
+		}
+		if i == 0 {
+			return projects
+		}
+
+		ordered := make([]string, 0, len(projects))
+		ordered = append(ordered, project)
+		ordered = append(ordered, projects[:i]...)
+		ordered = append(ordered, projects[i+1:]...)
+		return ordered
+	}
+	return projects
+}
+
+// relatedRoot picks the resource matching a search entry in the relationship graph,
+// preferring the one in the entry's location when several share the name.
+func relatedRoot(graph *search.Graph, kind search.ResourceKind, name, location string) (search.ResourceRef, bool) {
+	candidates := graph.Find(kind, name)
+	if len(candidates) == 0 {
+		return search.ResourceRef{}, false
+	}
+
+	for _, candidate := range candidates {
+		if candidate.Location == location {
+			return candidate, true
+		}
+	}
+
+	return candidates[0], true
+}
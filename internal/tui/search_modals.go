@@ -5,6 +5,7 @@ import (
 	"sort"
 
 	"github.com/gdamore/tcell/v2"
+	"github.com/kedare/compass/internal/gcp/search"
 	"github.com/rivo/tview"
 )
 
@@ -97,6 +98,15 @@ func showSearchResultDetail(app *tview.Application, table *tview.Table, mainFlex
 
 // showInstanceDetailModal displays instance details fetched from GCP
 func showInstanceDetailModal(app *tview.Application, table *tview.Table, mainFlex *tview.Flex, name string, details string, modalOpen *bool, status *tview.TextView, currentFilter string, onRestoreStatus func()) {
+	showInstanceDetailModalWithServiceAccounts(app, table, mainFlex, name, details, nil, modalOpen, onRestoreStatus, nil)
+}
+
+// showInstanceDetailModalWithServiceAccounts displays instance details and, when onJump is set,
+// lets the user jump to one of the attached service accounts with the 'a' key.
+// If several accounts are attached, a selection list is shown first.
+func showInstanceDetailModalWithServiceAccounts(app *tview.Application, table *tview.Table, mainFlex *tview.Flex, name string, details string, serviceAccounts []string, modalOpen *bool, onRestoreStatus func(), onJump func(email string)) {
+	canJump := onJump != nil && len(serviceAccounts) > 0
+
 	detailView := tview.NewTextView().
 		SetDynamicColors(true).
 		SetText(details).
@@ -105,9 +115,13 @@ func showInstanceDetailModal(app *tview.Application, table *tview.Table, mainFle
 	detailView.SetBorder(true).SetTitle(fmt.Sprintf(" %s ", name))
 
 	// Create status bar for detail view
+	statusText := " [yellow]Esc[-] back  [yellow]↑/↓[-] scroll"
+	if canJump {
+		statusText += "  [yellow]a[-] go to service account"
+	}
 	detailStatus := tview.NewTextView().
 		SetDynamicColors(true).
-		SetText(" [yellow]Esc[-] back  [yellow]↑/↓[-] scroll")
+		SetText(statusText)
 
 	// Create fullscreen detail layout
 	detailFlex := tview.NewFlex().
@@ -115,17 +129,36 @@ func showInstanceDetailModal(app *tview.Application, table *tview.Table, mainFle
 		AddItem(detailView, 0, 1, true).
 		AddItem(detailStatus, 1, 0, false)
 
+	closeModal := func() {
+		*modalOpen = false
+		app.SetRoot(mainFlex, true)
+		app.SetFocus(table)
+	}
+
+	jump := func(email string) {
+		closeModal()
+		onJump(email)
+	}
+
 	// Set up input handler
 	detailView.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
 		if event.Key() == tcell.KeyEscape {
-			*modalOpen = false
-			app.SetRoot(mainFlex, true)
-			app.SetFocus(table)
+			closeModal()
 			if onRestoreStatus != nil {
 				onRestoreStatus()
 			}
 			return nil
 		}
+		if canJump && event.Key() == tcell.KeyRune && event.Rune() == 'a' {
+			if len(serviceAccounts) == 1 {
+				jump(serviceAccounts[0])
+				return nil
+			}
+			showServiceAccountSelection(app, serviceAccounts, jump, func() {
+				app.SetRoot(detailFlex, true).SetFocus(detailView)
+			})
+			return nil
+		}
 		return event
 	})
 
@@ -133,6 +166,80 @@ func showInstanceDetailModal(app *tview.Application, table *tview.Table, mainFle
 	app.SetRoot(detailFlex, true).SetFocus(detailView)
 }
 
+// showServiceAccountSelection displays a small list to pick one of several service accounts.
+func showServiceAccountSelection(app *tview.Application, serviceAccounts []string, onSelect func(email string), onCancel func()) {
+	list := tview.NewList().ShowSecondaryText(false)
+	list.SetBorder(true).SetTitle(" Go to Service Account ")
+
+	for _, email := range serviceAccounts {
+		email := email
+		list.AddItem(email, "", 0, func() {
+			onSelect(email)
+		})
+	}
+
+	list.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			onCancel()
+			return nil
+		}
+		return event
+	})
+
+	// Height: items + border (2)
+	modalHeight := len(serviceAccounts) + 2
+	if modalHeight > 12 {
+		modalHeight = 12
+	}
+
+	modal := tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(list, modalHeight, 0, true).
+			AddItem(nil, 0, 1, false), 80, 0, true).
+		AddItem(nil, 0, 1, false)
+
+	app.SetRoot(modal, true).SetFocus(list)
+}
+
+// showRelatedSelection lists the resources related to root and calls onSelect with the chosen one.
+func showRelatedSelection(app *tview.Application, root search.ResourceRef, relations []search.Relation, onSelect func(ref search.ResourceRef), onCancel func()) {
+	list := tview.NewList().ShowSecondaryText(false)
+	list.SetBorder(true).SetTitle(fmt.Sprintf(" Related to %s ", root.Name))
+
+	for _, rel := range relations {
+		target := rel.To
+		list.AddItem(fmt.Sprintf("[yellow]%s:[-] %s", rel.Label, target), "", 0, func() {
+			onSelect(target)
+		})
+	}
+
+	list.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			onCancel()
+			return nil
+		}
+		return event
+	})
+
+	// Height: items + border (2)
+	modalHeight := len(relations) + 2
+	if modalHeight > 20 {
+		modalHeight = 20
+	}
+
+	modal := tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(list, modalHeight, 0, true).
+			AddItem(nil, 0, 1, false), 100, 0, true).
+		AddItem(nil, 0, 1, false)
+
+	app.SetRoot(modal, true).SetFocus(list)
+}
+
 // showSearchHelp displays help for the search view
 func showSearchHelp(app *tview.Application, table *tview.Table, mainFlex *tview.Flex, modalOpen *bool, currentFilter string, status *tview.TextView, onRestoreStatus func()) {
 	helpText := `[yellow::b]Search View - Keyboard Shortcuts[-:-:-]
@@ -140,7 +247,7 @@ func showSearchHelp(app *tview.Application, table *tview.Table, mainFlex *tview.
 [yellow]Search[-]
   [white]Enter[-]         Start search / Focus search input
   [white]↑/↓[-]           Navigate search history (in search box)
-  [white]Tab[-]           Toggle fuzzy matching
+  [white]Tab[-]           Cycle match mode (substring, fuzzy, regex, exact)
   [white]Esc[-]           Cancel search (if running) / Clear filter / Go back
 
 [yellow]Navigation[-]
@@ -153,8 +260,11 @@ func showSearchHelp(app *tview.Application, table *tview.Table, mainFlex *tview.
   [white]s[-]             SSH to selected instance
   [white]d[-]             Show details for selected result
   [white]b[-]             Open in Cloud Console (browser)
+  [white]a[-]             Go to service account (in instance details)
   [white]o[-]             Open in browser (for buckets)
+  [white]r[-]             Show related resources and jump to one
   [white]/[-]             Filter displayed results
+  [white]L[-]             Show/hide the Labels column
 
 [yellow]Search Features[-]
   • Results appear progressively as they're found
@@ -168,7 +278,10 @@ func showSearchHelp(app *tview.Application, table *tview.Table, mainFlex *tview.
   • Filter supports: spaces (AND), | (OR), - (NOT)
     Example: "compute.instance prod" = instances in prod projects
     Example: "web|api -dev" = web or api resources, excluding dev
-  • Tab toggles fuzzy mode (matches characters in order, e.g. "prd" matches "production")
+  • key=value searches match resource labels (e.g. "env=prod", "env=prod*", "team=*")
+  • Tab cycles the match mode: fuzzy matches characters in order (e.g. "prd" matches "production"),
+    regex matches a case-sensitive regular expression (e.g. "^api-v[0-9]+-") and exact only
+    matches values equal to the term
   • Context-aware actions based on resource type
 
 [darkgray]Press Esc or ? to close this help[-]`
@@ -15,17 +15,21 @@ type SearchViewState struct {
 	// Core data
 	AllResults  []searchEntry
 	AllWarnings []search.SearchWarning
+	AllSkipped  []search.SkippedProvider // Providers skipped because their API is disabled
 	SearchError error
 
 	// UI state
 	ModalOpen     bool
 	FilterMode    bool
 	CurrentFilter string
-	FuzzyMode     bool
+	MatchMode     search.MatchMode // How the search term is matched, cycled with Tab
+	ShowLabels    bool             // Show the Labels column in the results table
 
 	// Search context
-	CurrentSearchTerm string
-	RecordedAffinity  map[string]struct{}
+	CurrentSearchTerm   string
+	CurrentAffinityTerm string                // Term search affinity is learned under, see search.Query.AffinityTerm
+	CurrentSearchTypes  []search.ResourceKind // Type filter of the current search (nil means all types)
+	RecordedAffinity    map[string]struct{}
 
 	// Search history
 	SearchHistory []string
@@ -43,6 +47,7 @@ func NewSearchViewState() *SearchViewState {
 		AllResults:       []searchEntry{},
 		AllWarnings:      []search.SearchWarning{},
 		RecordedAffinity: make(map[string]struct{}),
+		MatchMode:        search.MatchSubstring,
 		HistoryIndex:     -1,
 	}
 }
@@ -5,6 +5,7 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 )
 
@@ -17,6 +18,19 @@ import (
 type TableUpdater struct {
 	Table             *tview.Table // The tview table widget
 	CurrentSearchTerm string       // Current search term for highlighting matches
+	ShowLabels        bool         // Render the optional Labels column
+}
+
+// labelsColumn is the index of the optional Labels column.
+const labelsColumn = 4
+
+// newSearchHeaderCell creates a header cell for the search results table.
+func newSearchHeaderCell(title string) *tview.TableCell {
+	return tview.NewTableCell(title).
+		SetTextColor(tcell.ColorBlack).
+		SetBackgroundColor(tcell.ColorDarkCyan).
+		SetSelectable(false).
+		SetExpansion(1)
 }
 
 // NewTableUpdater creates a new table updater for the given table.
@@ -34,6 +48,7 @@ func (tu *TableUpdater) UpdateWithData(filter string, results []searchEntry) {
 
 	// Clear all rows except header
 	tu.clearRows()
+	tu.syncLabelsHeader()
 
 	// Apply filter and populate rows
 	filterExpr := parseFilter(filter)
@@ -70,6 +85,17 @@ func (tu *TableUpdater) clearRows() {
 	}
 }
 
+// syncLabelsHeader adds or removes the Labels header cell to match ShowLabels.
+func (tu *TableUpdater) syncLabelsHeader() {
+	hasColumn := tu.Table.GetColumnCount() > labelsColumn
+	switch {
+	case tu.ShowLabels && !hasColumn:
+		tu.Table.SetCell(0, labelsColumn, newSearchHeaderCell("Labels"))
+	case !tu.ShowLabels && hasColumn:
+		tu.Table.RemoveColumn(labelsColumn)
+	}
+}
+
 // populateRows adds filtered entries to the table and attempts to restore selection.
 // Returns the number of matched entries and the row index of the restored selection (-1 if not found).
 func (tu *TableUpdater) populateRows(results []searchEntry, filterExpr filterExpr, selectedKey string) int {
@@ -89,6 +115,9 @@ func (tu *TableUpdater) populateRows(results []searchEntry, filterExpr filterExp
 		tu.Table.SetCell(currentRow, 1, tview.NewTableCell(highlightMatch(entry.Name, tu.CurrentSearchTerm)).SetExpansion(1))
 		tu.Table.SetCell(currentRow, 2, tview.NewTableCell(highlightMatch(entry.Project, tu.CurrentSearchTerm)).SetExpansion(1))
 		tu.Table.SetCell(currentRow, 3, tview.NewTableCell(highlightMatch(entry.Location, tu.CurrentSearchTerm)).SetExpansion(1))
+		if tu.ShowLabels {
+			tu.Table.SetCell(currentRow, labelsColumn, tview.NewTableCell(tview.Escape(formatLabels(entry.Labels))).SetExpansion(2))
+		}
 
 		// Check if this row matches the previously selected key
 		if selectedKey != "" && newSelectedRow == -1 {
@@ -21,6 +21,7 @@ type searchEntry struct {
 	Project  string
 	Location string
 	Details  map[string]string
+	Labels   map[string]string
 	Result   *search.Result
 }
 
@@ -31,7 +32,7 @@ type searchEntry struct {
 // The view supports:
 //   - Progressive search across multiple projects and resource types
 //   - Search history navigation with ↑/↓ keys
-//   - Fuzzy matching toggle with Tab key
+//   - Match mode (substring, fuzzy, regex, exact) cycled with Tab key
 //   - Real-time filtering with '/' key
 //   - Context-aware actions (SSH, details, browser) based on resource type
 //   - Project affinity learning (searches high-priority projects first)
@@ -53,7 +54,7 @@ func RunSearchView(ctx context.Context, c *cache.Cache, app *tview.Application,
 	}
 
 	// Create search engine with all providers
-	engine := createSearchEngine(parallelism)
+	engine := createSearchEngine(ctx, c, parallelism)
 
 	// Create UI components
 	searchInput := tview.NewInputField().
@@ -73,12 +74,7 @@ func RunSearchView(ctx context.Context, c *cache.Cache, app *tview.Application,
 	// Add header
 	headers := []string{"Type", "Name", "Project", "Location"}
 	for col, header := range headers {
-		cell := tview.NewTableCell(header).
-			SetTextColor(tcell.ColorBlack).
-			SetBackgroundColor(tcell.ColorDarkCyan).
-			SetSelectable(false).
-			SetExpansion(1)
-		table.SetCell(0, col, cell)
+		table.SetCell(0, col, newSearchHeaderCell(header))
 	}
 
 	// Create table updater
@@ -94,7 +90,7 @@ func RunSearchView(ctx context.Context, c *cache.Cache, app *tview.Application,
 	// Status bar
 	status := tview.NewTextView().
 		SetDynamicColors(true).
-		SetText(" [yellow]Tab[-] fuzzy  [yellow]Enter[-] search  [yellow]Esc[-] back  [yellow]/[-] filter results  [yellow]d[-] details  [yellow]?[-] help")
+		SetText(" [yellow]Tab[-] match mode  [yellow]Enter[-] search  [yellow]Esc[-] back  [yellow]/[-] filter results  [yellow]d[-] details  [yellow]?[-] help")
 
 	// Progress indicator
 	progressText := tview.NewTextView().
@@ -132,13 +128,16 @@ func RunSearchView(ctx context.Context, c *cache.Cache, app *tview.Application,
 		flex.AddItem(table, 0, 1, focusTable)
 
 		// Show warnings pane if there are warnings or errors
-		hasIssues := len(state.AllWarnings) > 0 || state.SearchError != nil
+		hasIssues := len(state.AllWarnings) > 0 || len(state.AllSkipped) > 0 || state.SearchError != nil
 		if hasIssues {
 			// Calculate height based on number of issues (min 3, max 8 lines)
 			height := len(state.AllWarnings) + 2 // +2 for border
 			if state.SearchError != nil {
 				height++
 			}
+			if len(state.AllSkipped) > 0 {
+				height++
+			}
 			if height < 3 {
 				height = 3
 			}
@@ -175,6 +174,10 @@ func RunSearchView(ctx context.Context, c *cache.Cache, app *tview.Application,
 			}
 		}
 
+		if len(state.AllSkipped) > 0 {
+			content.WriteString(fmt.Sprintf("[gray]Skipped %d resource type/project pair(s) with a disabled API (refresh the project to check again)[-]\n", len(state.AllSkipped)))
+		}
+
 		warningsPane.SetText(content.String())
 	}
 
@@ -232,37 +235,36 @@ func RunSearchView(ctx context.Context, c *cache.Cache, app *tview.Application,
 			actionStr = FormatActionsStatusBar(actions)
 		}
 
-		// Build fuzzy indicator and toggle hint
-		fuzzyBadge := ""
-		fuzzyToggle := "[yellow]Tab[-] fuzzy"
-		if state.FuzzyMode {
-			fuzzyBadge = " [green::b]FUZZY[-:-:-] "
-			fuzzyToggle = "[yellow]Tab[-] exact"
+		// Build match mode indicator and toggle hint
+		modeBadge := ""
+		if state.MatchMode != search.MatchSubstring {
+			modeBadge = fmt.Sprintf(" [green::b]%s[-:-:-] ", strings.ToUpper(string(state.MatchMode)))
 		}
+		modeToggle := "[yellow]Tab[-] " + string(nextMatchMode(state.MatchMode))
 
 		// During search, show search-specific status with context actions
 		if state.IsSearching {
 			if actionStr != "" {
-				status.SetText(fmt.Sprintf("%s [yellow]Searching...[-]  %s  [yellow]Esc[-] cancel", fuzzyBadge, actionStr))
+				status.SetText(fmt.Sprintf("%s [yellow]Searching...[-]  %s  [yellow]Esc[-] cancel", modeBadge, actionStr))
 			} else {
-				status.SetText(fmt.Sprintf("%s [yellow]Searching...[-]  [yellow]Esc[-] cancel", fuzzyBadge))
+				status.SetText(fmt.Sprintf("%s [yellow]Searching...[-]  [yellow]Esc[-] cancel", modeBadge))
 			}
 			return
 		}
 
 		if entry == nil {
 			if count > 0 {
-				status.SetText(fmt.Sprintf("%s[green]%d results[-]  %s  [yellow]Enter[-] search  [yellow]/[-] filter  [yellow]Esc[-] back  [yellow]?[-] help", fuzzyBadge, count, fuzzyToggle))
+				status.SetText(fmt.Sprintf("%s[green]%d results[-]  %s  [yellow]Enter[-] search  [yellow]/[-] filter  [yellow]Esc[-] back  [yellow]?[-] help", modeBadge, count, modeToggle))
 			} else {
-				status.SetText(fmt.Sprintf("%s%s  [yellow]Enter[-] search  [yellow]/[-] filter  [yellow]Esc[-] back  [yellow]?[-] help", fuzzyBadge, fuzzyToggle))
+				status.SetText(fmt.Sprintf("%s%s  [yellow]Enter[-] search  [yellow]/[-] filter  [yellow]Esc[-] back  [yellow]?[-] help", modeBadge, modeToggle))
 			}
 			return
 		}
 
 		if state.CurrentFilter != "" {
-			status.SetText(fmt.Sprintf("%s[green]Filter: %s[-]  %s  [yellow]/[-] edit  [yellow]Esc[-] clear  [yellow]?[-] help", fuzzyBadge, state.CurrentFilter, actionStr))
+			status.SetText(fmt.Sprintf("%s[green]Filter: %s[-]  %s  [yellow]/[-] edit  [yellow]Esc[-] clear  [yellow]?[-] help", modeBadge, state.CurrentFilter, actionStr))
 		} else {
-			status.SetText(fmt.Sprintf("%s%s  %s  [yellow]Enter[-] search  [yellow]/[-] filter  [yellow]Esc[-] back  [yellow]?[-] help", fuzzyBadge, actionStr, fuzzyToggle))
+			status.SetText(fmt.Sprintf("%s%s  %s  [yellow]Enter[-] search  [yellow]/[-] filter  [yellow]Esc[-] back  [yellow]?[-] help", modeBadge, actionStr, modeToggle))
 		}
 	}
 
@@ -274,12 +276,21 @@ func RunSearchView(ctx context.Context, c *cache.Cache, app *tview.Application,
 	})
 
 	// Function to perform search with streaming results
+	// Optional types restrict the search to the given resource kinds.
 	// This function should be called from a goroutine to avoid blocking the event loop
-	performSearch := func(query string) {
+	performSearch := func(query string, types ...search.ResourceKind) {
 		if query == "" {
 			return
 		}
 
+		searchQuery := search.Query{Term: query, Types: types}.WithMode(state.MatchMode)
+		if err := searchQuery.Validate(); err != nil {
+			app.QueueUpdateDraw(func() {
+				status.SetText(fmt.Sprintf(" [red]%s[-]", tview.Escape(err.Error())))
+			})
+			return
+		}
+
 		// Cancel any existing search
 		if state.SearchCancel != nil {
 			state.SearchCancel()
@@ -289,6 +300,7 @@ func RunSearchView(ctx context.Context, c *cache.Cache, app *tview.Application,
 		state.ResultsMu.Lock()
 		state.AllResults = []searchEntry{}
 		state.AllWarnings = nil
+		state.AllSkipped = nil
 		state.SearchError = nil
 		state.ResultsMu.Unlock()
 
@@ -298,6 +310,8 @@ func RunSearchView(ctx context.Context, c *cache.Cache, app *tview.Application,
 
 		// Track this search term for affinity reinforcement and reset recorded tracking
 		state.CurrentSearchTerm = query
+		state.CurrentAffinityTerm = searchQuery.AffinityTerm()
+		state.CurrentSearchTypes = types
 		state.RecordedAffinity = make(map[string]struct{})
 
 		// Add to search history
@@ -311,11 +325,20 @@ func RunSearchView(ctx context.Context, c *cache.Cache, app *tview.Application,
 		}()
 
 		// Get projects prioritized for this search term using learned affinity
-		searchProjects := c.GetProjectsForSearch(query, nil)
+		typeStrings := make([]string, 0, len(types))
+		for _, t := range types {
+			typeStrings = append(typeStrings, string(t))
+		}
+		searchProjects := c.GetProjectsForSearch(state.CurrentAffinityTerm, typeStrings)
 		if len(searchProjects) == 0 {
 			searchProjects = initialProjects
 		}
 
+		// Service account emails name their owning project, search it first
+		if owner := gcp.ServiceAccountProject(query); owner != "" {
+			searchProjects = prioritizeProject(searchProjects, owner)
+		}
+
 		// Track progress
 		var currentProgress search.SearchProgress
 		var progressMu sync.Mutex
@@ -414,7 +437,7 @@ func RunSearchView(ctx context.Context, c *cache.Cache, app *tview.Application,
 		})
 
 		// Run search
-		searchQuery := search.Query{Term: query, Fuzzy: state.FuzzyMode}
+		searchQuery.Affinity = c.SearchAffinityScores(state.CurrentAffinityTerm, typeStrings)
 
 		callback := func(results []search.Result, progress search.SearchProgress) error {
 			// Check if cancelled
@@ -441,6 +464,7 @@ func RunSearchView(ctx context.Context, c *cache.Cache, app *tview.Application,
 						Project:  r.Project,
 						Location: r.Location,
 						Details:  r.Details,
+						Labels:   r.Labels,
 						Result:   &r,
 					}
 					newEntries = append(newEntries, entry)
@@ -461,6 +485,7 @@ func RunSearchView(ctx context.Context, c *cache.Cache, app *tview.Application,
 
 				state.ResultsMu.Lock()
 				state.AllResults = append(state.AllResults, newEntries...)
+				sortEntriesByRelevance(state.AllResults)
 				state.ResultsMu.Unlock()
 
 				// Record affinity in real-time (in background to not block)
@@ -474,7 +499,7 @@ func RunSearchView(ctx context.Context, c *cache.Cache, app *tview.Application,
 								_ = c.RecordSearchAffinity(term, map[string]int{proj: count}, resType)
 							}
 						}
-					}(query, newProjectResults, newProjectTypeResults)
+					}(state.CurrentAffinityTerm, newProjectResults, newProjectTypeResults)
 				}
 			}
 
@@ -505,6 +530,9 @@ func RunSearchView(ctx context.Context, c *cache.Cache, app *tview.Application,
 		if output != nil && len(output.Warnings) > 0 {
 			state.AllWarnings = output.Warnings
 		}
+		if output != nil {
+			state.AllSkipped = output.Skipped
+		}
 		state.ResultsMu.Unlock()
 
 		// Note: Search affinity is now recorded in real-time during the search callback
@@ -602,18 +630,18 @@ func RunSearchView(ctx context.Context, c *cache.Cache, app *tview.Application,
 			return event
 		}
 
-		// Tab toggles fuzzy mode globally, regardless of focus
+		// Tab cycles the match mode globally, regardless of focus
 		if event.Key() == tcell.KeyTab {
-			state.FuzzyMode = !state.FuzzyMode
-			if state.FuzzyMode {
-				searchInput.SetLabel(" Search (fuzzy): ")
+			state.MatchMode = nextMatchMode(state.MatchMode)
+			if state.MatchMode != search.MatchSubstring {
+				searchInput.SetLabel(fmt.Sprintf(" Search (%s): ", state.MatchMode))
 			} else {
 				searchInput.SetLabel(" Search: ")
 			}
 			updateStatusWithActions()
 			// Re-run current search if there is one
 			if state.CurrentSearchTerm != "" {
-				go performSearch(state.CurrentSearchTerm)
+				go performSearch(state.CurrentSearchTerm, state.CurrentSearchTypes...)
 			}
 			return nil
 		}
@@ -698,11 +726,11 @@ func RunSearchView(ctx context.Context, c *cache.Cache, app *tview.Application,
 				}
 
 				// Reinforce search affinity when user SSHs to an instance
-				if state.CurrentSearchTerm != "" {
+				if state.CurrentAffinityTerm != "" {
 					go func(term, proj, resType string) {
 						_ = c.TouchSearchAffinity(term, proj, resType)
 						_ = c.TouchSearchAffinity(term, proj, "") // Also touch general affinity
-					}(state.CurrentSearchTerm, selectedEntry.Project, selectedEntry.Type)
+					}(state.CurrentAffinityTerm, selectedEntry.Project, selectedEntry.Type)
 				}
 
 				// Capture values for callbacks
@@ -875,11 +903,11 @@ func RunSearchView(ctx context.Context, c *cache.Cache, app *tview.Application,
 				}
 
 				// Reinforce search affinity when user views details
-				if state.CurrentSearchTerm != "" {
+				if state.CurrentAffinityTerm != "" {
 					go func(term, proj, resType string) {
 						_ = c.TouchSearchAffinity(term, proj, resType)
 						_ = c.TouchSearchAffinity(term, proj, "") // Also touch general affinity
-					}(state.CurrentSearchTerm, selectedEntry.Project, selectedEntry.Type)
+					}(state.CurrentAffinityTerm, selectedEntry.Project, selectedEntry.Type)
 				}
 
 				// For instances, fetch live details from GCP
@@ -913,8 +941,14 @@ func RunSearchView(ctx context.Context, c *cache.Cache, app *tview.Application,
 						},
 					}
 
-					executor.ExecuteDetails(actionCtx, func(details string) {
-						showInstanceDetailModal(app, table, flex, entryName, details, &state.ModalOpen, status, state.CurrentFilter, updateStatusWithActions)
+					executor.ExecuteDetails(actionCtx, func(details string, instance *gcp.Instance) {
+						showInstanceDetailModalWithServiceAccounts(app, table, flex, entryName, details, instance.ServiceAccounts, &state.ModalOpen, updateStatusWithActions,
+							func(email string) {
+								// Jump to the service account's search result
+								searchInput.SetText(email)
+								status.SetText(fmt.Sprintf(" [yellow]Searching service account %s...[-]", email))
+								go performSearch(email, search.KindServiceAccount)
+							})
 					})
 				} else if selectedEntry.Type == string(search.KindInstanceTemplate) {
 					// For instance templates, use formatted details
@@ -924,6 +958,45 @@ func RunSearchView(ctx context.Context, c *cache.Cache, app *tview.Application,
 					// For MIGs, use formatted details
 					details := FormatMIGDetails(selectedEntry.Name, selectedEntry.Project, selectedEntry.Location, selectedEntry.Details)
 					showInstanceDetailModal(app, table, flex, selectedEntry.Name, details, &state.ModalOpen, status, state.CurrentFilter, updateStatusWithActions)
+				} else if selectedEntry.Type == string(search.KindPubSubTopic) {
+					details := FormatPubSubTopicDetails(selectedEntry.Name, selectedEntry.Project, selectedEntry.Details)
+					showInstanceDetailModal(app, table, flex, selectedEntry.Name, details, &state.ModalOpen, status, state.CurrentFilter, updateStatusWithActions)
+				} else if selectedEntry.Type == string(search.KindPubSubSubscription) {
+					details := FormatPubSubSubscriptionDetails(selectedEntry.Name, selectedEntry.Project, selectedEntry.Details)
+					showInstanceDetailModal(app, table, flex, selectedEntry.Name, details, &state.ModalOpen, status, state.CurrentFilter, updateStatusWithActions)
+				} else if selectedEntry.Type == string(search.KindDNSManagedZone) {
+					details := FormatDNSManagedZoneDetails(selectedEntry.Name, selectedEntry.Project, selectedEntry.Details)
+					showInstanceDetailModal(app, table, flex, selectedEntry.Name, details, &state.ModalOpen, status, state.CurrentFilter, updateStatusWithActions)
+				} else if selectedEntry.Type == string(search.KindDNSRecordSet) {
+					details := FormatDNSRecordSetDetails(selectedEntry.Name, selectedEntry.Project, selectedEntry.Details)
+					showInstanceDetailModal(app, table, flex, selectedEntry.Name, details, &state.ModalOpen, status, state.CurrentFilter, updateStatusWithActions)
+				} else if selectedEntry.Type == string(search.KindRouter) {
+					details := FormatRouterDetails(selectedEntry.Name, selectedEntry.Project, selectedEntry.Location, selectedEntry.Details)
+					showInstanceDetailModal(app, table, flex, selectedEntry.Name, details, &state.ModalOpen, status, state.CurrentFilter, updateStatusWithActions)
+				} else if selectedEntry.Type == string(search.KindRouterNAT) {
+					details := FormatRouterNATDetails(selectedEntry.Name, selectedEntry.Project, selectedEntry.Location, selectedEntry.Details)
+					showInstanceDetailModal(app, table, flex, selectedEntry.Name, details, &state.ModalOpen, status, state.CurrentFilter, updateStatusWithActions)
+				} else if selectedEntry.Type == string(search.KindInterconnectAttachment) {
+					details := FormatInterconnectAttachmentDetails(selectedEntry.Name, selectedEntry.Project, selectedEntry.Location, selectedEntry.Details)
+					showInstanceDetailModal(app, table, flex, selectedEntry.Name, details, &state.ModalOpen, status, state.CurrentFilter, updateStatusWithActions)
+				} else if selectedEntry.Type == string(search.KindRedisInstance) {
+					details := FormatRedisInstanceDetails(selectedEntry.Name, selectedEntry.Project, selectedEntry.Location, selectedEntry.Details)
+					showInstanceDetailModal(app, table, flex, selectedEntry.Name, details, &state.ModalOpen, status, state.CurrentFilter, updateStatusWithActions)
+				} else if selectedEntry.Type == string(search.KindMemcacheInstance) {
+					details := FormatMemcacheInstanceDetails(selectedEntry.Name, selectedEntry.Project, selectedEntry.Location, selectedEntry.Details)
+					showInstanceDetailModal(app, table, flex, selectedEntry.Name, details, &state.ModalOpen, status, state.CurrentFilter, updateStatusWithActions)
+				} else if selectedEntry.Type == string(search.KindFilestoreInstance) {
+					details := FormatFilestoreInstanceDetails(selectedEntry.Name, selectedEntry.Project, selectedEntry.Location, selectedEntry.Details)
+					showInstanceDetailModal(app, table, flex, selectedEntry.Name, details, &state.ModalOpen, status, state.CurrentFilter, updateStatusWithActions)
+				} else if selectedEntry.Type == string(search.KindSpannerInstance) {
+					details := FormatSpannerInstanceDetails(selectedEntry.Name, selectedEntry.Project, selectedEntry.Details)
+					showInstanceDetailModal(app, table, flex, selectedEntry.Name, details, &state.ModalOpen, status, state.CurrentFilter, updateStatusWithActions)
+				} else if selectedEntry.Type == string(search.KindBigQueryDataset) {
+					details := FormatBigQueryDatasetDetails(selectedEntry.Name, selectedEntry.Project, selectedEntry.Location, selectedEntry.Details)
+					showInstanceDetailModal(app, table, flex, selectedEntry.Name, details, &state.ModalOpen, status, state.CurrentFilter, updateStatusWithActions)
+				} else if selectedEntry.Type == string(search.KindServiceAccount) {
+					details := FormatServiceAccountDetails(selectedEntry.Name, selectedEntry.Project, selectedEntry.Details)
+					showInstanceDetailModal(app, table, flex, selectedEntry.Name, details, &state.ModalOpen, status, state.CurrentFilter, updateStatusWithActions)
 				} else if selectedEntry.Type == string(search.KindConnectivityTest) {
 					// For connectivity tests, fetch and show full details
 					status.SetText(" [yellow]Loading connectivity test details...[-]")
@@ -1128,6 +1201,106 @@ func RunSearchView(ctx context.Context, c *cache.Cache, app *tview.Application,
 				})
 				return nil
 
+			case 'r':
+				// Show resources related to the selected result
+				selectedEntry := getSelectedEntry()
+				if selectedEntry == nil {
+					return nil
+				}
+
+				kind := search.ResourceKind(selectedEntry.Type)
+				if !search.IsRelatedKind(kind) {
+					status.SetText(" [yellow]Related resources are not available for this type[-]")
+					time.AfterFunc(2*time.Second, func() {
+						app.QueueUpdateDraw(func() {
+							updateStatusWithActions()
+						})
+					})
+					return nil
+				}
+
+				// Set status directly (not via QueueUpdateDraw) since we're in input handler
+				status.SetText(" [yellow]Loading related resources...[-]")
+
+				entryName, entryProject, entryLocation := selectedEntry.Name, selectedEntry.Project, selectedEntry.Location
+				go func() {
+					showError := func(err error) {
+						app.QueueUpdateDraw(func() {
+							status.SetText(fmt.Sprintf(" [red]Error: %v[-]", err))
+							time.AfterFunc(3*time.Second, func() {
+								app.QueueUpdateDraw(func() {
+									updateStatusWithActions()
+								})
+							})
+						})
+					}
+
+					client, err := gcp.NewClient(ctx, entryProject)
+					if err != nil {
+						showError(err)
+						return
+					}
+
+					// Resource types that fail to list are left out, the partial inventory is still useful
+					inventory, _ := search.LoadInventory(ctx, client)
+
+					graph := search.BuildGraph(inventory)
+					root, ok := relatedRoot(graph, kind, entryName, entryLocation)
+					if !ok {
+						showError(fmt.Errorf("%s %s not found in project %s", kind, entryName, entryProject))
+						return
+					}
+					relations := graph.Relations(root)
+
+					app.QueueUpdateDraw(func() {
+						if len(relations) == 0 {
+							status.SetText(fmt.Sprintf(" [yellow]No related resources found for %s[-]", entryName))
+							time.AfterFunc(2*time.Second, func() {
+								app.QueueUpdateDraw(func() {
+									updateStatusWithActions()
+								})
+							})
+							return
+						}
+
+						closeSelection := func() {
+							state.ModalOpen = false
+							app.SetRoot(flex, true)
+							app.SetFocus(table)
+						}
+
+						state.ModalOpen = true
+						showRelatedSelection(app, root, relations, func(ref search.ResourceRef) {
+							// Jump to the related resource's search result
+							closeSelection()
+							searchInput.SetText(ref.Name)
+							status.SetText(fmt.Sprintf(" [yellow]Searching %s %s...[-]", ref.Kind, ref.Name))
+							go performSearch(ref.Name, ref.Kind)
+						}, func() {
+							closeSelection()
+							updateStatusWithActions()
+						})
+					})
+				}()
+				return nil
+
+			case 'L':
+				// Toggle the Labels column
+				state.ShowLabels = !state.ShowLabels
+				tableUpdater.ShowLabels = state.ShowLabels
+				updateTable(state.CurrentFilter)
+				if state.ShowLabels {
+					status.SetText(" [green]Labels column shown[-]")
+				} else {
+					status.SetText(" [yellow]Labels column hidden[-]")
+				}
+				time.AfterFunc(2*time.Second, func() {
+					app.QueueUpdateDraw(func() {
+						updateStatusWithActions()
+					})
+				})
+				return nil
+
 			case '?':
 				// Show help
 				showSearchHelp(app, table, flex, &state.ModalOpen, state.CurrentFilter, status, updateStatusWithActions)
@@ -1141,131 +1314,17 @@ func RunSearchView(ctx context.Context, c *cache.Cache, app *tview.Application,
 	return nil
 }
 
-// createSearchEngine creates a search engine with all providers
-func createSearchEngine(parallelism int) *search.Engine {
-	engine := search.NewEngine(
-		&search.InstanceProvider{
-			NewClient: func(ctx context.Context, project string) (search.InstanceClient, error) {
-				return gcp.NewClient(ctx, project)
-			},
-		},
-		&search.MIGProvider{
-			NewClient: func(ctx context.Context, project string) (search.MIGClient, error) {
-				return gcp.NewClient(ctx, project)
-			},
-		},
-		&search.InstanceTemplateProvider{
-			NewClient: func(ctx context.Context, project string) (search.InstanceTemplateClient, error) {
-				return gcp.NewClient(ctx, project)
-			},
-		},
-		&search.AddressProvider{
-			NewClient: func(ctx context.Context, project string) (search.AddressClient, error) {
-				return gcp.NewClient(ctx, project)
-			},
-		},
-		&search.DiskProvider{
-			NewClient: func(ctx context.Context, project string) (search.DiskClient, error) {
-				return gcp.NewClient(ctx, project)
-			},
-		},
-		&search.SnapshotProvider{
-			NewClient: func(ctx context.Context, project string) (search.SnapshotClient, error) {
-				return gcp.NewClient(ctx, project)
-			},
-		},
-		&search.BucketProvider{
-			NewClient: func(ctx context.Context, project string) (search.BucketClient, error) {
-				return gcp.NewClient(ctx, project)
-			},
-		},
-		&search.ForwardingRuleProvider{
-			NewClient: func(ctx context.Context, project string) (search.ForwardingRuleClient, error) {
-				return gcp.NewClient(ctx, project)
-			},
-		},
-		&search.BackendServiceProvider{
-			NewClient: func(ctx context.Context, project string) (search.BackendServiceClient, error) {
-				return gcp.NewClient(ctx, project)
-			},
-		},
-		&search.TargetPoolProvider{
-			NewClient: func(ctx context.Context, project string) (search.TargetPoolClient, error) {
-				return gcp.NewClient(ctx, project)
-			},
-		},
-		&search.HealthCheckProvider{
-			NewClient: func(ctx context.Context, project string) (search.HealthCheckClient, error) {
-				return gcp.NewClient(ctx, project)
-			},
-		},
-		&search.URLMapProvider{
-			NewClient: func(ctx context.Context, project string) (search.URLMapClient, error) {
-				return gcp.NewClient(ctx, project)
-			},
-		},
-		&search.CloudSQLProvider{
-			NewClient: func(ctx context.Context, project string) (search.CloudSQLClient, error) {
-				return gcp.NewClient(ctx, project)
-			},
-		},
-		&search.GKEClusterProvider{
-			NewClient: func(ctx context.Context, project string) (search.GKEClusterClient, error) {
-				return gcp.NewClient(ctx, project)
-			},
-		},
-		&search.GKENodePoolProvider{
-			NewClient: func(ctx context.Context, project string) (search.GKENodePoolClient, error) {
-				return gcp.NewClient(ctx, project)
-			},
-		},
-		&search.VPCNetworkProvider{
-			NewClient: func(ctx context.Context, project string) (search.VPCNetworkClient, error) {
-				return gcp.NewClient(ctx, project)
-			},
-		},
-		&search.SubnetProvider{
-			NewClient: func(ctx context.Context, project string) (search.SubnetClient, error) {
-				return gcp.NewClient(ctx, project)
-			},
-		},
-		&search.CloudRunProvider{
-			NewClient: func(ctx context.Context, project string) (search.CloudRunClient, error) {
-				return gcp.NewClient(ctx, project)
-			},
-		},
-		&search.FirewallRuleProvider{
-			NewClient: func(ctx context.Context, project string) (search.FirewallRuleClient, error) {
-				return gcp.NewClient(ctx, project)
-			},
-		},
-		&search.SecretProvider{
-			NewClient: func(ctx context.Context, project string) (search.SecretClient, error) {
-				return gcp.NewClient(ctx, project)
-			},
-		},
-		&search.VPNGatewayProvider{
-			NewClient: func(ctx context.Context, project string) (search.VPNGatewayClient, error) {
-				return gcp.NewClient(ctx, project)
-			},
-		},
-		&search.VPNTunnelProvider{
-			NewClient: func(ctx context.Context, project string) (search.VPNTunnelClient, error) {
-				return gcp.NewClient(ctx, project)
-			},
-		},
-		&search.RouteProvider{
-			NewClient: func(ctx context.Context, project string) (search.RouteClient, error) {
-				return gcp.NewClient(ctx, project)
-			},
-		},
-		&search.ConnectivityTestProvider{
-			NewClient: func(ctx context.Context, project string) (search.ConnectivityTestClient, error) {
-				return gcp.NewConnectivityClient(ctx, project)
-			},
-		},
-	)
+// createSearchEngine creates a search engine with every registered provider, including
+// exec plugins, remembering disabled APIs in the cache when one is available
+func createSearchEngine(ctx context.Context, c *cache.Cache, parallelism int) *search.Engine {
+	// Plugins that fail to register are left out, the TUI has no place to report it yet
+	_, _ = search.RegisterPlugins(ctx)
+
+	engine := search.NewEngine(search.NewProviders()...)
 	engine.MaxConcurrentProjects = parallelism
+	if c != nil {
+		engine.ServiceState = c
+	}
 	return engine
 }
 
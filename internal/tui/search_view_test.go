@@ -1,6 +1,13 @@
 package tui
 
-import "testing"
+import (
+	"strings"
+	"testing"
+
+	"github.com/kedare/compass/internal/gcp"
+	"github.com/kedare/compass/internal/gcp/search"
+	"github.com/rivo/tview"
+)
 
 func TestHighlightMatch(t *testing.T) {
 	tests := []struct {
@@ -28,3 +35,120 @@ func TestHighlightMatch(t *testing.T) {
 		}
 	}
 }
+
+func TestPrioritizeProject(t *testing.T) {
+	tests := []struct {
+		name     string
+		projects []string
+		project  string
+		expected []string
+	}{
+		{"moves to front", []string{"a", "b", "c"}, "c", []string{"c", "a", "b"}},
+		{"already first", []string{"a", "b"}, "a", []string{"a", "b"}},
+		{"not present", []string{"a", "b"}, "z", []string{"a", "b"}},
+		{"empty list", nil, "a", nil},
+	}
+
+	for _, tt := range tests {
+		got := prioritizeProject(tt.projects, tt.project)
+		if strings.Join(got, ",") != strings.Join(tt.expected, ",") {
+			t.Errorf("%s: prioritizeProject(%v, %q) = %v, want %v", tt.name, tt.projects, tt.project, got, tt.expected)
+		}
+	}
+}
+
+func TestFormatLabels(t *testing.T) {
+	if got := formatLabels(nil); got != "" {
+		t.Errorf("formatLabels(nil) = %q, want empty", got)
+	}
+
+	got := formatLabels(map[string]string{"team": "sre", "env": "prod"})
+	if got != "env=prod, team=sre" {
+		t.Errorf("formatLabels() = %q, want %q", got, "env=prod, team=sre")
+	}
+}
+
+func TestTableUpdaterLabelsColumn(t *testing.T) {
+	table := tview.NewTable()
+	for col, header := range []string{"Type", "Name", "Project", "Location"} {
+		table.SetCell(0, col, newSearchHeaderCell(header))
+	}
+	updater := NewTableUpdater(table)
+	entries := []searchEntry{{Type: "compute.instance", Name: "web-1", Project: "p", Location: "z", Labels: map[string]string{"env": "prod"}}}
+
+	updater.UpdateWithData("", entries)
+	if table.GetColumnCount() != 4 {
+		t.Fatalf("expected 4 columns without labels, got %d", table.GetColumnCount())
+	}
+
+	updater.ShowLabels = true
+	updater.UpdateWithData("", entries)
+	if table.GetColumnCount() != 5 {
+		t.Fatalf("expected 5 columns with labels, got %d", table.GetColumnCount())
+	}
+	if got := table.GetCell(1, labelsColumn).Text; got != "env=prod" {
+		t.Fatalf("expected labels cell env=prod, got %q", got)
+	}
+
+	updater.ShowLabels = false
+	updater.UpdateWithData("", entries)
+	if table.GetColumnCount() != 4 {
+		t.Fatalf("expected labels column to be removed, got %d columns", table.GetColumnCount())
+	}
+}
+
+func TestSortEntriesByRelevance(t *testing.T) {
+	entries := []searchEntry{
+		{Name: "details-match", Result: &search.Result{Score: search.ScoreDetailsMatch}},
+		{Name: "no-result"},
+		{Name: "exact", Result: &search.Result{Score: search.ScoreExactName}},
+		{Name: "prefix", Result: &search.Result{Score: search.ScoreNamePrefix}},
+	}
+
+	sortEntriesByRelevance(entries)
+
+	got := make([]string, 0, len(entries))
+	for _, e := range entries {
+		got = append(got, e.Name)
+	}
+	if strings.Join(got, ",") != "exact,prefix,details-match,no-result" {
+		t.Fatalf("unexpected order: %v", got)
+	}
+}
+
+func TestRelatedRootPrefersLocation(t *testing.T) {
+	graph := search.BuildGraph(&search.Inventory{
+		Subnets: []*gcp.Subnet{
+			{Name: "default", Region: "us-central1"},
+			{Name: "default", Region: "europe-west1"},
+		},
+	})
+
+	root, ok := relatedRoot(graph, search.KindSubnet, "default", "europe-west1")
+	if !ok || root.Location != "europe-west1" {
+		t.Fatalf("expected europe-west1 subnet, got %+v (found=%v)", root, ok)
+	}
+
+	if _, ok := relatedRoot(graph, search.KindSubnet, "missing", ""); ok {
+		t.Fatal("expected missing resource not to be found")
+	}
+}
+
+func TestNextMatchModeCyclesAllModes(t *testing.T) {
+	mode := NewSearchViewState().MatchMode
+	seen := map[search.MatchMode]bool{}
+	for i := 0; i < len(matchModeCycle); i++ {
+		seen[mode] = true
+		mode = nextMatchMode(mode)
+	}
+
+	if mode != search.MatchSubstring {
+		t.Fatalf("expected the cycle to return to substring matching, got %s", mode)
+	}
+	if len(seen) != 4 {
+		t.Fatalf("expected every mode to be visited, got %v", seen)
+	}
+	if got := nextMatchMode("unknown"); got != search.MatchSubstring {
+		t.Fatalf("expected unknown modes to reset to substring, got %s", got)
+	}
+}